@@ -0,0 +1,168 @@
+package geodesic
+
+import "math"
+
+// AlbersEqualArea is the Albers equal-area conic projection of an
+// ellipsoid, which is used for the maps of the contiguous United States
+// and of other wide countries where areas must be kept. It is safe for
+// concurrent use.
+//
+// Every region has the same area on the map as on the ellipsoid, so the
+// area of a projected polygon, with its edges drawn as the straight lines
+// of the map, can be compared with the geodesic area of the same vertices
+// from PolygonArea; the two differ only by the bulge of the geodesic edges
+// away from the straight ones. The scale along the parallels is one on the
+// standard parallels, and the scale along the meridians is its reciprocal.
+// The projection is computed in closed form, with the latitudes of
+// Reverse found by Newton's method from the authalic latitude.
+type AlbersEqualArea struct {
+	conformal // for the eccentricity, not the conformal latitudes
+	a         float64
+	n         float64 // the cone constant
+	c         float64 // m^2 + n*q on the first standard parallel
+	qp        float64 // q at the north pole
+}
+
+// NewAlbersEqualArea returns the Albers equal-area conic projection of the
+// ellipsoid.
+//
+// Param lat1 is the first standard parallel (degrees).
+// Param lat2 is the second standard parallel (degrees), which is the same
+// as lat1 for a projection with one standard parallel.
+//
+// The standard parallels must not be opposite to each other across the
+// equator, where the cone becomes a cylinder; Forward and Reverse then
+// return NaNs.
+func (e *Ellipsoid) NewAlbersEqualArea(lat1, lat2 float64) *AlbersEqualArea {
+	ae := &AlbersEqualArea{conformal: newConformal(e.Flattening()),
+		a: e.Radius()}
+	ae.qp = ae.q(1)
+	s1, c1 := sincosd(lat1)
+	mm1 := c1 * c1 / (1 - ae.e2*s1*s1) // m1^2
+	q1 := ae.q(s1)
+	if lat1 == lat2 {
+		ae.n = s1
+	} else {
+		s2, c2 := sincosd(lat2)
+		ae.n = (mm1 - c2*c2/(1-ae.e2*s2*s2)) / (ae.q(s2) - q1)
+	}
+	if !(math.Abs(ae.n) > 0) {
+		ae.n = math.NaN()
+	}
+	ae.c = mm1 + ae.n*q1
+	return ae
+}
+
+// q returns the function of the sine of the latitude that the areas
+// between the equator and the parallels are proportional to, which is the
+// sine of the authalic latitude times its value at the pole.
+func (ae *AlbersEqualArea) q(sphi float64) float64 {
+	w := 1 - ae.e2*sphi*sphi
+	at := sphi // atanh(e*sphi)/e
+	if ae.e2 != 0 {
+		at = ae.eatanhe(sphi) / ae.e2
+	}
+	return (1 - ae.e2) * (sphi/w + at)
+}
+
+// ConeConstant returns the ratio of the angle between two meridians on the
+// map to the difference of their longitudes.
+func (ae *AlbersEqualArea) ConeConstant() float64 {
+	return ae.n
+}
+
+// Forward projects a point.
+//
+// Param lon0 is the central meridian (degrees).
+// Param lat is the latitude of the point (degrees).
+// Param lon is the longitude of the point (degrees).
+// Returns the easting x and northing y of the point (meters), the meridian
+// convergence gamma, which is the bearing of grid north clockwise from
+// true north (degrees), and the scale k along the parallel, which is the
+// reciprocal of the scale along the meridian.
+//
+// The origin of x and y is on the central meridian at the equator, with no
+// false easting or northing. For an origin at a latitude lat0, subtract
+// the northing of the point at lat0 on the central meridian. The poles are
+// arcs of circles about the apex of the cone, or the apex itself if the
+// cone constant is one, and the scale along the parallels is infinite
+// there.
+func (ae *AlbersEqualArea) Forward(lon0, lat, lon float64,
+) (x, y, gamma, k float64) {
+	if !(math.Abs(lat) <= 90) {
+		nan := math.NaN()
+		return nan, nan, nan, nan
+	}
+	sphi, cphi := sincosd(lat)
+	if math.Abs(lat) == 90 {
+		cphi = 0
+	}
+	rho := ae.rho(ae.q(sphi))
+	theta := ae.n * math.Remainder(lon-lon0, 360)
+	s, c := sincosd(theta)
+	x, y = rho*s, ae.rho(0)-rho*c
+	k = math.Inf(1)
+	if cphi != 0 {
+		k = ae.n * rho * math.Sqrt(1-ae.e2*sphi*sphi) / (ae.a * cphi)
+	}
+	return x, y, theta, k
+}
+
+// rho returns the radius on the map of the parallel with the given q.
+func (ae *AlbersEqualArea) rho(q float64) float64 {
+	return ae.a * math.Sqrt(math.Max(0, ae.c-ae.n*q)) / ae.n
+}
+
+// Reverse unprojects a point.
+//
+// Param lon0 is the central meridian (degrees).
+// Param x is the easting of the point (meters).
+// Param y is the northing of the point (meters).
+// Returns the latitude and longitude of the point (degrees), the meridian
+// convergence gamma (degrees), and the scale k, see AlbersEqualArea.Forward.
+//
+// Points beyond the images of the poles are put at the poles.
+func (ae *AlbersEqualArea) Reverse(lon0, x, y float64,
+) (lat, lon, gamma, k float64) {
+	sn := math.Copysign(1, ae.n)
+	dy := ae.rho(0) - y
+	rho := math.Hypot(x, dy) / ae.a
+	theta := math.Atan2(sn*x, sn*dy) * (180 / math.Pi)
+	q := (ae.c - rho*rho*ae.n*ae.n) / ae.n
+	lat = ae.latitude(q)
+	lon = normLon(lon0 + theta/ae.n)
+	_, _, gamma, k = ae.Forward(lon0, lat, lon)
+	return lat, lon, gamma, k
+}
+
+// latitude returns the latitude (degrees) at which q has the given value.
+func (ae *AlbersEqualArea) latitude(q float64) float64 {
+	if math.IsNaN(q) {
+		return q
+	}
+	sbeta := q / ae.qp // the sine of the authalic latitude
+	if !(math.Abs(sbeta) < 1) {
+		return math.Copysign(90, sbeta)
+	}
+	// The series for the latitude from the authalic latitude, then
+	// Newton's method on q.
+	beta := math.Asin(sbeta)
+	e2, e4 := ae.e2, ae.e2*ae.e2
+	phi := beta + (e2/3+e4*31/180+e4*e2*517/5040)*math.Sin(2*beta) +
+		(e4*23/360+e4*e2*251/3780)*math.Sin(4*beta) +
+		e4*e2*761/45360*math.Sin(6*beta)
+	for i := 0; i < 5; i++ {
+		sphi, cphi := math.Sincos(phi)
+		w := 1 - ae.e2*sphi*sphi
+		// dq/dphi = 2*(1-e2)*cos(phi)/w^2.
+		dphi := (q - ae.q(sphi)) * w * w / (2 * (1 - ae.e2) * cphi)
+		if !(math.Abs(dphi) < 0.1) {
+			break
+		}
+		phi += dphi
+		if math.Abs(dphi) < 1e-15 {
+			break
+		}
+	}
+	return math.Max(-90, math.Min(90, phi*(180/math.Pi)))
+}
@@ -0,0 +1,73 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAlbersEqualArea(t *testing.T) {
+	// The example of Snyder's Map Projections: A Working Manual, on Clarke
+	// 1866 with an origin at 23N 96W.
+	clarke := NewEllipsoid(6378206.4, 1/294.9786982)
+	ae := clarke.NewAlbersEqualArea(29.5, 45.5)
+	if n := ae.ConeConstant(); !eqish(n, 0.6029035, 7) {
+		t.Fatalf("expected a cone constant of 0.6029035, got %v", n)
+	}
+	_, y0, _, _ := ae.Forward(-96, 23, -96)
+	x, y, _, k := ae.Forward(-96, 35, -75)
+	if !eqish(x, 1885472.7, 1) || !eqish(y-y0, 1535925.0, 1) ||
+		!eqish(k, 0.9915546, 7) {
+		t.Fatalf("expected '1885472.7, 1535925.0, 0.9915546', got '%v, %v, %v'",
+			x, y-y0, k)
+	}
+	for _, lat := range []float64{29.5, 45.5} {
+		if _, _, _, k := ae.Forward(-96, lat, -80); !eqish(k, 1, 12) {
+			t.Fatalf("expected a scale of 1 on the standard parallel, got %v",
+				k)
+		}
+	}
+
+	// The area of a densified ring on the map is its geodesic area.
+	ring := []LatLng{{37, -109}, {37, -102}, {41, -102}, {41, -109}, {37, -109}}
+	dense := Densify(WGS84, ring, 1000)
+	ae = WGS84.NewAlbersEqualArea(29.5, 45.5)
+	var area float64
+	for i := 0; i+1 < len(dense); i++ {
+		x1, y1, _, _ := ae.Forward(-96, dense[i].Lat, dense[i].Lon)
+		x2, y2, _, _ := ae.Forward(-96, dense[i+1].Lat, dense[i+1].Lon)
+		area += (x1*y2 - x2*y1) / 2
+	}
+	want, _ := PolygonArea(WGS84, ring[:4])
+	if !eqish(area/want, 1, 7) {
+		t.Fatalf("expected an area of %v, got %v", want, area)
+	}
+
+	flat := WGS84.NewAlbersEqualArea(-20, 20)
+	if x, _, _, _ := flat.Forward(0, 10, 10); !math.IsNaN(x) {
+		t.Fatalf("expected NaN, got %v", x)
+	}
+	if lat, _, _, _ := ae.Reverse(-96, 0, -2e7); lat != -90 {
+		t.Fatalf("expected the south pole, got %v", lat)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, ae := range []*AlbersEqualArea{
+		WGS84.NewAlbersEqualArea(29.5, 45.5),
+		WGS84.NewAlbersEqualArea(-18, -36),
+		WGS84.NewAlbersEqualArea(60, 60),
+		NewEllipsoid(6378137, -1.0/50).NewAlbersEqualArea(20, 50),
+	} {
+		for i := 0; i < 300; i++ {
+			lat := rng.Float64()*178 - 89
+			lon := rng.Float64()*300 - 150
+			x, y, gamma, k := ae.Forward(0, lat, lon)
+			lat2, lon2, gamma2, k2 := ae.Reverse(0, x, y)
+			if !eqish(lat, lat2, 9) || !eqish(lon, lon2, 9) ||
+				!eqish(gamma, gamma2, 9) || !eqish(k/k2, 1, 9) {
+				t.Fatalf("expected '%v, %v, %v, %v', got '%v, %v, %v, %v'",
+					lat, lon, gamma, k, lat2, lon2, gamma2, k2)
+			}
+		}
+	}
+}
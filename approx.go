@@ -0,0 +1,76 @@
+package geodesic
+
+import "math"
+
+// approxMethod is a distance formula chosen by Ellipsoid.DistanceApprox.
+type approxMethod int
+
+const (
+	approxEquirect approxMethod = iota
+	approxHaversine
+	approxKarney
+)
+
+// roundoff is a floor on the error bounds of the approximate formulas
+// (meters), covering the rounding of the longitude difference.
+const roundoff = 1e-8
+
+// DistanceApprox returns the distance between two points to within an
+// error bound, using the fastest formula that meets it.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+// Param maxError is the largest acceptable error (meters).
+//
+// Three formulas are tried, in order of cost. The equirectangular
+// approximation, which treats a small neighborhood of the points as flat
+// using the radii of curvature of the ellipsoid at their mean latitude,
+// has an error that grows with the cube of the distance and gets worse
+// toward the poles; it is good to a millimeter for points 10 km apart at
+// mid-latitudes. The haversine formula on a sphere of the mean radius has
+// an error of up to twice the flattening, about 0.7% for the earth, at any
+// distance. Otherwise, and for any maxError below about 1e-8 m, the result
+// is the geodesic distance of Ellipsoid.Inverse. The bounds are
+// conservative, so the actual error is usually far smaller than maxError.
+func (e *Ellipsoid) DistanceApprox(p1, p2 LatLng, maxError float64) Distance {
+	s, _ := e.distanceApprox(p1, p2, maxError)
+	return Distance(s)
+}
+
+func (e *Ellipsoid) distanceApprox(p1, p2 LatLng, maxError float64) (float64, approxMethod) {
+	if maxError > roundoff {
+		a, f := e.Radius(), e.Flattening()
+		r := a * (3 - f) / 3
+		sh := haversine(p1, p2, r)
+		// An upper bound on the true distance.
+		smax := sh * (1 + 2*math.Abs(f)) * 1.01
+		c := math.Cos(math.Max(math.Abs(p1.Lat), math.Abs(p2.Lat)) * (math.Pi / 180))
+		if smax <= 0.1*r*c &&
+			0.25*smax*smax*smax/(r*r*c*c)+roundoff <= maxError {
+			return e.equirect(p1, p2), approxEquirect
+		}
+		if 2*math.Abs(f)*smax+roundoff <= maxError {
+			return sh, approxHaversine
+		}
+	}
+	return e.distance(p1, p2), approxKarney
+}
+
+// haversine returns the great circle distance between two points on a
+// sphere of radius r (meters).
+func haversine(p1, p2 LatLng, r float64) float64 {
+	sdlat := math.Sin((p2.Lat - p1.Lat) * (math.Pi / 360))
+	sdlon := math.Sin((p2.Lon - p1.Lon) * (math.Pi / 360))
+	h := sdlat*sdlat + math.Cos(p1.Lat*(math.Pi/180))*
+		math.Cos(p2.Lat*(math.Pi/180))*sdlon*sdlon
+	return 2 * r * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// equirect returns the distance between two close points with the
+// equirectangular approximation at their mean latitude (meters).
+func (e *Ellipsoid) equirect(p1, p2 LatLng) float64 {
+	lat := (p1.Lat + p2.Lat) / 2
+	x := math.Remainder(p2.Lon-p1.Lon, 360) * e.MetersPerDegreeLon(lat)
+	y := (p2.Lat - p1.Lat) * e.MetersPerDegreeLat(lat)
+	return math.Hypot(x, y)
+}
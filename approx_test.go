@@ -0,0 +1,43 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDistanceApprox(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var counts [3]int
+	for i := 0; i < 20000; i++ {
+		p := LatLng{Lat: rng.Float64()*180 - 90, Lon: rng.Float64()*360 - 180}
+		s := math.Pow(10, rng.Float64()*7.3)
+		var q LatLng
+		WGS84.Direct(p.Lat, p.Lon, rng.Float64()*360, s, &q.Lat, &q.Lon, nil)
+		want := WGS84.distance(p, q)
+		maxErr := math.Pow(10, rng.Float64()*14-9)
+		got, method := WGS84.distanceApprox(p, q, maxErr)
+		if math.Abs(got-want) > maxErr {
+			t.Fatalf("%v %v: expected %v within %v, got %v (method %d)",
+				p, q, want, maxErr, got, method)
+		}
+		counts[method]++
+	}
+	// Each formula gets used.
+	for method, n := range counts {
+		if n < 100 {
+			t.Fatalf("method %d: expected more uses, got %d", method, n)
+		}
+	}
+	p, q := LatLng{Lat: 0, Lon: 0}, LatLng{Lat: 0, Lon: 1}
+	if d := WGS84.DistanceApprox(p, q, 0); float64(d) != WGS84.distance(p, q) {
+		t.Fatalf("expected the geodesic distance, got %v", d)
+	}
+}
+
+func BenchmarkDistanceApprox(b *testing.B) {
+	p, q := LatLng{Lat: 45, Lon: 10}, LatLng{Lat: 45.01, Lon: 10.01}
+	for i := 0; i < b.N; i++ {
+		WGS84.DistanceApprox(p, q, 0.01)
+	}
+}
@@ -0,0 +1,47 @@
+package geodesic
+
+// AreaConvention selects how the direction of a ring determines its area,
+// for Polygon.Result and PolygonArea. It is a combination of the flags
+// below, which set the reverse and sign parameters of Polygon.Compute.
+type AreaConvention int
+
+const (
+	// AreaCounterClockwise counts counter-clockwise traversal as a
+	// positive area, and gives a negative area for a clockwise ring. This
+	// is the default.
+	AreaCounterClockwise AreaConvention = 0
+	// AreaClockwise counts clockwise traversal as a positive area, and
+	// gives a negative area for a counter-clockwise ring. It is the
+	// reverse parameter of Polygon.Compute.
+	AreaClockwise AreaConvention = 1
+	// AreaComplement, combined with either of the above, gives the area of
+	// the rest of the earth, rather than a negative area, for a ring that
+	// is traversed in the other direction. It is the inverse of the sign
+	// parameter of Polygon.Compute.
+	AreaComplement AreaConvention = 2
+)
+
+// flags returns the reverse and sign parameters of Polygon.Compute.
+func (c AreaConvention) flags() (reverse, sign bool) {
+	return c&AreaClockwise != 0, c&AreaComplement == 0
+}
+
+// WithAreas returns a copy of the ellipsoid that computes areas using the
+// convention. The original ellipsoid is not changed, so this is safe to use
+// on shared values, such as WGS84.
+//
+// The convention applies to Polygon.Result and PolygonArea. Calls that
+// take the reverse and sign flags, such as Polygon.Compute, are not
+// affected.
+func (e *Ellipsoid) WithAreas(c AreaConvention) *Ellipsoid {
+	ec := *e
+	ec.areas = c
+	return &ec
+}
+
+// Result computes the results for a polygon, as Polygon.ComputeResult, with
+// the flags of the area convention of the ellipsoid it was initialized
+// from.
+func (p *Polygon) Result() PolygonResult {
+	return p.ComputeResult(p.e.areas.flags())
+}
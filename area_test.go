@@ -0,0 +1,41 @@
+package geodesic
+
+import "testing"
+
+func TestWithAreas(t *testing.T) {
+	cw := []LatLng{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	area0, _ := PolygonArea(WGS84, cw)
+	if area0 >= 0 || area0 < -2e10 {
+		t.Fatalf("expected a small negative area, got %v", area0)
+	}
+	for _, v := range []struct {
+		c    AreaConvention
+		want float64
+	}{
+		{AreaCounterClockwise, area0},
+		{AreaClockwise, -area0},
+		{AreaClockwise | AreaComplement, -area0},
+		{AreaCounterClockwise | AreaComplement, WGS84.totalArea() + area0},
+	} {
+		e := WGS84.WithAreas(v.c)
+		area, _ := PolygonArea(e, cw)
+		if !eqish(area, v.want, 0) {
+			t.Fatalf("%d: expected %v, got %v", v.c, v.want, area)
+		}
+		p := e.PolygonInit(false)
+		for _, pt := range cw {
+			p.AddPoint(pt.Lat, pt.Lon)
+		}
+		r := p.Result()
+		if r.Area != area {
+			t.Fatalf("%d: expected %v, got %v", v.c, area, r.Area)
+		}
+		if r.Reverse != (v.c&AreaClockwise != 0) ||
+			r.Sign != (v.c&AreaComplement == 0) {
+			t.Fatalf("%d: expected the convention flags, got %+v", v.c, r)
+		}
+	}
+	if WGS84.areas != AreaCounterClockwise {
+		t.Fatalf("expected WGS84 to be unchanged")
+	}
+}
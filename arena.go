@@ -0,0 +1,143 @@
+package geodesic
+
+import "sync"
+
+// arenaBlockSize is the number of values in each block of an Arena.
+const arenaBlockSize = 256
+
+// Arena hands out lines and polygons of an ellipsoid from blocks of memory
+// that are reused.
+//
+// Each line or polygon wraps the state of the geodesic routines, a C
+// struct of several hundred bytes in cgo builds, which the routines fill in
+// through a pointer. That pointer keeps a Line or Polygon returned by value
+// from living on the stack, so each one made by Ellipsoid.InverseLine and
+// friends is a separate allocation for the garbage collector. An arena
+// makes them in place in large blocks instead, and Reset makes the blocks
+// available again, so a loop that makes millions of short lived lines does
+// no allocation once it is warmed up. The C structs stay in Go memory, and
+// only pointers to them, not to the Go values around them, are passed to
+// C, as the cgo pointer rules require.
+//
+// The hot paths of the package, PolygonArea, PolylineLength, DensifySeq,
+// and Resample, take their polygon or lines from a pool of arenas, so they
+// do not allocate for them either. The batch routines, such as
+// Ellipsoid.InverseBatch, solve each problem without a line and need none.
+//
+// The lines and polygons of an arena must not be used after Reset or Free.
+// An Arena is not safe for concurrent use; use an arena per goroutine.
+type Arena struct {
+	e     *Ellipsoid
+	lines arenaBlocks[Line]
+	polys arenaBlocks[Polygon]
+}
+
+// NewArena returns an empty arena for lines and polygons of the ellipsoid.
+func (e *Ellipsoid) NewArena() *Arena {
+	return &Arena{e: e}
+}
+
+// arenas is the pool of arenas of the hot paths, see getArena.
+var arenas = sync.Pool{New: func() any { return new(Arena) }}
+
+// getArena returns an empty arena for the lines and polygons of the
+// ellipsoid, from the pool. It must be handed back with putArena once they
+// are no longer used.
+func (e *Ellipsoid) getArena() *Arena {
+	a := arenas.Get().(*Arena)
+	a.e = e
+	return a
+}
+
+// putArena resets the arena and returns it to the pool.
+func putArena(a *Arena) {
+	a.Reset()
+	a.e = nil
+	arenas.Put(a)
+}
+
+// LineInit returns a geodesic line from the arena, as for
+// Ellipsoid.LineInit.
+func (a *Arena) LineInit(lat1, lon1, azi1 float64) *Line {
+	l := a.lines.next()
+	a.e.lineInit(l, lat1, lon1, azi1)
+	return l
+}
+
+// DirectLine returns a geodesic line from the arena, as for
+// Ellipsoid.DirectLine.
+func (a *Arena) DirectLine(lat1, lon1, azi1, s12 float64) *Line {
+	l := a.lines.next()
+	a.e.directLine(l, lat1, lon1, azi1, s12)
+	return l
+}
+
+// InverseLine returns a geodesic line from the arena, as for
+// Ellipsoid.InverseLine.
+func (a *Arena) InverseLine(lat1, lon1, lat2, lon2 float64) *Line {
+	l := a.lines.next()
+	a.e.inverseLine(l, lat1, lon1, lat2, lon2)
+	return l
+}
+
+// PolygonInit returns a polygon from the arena, as for
+// Ellipsoid.PolygonInit.
+func (a *Arena) PolygonInit(polyline bool) *Polygon {
+	p := a.polys.next()
+	a.e.polygonInit(p, polyline)
+	return p
+}
+
+// Len returns the number of lines and polygons handed out since the arena
+// was made or last reset.
+func (a *Arena) Len() int {
+	return a.lines.n + a.polys.n
+}
+
+// Reset makes all of the memory of the arena available again, closing its
+// polygons. The blocks are kept for reuse.
+func (a *Arena) Reset() {
+	a.polys.each(func(p *Polygon) { p.Close() })
+	a.lines.reset()
+	a.polys.reset()
+}
+
+// Free releases all of the memory of the arena, closing its polygons. The
+// arena may be used again afterwards, starting from no blocks.
+func (a *Arena) Free() {
+	a.Reset()
+	a.lines = arenaBlocks[Line]{}
+	a.polys = arenaBlocks[Polygon]{}
+}
+
+// arenaBlocks is a list of blocks of values, of which the first n values
+// are in use.
+type arenaBlocks[T any] struct {
+	blocks [][]T
+	n      int
+}
+
+// next returns the next free value, zeroed.
+func (b *arenaBlocks[T]) next() *T {
+	i, j := b.n/arenaBlockSize, b.n%arenaBlockSize
+	if i == len(b.blocks) {
+		b.blocks = append(b.blocks, make([]T, arenaBlockSize))
+	}
+	b.n++
+	v := &b.blocks[i][j]
+	var zero T
+	*v = zero
+	return v
+}
+
+// each calls fn for each value in use.
+func (b *arenaBlocks[T]) each(fn func(*T)) {
+	for k := 0; k < b.n; k++ {
+		fn(&b.blocks[k/arenaBlockSize][k%arenaBlockSize])
+	}
+}
+
+// reset marks all values as free, keeping the blocks.
+func (b *arenaBlocks[T]) reset() {
+	b.n = 0
+}
@@ -0,0 +1,25 @@
+//go:build !race
+
+package geodesic
+
+import "testing"
+
+func TestArenaHotPaths(t *testing.T) {
+	// The pooled arenas keep the hot paths from allocating their polygons
+	// and lines. The race detector makes sync.Pool drop items at random,
+	// so they allocate under it, and this file is left out of those
+	// builds.
+	ring := []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	var area, length float64
+	if n := testing.AllocsPerRun(100, func() {
+		area, _ = PolygonArea(WGS84, ring)
+		length = PolylineLength(WGS84, ring)
+		for range DensifySeq(WGS84, ring, 10000) {
+		}
+	}); n != 0 {
+		t.Fatalf("expected 0 allocations, got %v", n)
+	}
+	if !(area > 0 && length > 0) {
+		t.Fatalf("expected an area and a length, got %v, %v", area, length)
+	}
+}
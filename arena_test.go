@@ -0,0 +1,102 @@
+package geodesic
+
+import "testing"
+
+func TestArena(t *testing.T) {
+	a := WGS84.NewArena()
+	l := a.InverseLine(40.64, -73.78, 1.36, 103.99)
+	l0 := WGS84.InverseLine(40.64, -73.78, 1.36, 103.99)
+	if l.Distance() != l0.Distance() || l.Azi1() != l0.Azi1() {
+		t.Fatalf("expected '%v, %v', got '%v, %v'",
+			l0.Distance(), l0.Azi1(), l.Distance(), l.Azi1())
+	}
+	d := a.DirectLine(40.64, -73.78, 45, 1e6)
+	d0 := WGS84.DirectLine(40.64, -73.78, 45, 1e6)
+	var lat, lon, lat0, lon0 float64
+	d.Position(d.Distance(), &lat, &lon, nil)
+	d0.Position(d0.Distance(), &lat0, &lon0, nil)
+	if lat != lat0 || lon != lon0 {
+		t.Fatalf("expected '%v, %v', got '%v, %v'", lat0, lon0, lat, lon)
+	}
+	if s := a.LineInit(0, 0, 90).Distance(); s == s {
+		t.Fatalf("expected NaN, got %v", s)
+	}
+	p := a.PolygonInit(false)
+	p.AddPoint(0, 0)
+	p.AddPoint(0, 90)
+	p.AddPoint(90, 0)
+	var area float64
+	p.Compute(false, true, &area, nil)
+	if want := WGS84.totalArea() / 8; !eqish(area, want, 0) {
+		t.Fatalf("expected %v, got %v", want, area)
+	}
+	if a.Len() != 4 {
+		t.Fatalf("expected 4, got %d", a.Len())
+	}
+	// Values beyond the first block are handed out from a new one.
+	for i := 0; i < 2*arenaBlockSize; i++ {
+		a.InverseLine(0, 0, 1, float64(i))
+	}
+	if a.Len() != 4+2*arenaBlockSize {
+		t.Fatalf("expected %d, got %d", 4+2*arenaBlockSize, a.Len())
+	}
+	a.Reset()
+	if a.Len() != 0 {
+		t.Fatalf("expected 0, got %d", a.Len())
+	}
+	// A reused polygon starts over with nothing from the last one.
+	if q := a.PolygonInit(false); q != p {
+		t.Fatalf("expected the polygon to be reused")
+	}
+	if p.RemoveLastPoint() || p.Compute(false, true, nil, nil) != 0 {
+		t.Fatalf("expected an empty polygon")
+	}
+	a.Free()
+	if a.Len() != 0 || len(a.lines.blocks) != 0 {
+		t.Fatalf("expected an empty arena")
+	}
+}
+
+func TestArenaAllocs(t *testing.T) {
+	a := WGS84.NewArena()
+	var lat float64
+	if n := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 1000; i++ {
+			l := a.InverseLine(40.64, -73.78, 1.36, float64(i%180))
+			l.Position(l.Distance()/2, &lat, nil, nil)
+		}
+		p := a.PolygonInit(false)
+		p.AddPoint(0, 0)
+		p.AddPoint(0, 90)
+		p.Compute(false, true, nil, nil)
+		a.Reset()
+	}); n != 0 {
+		t.Fatalf("expected 0 allocations, got %v", n)
+	}
+}
+
+func TestPolygonClose(t *testing.T) {
+	p := WGS84.PolygonInit(false)
+	p.AddPoint(0, 0)
+	p.Close()
+	p.Close()
+}
+
+func BenchmarkArenaInverseLine(b *testing.B) {
+	a := WGS84.NewArena()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.InverseLine(40.64, -73.78, 1.36, 103.99)
+		if a.Len() == 1024 {
+			a.Reset()
+		}
+	}
+}
+
+func BenchmarkInverseLine(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l := WGS84.InverseLine(40.64, -73.78, 1.36, 103.99)
+		_ = l.Distance()
+	}
+}
@@ -0,0 +1,24 @@
+package geodesic
+
+// AssignNearest returns the index of the nearest site for each point.
+//
+// Param points is the set of points to classify.
+// Param sites is the set of sites.
+// Returns, for each point, the index into sites of the nearest site by
+// geodesic distance, or -1 when there are no sites.
+//
+// This is a discrete Voronoi classification on the ellipsoid. The sites
+// are prepared once as a PointSet, and each point is classified with
+// PointSet.KNearest, whose chord prefilter leaves only the sites near the
+// answer to be solved. Ties go to the site with the lowest index.
+func (e *Ellipsoid) AssignNearest(points, sites []LatLng) []int {
+	set := e.NewPointSet(sites)
+	out := make([]int, len(points))
+	for i, p := range points {
+		out[i] = -1
+		if nb := set.KNearest(p, 1); len(nb) > 0 {
+			out[i] = nb[0].Index
+		}
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package geodesic
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAssignNearest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var sites, points []LatLng
+	for i := 0; i < 20; i++ {
+		sites = append(sites, LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180})
+	}
+	for i := 0; i < 200; i++ {
+		points = append(points, LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180})
+	}
+	out := WGS84.AssignNearest(points, sites)
+	for i, p := range points {
+		best := -1
+		var bestDist float64
+		for j, s := range sites {
+			if d := WGS84.distance(p, s); best == -1 || d < bestDist {
+				best, bestDist = j, d
+			}
+		}
+		if out[i] != best {
+			t.Fatalf("point %d: expected site %d, got %d", i, best, out[i])
+		}
+	}
+	if out := WGS84.AssignNearest(points[:1], nil); out[0] != -1 {
+		t.Fatalf("expected -1, got %d", out[0])
+	}
+}
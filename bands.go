@@ -0,0 +1,220 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+)
+
+// LatitudeBandAreas returns the area of the inside of a ring in each of a
+// set of latitude bands.
+//
+// Param ring is the vertices of the ring, as for Ellipsoid.RingContains.
+// Param breaks is the latitudes between the bands (degrees), increasing.
+// Returns the areas (meters-squared) of the len(breaks)+1 bands, from the
+// one south of breaks[0] to the one north of the last break.
+//
+// The bands are bounded by parallels, which are not geodesics, so the
+// ring is split where its edges cross each break and the part of each
+// edge in a band is measured against the parallel with the zone area of
+// the ellipsoid. Nothing is approximated: the areas add up to the area of
+// the ring, and a band that is a whole polar cap or zone of a ring around
+// a pole gets the exact area of the cap or zone. Returns nil if the breaks
+// are not increasing or not in [-90,+90], see
+// Ellipsoid.CheckedLatitudeBandAreas for the reason. A ring with fewer
+// than three distinct vertices has no inside, and all of its areas are
+// zero.
+func (e *Ellipsoid) LatitudeBandAreas(ring []LatLng, breaks []float64) []float64 {
+	for i, lat := range breaks {
+		if !(lat >= -90 && lat <= 90) || (i > 0 && !(lat > breaks[i-1])) {
+			return nil
+		}
+	}
+	areas := make([]float64, len(breaks)+1)
+	r := e.newRingRegion(ring)
+	if len(r.edges) == 0 {
+		return areas
+	}
+	parts := r.bandParts()
+	p := e.PolygonInit(false)
+	defer p.Close()
+	// Each band is the difference of the areas north of its two sides.
+	north := 0.0
+	for i := len(breaks); i >= 0; i-- {
+		lat := -90.0
+		if i > 0 {
+			lat = breaks[i-1]
+		}
+		a := e.areaNorthOf(&r, parts, lat, &p)
+		areas[i] = math.Max(a-north, 0)
+		north = a
+	}
+	return areas
+}
+
+// CheckedLatitudeBandAreas returns the area of the inside of a ring in each
+// of a set of latitude bands, as Ellipsoid.LatitudeBandAreas, and reports
+// the problems with the ring and the breaks as errors.
+//
+// Param ring is the vertices of the ring.
+// Param breaks is the latitudes between the bands (degrees), increasing.
+// Returns the areas, or an error: ErrInvalidLatitude if a latitude of the
+// ring is out of range under the LatitudePolicy of the ellipsoid, or a
+// break is outside of [-90,+90], ErrInvalidLongitude if a longitude of
+// the ring is not a finite number, or ErrInvalidArgument if a break is not
+// above the one before it. The errors are wrapped with the index of the vertex or break.
+func (e *Ellipsoid) CheckedLatitudeBandAreas(ring []LatLng, breaks []float64,
+) ([]float64, error) {
+	pts, err := checkPoints(e, ring)
+	if err != nil {
+		return nil, err
+	}
+	for i, lat := range breaks {
+		if !(lat >= -90 && lat <= 90) {
+			return nil, fmt.Errorf("%w: break %v at %d", ErrInvalidLatitude,
+				lat, i)
+		}
+		if i > 0 && !(lat > breaks[i-1]) {
+			return nil, fmt.Errorf("%w: break %v at %d is not above %v",
+				ErrInvalidArgument, lat, i, breaks[i-1])
+		}
+	}
+	return e.LatitudeBandAreas(pts, breaks), nil
+}
+
+// bandPart is a part of a ring edge along which the latitude is monotonic.
+type bandPart struct {
+	ed         *ringEdge
+	s0, s1     float64 // distances along the edge (meters)
+	lat0, lat1 float64 // latitudes at s0 and s1 (degrees)
+}
+
+// bandParts splits the edges of the ring at their vertices, where the
+// latitude stops increasing or decreasing.
+func (r *ringRegion) bandParts() []bandPart {
+	var parts []bandPart
+	for i := range r.edges {
+		ed := &r.edges[i]
+		azi1 := ed.l.Azi1()
+		var azi2 float64
+		ed.l.Position(ed.s12, nil, nil, &azi2)
+		c1 := math.Cos(azi1 * (math.Pi / 180))
+		c2 := math.Cos(azi2 * (math.Pi / 180))
+		if (c1 > 0 && c2 < 0) || (c1 < 0 && c2 > 0) {
+			lo := vertexDist(&ed.l, 0, ed.s12, c1 > 0)
+			var lat float64
+			ed.l.Position(lo, &lat, nil, nil)
+			parts = append(parts,
+				bandPart{ed, 0, lo, ed.a.Lat, lat},
+				bandPart{ed, lo, ed.s12, lat, ed.b.Lat})
+		} else {
+			parts = append(parts, bandPart{ed, 0, ed.s12, ed.a.Lat, ed.b.Lat})
+		}
+	}
+	return parts
+}
+
+// vertexDist returns the distance along l, between lo and hi, of the
+// vertex where the azimuth crosses east or west, which must be between
+// them. Param north is whether l heads north at lo.
+func vertexDist(l *Line, lo, hi float64, north bool) float64 {
+	for j := 0; j < 64 && hi-lo > 1e-9; j++ {
+		mid := (lo + hi) / 2
+		var azi float64
+		l.Position(mid, nil, nil, &azi)
+		if (math.Cos(azi*(math.Pi/180)) > 0) == north {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// areaNorthOf returns the area of the inside of the ring north of the
+// parallel at lat, using p as scratch space.
+//
+// With the inside on the left, the area of a region of the polar cap north
+// of lat is minus the integral of Z(lat)-Z(lat0) over the longitude around
+// its boundary, where Z is the zone area, plus the area of the cap if the
+// region contains the pole. The integrand is zero on the parallel, so only
+// the parts of the edges north of lat contribute.
+func (e *Ellipsoid) areaNorthOf(r *ringRegion, parts []bandPart, lat float64,
+	p *Polygon,
+) float64 {
+	z0 := e.zoneArea(lat)
+	var sum float64
+	for _, pt := range parts {
+		in0, in1 := pt.lat0 >= lat, pt.lat1 >= lat
+		s0, s1 := pt.s0, pt.s1
+		switch {
+		case !in0 && !in1:
+			continue
+		case in0 && !in1:
+			s1 = pt.crossing(lat)
+		case !in0 && in1:
+			s0 = pt.crossing(lat)
+		}
+		sum += e.zoneIntegral(pt.ed, s0, s1, z0, p, 0)
+	}
+	capArea := 2 * math.Pi * (e.zoneArea(90) - z0)
+	area := -sum
+	if r.northIn == r.leftIn {
+		area += capArea
+	}
+	if !r.leftIn {
+		area = capArea - area
+	}
+	return math.Max(area, 0)
+}
+
+// crossing returns the distance along the edge at which the part crosses
+// the parallel at lat, which it must cross.
+func (pt *bandPart) crossing(lat float64) float64 {
+	lo, hi := pt.s0, pt.s1
+	up := pt.lat1 > pt.lat0
+	for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+		mid := (lo + hi) / 2
+		var lat2 float64
+		pt.ed.l.Position(mid, &lat2, nil, nil)
+		if (lat2 < lat) == up {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// zoneIntegral returns the integral of Z(lat)-z0 over the longitude along
+// the edge from s0 to s1, where Z is the zone area.
+//
+// The integral of Z alone is the area between the edge and the equator,
+// which is the area of the geodesic quadrilateral made by the edge, the
+// meridians through its ends, and the equator. That is only one shape for
+// less than 90 degrees of longitude, so longer pieces are split.
+func (e *Ellipsoid) zoneIntegral(ed *ringEdge, s0, s1, z0 float64,
+	p *Polygon, depth int,
+) float64 {
+	var a, b LatLng
+	ed.l.Position(s0, &a.Lat, &a.Lon, nil)
+	ed.l.Position(s1, &b.Lat, &b.Lon, nil)
+	dlon := math.Remainder(b.Lon-a.Lon, 360)
+	if math.Abs(dlon) > 90 && depth < 32 {
+		mid := (s0 + s1) / 2
+		return e.zoneIntegral(ed, s0, mid, z0, p, depth+1) +
+			e.zoneIntegral(ed, mid, s1, z0, p, depth+1)
+	}
+	if dlon == 0 {
+		return 0
+	}
+	p.Clear()
+	p.AddPoint(a.Lat, a.Lon)
+	p.AddPoint(b.Lat, b.Lon)
+	p.AddPoint(0, b.Lon)
+	p.AddPoint(0, a.Lon)
+	var area float64
+	p.Compute(false, true, &area, nil)
+	// The quadrilateral goes clockwise around the area north of the
+	// equator when the edge heads east.
+	return -area - z0*dlon*(math.Pi/180)
+}
@@ -0,0 +1,104 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLatitudeBandAreas(t *testing.T) {
+	// The edges of a square on the equator are meridians and the equator,
+	// but for the northern edge, which bulges toward the pole. Below lat 9
+	// the square is a latitude/longitude rectangle.
+	ring := []LatLng{{0, 0}, {0, 10}, {10, 10}, {10, 0}}
+	total, _ := PolygonArea(WGS84, ring)
+	areas := WGS84.LatitudeBandAreas(ring, []float64{-1, 5, 9})
+	want := []float64{
+		0,
+		WGS84.RectArea(0, 0, 5, 10),
+		WGS84.RectArea(5, 0, 9, 10),
+		total - WGS84.RectArea(0, 0, 9, 10),
+	}
+	for i := range want {
+		if !eqish(areas[i], want[i], 0) {
+			t.Fatalf("band %d: expected %v, got %v", i, want[i], areas[i])
+		}
+	}
+	// The direction of the ring does not matter.
+	rev := []LatLng{{10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	for i, a := range WGS84.LatitudeBandAreas(rev, []float64{-1, 5, 9}) {
+		if !eqish(a, want[i], 0) {
+			t.Fatalf("band %d: expected %v, got %v", i, want[i], a)
+		}
+	}
+
+	// A ring around the north pole, for which the band north of its
+	// vertices is the whole polar cap.
+	var around []LatLng
+	for lon := -180.0; lon < 180; lon += 30 {
+		around = append(around, LatLng{80, lon})
+	}
+	total, _ = PolygonArea(WGS84, around)
+	areas = WGS84.LatitudeBandAreas(around, []float64{0, 85})
+	polar := WGS84.RectArea(85, -180, 90, 180)
+	prec := seriesPrec(0)
+	if !eqish(areas[0], 0, prec) || !eqish(areas[2], polar, prec) ||
+		!eqish(areas[1], total-polar, prec) {
+		t.Fatalf("expected '0, %v, %v', got %v", total-polar, polar, areas)
+	}
+
+	// A triangle across the equator, whose bands add up to its area
+	// however it is split.
+	tri := []LatLng{{-20, 10}, {30, 40}, {15, -25}}
+	total, _ = PolygonArea(WGS84, tri)
+	fine := WGS84.LatitudeBandAreas(tri, []float64{-10, 0, 7, 20})
+	coarse := WGS84.LatitudeBandAreas(tri, []float64{0, 20})
+	var sum float64
+	for _, a := range fine {
+		sum += a
+	}
+	if !eqish(sum, total, prec) {
+		t.Fatalf("expected %v, got %v", total, sum)
+	}
+	if !eqish(fine[0]+fine[1], coarse[0], 0) ||
+		!eqish(fine[2]+fine[3], coarse[1], 0) || !eqish(fine[4], coarse[2], 0) {
+		t.Fatalf("expected %v to refine %v", fine, coarse)
+	}
+
+	if areas := WGS84.LatitudeBandAreas(ring, []float64{5, 5}); areas != nil {
+		t.Fatalf("expected nil, got %v", areas)
+	}
+	if areas := WGS84.LatitudeBandAreas(ring, []float64{math.NaN()}); areas != nil {
+		t.Fatalf("expected nil, got %v", areas)
+	}
+	if areas := WGS84.LatitudeBandAreas(ring[:2], nil); len(areas) != 1 || areas[0] != 0 {
+		t.Fatalf("expected [0], got %v", areas)
+	}
+}
+
+func TestCheckedLatitudeBandAreas(t *testing.T) {
+	ring := []LatLng{{0, 0}, {0, 10}, {10, 10}, {10, 0}}
+	areas, err := WGS84.CheckedLatitudeBandAreas(ring, []float64{5})
+	want := WGS84.LatitudeBandAreas(ring, []float64{5})
+	if err != nil || len(areas) != 2 || areas[0] != want[0] ||
+		areas[1] != want[1] {
+		t.Fatalf("expected %v, got %v, %v", want, areas, err)
+	}
+	for _, c := range []struct {
+		ring   []LatLng
+		breaks []float64
+		err    error
+	}{
+		{ring, []float64{5, 5}, ErrInvalidArgument},
+		{ring, []float64{5, math.NaN()}, ErrInvalidLatitude},
+		{ring, []float64{-91}, ErrInvalidLatitude},
+		{[]LatLng{{0, 0}, {91, 10}, {10, 0}}, nil, ErrInvalidLatitude},
+		{[]LatLng{{0, 0}, {5, math.Inf(1)}, {10, 0}}, nil, ErrInvalidLongitude},
+	} {
+		if areas, err := WGS84.CheckedLatitudeBandAreas(c.ring,
+			c.breaks); !errors.Is(err, c.err) || areas != nil {
+			t.Fatalf("%v %v: expected %v, got %v, %v", c.ring, c.breaks,
+				c.err, areas, err)
+		}
+	}
+}
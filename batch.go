@@ -0,0 +1,199 @@
+package geodesic
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchOptions are the options for the batch operations.
+type BatchOptions struct {
+	// Workers is the number of goroutines to use. Zero or less uses
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// Progress, if set, is called as work completes with the number of
+	// problems solved so far and the total. Calls are never made
+	// concurrently, and done only increases.
+	Progress func(done, total int)
+	// MaxDistance, if positive, is the largest distance of interest to
+	// Ellipsoid.DistanceMatrix (meters). Pairs that are further apart are
+	// +Inf in the matrix, and most of them are ruled out by a cheap chord
+	// test without solving the inverse problem.
+	MaxDistance float64
+}
+
+// ErrLengthMismatch is returned by the batch operations when the input
+// slices have different lengths.
+var ErrLengthMismatch = errors.New("geodesic: input lengths do not match")
+
+// batchChunk is the number of problems that a worker solves between
+// checking the context and reporting progress.
+const batchChunk = 256
+
+// runBatch calls fn for every index in [0,total) across the workers. It
+// returns the error of ctx only if some indexes were left undone.
+func runBatch(ctx context.Context, total int, opts *BatchOptions, fn func(i int)) error {
+	var o BatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	var next int64
+	var mu sync.Mutex
+	var done int
+	var wg sync.WaitGroup
+	for w := 0; w < o.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				start := int(atomic.AddInt64(&next, batchChunk)) - batchChunk
+				if start >= total {
+					return
+				}
+				end := start + batchChunk
+				if end > total {
+					end = total
+				}
+				for i := start; i < end; i++ {
+					fn(i)
+				}
+				mu.Lock()
+				done += end - start
+				if o.Progress != nil {
+					o.Progress(done, total)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if done == total {
+		// A context that is done just as the last chunk finishes does not
+		// fail a batch that has every result.
+		return nil
+	}
+	return ctx.Err()
+}
+
+// InverseSolution is one solution from Ellipsoid.InverseBatch.
+type InverseSolution struct {
+	S12  float64 // distance from point 1 to point 2 (meters)
+	Azi1 float64 // azimuth at point 1 (degrees)
+	Azi2 float64 // (forward) azimuth at point 2 (degrees)
+}
+
+// InverseBatch solves many inverse geodesic problems in parallel.
+//
+// Param ctx is checked between chunks of work; when it is done, the batch
+// stops early and returns the context's error with partial results.
+// Param p1 is the points 1.
+// Param p2 is the points 2, which must be the same length as p1.
+// Param opts are the options, or nil for the defaults.
+// Returns the solution of each problem, in the order of the inputs.
+func (e *Ellipsoid) InverseBatch(ctx context.Context, p1, p2 []LatLng,
+	opts *BatchOptions,
+) ([]InverseSolution, error) {
+	if len(p1) != len(p2) {
+		return nil, ErrLengthMismatch
+	}
+	out := make([]InverseSolution, len(p1))
+	err := runBatch(ctx, len(p1), opts, func(i int) {
+		r := &out[i]
+		e.Inverse(p1[i].Lat, p1[i].Lon, p2[i].Lat, p2[i].Lon,
+			&r.S12, &r.Azi1, &r.Azi2)
+	})
+	return out, err
+}
+
+// DirectSolution is one solution from Ellipsoid.DirectBatch.
+type DirectSolution struct {
+	Point LatLng  // point 2
+	Azi2  float64 // (forward) azimuth at point 2 (degrees)
+}
+
+// DirectBatch solves many direct geodesic problems in parallel.
+//
+// Param ctx is checked between chunks of work; when it is done, the batch
+// stops early and returns the context's error with partial results.
+// Param p1 is the points 1.
+// Param azi1 is the azimuths at points 1 (degrees).
+// Param s12 is the distances from points 1 to points 2 (meters).
+// Param opts are the options, or nil for the defaults.
+// Returns the solution of each problem, in the order of the inputs.
+//
+// The inputs must all be the same length.
+func (e *Ellipsoid) DirectBatch(ctx context.Context, p1 []LatLng,
+	azi1, s12 []float64, opts *BatchOptions,
+) ([]DirectSolution, error) {
+	if len(p1) != len(azi1) || len(p1) != len(s12) {
+		return nil, ErrLengthMismatch
+	}
+	out := make([]DirectSolution, len(p1))
+	err := runBatch(ctx, len(p1), opts, func(i int) {
+		r := &out[i]
+		e.Direct(p1[i].Lat, p1[i].Lon, azi1[i], s12[i],
+			&r.Point.Lat, &r.Point.Lon, &r.Azi2)
+	})
+	return out, err
+}
+
+// DistanceMatrix computes the geodesic distances between every point in
+// from and every point in to, in parallel.
+//
+// Param ctx is checked between chunks of work; when it is done, the batch
+// stops early and returns the context's error with partial results.
+// Param from is the origins.
+// Param to is the destinations.
+// Param opts are the options, or nil for the defaults. Progress counts
+// individual distances.
+// Returns the matrix, where m[i][j] is the distance from from[i] to to[j]
+// (meters), or +Inf if it is beyond opts.MaxDistance.
+func (e *Ellipsoid) DistanceMatrix(ctx context.Context, from, to []LatLng,
+	opts *BatchOptions,
+) ([][]float64, error) {
+	cells := make([]float64, len(from)*len(to))
+	m := make([][]float64, len(from))
+	for i := range m {
+		m[i] = cells[i*len(to) : (i+1)*len(to)]
+	}
+	if opts != nil && opts.MaxDistance > 0 {
+		limit := opts.MaxDistance
+		fb, tb := e.newECEFBlock(from), e.newECEFBlock(to)
+		// Work by rows, so that each row is prefiltered in one pass.
+		rows := &BatchOptions{Workers: opts.Workers}
+		if opts.Progress != nil {
+			rows.Progress = func(done, total int) {
+				opts.Progress(done*len(to), total*len(to))
+			}
+		}
+		err := runBatch(ctx, len(from), rows, func(i int) {
+			row := m[i]
+			tb.chord2(fb.x[i], fb.y[i], fb.z[i], row)
+			for j, c := range row {
+				row[j] = math.Inf(1)
+				if c > chordBound(limit) {
+					continue
+				}
+				var s12 float64
+				e.Inverse(from[i].Lat, from[i].Lon, to[j].Lat, to[j].Lon,
+					&s12, nil, nil)
+				if s12 <= limit {
+					row[j] = s12
+				}
+			}
+		})
+		return m, err
+	}
+	err := runBatch(ctx, len(cells), opts, func(k int) {
+		i, j := k/len(to), k%len(to)
+		e.Inverse(from[i].Lat, from[i].Lon, to[j].Lat, to[j].Lon,
+			&cells[k], nil, nil)
+	})
+	return m, err
+}
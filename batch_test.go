@@ -0,0 +1,114 @@
+package geodesic
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func randPoints(rng *rand.Rand, n int) []LatLng {
+	pts := make([]LatLng, n)
+	for i := range pts {
+		pts[i] = LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+	}
+	return pts
+}
+
+func TestInverseBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	p1, p2 := randPoints(rng, 1000), randPoints(rng, 1000)
+	var last int
+	out, err := WGS84.InverseBatch(context.Background(), p1, p2,
+		&BatchOptions{Workers: 4, Progress: func(done, total int) {
+			if done <= last || total != 1000 {
+				t.Errorf("bad progress %d/%d after %d", done, total, last)
+			}
+			last = done
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last != 1000 {
+		t.Fatalf("expected progress to reach 1000, got %d", last)
+	}
+	for i := range p1 {
+		var r InverseSolution
+		WGS84.Inverse(p1[i].Lat, p1[i].Lon, p2[i].Lat, p2[i].Lon,
+			&r.S12, &r.Azi1, &r.Azi2)
+		if out[i] != r {
+			t.Fatalf("expected %v, got %v", r, out[i])
+		}
+	}
+	if _, err := WGS84.InverseBatch(context.Background(), p1, p2[1:],
+		nil); err != ErrLengthMismatch {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := WGS84.InverseBatch(ctx, p1, p2, nil); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRunBatchDone(t *testing.T) {
+	// A context canceled as the last chunk finishes leaves nothing undone,
+	// so the batch succeeds.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := 0
+	err := runBatch(ctx, 2*batchChunk, &BatchOptions{Workers: 1,
+		Progress: func(done, total int) {
+			if done == total {
+				cancel()
+			}
+		}}, func(int) { n++ })
+	if err != nil || n != 2*batchChunk {
+		t.Fatalf("expected %d and no error, got %d, %v", 2*batchChunk, n, err)
+	}
+	// Canceled halfway, it fails.
+	ctx, cancel = context.WithCancel(context.Background())
+	err = runBatch(ctx, 2*batchChunk, &BatchOptions{Workers: 1,
+		Progress: func(int, int) { cancel() }}, func(int) {})
+	if err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestDirectBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	p1 := randPoints(rng, 600)
+	azi1 := make([]float64, len(p1))
+	s12 := make([]float64, len(p1))
+	for i := range p1 {
+		azi1[i] = rng.Float64()*360 - 180
+		s12[i] = rng.Float64() * 1e7
+	}
+	out, err := WGS84.DirectBatch(context.Background(), p1, azi1, s12, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range p1 {
+		var r DirectSolution
+		WGS84.Direct(p1[i].Lat, p1[i].Lon, azi1[i], s12[i],
+			&r.Point.Lat, &r.Point.Lon, &r.Azi2)
+		if out[i] != r {
+			t.Fatalf("expected %v, got %v", r, out[i])
+		}
+	}
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	from, to := randPoints(rng, 30), randPoints(rng, 40)
+	m, err := WGS84.DistanceMatrix(context.Background(), from, to, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range from {
+		for j := range to {
+			if d := WGS84.distance(from[i], to[j]); m[i][j] != d {
+				t.Fatalf("[%d][%d]: expected %f, got %f", i, j, d, m[i][j])
+			}
+		}
+	}
+}
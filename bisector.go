@@ -0,0 +1,123 @@
+package geodesic
+
+import "math"
+
+// EquidistantBoundary returns the points that are the same geodesic
+// distance from two points, the geodesic analog of the perpendicular
+// bisector, as polylines within a region.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+// Param region is the region of interest; Bounds{-90, -180, 90, 180} is
+// the whole ellipsoid.
+// Param step is the spacing of the points along the boundary (meters).
+// Returns the parts of the boundary within the region, in order along it,
+// or nil if the points are the same or step is not positive.
+//
+// The boundary is a closed curve around the ellipsoid, which divides it
+// into the points nearer to p1 and those nearer to p2, as between two
+// neighboring sites of a Voronoi partition. It is traced from the midpoint
+// of the geodesic between the points, with each new point corrected until
+// its distances to p1 and p2 differ by less than a micrometer, and the
+// parts of it outside of the region are dropped, so that each of the
+// returned polylines starts and ends within step of the edge of the region,
+// or the trace closes on itself within it. The number of points is about
+// the length of the boundary over step; a step of 10 km gives about 4000
+// points around the earth.
+func (e *Ellipsoid) EquidistantBoundary(p1, p2 LatLng, region Bounds,
+	step float64,
+) [][]LatLng {
+	if !(step > 0) || coincidentPoints(p1.Lat, p1.Lon, p2.Lat, p2.Lon) {
+		return nil
+	}
+	loop := e.bisectorLoop(p1, p2, step)
+	// Start from a point outside of the region, if there is one, so that
+	// no part is split at the start of the loop.
+	ring := loop[:len(loop)-1]
+	start := 0
+	for start < len(ring) && region.contains(ring[start]) {
+		start++
+	}
+	if start == len(ring) {
+		return [][]LatLng{loop}
+	}
+	var parts [][]LatLng
+	var part []LatLng
+	for i := range ring {
+		p := ring[(start+i)%len(ring)]
+		if region.contains(p) {
+			part = append(part, p)
+		} else if part != nil {
+			parts = append(parts, part)
+			part = nil
+		}
+	}
+	if part != nil {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// bisectorLoop returns the points of the equidistant boundary of p1 and p2
+// at steps along it, closed by repeating the first point.
+func (e *Ellipsoid) bisectorLoop(p1, p2 LatLng, step float64) []LatLng {
+	l := e.InverseLine(p1.Lat, p1.Lon, p2.Lat, p2.Lon)
+	var m LatLng
+	var azi float64
+	l.Position(l.Distance()/2, &m.Lat, &m.Lon, &azi)
+	// The curve is at most as long as a meridian of the larger of the two
+	// radii, with some slack for the steps that it cuts short.
+	a := math.Max(e.Radius(), e.Radius()*(1-e.Flattening()))
+	maxSteps := int(math.Ceil(1.1*2*math.Pi*a/step)) + 10
+
+	loop := []LatLng{m}
+	heading := azi + 90
+	q := m
+	var traveled float64
+	for i := 0; i < maxSteps; i++ {
+		var next LatLng
+		var arrive float64
+		e.Direct(q.Lat, q.Lon, heading, step, &next.Lat, &next.Lon, &arrive)
+		next = e.bisectorCorrect(p1, p2, next)
+		traveled += step
+		loop = append(loop, next)
+		if traveled > 2*step && e.distance(next, m) <= step {
+			break
+		}
+		// Keep on along the tangent of the curve that is nearer the course
+		// arrived on.
+		_, gaz, _ := e.bisectorGradient(p1, p2, next)
+		heading = gaz + 90
+		if math.Abs(math.Remainder(heading-arrive, 360)) > 90 {
+			heading = gaz - 90
+		}
+		q = next
+	}
+	return append(loop, m)
+}
+
+// bisectorCorrect returns the point of the equidistant boundary of p1 and
+// p2 near q, by Newton's method along the gradient.
+func (e *Ellipsoid) bisectorCorrect(p1, p2, q LatLng) LatLng {
+	for i := 0; i < 10; i++ {
+		f, gaz, gn := e.bisectorGradient(p1, p2, q)
+		if math.Abs(f) < 1e-6 || !(gn > 0) {
+			break
+		}
+		e.Direct(q.Lat, q.Lon, gaz, -f/gn, &q.Lat, &q.Lon, nil)
+	}
+	return q
+}
+
+// bisectorGradient returns the difference f of the distances of q from p1
+// and p2 (meters), and the azimuth (degrees) and size of its gradient,
+// which is the rate of change of f with distance along that azimuth.
+func (e *Ellipsoid) bisectorGradient(p1, p2, q LatLng) (f, gaz, gn float64) {
+	var s1, a1, s2, a2 float64
+	e.Inverse(p1.Lat, p1.Lon, q.Lat, q.Lon, &s1, nil, &a1)
+	e.Inverse(p2.Lat, p2.Lon, q.Lat, q.Lon, &s2, nil, &a2)
+	sa1, ca1 := sincosd(a1)
+	sa2, ca2 := sincosd(a2)
+	gx, gy := sa1-sa2, ca1-ca2
+	return s1 - s2, math.Atan2(gx, gy) * (180 / math.Pi), math.Hypot(gx, gy)
+}
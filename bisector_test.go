@@ -0,0 +1,75 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEquidistantBoundary(t *testing.T) {
+	world := Bounds{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}
+
+	// On a sphere the boundary of two points on the equator is the great
+	// circle of the meridians halfway between them.
+	sphere := NewEllipsoid(6371e3, 0)
+	parts := sphere.EquidistantBoundary(LatLng{0, 0}, LatLng{0, 10}, world,
+		50e3)
+	if len(parts) != 1 {
+		t.Fatalf("expected one closed boundary, got %d parts", len(parts))
+	}
+	loop := parts[0]
+	if n := len(loop); n < 790 || n > 810 || loop[0] != loop[n-1] {
+		t.Fatalf("expected a closed loop of about 800 points, got %d", n)
+	}
+	for _, p := range loop {
+		if math.Abs(p.Lat) < 89.9 && !eqish(math.Abs(math.Remainder(p.Lon-5, 180)), 0, 7) {
+			t.Fatalf("expected the meridians 5 and -175, got %v", p)
+		}
+	}
+
+	for _, c := range []struct{ p1, p2 LatLng }{
+		{LatLng{51.5, -0.1}, LatLng{48.9, 2.4}},
+		{LatLng{-33.9, 18.4}, LatLng{40.7, -74}},
+		{LatLng{89, 0}, LatLng{-89, 180}},
+	} {
+		parts := WGS84.EquidistantBoundary(c.p1, c.p2, world, 100e3)
+		if len(parts) != 1 {
+			t.Fatalf("%v: expected one closed boundary, got %d parts", c,
+				len(parts))
+		}
+		loop := parts[0]
+		var length float64
+		for i, p := range loop {
+			d := WGS84.distance(p, c.p1) - WGS84.distance(p, c.p2)
+			if math.Abs(d) > seriesTolerance(1e-6) {
+				t.Fatalf("%v: expected %v to be equidistant, got %v", c, p, d)
+			}
+			if i > 0 {
+				s := WGS84.distance(loop[i-1], p)
+				if s > 101e3 {
+					t.Fatalf("%v: expected steps up to 100 km, got %v", c, s)
+				}
+				length += s
+			}
+		}
+		if length < 39e6 || length > 40.1e6 {
+			t.Fatalf("%v: expected the length of a great ellipse, got %v", c,
+				length)
+		}
+	}
+
+	// A region cuts the boundary into parts, each within it.
+	region := Bounds{MinLat: 40, MinLon: -10, MaxLat: 60, MaxLon: 10}
+	parts = WGS84.EquidistantBoundary(LatLng{51.5, -0.1}, LatLng{48.9, 2.4},
+		region, 10e3)
+	if len(parts) != 1 || len(parts[0]) < 150 {
+		t.Fatalf("expected one part across the region, got %v", parts)
+	}
+	for _, p := range parts[0] {
+		if !region.contains(p) {
+			t.Fatalf("expected %v to be in the region", p)
+		}
+	}
+	if parts := WGS84.EquidistantBoundary(LatLng{1, 2}, LatLng{1, 2}, world, 1e3); parts != nil {
+		t.Fatalf("expected nil, got %v", parts)
+	}
+}
@@ -0,0 +1,33 @@
+package geodesic
+
+// Ellipsoids of other solar system bodies, from the IAU Working Group on
+// Cartographic Coordinates and Rotational Elements (WGCCRE) 2015 report,
+// Archinal et al., Celest. Mech. Dyn. Astr. 130:22 (2018).
+//
+// The geodesic routines work on any body; only the radius and flattening
+// change. The latitudes are planetographic, measured from the normal to
+// the ellipsoid like geodetic latitudes on the earth. Bodies that the
+// report models as spheres have a flattening of zero, and Mercury, which
+// it models with slightly different equatorial radii, uses their mean.
+var (
+	Mercury = NewIAUEllipsoid(2440530, 2438260)
+	Venus   = NewIAUEllipsoid(6051800, 6051800)
+	Moon    = NewIAUEllipsoid(1737400, 1737400)
+	Mars    = NewIAUEllipsoid(3396190, 3376200)
+	Jupiter = NewIAUEllipsoid(71492000, 66854000)
+	Saturn  = NewIAUEllipsoid(60268000, 54364000)
+	Uranus  = NewIAUEllipsoid(25559000, 24973000)
+	Neptune = NewIAUEllipsoid(24764000, 24341000)
+	Pluto   = NewIAUEllipsoid(1188300, 1188300)
+)
+
+// NewIAUEllipsoid initializes a new geodesic ellipsoid from the radii that
+// the IAU reports give for a body.
+// Param equatorial is the equatorial radius (meters).
+// Param polar is the polar radius (meters).
+//
+// The IAU tables are in kilometers, so multiply by 1000. The flattening is
+// (equatorial - polar) / equatorial, which is negative for a prolate body.
+func NewIAUEllipsoid(equatorial, polar float64) *Ellipsoid {
+	return NewEllipsoid(equatorial, (equatorial-polar)/equatorial)
+}
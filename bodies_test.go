@@ -0,0 +1,28 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBodies(t *testing.T) {
+	if f := Mars.Flattening(); !eqish(f, (3396190.0-3376200)/3396190, 15) {
+		t.Fatalf("expected %v, got %v", (3396190.0-3376200)/3396190, f)
+	}
+	if Moon.Radius() != 1737400 || Moon.Flattening() != 0 {
+		t.Fatalf("expected a sphere, got %v %v", Moon.Radius(), Moon.Flattening())
+	}
+	// A quarter of a meridian of the Moon, which is a sphere.
+	var s12 float64
+	Moon.Inverse(0, 0, 90, 0, &s12, nil, nil)
+	if want := 1737400 * math.Pi / 2; !eqish(s12, want, 6) {
+		t.Fatalf("expected %v, got %v", want, s12)
+	}
+	// The polar radius round trips.
+	if c := Jupiter.Radius() * (1 - Jupiter.Flattening()); !eqish(c, 66854000, 6) {
+		t.Fatalf("expected %v, got %v", 66854000, c)
+	}
+	if e := NewIAUEllipsoid(1000, 1100); !(e.Flattening() < 0) {
+		t.Fatalf("expected a prolate ellipsoid, got %v", e.Flattening())
+	}
+}
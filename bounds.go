@@ -0,0 +1,106 @@
+package geodesic
+
+import "math"
+
+// Bounds is a latitude/longitude rectangle (degrees).
+//
+// The rectangle runs east from MinLon to MaxLon, so if MinLon is greater
+// than MaxLon then the rectangle crosses the antimeridian.
+type Bounds struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// lonSpan returns the eastward longitude span of the bounds (degrees).
+func (b Bounds) lonSpan() float64 {
+	dlon := b.MaxLon - b.MinLon
+	if dlon < 0 {
+		dlon += 360
+	}
+	return math.Min(dlon, 360)
+}
+
+// contains reports whether the bounds contain a point.
+func (b Bounds) contains(p LatLng) bool {
+	if p.Lat < b.MinLat || p.Lat > b.MaxLat {
+		return false
+	}
+	dlon := normLon(p.Lon - b.MinLon)
+	if dlon < 0 {
+		dlon += 360
+	}
+	return dlon <= b.lonSpan()
+}
+
+// ExpandBounds grows a bounding box by a ground distance on all sides.
+//
+// Param bbox is the bounding box to expand.
+// Param meters is the non-negative distance to expand by (meters).
+// Returns the expanded bounding box.
+//
+// Every point within meters of the original box is inside the result. The
+// longitude expansion is computed at the latitude of the box nearest to a
+// pole, where a given distance spans the most longitude. If the expansion
+// reaches a pole, the latitude is clamped to ±90 and the result covers all
+// longitudes, [-180,+180]. A result that crosses the antimeridian has
+// MinLon greater than MaxLon.
+func (e *Ellipsoid) ExpandBounds(bbox Bounds, meters float64) Bounds {
+	var out Bounds
+	var toPole float64
+	fullLon := false
+
+	// Expand north and south along the meridian.
+	e.Inverse(bbox.MaxLat, 0, 90, 0, &toPole, nil, nil)
+	if meters >= toPole {
+		out.MaxLat = 90
+		fullLon = true
+	} else {
+		e.Direct(bbox.MaxLat, 0, 0, meters, &out.MaxLat, nil, nil)
+	}
+	e.Inverse(bbox.MinLat, 0, -90, 0, &toPole, nil, nil)
+	if meters >= toPole {
+		out.MinLat = -90
+		fullLon = true
+	} else {
+		e.Direct(bbox.MinLat, 0, 180, meters, &out.MinLat, nil, nil)
+	}
+
+	if !fullLon {
+		lat := bbox.MaxLat
+		if math.Abs(bbox.MinLat) > math.Abs(lat) {
+			lat = bbox.MinLat
+		}
+		dlon := e.maxLonReach(lat, meters)
+		if bbox.lonSpan()+2*dlon >= 360 {
+			fullLon = true
+		} else {
+			out.MinLon = normLon(bbox.MinLon - dlon)
+			out.MaxLon = normLon(bbox.MaxLon + dlon)
+		}
+	}
+	if fullLon {
+		out.MinLon, out.MaxLon = -180, 180
+	}
+	return out
+}
+
+// maxLonReach returns the largest change of longitude (degrees) that can be
+// made by traveling meters from a point at lat. Results of 180 or more mean
+// that all longitudes can be reached.
+func (e *Ellipsoid) maxLonReach(lat, meters float64) float64 {
+	if meters <= 0 {
+		return 0
+	}
+	reach := func(azi float64) float64 {
+		var lon2 float64
+		e.Direct(lat, 0, azi, meters, nil, &lon2, nil)
+		if lon2 < 0 {
+			lon2 += 360
+		}
+		return lon2
+	}
+	_, fx := goldenMin(0, 180, func(azi float64) float64 {
+		return -reach(azi)
+	})
+	return -fx
+}
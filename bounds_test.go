@@ -0,0 +1,44 @@
+package geodesic
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestExpandBounds(t *testing.T) {
+	bbox := Bounds{MinLat: 30, MinLon: -10, MaxLat: 50, MaxLon: 10}
+	ex := WGS84.ExpandBounds(bbox, 100000)
+	if ex.MinLat >= 30 || ex.MaxLat <= 50 || ex.MinLon >= -10 || ex.MaxLon <= 10 {
+		t.Fatalf("bounds did not grow: %v", ex)
+	}
+	var s12 float64
+	WGS84.Inverse(50, 0, ex.MaxLat, 0, &s12, nil, nil)
+	if !eqish(s12, 100000, seriesPrec(6)) {
+		t.Fatalf("expected %f, got %f", 100000.0, s12)
+	}
+	// Every point at the distance from the box edges must fall inside.
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		lat := 30 + rng.Float64()*20
+		lon := 10.0
+		if i%2 == 0 {
+			lon = -10
+		}
+		var lat2, lon2 float64
+		WGS84.Direct(lat, lon, rng.Float64()*360, 100000, &lat2, &lon2, nil)
+		if lat2 < ex.MinLat || lat2 > ex.MaxLat ||
+			lon2 < ex.MinLon-1e-9 || lon2 > ex.MaxLon+1e-9 {
+			t.Fatalf("point %f,%f outside of %v", lat2, lon2, ex)
+		}
+	}
+	// Antimeridian wraparound.
+	ex = WGS84.ExpandBounds(Bounds{-1, 179, 1, 179.5}, 200000)
+	if ex.MinLon < 170 || ex.MaxLon > -170 || ex.MinLon <= ex.MaxLon {
+		t.Fatalf("expected antimeridian crossing, got %v", ex)
+	}
+	// Reaching a pole covers all longitudes.
+	ex = WGS84.ExpandBounds(Bounds{85, 0, 89, 1}, 200000)
+	if ex != (Bounds{ex.MinLat, -180, 90, 180}) {
+		t.Fatalf("expected polar cap, got %v", ex)
+	}
+}
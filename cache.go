@@ -0,0 +1,52 @@
+package geodesic
+
+import (
+	"math"
+	"sync"
+)
+
+// ellipsoidKey identifies the parameters of a cached ellipsoid. The bits
+// are used so that each signed zero has a single entry, and every NaN is
+// made the same NaN first, see newEllipsoidKey, so that they share one.
+type ellipsoidKey struct {
+	a, f uint64
+}
+
+func newEllipsoidKey(radius, flattening float64) ellipsoidKey {
+	bits := func(x float64) uint64 {
+		if math.IsNaN(x) {
+			x = math.NaN()
+		}
+		return math.Float64bits(x)
+	}
+	return ellipsoidKey{bits(radius), bits(flattening)}
+}
+
+var ellipsoids sync.Map // ellipsoidKey -> *Ellipsoid
+
+// GetEllipsoid returns a shared ellipsoid with an equatorial radius and
+// flattening, creating it on the first call with those parameters.
+// Param a is the equatorial radius (meters).
+// Param f is the flattening.
+//
+// This avoids the allocation and initialization of NewEllipsoid in code
+// that repeatedly needs the ellipsoid it was configured with. The returned
+// ellipsoid is shared by all callers and must not be changed, such as with
+// Ellipsoid.Init; use Ellipsoid.WithAzimuths for a copy with a different
+// convention. It is safe for concurrent use.
+//
+// The cache is never pruned, so it is meant for a small set of parameters
+// rather than arbitrary user input.
+func GetEllipsoid(radius, flattening float64) *Ellipsoid {
+	key := newEllipsoidKey(radius, flattening)
+	if e, ok := ellipsoids.Load(key); ok {
+		return e.(*Ellipsoid)
+	}
+	e, _ := ellipsoids.LoadOrStore(key, NewEllipsoid(radius, flattening))
+	return e.(*Ellipsoid)
+}
+
+func init() {
+	ellipsoids.Store(newEllipsoidKey(WGS84.Radius(), WGS84.Flattening()),
+		WGS84)
+}
@@ -0,0 +1,57 @@
+package geodesic
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestGetEllipsoid(t *testing.T) {
+	if e := GetEllipsoid(WGS84.Radius(), WGS84.Flattening()); e != WGS84 {
+		t.Fatalf("expected WGS84, got %p", e)
+	}
+	e1 := GetEllipsoid(6378206.4, 1/294.978698214)
+	e2 := GetEllipsoid(6378206.4, 1/294.978698214)
+	if e1 != e2 {
+		t.Fatalf("expected the same ellipsoid, got %p and %p", e1, e2)
+	}
+	if e1.Radius() != 6378206.4 || e1.Flattening() != 1/294.978698214 {
+		t.Fatalf("expected '6378206.4, %v', got '%v, %v'",
+			1/294.978698214, e1.Radius(), e1.Flattening())
+	}
+	if e := GetEllipsoid(6378206.4, 0); e == e1 {
+		t.Fatalf("expected a different ellipsoid")
+	}
+	if GetEllipsoid(math.NaN(), 0) != GetEllipsoid(math.NaN(), 0) {
+		t.Fatalf("expected NaN parameters to share an entry")
+	}
+	// NaNs with other payloads and signs share it too.
+	for _, bits := range []uint64{0x7ff8000000000001, 0xfff8000000000000} {
+		if GetEllipsoid(math.Float64frombits(bits), 0) != GetEllipsoid(math.NaN(), 0) {
+			t.Fatalf("expected the NaN %#x to share an entry", bits)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		GetEllipsoid(6378206.4, 1/294.978698214)
+	}); n != 0 {
+		t.Fatalf("expected 0 allocations, got %v", n)
+	}
+}
+
+func TestGetEllipsoidConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	res := make([]*Ellipsoid, 8)
+	for i := range res {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res[i] = GetEllipsoid(6371000, 0)
+		}()
+	}
+	wg.Wait()
+	for _, e := range res {
+		if e != res[0] {
+			t.Fatalf("expected the same ellipsoid, got %p and %p", e, res[0])
+		}
+	}
+}
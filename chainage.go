@@ -0,0 +1,155 @@
+package geodesic
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Chainage is a polyline with the cumulative geodesic distance along it to
+// each vertex, for the linear referencing of roads, pipelines, and
+// railways, where positions are given as a distance from the start.
+//
+// A Chainage must be created with Ellipsoid.NewChainage. It is not changed
+// by its methods, and so may be used from many goroutines.
+type Chainage struct {
+	e     *Ellipsoid
+	pts   []LatLng
+	cum   []float64 // distance from the start to each vertex
+	lines []Line    // the geodesic of each segment
+	b     ecefBlock // the vertices, for the chords of Locate
+}
+
+// NewChainage returns the chainage of a polyline.
+//
+// Param line is the polyline, which is copied.
+//
+// Each segment is the geodesic between its vertices, solved once here, so
+// that the queries need no inverse problems to place a point.
+func (e *Ellipsoid) NewChainage(line []LatLng) *Chainage {
+	c := &Chainage{e: e, pts: append([]LatLng(nil), line...),
+		b: e.newECEFBlock(line)}
+	c.cum = make([]float64, len(line))
+	if len(line) > 1 {
+		c.lines = make([]Line, len(line)-1)
+	}
+	for i := range c.lines {
+		a, b := line[i], line[i+1]
+		c.lines[i] = e.InverseLine(a.Lat, a.Lon, b.Lat, b.Lon)
+		c.cum[i+1] = c.cum[i] + c.lines[i].Distance()
+	}
+	return c
+}
+
+// Length returns the length of the polyline (meters).
+func (c *Chainage) Length() float64 {
+	if len(c.cum) == 0 {
+		return 0
+	}
+	return c.cum[len(c.cum)-1]
+}
+
+// VertexDistance returns the distance along the polyline from its start to
+// vertex i (meters).
+func (c *Chainage) VertexDistance(i int) float64 {
+	return c.cum[i]
+}
+
+// PointAt returns the point at a distance along the polyline.
+//
+// Param s is the distance from the start of the polyline (meters).
+// Returns the point and the (forward) azimuth of the polyline there
+// (degrees).
+//
+// The segment that holds the point is found by a binary search of the
+// cumulative distances, in O(log n). Distances before the start or past
+// the end are clamped to the ends, and a point at a vertex is given the
+// azimuth of the segment that leaves it. An empty polyline returns NaNs,
+// and a single vertex has a NaN azimuth.
+func (c *Chainage) PointAt(s float64) (p LatLng, azi float64) {
+	switch len(c.pts) {
+	case 0:
+		return LatLng{math.NaN(), math.NaN()}, math.NaN()
+	case 1:
+		return c.pts[0], math.NaN()
+	}
+	s = math.Max(0, math.Min(s, c.Length()))
+	// The segment that starts at the last vertex at or before s, which
+	// skips segments of no length.
+	i := sort.Search(len(c.cum), func(j int) bool { return c.cum[j] > s })
+	i = max(0, min(i-1, len(c.lines)-1))
+	c.lines[i].Position(s-c.cum[i], &p.Lat, &p.Lon, &azi)
+	return p, azi
+}
+
+// Locate returns the point on the polyline nearest to p, as
+// Ellipsoid.NearestOnPolyline, whose Along is the chainage of the point.
+//
+// Param p is the point to locate.
+// Returns the nearest position on the polyline.
+//
+// The chords from p to all of the vertices are computed in bulk, without
+// solving any inverse problems, and as the chords are never longer than
+// the geodesics they give a lower bound on the distance to each segment by
+// the triangle inequality. The segments are then solved nearest bound
+// first, stopping at the first whose bound is beyond the nearest point
+// found so far, so a lookup near a long polyline costs O(n) arithmetic and
+// the inverse solutions of the few segments near p.
+func (c *Chainage) Locate(p LatLng) PolylineMatch {
+	switch len(c.pts) {
+	case 0:
+		return PolylineMatch{Segment: -1, Distance: math.NaN()}
+	case 1:
+		return PolylineMatch{Point: c.pts[0], Distance: c.e.distance(p, c.pts[0])}
+	}
+	x, y, z := c.e.toECEF(p.Lat, p.Lon, 0)
+	chord := make([]float64, len(c.pts))
+	c.b.chord2(x, y, z, chord)
+	near := 0
+	for i, c2 := range chord {
+		chord[i] = math.Sqrt(c2)
+		if chord[i] < chord[near] {
+			near = i
+		}
+	}
+	best := PolylineMatch{
+		Point: c.pts[near], Segment: min(near, len(c.lines)-1),
+		Distance: c.e.distance(p, c.pts[near]), Along: c.cum[near],
+	}
+	type bound struct {
+		seg int
+		lo  float64
+	}
+	var bounds []bound
+	for i := range c.lines {
+		// Every point of the segment is at least this far from p, since
+		// the distances from the point to its ends add up to its length.
+		lo := (chord[i] + chord[i+1] - c.lines[i].Distance()) / 2
+		if lo < best.Distance {
+			bounds = append(bounds, bound{i, lo})
+		}
+	}
+	slices.SortFunc(bounds, func(a, b bound) int {
+		return cmp.Compare(a.lo, b.lo)
+	})
+	for _, b := range bounds {
+		if b.lo > best.Distance {
+			break
+		}
+		i := b.seg
+		q, dist, along := c.e.segmentNearest(p, c.pts[i], c.pts[i+1])
+		if dist < best.Distance || dist == best.Distance && i < best.Segment {
+			best = PolylineMatch{Point: q, Segment: i, Distance: dist,
+				Along: c.cum[i] + along}
+		}
+	}
+	return best
+}
+
+// DistanceOf returns the chainage of the point on the polyline nearest to p,
+// the distance along the polyline from its start (meters). It costs as much
+// as Chainage.Locate.
+func (c *Chainage) DistanceOf(p LatLng) float64 {
+	return c.Locate(p).Along
+}
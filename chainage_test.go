@@ -0,0 +1,85 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestChainage(t *testing.T) {
+	line := []LatLng{{51.5, -0.1}, {52.2, 0.1}, {52.2, 0.1}, {53.5, -1.5},
+		{55.9, -3.2}}
+	c := WGS84.NewChainage(line)
+	if want := PolylineLength(WGS84, line); !eqish(c.Length(), want, 6) {
+		t.Fatalf("expected %v, got %v", want, c.Length())
+	}
+	if c.VertexDistance(1) != c.VertexDistance(2) {
+		t.Fatalf("expected a repeated vertex at the same distance, got %v %v",
+			c.VertexDistance(1), c.VertexDistance(2))
+	}
+	for i, v := range line {
+		p, azi := c.PointAt(c.VertexDistance(i))
+		if WGS84.distance(p, v) > seriesTolerance(1e-6) {
+			t.Fatalf("vertex %d: expected %v, got %v", i, v, p)
+		}
+		if i == 1 || i == 2 {
+			var want float64
+			WGS84.Inverse(v.Lat, v.Lon, line[3].Lat, line[3].Lon, nil, &want, nil)
+			if !eqish(azi, want, 9) {
+				t.Fatalf("vertex %d: expected the azimuth %v, got %v", i, want,
+					azi)
+			}
+		}
+	}
+	if p, _ := c.PointAt(-10); WGS84.distance(p, line[0]) > seriesTolerance(1e-6) {
+		t.Fatalf("expected the start, got %v", p)
+	}
+	if p, _ := c.PointAt(1e9); WGS84.distance(p, line[4]) > seriesTolerance(1e-6) {
+		t.Fatalf("expected the end, got %v", p)
+	}
+
+	// A point offset from the line at a distance along it is located back
+	// at that distance, as by NearestOnPolyline.
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		s := rng.Float64() * c.Length()
+		q, azi := c.PointAt(s)
+		var p LatLng
+		WGS84.Direct(q.Lat, q.Lon, azi+90, 500, &p.Lat, &p.Lon, nil)
+		m := c.Locate(p)
+		want := WGS84.NearestOnPolyline(p, line)
+		if math.Abs(m.Along-want.Along) > 1e-3 || !eqish(m.Distance, want.Distance, 3) {
+			t.Fatalf("%v: expected %+v, got %+v", p, want, m)
+		}
+		if d := c.DistanceOf(p); math.Abs(d-s) > 1 {
+			t.Fatalf("%v: expected %v, got %v", p, s, d)
+		}
+	}
+
+	// A long winding line, most of whose segments are ruled out by the
+	// chords to their vertices.
+	var long []LatLng
+	for i := 0; i < 200; i++ {
+		long = append(long, LatLng{40 + 0.02*float64(i%7), -5 + 0.01*float64(i)})
+	}
+	lc := WGS84.NewChainage(long)
+	for i := 0; i < 20; i++ {
+		p := LatLng{39.9 + rng.Float64()*0.3, -5.1 + rng.Float64()*2.2}
+		m, want := lc.Locate(p), WGS84.NearestOnPolyline(p, long)
+		if math.Abs(m.Along-want.Along) > 1e-6 ||
+			!eqish(m.Distance, want.Distance, seriesPrec(6)) {
+			t.Fatalf("%v: expected %+v, got %+v", p, want, m)
+		}
+	}
+
+	if p, azi := WGS84.NewChainage(nil).PointAt(0); !math.IsNaN(p.Lat) || !math.IsNaN(azi) {
+		t.Fatalf("expected NaNs, got %v %v", p, azi)
+	}
+	if m := WGS84.NewChainage(nil).Locate(line[0]); m.Segment != -1 {
+		t.Fatalf("expected no segment, got %+v", m)
+	}
+	one := WGS84.NewChainage(line[:1])
+	if p, _ := one.PointAt(5); one.Length() != 0 || p != line[0] {
+		t.Fatalf("expected the vertex, got %v %v", one.Length(), p)
+	}
+}
@@ -0,0 +1,65 @@
+package geodesic
+
+import "math"
+
+// ChordDistance returns the straight line distance through the ellipsoid
+// between two points on its surface.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+//
+// This is Ellipsoid.Distance3D with both heights zero. The chord is what
+// matters for line of sight between antennas and for visibility from a
+// satellite, and it is also the distance used by many spatial indexes
+// that work on unit vectors. It is never longer than the geodesic
+// distance.
+func (e *Ellipsoid) ChordDistance(p1, p2 LatLng) Distance {
+	return e.Distance3D(p1.Lat, p1.Lon, 0, p2.Lat, p2.Lon, 0)
+}
+
+// meanRadius returns the mean radius of the ellipsoid, (2a + b) / 3, which
+// is the radius of the sphere used by the chord conversions.
+func (e *Ellipsoid) meanRadius() float64 {
+	return e.Radius() * (3 - e.Flattening()) / 3
+}
+
+// ChordToArc returns the central angle subtended by a chord on the sphere
+// with the mean radius of the ellipsoid (degrees).
+//
+// Param chord is the chord length (meters).
+//
+// The chord, arc, and distance conversions relate lengths on a sphere, so
+// they are exact for a sphere and good to within the flattening, about a
+// third of a percent for the earth, otherwise. They are meant for turning
+// a distance threshold into a chord threshold and back, such as for a
+// unit vector index; use Ellipsoid.ChordDistance and Ellipsoid.Distance
+// for the lengths between actual points. A chord longer than the diameter
+// of the sphere gives 180.
+func (e *Ellipsoid) ChordToArc(chord float64) float64 {
+	x := math.Min(chord/(2*e.meanRadius()), 1)
+	return 2 * math.Asin(x) * (180 / math.Pi)
+}
+
+// ArcToChord returns the chord subtended by a central angle on the mean
+// sphere (meters). See Ellipsoid.ChordToArc.
+//
+// Param arc is the central angle (degrees).
+func (e *Ellipsoid) ArcToChord(arc float64) float64 {
+	return 2 * e.meanRadius() * math.Abs(math.Sin(arc*(math.Pi/360)))
+}
+
+// ChordToDistance returns the distance along the mean sphere spanned by a
+// chord (meters). See Ellipsoid.ChordToArc.
+//
+// Param chord is the chord length (meters).
+func (e *Ellipsoid) ChordToDistance(chord float64) float64 {
+	return e.ArcToMeters(e.ChordToArc(chord))
+}
+
+// DistanceToChord returns the chord spanning a distance along the mean
+// sphere (meters). See Ellipsoid.ChordToArc.
+//
+// Param s is the distance (meters).
+func (e *Ellipsoid) DistanceToChord(s float64) float64 {
+	return e.ArcToChord(e.MetersToArc(s))
+}
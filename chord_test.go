@@ -0,0 +1,50 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChordDistance(t *testing.T) {
+	p1, p2 := LatLng{Lat: 10, Lon: 20}, LatLng{Lat: -30, Lon: 100}
+	d := WGS84.ChordDistance(p1, p2)
+	if want := WGS84.Distance3D(10, 20, 0, -30, 100, 0); d != want {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	s := WGS84.Distance(10, 20, -30, 100)
+	if !(d < s) {
+		t.Fatalf("expected under %v, got %v", s, d)
+	}
+	// The sphere conversions are within the flattening of the truth.
+	if s, want := WGS84.ChordToDistance(float64(d)), float64(s); math.Abs(s/want-1) > WGS84.Flattening() {
+		t.Fatalf("expected about %v, got %v", want, s)
+	}
+}
+
+func TestChordConversions(t *testing.T) {
+	moon := NewEllipsoid(1737400, 0)
+	if c := moon.ArcToChord(60); !eqish(c, 1737400, 6) {
+		t.Fatalf("expected %v, got %v", 1737400, c)
+	}
+	if a := moon.ChordToArc(1737400); !eqish(a, 60, 12) {
+		t.Fatalf("expected 60, got %v", a)
+	}
+	if c := moon.ArcToChord(180); !eqish(c, 2*1737400, 6) {
+		t.Fatalf("expected %v, got %v", 2*1737400, c)
+	}
+	if a := moon.ChordToArc(1e9); a != 180 {
+		t.Fatalf("expected 180, got %v", a)
+	}
+	for _, s := range []float64{0, 1, 1e3, 1e6, 1e7} {
+		if s2 := WGS84.ChordToDistance(WGS84.DistanceToChord(s)); !eqish(s2, s, 6) {
+			t.Fatalf("expected %v, got %v", s, s2)
+		}
+	}
+	// On a sphere the chord between points matches the conversion.
+	var s12 float64
+	moon.Inverse(0, 0, 40, 50, &s12, nil, nil)
+	d := moon.ChordDistance(LatLng{0, 0}, LatLng{40, 50})
+	if c := moon.DistanceToChord(s12); !eqish(c, float64(d), 6) {
+		t.Fatalf("expected %v, got %v", d, c)
+	}
+}
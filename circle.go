@@ -0,0 +1,148 @@
+package geodesic
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// CirclePoints returns the points of a geodesic circle.
+//
+// Param center is the center of the circle.
+// Param radius is the radius of the circle (meters).
+// Param segments is the number of points on the circle.
+// Returns a sequence of points.
+//
+// The points are at the given geodesic distance from the center, at equal
+// steps of azimuth going clockwise from north. The first point is not
+// repeated at the end.
+func (e *Ellipsoid) CirclePoints(center LatLng, radius float64, segments int) iter.Seq[LatLng] {
+	return func(yield func(LatLng) bool) {
+		for i := 0; i < segments; i++ {
+			var p LatLng
+			azi := 360 * float64(i) / float64(segments)
+			e.Direct(center.Lat, center.Lon, azi, radius, &p.Lat, &p.Lon, nil)
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Circle returns the points of a geodesic circle as a ring.
+// See Ellipsoid.CirclePoints.
+func (e *Ellipsoid) Circle(center LatLng, radius float64, segments int) []LatLng {
+	return slices.Collect(e.CirclePoints(center, radius, segments))
+}
+
+// RangeRings returns concentric geodesic circles about a center, one ring
+// for each radius, in order.
+// See Ellipsoid.CirclePoints.
+func (e *Ellipsoid) RangeRings(center LatLng, radii []float64, segments int) [][]LatLng {
+	rings := make([][]LatLng, len(radii))
+	for i, radius := range radii {
+		rings[i] = e.Circle(center, radius, segments)
+	}
+	return rings
+}
+
+// Sector returns the ring of a geodesic sector, or wedge.
+//
+// Param center is the center of the sector.
+// Param radius is the radius of the sector (meters).
+// Param startAzi is the azimuth of the start of the arc (degrees).
+// Param endAzi is the azimuth of the end of the arc (degrees).
+// Param segments is the number of segments of the arc, at least 1.
+// Returns the ring.
+//
+// The arc goes clockwise from startAzi to endAzi, so a sector from 350 to
+// 10 is the 20 degree wedge about north. The ring starts at the center,
+// followed by the segments+1 points of the arc, at equal steps of azimuth;
+// the center is not repeated at the end. The sweep is taken modulo 360
+// degrees, so a sector from 0 to 370 is the 10 degree wedge from north.
+// A sector whose azimuths are equal modulo 360, such as from 0 to 360, is
+// the circle of Ellipsoid.Circle with segments points. A segments of less
+// than 1 is taken as 1, and arguments that are not finite numbers give NaN
+// points, see Ellipsoid.CheckedSector.
+func (e *Ellipsoid) Sector(center LatLng, radius, startAzi, endAzi float64, segments int) []LatLng {
+	segments = max(segments, 1)
+	sweep := math.Mod(endAzi-startAzi, 360)
+	if sweep < 0 {
+		sweep += 360
+	}
+	if sweep == 0 {
+		return e.Circle(center, radius, segments)
+	}
+	ring := make([]LatLng, 0, segments+2)
+	ring = append(ring, center)
+	for i := 0; i <= segments; i++ {
+		var p LatLng
+		azi := startAzi + sweep*float64(i)/float64(segments)
+		e.Direct(center.Lat, center.Lon, azi, radius, &p.Lat, &p.Lon, nil)
+		ring = append(ring, p)
+	}
+	return ring
+}
+
+// CheckedSector returns the ring of a geodesic sector, as Ellipsoid.Sector,
+// and reports the problems with its arguments as errors.
+//
+// Param center is the center of the sector.
+// Param radius is the radius of the sector (meters).
+// Param startAzi is the azimuth of the start of the arc (degrees).
+// Param endAzi is the azimuth of the end of the arc (degrees).
+// Param segments is the number of segments of the arc, at least 1.
+// Returns the ring, or an error: ErrInvalidLatitude if the latitude of the
+// center is out of range under the LatitudePolicy of the ellipsoid,
+// ErrInvalidLongitude if its longitude is not a finite number, or
+// ErrInvalidArgument if the radius or an azimuth is not a finite number,
+// the radius is negative, or segments is less than 1.
+func (e *Ellipsoid) CheckedSector(center LatLng, radius, startAzi, endAzi float64, segments int) ([]LatLng, error) {
+	center, err := e.checkPoint(center)
+	if err != nil {
+		return nil, fmt.Errorf("%w at the center", err)
+	}
+	if !(radius >= 0) || math.IsInf(radius, 1) {
+		return nil, fmt.Errorf("%w: radius %v", ErrInvalidArgument, radius)
+	}
+	for _, azi := range [2]float64{startAzi, endAzi} {
+		if math.IsNaN(azi) || math.IsInf(azi, 0) {
+			return nil, fmt.Errorf("%w: azimuth %v", ErrInvalidArgument, azi)
+		}
+	}
+	if segments < 1 {
+		return nil, fmt.Errorf("%w: %d segments", ErrInvalidArgument, segments)
+	}
+	return e.Sector(center, radius, startAzi, endAzi, segments), nil
+}
+
+// Fan returns the destinations from a point along each of a number of
+// azimuths, at each of a number of distances, the polar grid of a
+// viewshed or of the ground pattern of an antenna.
+//
+// Param origin is the point that the geodesics start from.
+// Param azimuths is the azimuths at the origin (degrees).
+// Param distances is the distances from the origin (meters). negative is
+// ok.
+// Returns the destinations, where the point at [i][j] is along
+// azimuths[i] at distances[j].
+//
+// Each azimuth is one geodesic line, set up once, so each destination only
+// takes a position on the line rather than a direct problem of its own, and
+// the points share one allocation. The distances need not be in order. For
+// azimuths at equal steps from north, the points at one distance are those
+// of Ellipsoid.Circle.
+func (e *Ellipsoid) Fan(origin LatLng, azimuths, distances []float64) [][]LatLng {
+	pts := make([]LatLng, len(azimuths)*len(distances))
+	fan := make([][]LatLng, len(azimuths))
+	var l Line
+	for i, azi := range azimuths {
+		e.lineInit(&l, origin.Lat, origin.Lon, azi)
+		fan[i] = pts[i*len(distances) : (i+1)*len(distances) : (i+1)*len(distances)]
+		for j, s := range distances {
+			l.Position(s, &fan[i][j].Lat, &fan[i][j].Lon, nil)
+		}
+	}
+	return fan
+}
@@ -0,0 +1,146 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCircle(t *testing.T) {
+	c := LatLng{52.5, 13.4}
+	ring := WGS84.Circle(c, 5000, 64)
+	if len(ring) != 64 {
+		t.Fatalf("expected 64 points, got %d", len(ring))
+	}
+	for _, p := range ring {
+		if d := WGS84.distance(c, p); !eqish(d, 5000, seriesPrec(6)) {
+			t.Fatalf("expected %f, got %f", 5000.0, d)
+		}
+	}
+	for p := range WGS84.CirclePoints(c, 5000, 64) {
+		if p != ring[0] {
+			t.Fatalf("expected %v, got %v", ring[0], p)
+		}
+		break
+	}
+}
+
+func TestRangeRings(t *testing.T) {
+	c := LatLng{52.5, 13.4}
+	rings := WGS84.RangeRings(c, []float64{1000, 5000, 10000}, 32)
+	if len(rings) != 3 {
+		t.Fatalf("expected 3 rings, got %d", len(rings))
+	}
+	for i, r := range []float64{1000, 5000, 10000} {
+		d := WGS84.distance(c, rings[i][7])
+		if len(rings[i]) != 32 || !eqish(d, r, seriesPrec(6)) {
+			t.Fatalf("ring %d: expected 32 points at %v", i, r)
+		}
+	}
+}
+
+func TestSector(t *testing.T) {
+	c := LatLng{52.5, 13.4}
+	ring := WGS84.Sector(c, 5000, 350, 10, 4)
+	if len(ring) != 6 || ring[0] != c {
+		t.Fatalf("expected the center and 5 arc points, got %v", ring)
+	}
+	for i, want := range []float64{-10, -5, 0, 5, 10} {
+		var d, azi float64
+		WGS84.Inverse(c.Lat, c.Lon, ring[i+1].Lat, ring[i+1].Lon, &d, &azi, nil)
+		if !eqish(d, 5000, seriesPrec(6)) || !eqish(azi, want, 9) {
+			t.Fatalf("point %d: expected '5000, %v', got '%v, %v'",
+				i+1, want, d, azi)
+		}
+	}
+	// A quarter of the circle has about a quarter of its area, clockwise.
+	area, _ := PolygonArea(WGS84, WGS84.Sector(c, 5000, 0, 90, 64))
+	circle, _ := PolygonArea(WGS84, WGS84.Circle(c, 5000, 256))
+	if !(area < 0 && math.Abs(area/circle-0.25) < 1e-3) {
+		t.Fatalf("expected a quarter of %v, got %v", circle, area)
+	}
+	if ring := WGS84.Sector(c, 5000, 45, 45, 16); len(ring) != 16 {
+		t.Fatalf("expected the whole circle, got %d points", len(ring))
+	}
+	if ring := WGS84.Sector(c, 5000, 0, 360, 16); len(ring) != 16 {
+		t.Fatalf("expected the whole circle, got %d points", len(ring))
+	}
+	// The sweep is modulo 360, so 0 to 370 is a 10 degree wedge.
+	ring = WGS84.Sector(c, 5000, 0, 370, 2)
+	var azi float64
+	WGS84.Inverse(c.Lat, c.Lon, ring[3].Lat, ring[3].Lon, nil, &azi, nil)
+	if len(ring) != 4 || !eqish(azi, 10, 9) {
+		t.Fatalf("expected a 10 degree wedge, got %v, %v", ring, azi)
+	}
+	// Fewer than one segment is one segment, not a division by zero.
+	for _, segments := range []int{0, -3} {
+		ring := WGS84.Sector(c, 5000, 0, 90, segments)
+		if len(ring) != 3 {
+			t.Fatalf("segments %d: expected 3 points, got %v", segments, ring)
+		}
+		for _, p := range ring {
+			if math.IsNaN(p.Lat) || math.IsNaN(p.Lon) {
+				t.Fatalf("segments %d: expected no NaN, got %v", segments, ring)
+			}
+		}
+	}
+}
+
+func TestCheckedSector(t *testing.T) {
+	c := LatLng{52.5, 13.4}
+	ring, err := WGS84.CheckedSector(c, 5000, 350, 10, 4)
+	if want := WGS84.Sector(c, 5000, 350, 10, 4); err != nil ||
+		len(ring) != len(want) || ring[3] != want[3] {
+		t.Fatalf("expected %v, got %v, %v", want, ring, err)
+	}
+	for _, v := range []struct {
+		c                  LatLng
+		radius, start, end float64
+		segments           int
+		err                error
+	}{
+		{LatLng{-91, 0}, 5000, 0, 10, 4, ErrInvalidLatitude},
+		{LatLng{0, math.NaN()}, 5000, 0, 10, 4, ErrInvalidLongitude},
+		{c, -1, 0, 10, 4, ErrInvalidArgument},
+		{c, math.Inf(1), 0, 10, 4, ErrInvalidArgument},
+		{c, 5000, math.NaN(), 10, 4, ErrInvalidArgument},
+		{c, 5000, 0, math.Inf(1), 4, ErrInvalidArgument},
+		{c, 5000, 0, 10, 0, ErrInvalidArgument},
+	} {
+		if ring, err := WGS84.CheckedSector(v.c, v.radius, v.start, v.end,
+			v.segments); !errors.Is(err, v.err) || ring != nil {
+			t.Fatalf("%+v: expected %v, got %v, %v", v, v.err, ring, err)
+		}
+	}
+}
+
+func TestFan(t *testing.T) {
+	c := LatLng{52.5, 13.4}
+	azis := []float64{0, 45, 90, 200}
+	dists := []float64{1000, 0, 25000, -500}
+	fan := WGS84.Fan(c, azis, dists)
+	if len(fan) != len(azis) {
+		t.Fatalf("expected %d rays, got %d", len(azis), len(fan))
+	}
+	for i, azi := range azis {
+		if len(fan[i]) != len(dists) {
+			t.Fatalf("ray %d: expected %d points, got %d", i, len(dists),
+				len(fan[i]))
+		}
+		for j, s := range dists {
+			var p LatLng
+			WGS84.Direct(c.Lat, c.Lon, azi, s, &p.Lat, &p.Lon, nil)
+			if !eqish(fan[i][j].Lat, p.Lat, 12) || !eqish(fan[i][j].Lon, p.Lon, 12) {
+				t.Fatalf("[%d][%d]: expected %v, got %v", i, j, p, fan[i][j])
+			}
+		}
+	}
+	// The rays do not share their ends.
+	fan[0] = append(fan[0], c)
+	if fan[1][0] == c {
+		t.Fatal("expected the rays to be separate")
+	}
+	if fan := WGS84.Fan(c, azis, nil); len(fan) != 4 || len(fan[0]) != 0 {
+		t.Fatalf("expected empty rays, got %v", fan)
+	}
+}
@@ -0,0 +1,240 @@
+package geodesic
+
+import "math"
+
+// CircleIntersections returns the points where two geodesic circles meet.
+//
+// Param c1 is the center of circle 1.
+// Param r1 is the radius of circle 1 (meters).
+// Param c2 is the center of circle 2.
+// Param r2 is the radius of circle 2 (meters).
+// Returns the points that are r1 from c1 and r2 from c2, which are none,
+// one where the circles just touch, or two.
+//
+// This is the fix from two ranges, as from two radio beacons, and the edges
+// of the overlap of two coverage areas. Of two points, the first is to the
+// left of the geodesic from c1 to c2 and the second is to its right. The
+// points are found on a sphere and then refined on the ellipsoid with
+// Newton's method until their distances are within a micrometer, or the
+// errors of the series for a build with fewer terms, see SeriesOrder, so
+// circles that miss each other on the sphere by a little are still found to
+// meet if they do on the ellipsoid. Where the circles touch, the point is
+// ill conditioned, and two points closer than a millionth of the larger
+// radius are returned as one. Circles with the same or antipodal centers, or
+// a radius that is not positive, return nil.
+func (e *Ellipsoid) CircleIntersections(c1 LatLng, r1 float64, c2 LatLng, r2 float64) []LatLng {
+	if !(r1 > 0) || !(r2 > 0) || c1 == c2 {
+		return nil
+	}
+	u1, u2 := unitVector(c1), unitVector(c2)
+	if n := vecCross(u1, u2); vecDot(n, n) < 1e-24 {
+		return nil // the same or antipodal centers
+	}
+	// On a sphere of the mean radius, the points are r1 from c1 at the
+	// angle A to either side of the geodesic to c2, where
+	//
+	//	hav(r2) = hav(d-r1) + sin(r1) sin(d) hav(A)
+	//
+	// for the angles subtended by r1, r2, and the distance d between the
+	// centers. The difference of the haversines is taken as a product of
+	// sines, which keeps its precision for centers that are close together.
+	var d, azi float64
+	e.Inverse(c1.Lat, c1.Lon, c2.Lat, c2.Lon, &d, &azi, nil)
+	R := e.meanRadius()
+	den := math.Sin(r1/R) * math.Sin(d/R)
+	if den == 0 {
+		return nil
+	}
+	h := math.Sin((r1+r2-d)/(2*R)) * math.Sin((r2-r1+d)/(2*R)) / den
+	A := 2 * math.Asin(math.Sqrt(math.Min(math.Max(h, 0), 1))) * 180 / math.Pi
+	if h < 0 || h > 1 {
+		// The circles miss on the sphere. The distances on the sphere are
+		// off by a fraction of a percent, which moves a point where the
+		// circles nearly touch by up to a tenth or so of the radius, so
+		// they may still meet on the ellipsoid. That is tried from guesses
+		// as far off to either side of the line of centers as the miss.
+		miss := math.Max(d-r1-r2, math.Abs(r1-r2)-d)
+		if miss > 0.2*math.Max(r1, r2) {
+			return nil
+		}
+		// The nearest point of circle 1 to circle 2 is toward c2, unless
+		// circle 1 is inside circle 2.
+		off := math.Abs(miss) / r1 * (180 / math.Pi)
+		if A = off; h > 1 {
+			A = 180 - off
+		}
+	}
+	var pts []LatLng
+	for _, azi1 := range [2]float64{azi - A, azi + A} {
+		var x LatLng
+		e.Direct(c1.Lat, c1.Lon, azi1, r1, &x.Lat, &x.Lon, nil)
+		x, ok := e.refineCircles(c1, r1, c2, r2, x)
+		if !ok {
+			continue
+		}
+		if len(pts) == 1 && e.distance(pts[0], x) < 1e-6*math.Max(r1, r2) {
+			continue // the circles touch
+		}
+		pts = append(pts, x)
+	}
+	return pts
+}
+
+// refineCircles moves x to where it is r1 from c1 and r2 from c2 with
+// Newton's method. The distance from a center grows fastest in the
+// direction away from it, so each step solves for the move in the tangent
+// plane at x that corrects both distances at once.
+func (e *Ellipsoid) refineCircles(c1 LatLng, r1 float64, c2 LatLng, r2 float64,
+	x LatLng,
+) (LatLng, bool) {
+	maxStep := math.Min(r1, r2)
+	tol := seriesTolerance(1e-9)
+	for i := 0; i < 64; i++ {
+		var s1, s2, a1, a2 float64
+		e.Inverse(c1.Lat, c1.Lon, x.Lat, x.Lon, &s1, nil, &a1)
+		e.Inverse(c2.Lat, c2.Lon, x.Lat, x.Lon, &s2, nil, &a2)
+		f1, f2 := r1-s1, r2-s2
+		if math.Abs(f1) < tol && math.Abs(f2) < tol {
+			return x, true
+		}
+		e1, n1 := sincosd(a1)
+		e2, n2 := sincosd(a2)
+		det := n1*e2 - e1*n2
+		if math.Abs(det) < 1e-12 {
+			break // the directions are the same, as where circles touch
+		}
+		dn := (f1*e2 - e1*f2) / det
+		de := (n1*f2 - f1*n2) / det
+		step := math.Hypot(dn, de)
+		if step < 1e-10 {
+			break
+		}
+		e.Direct(x.Lat, x.Lon, math.Atan2(de, dn)*(180/math.Pi),
+			math.Min(step, maxStep), &x.Lat, &x.Lon, nil)
+	}
+	var s1, s2 float64
+	e.Inverse(c1.Lat, c1.Lon, x.Lat, x.Lon, &s1, nil, nil)
+	e.Inverse(c2.Lat, c2.Lon, x.Lat, x.Lon, &s2, nil, nil)
+	tol = seriesTolerance(1e-6)
+	return x, math.Abs(r1-s1) < tol && math.Abs(r2-s2) < tol
+}
+
+// CircleCrossing is a point where a geodesic crosses a circle.
+type CircleCrossing struct {
+	S12      float64 // distance along the geodesic from its start (meters)
+	Point    LatLng  // the point of the crossing
+	Entering bool    // whether the geodesic goes into the circle here
+}
+
+// LineCircleCrossings returns where a geodesic line enters and leaves a
+// geodesic circle.
+//
+// Param l is the line, from Ellipsoid.DirectLine or Ellipsoid.InverseLine.
+// Param center is the center of the circle.
+// Param radius is the radius of the circle (meters).
+// Returns the crossings between point 1 and point 3 of the line, in order
+// along it.
+//
+// This answers when a track comes within a range of a site: the first
+// crossing that is entering is where it does, and the next crossing is
+// where it leaves again. A line that starts inside the circle has a first
+// crossing that is leaving. A line that only touches the circle may be
+// reported as entering and leaving at the same place, or not at all. For
+// each approach to the center, the closest point is found and the
+// crossings on either side of it are refined on the ellipsoid with
+// Newton's method, so a line that goes more than once around the
+// ellipsoid has a pair of crossings per pass. Lines from Ellipsoid.LineInit,
+// which have no point 3, return nil.
+func (e *Ellipsoid) LineCircleCrossings(l *Line, center LatLng, radius float64) []CircleCrossing {
+	L := l.Distance()
+	if !(L >= 0) || !(radius > 0) {
+		return nil
+	}
+	f := func(s float64) float64 {
+		var p LatLng
+		l.Position(s, &p.Lat, &p.Lon, nil)
+		return e.distance(center, p) - radius
+	}
+	// Where the line passes closest to the center, on a sphere.
+	R := e.meanRadius()
+	var d, aziC float64
+	e.Inverse(l.Lat1(), l.Lon1(), center.Lat, center.Lon, &d, &aziC, nil)
+	_, cosA := sincosd(l.Azi1() - aziC)
+	at := math.Atan2(math.Sin(d/R)*cosA, math.Cos(d/R)) * R
+	// Each pass around the ellipsoid has a closest approach, with the
+	// entering crossing before it and the leaving one after.
+	period := 2 * math.Pi * R
+	var out []CircleCrossing
+	k0 := math.Floor((-period/2 - at) / period)
+	for k := k0; at+k*period-period/2 <= L; k++ {
+		guess := at + k*period
+		smin, fmin := goldenMin(guess-period/4, guess+period/4, f)
+		if fmin > 0 {
+			continue
+		}
+		for _, c := range [2]struct {
+			lo, hi   float64
+			entering bool
+		}{
+			{smin - period/2, smin, true},
+			{smin, smin + period/2, false},
+		} {
+			s, ok := e.circleRoot(l, center, radius, f, c.lo, c.hi)
+			if !ok || s < 0 || s > L {
+				continue
+			}
+			x := CircleCrossing{S12: s, Entering: c.entering}
+			l.Position(s, &x.Point.Lat, &x.Point.Lon, nil)
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// SegmentCircleCrossings returns where the geodesic segment from a to b
+// enters and leaves a geodesic circle. See Ellipsoid.LineCircleCrossings.
+func (e *Ellipsoid) SegmentCircleCrossings(a, b, center LatLng, radius float64) []CircleCrossing {
+	l := e.InverseLine(a.Lat, a.Lon, b.Lat, b.Lon)
+	return e.LineCircleCrossings(&l, center, radius)
+}
+
+// circleRoot finds the distance in [lo,hi] along the line where f, the
+// distance from the center less the radius, is zero. One end of the range
+// is a closest approach, where f is negative, and f should change sign
+// once over the range. The slope of f is the cosine of the angle between
+// the line and the direction away from the center, which guides Newton's
+// method, and bisection keeps it within the range.
+func (e *Ellipsoid) circleRoot(l *Line, center LatLng, radius float64,
+	f func(s float64) float64, lo, hi float64,
+) (float64, bool) {
+	flo, fhi := f(lo), f(hi)
+	if (flo > 0) == (fhi > 0) {
+		return 0, false
+	}
+	if flo > 0 {
+		lo, hi = hi, lo // so that f(lo) <= 0 < f(hi)
+	}
+	s := (lo + hi) / 2
+	for i := 0; i < 100 && math.Abs(hi-lo) > 1e-9; i++ {
+		var p LatLng
+		var azi float64
+		l.Position(s, &p.Lat, &p.Lon, &azi)
+		var d, aziC float64
+		e.Inverse(center.Lat, center.Lon, p.Lat, p.Lon, &d, nil, &aziC)
+		fs := d - radius
+		if math.Abs(fs) < 1e-9 {
+			return s, true
+		}
+		if fs > 0 {
+			hi = s
+		} else {
+			lo = s
+		}
+		next := s - fs/math.Cos((azi-aziC)*(math.Pi/180))
+		if !(next > math.Min(lo, hi) && next < math.Max(lo, hi)) {
+			next = (lo + hi) / 2
+		}
+		s = next
+	}
+	return s, true
+}
@@ -0,0 +1,156 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCircleIntersections(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		c1 := LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+		c2 := LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+		x := LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+		if i%2 == 0 {
+			// Nearby, as for most real fixes.
+			WGS84.Direct(c1.Lat, c1.Lon, rng.Float64()*360, rng.Float64()*1e5,
+				&c2.Lat, &c2.Lon, nil)
+			WGS84.Direct(c1.Lat, c1.Lon, rng.Float64()*360, rng.Float64()*1e5,
+				&x.Lat, &x.Lon, nil)
+		}
+		r1, r2 := WGS84.distance(c1, x), WGS84.distance(c2, x)
+		pts := WGS84.CircleIntersections(c1, r1, c2, r2)
+		var found bool
+		for _, p := range pts {
+			d1, d2 := WGS84.distance(c1, p), WGS84.distance(c2, p)
+			if !eqish(d1, r1, seriesPrec(6)) || !eqish(d2, r2, seriesPrec(6)) {
+				t.Fatalf("%d: expected '%v, %v', got '%v, %v'", i, r1, r2, d1, d2)
+			}
+			if WGS84.distance(p, x) < seriesTolerance(1e-3) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("%d: expected %v in %v", i, x, pts)
+		}
+	}
+}
+
+func TestCircleIntersectionsSides(t *testing.T) {
+	// Going east along the equator, north is on the left.
+	pts := WGS84.CircleIntersections(LatLng{0, 0}, 1e5, LatLng{0, 1}, 1e5)
+	if len(pts) != 2 || !(pts[0].Lat > 0 && pts[1].Lat < 0) {
+		t.Fatalf("expected a point to the north and the south, got %v", pts)
+	}
+	if !eqish(pts[0].Lat, -pts[1].Lat, 9) || !eqish(pts[0].Lon, 0.5, 9) {
+		t.Fatalf("expected symmetric points, got %v", pts)
+	}
+	// Too far apart, one inside the other, and touching.
+	d := WGS84.distance(LatLng{0, 0}, LatLng{0, 1})
+	for _, c := range []struct {
+		r1, r2 float64
+		n      int
+	}{
+		{d/2 - 1, d/2 - 1, 0},
+		{d * 3, d, 0},
+		{d / 2, d / 2, 1},
+	} {
+		pts := WGS84.CircleIntersections(LatLng{0, 0}, c.r1, LatLng{0, 1}, c.r2)
+		if len(pts) != c.n {
+			t.Fatalf("expected %d points, got %v", c.n, pts)
+		}
+		if c.n == 1 && !(math.Abs(pts[0].Lat) < 1e-6 && eqish(pts[0].Lon, 0.5, 6)) {
+			t.Fatalf("expected '0, 0.5', got %v", pts[0])
+		}
+	}
+	if pts := WGS84.CircleIntersections(LatLng{10, 10}, 1e5, LatLng{10, 10}, 1e5); pts != nil {
+		t.Fatalf("expected nil, got %v", pts)
+	}
+	if pts := WGS84.CircleIntersections(LatLng{10, 10}, 0, LatLng{10, 11}, 1e5); pts != nil {
+		t.Fatalf("expected nil, got %v", pts)
+	}
+	// Centers a few meters apart, with radii that differ by nearly as
+	// much, meet far from the line of centers.
+	c1 := LatLng{19.1, -154.5}
+	var c2, x LatLng
+	WGS84.Direct(c1.Lat, c1.Lon, 30, 3, &c2.Lat, &c2.Lon, nil)
+	WGS84.Direct(c1.Lat, c1.Lon, 0, 3e4, &x.Lat, &x.Lon, nil)
+	r1, r2 := WGS84.distance(c1, x), WGS84.distance(c2, x)
+	pts = WGS84.CircleIntersections(c1, r1, c2, r2)
+	if len(pts) != 2 || WGS84.distance(pts[0], x) > seriesTolerance(1e-3) {
+		t.Fatalf("expected %v first, got %v", x, pts)
+	}
+}
+
+func TestLineCircleCrossings(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		center := LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+		radius := rng.Float64() * 3e6
+		var l Line
+		if i%2 == 0 {
+			l = WGS84.InverseLine(rng.Float64()*180-90, rng.Float64()*360-180,
+				rng.Float64()*180-90, rng.Float64()*360-180)
+		} else {
+			// Past the antipode and around again.
+			l = WGS84.DirectLine(rng.Float64()*180-90, rng.Float64()*360-180,
+				rng.Float64()*360, 3e7+rng.Float64()*5e7)
+		}
+		got := WGS84.LineCircleCrossings(&l, center, radius)
+		// Count the sign changes of the distance to the circle at steps
+		// along the line.
+		const step = 1e4
+		var want []float64
+		inside := func(s float64) bool {
+			var p LatLng
+			l.Position(s, &p.Lat, &p.Lon, nil)
+			return WGS84.distance(center, p) < radius
+		}
+		prev := inside(0)
+		for s := step; s < l.Distance()+step; s += step {
+			s := math.Min(s, l.Distance())
+			if in := inside(s); in != prev {
+				want = append(want, s)
+				prev = in
+			}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%d: expected %d crossings near %v, got %v", i, len(want), want, got)
+		}
+		for j, c := range got {
+			if math.Abs(c.S12-want[j]) > step || c.Entering != inside(want[j]) {
+				t.Fatalf("%d: expected a crossing near %v, got %v", i, want[j], c)
+			}
+			if d := WGS84.distance(center, c.Point); !eqish(d, radius, 6) {
+				t.Fatalf("%d: expected %v, got %v", i, radius, d)
+			}
+		}
+	}
+}
+
+func TestSegmentCircleCrossings(t *testing.T) {
+	// Along the equator through a circle about a point on it.
+	cs := WGS84.SegmentCircleCrossings(LatLng{0, -1}, LatLng{0, 1}, LatLng{0, 0}, 1e4)
+	if len(cs) != 2 || !cs[0].Entering || cs[1].Entering {
+		t.Fatalf("expected an entry and an exit, got %v", cs)
+	}
+	var s float64
+	WGS84.Inverse(0, -1, 0, 0, &s, nil, nil)
+	if !eqish(cs[0].S12, s-1e4, 6) || !eqish(cs[1].S12, s+1e4, 6) {
+		t.Fatalf("expected '%v, %v', got '%v, %v'", s-1e4, s+1e4, cs[0].S12, cs[1].S12)
+	}
+	// Starting inside, and missing.
+	cs = WGS84.SegmentCircleCrossings(LatLng{0, 0}, LatLng{0, 1}, LatLng{0, 0}, 1e4)
+	if len(cs) != 1 || cs[0].Entering {
+		t.Fatalf("expected an exit, got %v", cs)
+	}
+	cs = WGS84.SegmentCircleCrossings(LatLng{1, -1}, LatLng{1, 1}, LatLng{0, 0}, 1e4)
+	if cs != nil {
+		t.Fatalf("expected nil, got %v", cs)
+	}
+	l := WGS84.LineInit(0, 0, 90)
+	if cs := WGS84.LineCircleCrossings(&l, LatLng{0, 1}, 1e4); cs != nil {
+		t.Fatalf("expected nil, got %v", cs)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/tidwall/geodesic_cgo/internal/gendata"
+)
+
+// generateHard returns the corpus of hard cases: nearly antipodal points,
+// points at and near the poles, geodesics along and close to the equator,
+// and tiny separations, which are where the inverse solver switches
+// methods and loses the most accuracy, with polygons around and at the
+// poles and across the antimeridian.
+func generateHard() *gendata.Data {
+	e := geodesic.WGS84
+	d := &gendata.Data{Version: gendata.Version}
+	inv := func(lat1, lon1, lat2, lon2 float64) {
+		c := gendata.Inverse{Lat1: lat1, Lon1: lon1, Lat2: lat2, Lon2: lon2}
+		e.Inverse(lat1, lon1, lat2, lon2, &c.S12, &c.Azi1, &c.Azi2)
+		d.Inverse = append(d.Inverse, c)
+	}
+
+	// nearly antipodal
+	for _, lat := range []float64{0, 1e-10, 0.1, 1, 10, 30, 45, 60, 80, 89, 89.9} {
+		for _, lon2 := range []float64{
+			180, 180 - 1e-10, 179.9999, 179.99, 179.9, 179.5, 179,
+		} {
+			inv(lat, 0, -lat, lon2)
+		}
+		inv(lat, 0, -lat+1e-7, 180)
+	}
+	// from the GeographicLib change log
+	inv(48.522876735459, 0, -48.52287673545898293, 179.599720456223079643)
+	inv(56.320923501171, 0, -56.320923501171, 179.664747671772880215)
+	inv(52.784459512564, 0, -52.784459512563990912, 179.634407464943777557)
+	inv(88.202499451857, 0, -88.202499451857, 179.981022032992859592)
+	inv(89.262080389218, 0, -89.262080389218, 179.992207982775375662)
+	inv(89.333123580033, 0, -89.333123580032997687, 179.99295812360148422)
+
+	// at and near the poles
+	for _, lat1 := range []float64{90, -90, 89.9999999, -89.9999999} {
+		for _, lat2 := range []float64{
+			-90, -89.9999999, -45, 0, 45, 89.9999999, 90,
+		} {
+			for _, lon2 := range []float64{0, 90, 180} {
+				inv(lat1, 0, lat2, lon2)
+			}
+		}
+	}
+
+	// along and close to the equator
+	for _, lon2 := range []float64{
+		1e-10, 1e-5, 1, 45, 90, 135, 170, 179, 179.4, 179.5, 179.6, 179.9,
+		179.99, 180,
+	} {
+		inv(0, 0, 0, lon2)
+		inv(1e-10, 0, -1e-10, lon2)
+		inv(0, 0, 1e-6, lon2)
+	}
+
+	// tiny separations
+	for _, lat := range []float64{0, 45, 89, -60} {
+		for _, ds := range []float64{1e-12, 1e-9, 1e-6, 1e-3} {
+			inv(lat, 10, lat+ds, 10)
+			inv(lat, 10, lat, 10+ds)
+			inv(lat, 10, lat+ds, 10+ds)
+		}
+	}
+
+	// polygons around and at the poles and across the antimeridian
+	for _, ring := range [][][2]float64{
+		{{89, 0}, {89, 90}, {89, 180}, {89, -90}},
+		{{-89.9, 0}, {-89.9, -120}, {-89.9, 120}},
+		{{90, 0}, {80, 0}, {80, 90}},
+		{{89.99999, 0}, {89.99999, 120}, {89.99999, -120}},
+		{{10, 179}, {10, -179}, {-10, -179}, {-10, 179}},
+		{{0, 179.9999999}, {0, -179.9999999}, {1e-7, 180}},
+		{{0, 0}, {0, 90}, {0, 180}, {0, -90}},
+	} {
+		poly := gendata.Polygon{Points: ring}
+		p := e.PolygonInit(false)
+		for _, pt := range ring {
+			p.AddPoint(pt[0], pt[1])
+		}
+		for j, flags := range gendata.PolygonFlags {
+			p.Compute(flags[0], flags[1],
+				&poly.Results[j][0], &poly.Results[j][1])
+		}
+		p.Close()
+		d.Polygons = append(d.Polygons, poly)
+	}
+	return d
+}
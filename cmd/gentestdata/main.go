@@ -0,0 +1,96 @@
+// Command gentestdata generates the reference data read by the geodesic
+// tests.
+//
+// It solves random inverse problems and computes random geodesic circles
+// as polygons, on WGS84, and writes the results in the text format
+// described in internal/gendata. The same seed always gives the same
+// inputs, so files can be regenerated and compared after changing the C
+// library or the Go port.
+//
+// With -hard it writes the curated corpus of hard cases in
+// testdata/hard.txt instead, see generateHard.
+//
+// Usage:
+//
+//	go run ./cmd/gentestdata [-seed n] [-inverse n] [-polygons n] [-o file]
+//	go run ./cmd/gentestdata -hard [-o file]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/tidwall/geodesic_cgo/internal/gendata"
+)
+
+func main() {
+	seed := flag.Int64("seed", 1, "random seed")
+	ninv := flag.Int("inverse", 1000, "number of inverse problems")
+	npoly := flag.Int("polygons", 50, "number of polygons")
+	hard := flag.Bool("hard", false, "write the corpus of hard cases")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	var d *gendata.Data
+	if *hard {
+		d = generateHard()
+	} else {
+		d = generate(*seed, *ninv, *npoly)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := gendata.Write(w, d); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(seed int64, ninv, npoly int) *gendata.Data {
+	e := geodesic.WGS84
+	rng := rand.New(rand.NewSource(seed))
+	d := &gendata.Data{Version: gendata.Version, Seed: seed}
+	for i := 0; i < ninv; i++ {
+		var c gendata.Inverse
+		c.Lat1 = rng.Float64()*180 - 90
+		c.Lon1 = rng.Float64()*360 - 180
+		c.Lat2 = rng.Float64()*180 - 90
+		c.Lon2 = rng.Float64()*360 - 180
+		e.Inverse(c.Lat1, c.Lon1, c.Lat2, c.Lon2, &c.S12, &c.Azi1, &c.Azi2)
+		d.Inverse = append(d.Inverse, c)
+	}
+	for i := 0; i < npoly; i++ {
+		lat1 := rng.Float64()*180 - 90
+		lon1 := rng.Float64()*360 - 180
+		steps := rng.Intn(10) + 4        // 4 - 14 steps
+		dist := rng.Float64()*20000 + 10 // 10-20000 meters
+		var poly gendata.Polygon
+		p := e.PolygonInit(false)
+		// generate the circle
+		for j := 0; j < steps; j++ {
+			azi := 360 * float64(j) / float64(steps)
+			var lat2, lon2 float64
+			e.Direct(lat1, lon1, azi, dist, &lat2, &lon2, nil)
+			p.AddPoint(lat2, lon2)
+			poly.Points = append(poly.Points, [2]float64{lat2, lon2})
+		}
+		for j, flags := range gendata.PolygonFlags {
+			p.Compute(flags[0], flags[1],
+				&poly.Results[j][0], &poly.Results[j][1])
+		}
+		d.Polygons = append(d.Polygons, poly)
+	}
+	return d
+}
@@ -0,0 +1,92 @@
+// Command geodcsv appends the geodesic distance and azimuths between two
+// points to each row of a CSV file.
+//
+// The rows are read from the named file, or standard input, and written
+// with "distance", "azi1", and "azi2" columns appended, in meters and
+// degrees on WGS84. See the geodcsv package for the details.
+//
+// Usage:
+//
+//	go run ./cmd/geodcsv [-header] [-cols lat1,lon1,lat2,lon2] [-d ,]
+//		[-lenient] [-o file] [file]
+//
+// The -cols flag gives the columns of the coordinates, either as
+// zero-based numbers or, with -header, as names in the header.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tidwall/geodesic_cgo/geodcsv"
+)
+
+func main() {
+	header := flag.Bool("header", false, "the first row is a header")
+	cols := flag.String("cols", "0,1,2,3", "columns of lat1,lon1,lat2,lon2")
+	delim := flag.String("d", ",", "field delimiter")
+	lenient := flag.Bool("lenient", false, "leave bad rows unannotated")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	opts, err := options(*header, *cols, *delim, *lenient)
+	if err != nil {
+		fail(err)
+	}
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		r = f
+	}
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := geodcsv.Annotate(w, r, opts); err != nil {
+		fail(err)
+	}
+}
+
+func options(header bool, cols, delim string, lenient bool,
+) (*geodcsv.Options, error) {
+	opts := &geodcsv.Options{Header: header, Lenient: lenient}
+	c, size := utf8.DecodeRuneInString(delim)
+	if size == 0 || size != len(delim) {
+		return nil, fmt.Errorf("invalid delimiter %q", delim)
+	}
+	opts.Comma = c
+	fields := strings.Split(cols, ",")
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("expected 4 columns, got %d", len(fields))
+	}
+	for i, s := range fields {
+		s = strings.TrimSpace(s)
+		if j, err := strconv.Atoi(s); err == nil {
+			opts.Index[i] = j
+		} else if header {
+			opts.Names[i] = s
+		} else {
+			return nil, fmt.Errorf("column %q needs -header", s)
+		}
+	}
+	return opts, nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,123 @@
+// Command geoddist writes the geodesic distance, and optionally the
+// azimuths, between the two points of each row of a CSV or TSV file.
+//
+// The rows are read from the named file, or standard input, and solved in
+// parallel on WGS84, with one result written per row in the same order.
+// See the geoddist package for the details.
+//
+// Usage:
+//
+//	go run ./cmd/geoddist [-header] [-cols lat1,lon1,lat2,lon2] [-d ,]
+//		[-units m|km|mi|nmi|ft] [-format csv|tsv|json] [-azimuths]
+//		[-lenient] [-workers n] [-o file] [file]
+//
+// The -cols flag gives the zero-based columns of the coordinates. The -d
+// flag defaults to a tab for files whose names end in ".tsv".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/tidwall/geodesic_cgo/geoddist"
+)
+
+var units = map[string]geodesic.Distance{
+	"m":   geodesic.Meter,
+	"km":  geodesic.Kilometer,
+	"mi":  geodesic.Mile,
+	"nmi": geodesic.NauticalMile,
+	"ft":  geodesic.Foot,
+}
+
+var formats = map[string]geoddist.Format{
+	"csv":  geoddist.FormatCSV,
+	"tsv":  geoddist.FormatTSV,
+	"json": geoddist.FormatJSON,
+}
+
+func main() {
+	header := flag.Bool("header", false, "the first row is a header")
+	cols := flag.String("cols", "0,1,2,3", "columns of lat1,lon1,lat2,lon2")
+	delim := flag.String("d", "", "field delimiter (default , or tab for .tsv)")
+	unit := flag.String("units", "m", "unit of distance: m, km, mi, nmi, or ft")
+	format := flag.String("format", "csv", "output format: csv, tsv, or json")
+	azimuths := flag.Bool("azimuths", false, "also write the azimuths")
+	lenient := flag.Bool("lenient", false, "write empty results for bad rows")
+	workers := flag.Int("workers", 0, "number of goroutines (default all cores)")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	opts := &geoddist.Options{
+		Header:   *header,
+		Azimuths: *azimuths,
+		Lenient:  *lenient,
+		Workers:  *workers,
+	}
+	var ok bool
+	if opts.Unit, ok = units[*unit]; !ok {
+		fail(fmt.Errorf("unknown unit %q", *unit))
+	}
+	if opts.Format, ok = formats[*format]; !ok {
+		fail(fmt.Errorf("unknown format %q", *format))
+	}
+	if *delim == "" {
+		*delim = ","
+		if strings.HasSuffix(flag.Arg(0), ".tsv") {
+			*delim = "\t"
+		}
+	}
+	c, size := utf8.DecodeRuneInString(*delim)
+	if size != len(*delim) {
+		fail(fmt.Errorf("invalid delimiter %q", *delim))
+	}
+	opts.Comma = c
+	fields := strings.Split(*cols, ",")
+	if len(fields) != 4 {
+		fail(fmt.Errorf("expected 4 columns, got %d", len(fields)))
+	}
+	for i, s := range fields {
+		j, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			fail(fmt.Errorf("invalid column %q", s))
+		}
+		opts.Index[i] = j
+	}
+
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		r = f
+	}
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if _, err := geoddist.Run(ctx, w, r, opts); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
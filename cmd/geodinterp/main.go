@@ -0,0 +1,66 @@
+// Command geodinterp densifies the edges of GeoJSON or WKT geometries along
+// geodesics, for drawing them on a map.
+//
+// The geometries are read from the named file, or standard input, and
+// written with points inserted along every edge of their LineStrings and
+// Polygons, so that no edge is longer than -max meters on WGS84. See the
+// geodinterp package for the details.
+//
+// Usage:
+//
+//	go run ./cmd/geodinterp -max meters [-format auto|geojson|wkt]
+//		[-o file] [file]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tidwall/geodesic_cgo/geodinterp"
+)
+
+func main() {
+	maxSegment := flag.Float64("max", 0, "maximum segment length (meters)")
+	format := flag.String("format", "auto", "input format: auto, geojson, or wkt")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	opts := &geodinterp.Options{MaxSegment: *maxSegment}
+	switch *format {
+	case "auto":
+	case "geojson":
+		opts.Format = geodinterp.FormatGeoJSON
+	case "wkt":
+		opts.Format = geodinterp.FormatWKT
+	default:
+		fail(fmt.Errorf("unknown format %q", *format))
+	}
+	var r io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		r = f
+	}
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := geodinterp.Densify(w, r, opts); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
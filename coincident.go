@@ -0,0 +1,75 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrCoincident is returned by Ellipsoid.CheckedInverse for two points that
+// are the same under the CoincidentStrict policy.
+var ErrCoincident = errors.New("geodesic: coincident points")
+
+// CoincidentPolicy selects the azimuths reported by an Ellipsoid for the
+// inverse problem between two points that are the same, where the geodesic
+// has no length and no direction.
+//
+// Two points are the same when their latitudes are equal and their
+// longitudes are equal modulo 360. Two points at a pole with different
+// longitudes are not, since the azimuths there are relative to the
+// meridians of the longitudes given and so still mean something.
+type CoincidentPolicy int
+
+const (
+	// CoincidentLibrary reports the azimuths that the geodesic routines
+	// happen to derive for a geodesic of no length, which are normally
+	// 180, but 0 for some pairs of longitudes such as -180 and +180. This
+	// is the default.
+	CoincidentLibrary CoincidentPolicy = iota
+	// CoincidentNaN reports the azimuths as NaN.
+	CoincidentNaN
+	// CoincidentZero reports the azimuths as 0, due north.
+	CoincidentZero
+	// CoincidentStrict reports the azimuths as NaN, as CoincidentNaN does,
+	// and makes Ellipsoid.CheckedInverse return ErrCoincident.
+	CoincidentStrict
+)
+
+// WithCoincident returns a copy of the ellipsoid that reports the azimuths
+// between coincident points using the policy. The original ellipsoid is not
+// changed, so this is safe to use on shared values, such as WGS84.
+//
+// The policy applies to the azimuths returned by Ellipsoid.Inverse and
+// Ellipsoid.InverseArc, and so to everything built on them, such as
+// Ellipsoid.InverseBatch. The distance is zero under every policy.
+func (e *Ellipsoid) WithCoincident(p CoincidentPolicy) *Ellipsoid {
+	ec := *e
+	ec.coincident = p
+	return &ec
+}
+
+// coincidentPoints reports whether two points are the same, see
+// CoincidentPolicy.
+func coincidentPoints(lat1, lon1, lat2, lon2 float64) bool {
+	return lat1 == lat2 && math.Remainder(lon2-lon1, 360) == 0
+}
+
+// apply replaces the azimuths of the inverse problem between two points by
+// those of the policy, if the points are the same. A nil azimuth is
+// ignored.
+func (c CoincidentPolicy) apply(lat1, lon1, lat2, lon2 float64,
+	azi1, azi2 *float64,
+) {
+	if c == CoincidentLibrary || !coincidentPoints(lat1, lon1, lat2, lon2) {
+		return
+	}
+	azi := 0.0
+	if c != CoincidentZero {
+		azi = math.NaN()
+	}
+	if azi1 != nil {
+		*azi1 = azi
+	}
+	if azi2 != nil {
+		*azi2 = azi
+	}
+}
@@ -0,0 +1,64 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCoincident(t *testing.T) {
+	same := [][4]float64{
+		{10, 20, 10, 20}, {0, 0, 0, 0}, {90, 0, 90, 0},
+		{-30, 180, -30, -180}, {10, 20, 10, 380},
+	}
+	for _, c := range same {
+		var s12, azi1, azi2 float64
+		WGS84.Inverse(c[0], c[1], c[2], c[3], &s12, &azi1, &azi2)
+		if s12 != 0 || math.IsNaN(azi1) || math.IsNaN(azi2) {
+			t.Fatalf("%v: expected the library azimuths, got %v %v %v",
+				c, s12, azi1, azi2)
+		}
+		WGS84.WithCoincident(CoincidentNaN).Inverse(c[0], c[1], c[2], c[3],
+			&s12, &azi1, &azi2)
+		if s12 != 0 || !math.IsNaN(azi1) || !math.IsNaN(azi2) {
+			t.Fatalf("%v: expected NaN azimuths, got %v %v %v",
+				c, s12, azi1, azi2)
+		}
+		e := WGS84.WithCoincident(CoincidentZero).WithAzimuths(AzimuthUnsigned)
+		e.Inverse(c[0], c[1], c[2], c[3], &s12, &azi1, &azi2)
+		if s12 != 0 || azi1 != 0 || azi2 != 0 {
+			t.Fatalf("%v: expected zero azimuths, got %v %v %v",
+				c, s12, azi1, azi2)
+		}
+		_, err := WGS84.CheckedInverse(LatLng{c[0], c[1]}, LatLng{c[2], c[3]})
+		if err != nil {
+			t.Fatalf("%v: expected no error, got %v", c, err)
+		}
+		r, err := WGS84.WithCoincident(CoincidentStrict).CheckedInverse(
+			LatLng{c[0], c[1]}, LatLng{c[2], c[3]})
+		if !errors.Is(err, ErrCoincident) || !math.IsNaN(r.Azi1) {
+			t.Fatalf("%v: expected ErrCoincident, got %v %v", c, r, err)
+		}
+	}
+
+	// Points at a pole with different longitudes keep their azimuths, and
+	// distinct points are not affected.
+	e := WGS84.WithCoincident(CoincidentStrict)
+	var azi1 float64
+	e.Inverse(90, 0, 90, 50, nil, &azi1, nil)
+	if azi1 != 130 {
+		t.Fatalf("expected 130, got %v", azi1)
+	}
+	r, err := e.CheckedInverse(LatLng{10, 20}, LatLng{10, 20.001})
+	if err != nil || !eqish(r.Azi1, 90, 2) {
+		t.Fatalf("expected an eastward solution, got %v %v", r, err)
+	}
+
+	// Init resets the policy.
+	var ec Ellipsoid
+	ec = *e
+	ec.Init(WGS84.Radius(), WGS84.Flattening())
+	if _, err := ec.CheckedInverse(LatLng{1, 2}, LatLng{1, 2}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
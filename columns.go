@@ -0,0 +1,141 @@
+package geodesic
+
+import "context"
+
+// The column functions take and fill plain float64 slices, one per
+// quantity, rather than slices of structs. That is the layout of the
+// values of an Arrow Float64 array and of the columns of most dataframe
+// libraries, so those can be passed through without copying: an Arrow
+// array's Float64Values, or a Parquet column read into a []float64, are
+// already in this form. Nulls should be given as NaN, which gives NaN
+// results.
+
+// InverseColumns solves many inverse geodesic problems in parallel, as
+// Ellipsoid.InverseBatch, with the inputs and outputs as columns.
+//
+// Param ctx is checked between chunks of work; when it is done, the batch
+// stops early and returns the context's error with partial results.
+// Param lat1, lon1, lat2, and lon2 are the points (degrees).
+// Out param s12 is the distances (meters).
+// Out param azi1 and azi2 are the azimuths at the points (degrees).
+// Param opts are the options, or nil for the defaults.
+//
+// Any of the outputs may be nil if they are not needed. The others must be
+// the same length as the inputs, or ErrLengthMismatch is returned.
+func (e *Ellipsoid) InverseColumns(ctx context.Context,
+	lat1, lon1, lat2, lon2 []float64, s12, azi1, azi2 []float64,
+	opts *BatchOptions,
+) error {
+	n := len(lat1)
+	if !sameLen(n, lon1, lat2, lon2) || !outLen(n, s12, azi1, azi2) {
+		return ErrLengthMismatch
+	}
+	return runBatch(ctx, n, opts, func(i int) {
+		e.Inverse(lat1[i], lon1[i], lat2[i], lon2[i],
+			column(s12, i), column(azi1, i), column(azi2, i))
+	})
+}
+
+// DirectColumns solves many direct geodesic problems in parallel, as
+// Ellipsoid.DirectBatch, with the inputs and outputs as columns.
+//
+// Param ctx is checked between chunks of work; when it is done, the batch
+// stops early and returns the context's error with partial results.
+// Param lat1 and lon1 are the points 1 (degrees).
+// Param azi1 is the azimuths at points 1 (degrees).
+// Param s12 is the distances from points 1 to points 2 (meters).
+// Out param lat2 and lon2 are the points 2 (degrees).
+// Out param azi2 is the azimuths at points 2 (degrees).
+// Param opts are the options, or nil for the defaults.
+//
+// Any of the outputs may be nil if they are not needed. The others must be
+// the same length as the inputs, or ErrLengthMismatch is returned.
+func (e *Ellipsoid) DirectColumns(ctx context.Context,
+	lat1, lon1, azi1, s12 []float64, lat2, lon2, azi2 []float64,
+	opts *BatchOptions,
+) error {
+	n := len(lat1)
+	if !sameLen(n, lon1, azi1, s12) || !outLen(n, lat2, lon2, azi2) {
+		return ErrLengthMismatch
+	}
+	return runBatch(ctx, n, opts, func(i int) {
+		e.Direct(lat1[i], lon1[i], azi1[i], s12[i],
+			column(lat2, i), column(lon2, i), column(azi2, i))
+	})
+}
+
+// PolygonAreaColumns computes the areas and perimeters of many polygons in
+// parallel, with the vertices of all of them as columns.
+//
+// Param ctx is checked between chunks of work; when it is done, the batch
+// stops early and returns the context's error with partial results.
+// Param lats and lons are the vertices of all of the polygons (degrees).
+// Param offsets is where each polygon starts in lats and lons, with one
+// more at the end for where the last one ends, as the offsets of an Arrow
+// list array. Polygon i is lats[offsets[i]:offsets[i+1]].
+// Out param area is the areas of the polygons (meters-squared), with the
+// convention of the ellipsoid as for PolygonArea.
+// Out param perimeter is the perimeters of the polygons (meters).
+// Param opts are the options, or nil for the defaults. Progress counts
+// polygons.
+//
+// Either output may be nil if it is not needed, otherwise it must have one
+// value per polygon, one less than the offsets. The offsets must not
+// decrease and must be within the vertices, or ErrLengthMismatch is
+// returned.
+func (e *Ellipsoid) PolygonAreaColumns(ctx context.Context,
+	lats, lons []float64, offsets []int, area, perimeter []float64,
+	opts *BatchOptions,
+) error {
+	if len(lats) != len(lons) || len(offsets) == 0 {
+		return ErrLengthMismatch
+	}
+	n := len(offsets) - 1
+	if !outLen(n, area, perimeter) {
+		return ErrLengthMismatch
+	}
+	for i := 0; i < n; i++ {
+		if offsets[i] < 0 || offsets[i] > offsets[i+1] ||
+			offsets[i+1] > len(lats) {
+			return ErrLengthMismatch
+		}
+	}
+	reverse, sign := e.areas.flags()
+	return runBatch(ctx, n, opts, func(i int) {
+		p := e.PolygonInit(false)
+		for j := offsets[i]; j < offsets[i+1]; j++ {
+			p.AddPoint(lats[j], lons[j])
+		}
+		p.Compute(reverse, sign, column(area, i), column(perimeter, i))
+	})
+}
+
+// sameLen reports whether the input columns all have length n.
+func sameLen(n int, cols ...[]float64) bool {
+	for _, c := range cols {
+		if len(c) != n {
+			return false
+		}
+	}
+	return true
+}
+
+// outLen reports whether the output columns that are not nil all have
+// length n.
+func outLen(n int, cols ...[]float64) bool {
+	for _, c := range cols {
+		if c != nil && len(c) != n {
+			return false
+		}
+	}
+	return true
+}
+
+// column returns a pointer to the value i of an output column, or nil if
+// the column is nil.
+func column(c []float64, i int) *float64 {
+	if c == nil {
+		return nil
+	}
+	return &c[i]
+}
@@ -0,0 +1,116 @@
+package geodesic
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInverseColumns(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 1000
+	lat1, lon1 := make([]float64, n), make([]float64, n)
+	lat2, lon2 := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		lat1[i], lon1[i] = rng.Float64()*180-90, rng.Float64()*360-180
+		lat2[i], lon2[i] = rng.Float64()*180-90, rng.Float64()*360-180
+	}
+	lat1[7] = math.NaN() // a null
+	s12, azi2 := make([]float64, n), make([]float64, n)
+	err := WGS84.InverseColumns(context.Background(), lat1, lon1, lat2, lon2,
+		s12, nil, azi2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		var s, z2 float64
+		WGS84.Inverse(lat1[i], lon1[i], lat2[i], lon2[i], &s, nil, &z2)
+		if math.Float64bits(s12[i]) != math.Float64bits(s) ||
+			math.Float64bits(azi2[i]) != math.Float64bits(z2) {
+			t.Fatalf("expected '%v, %v', got '%v, %v'", s, z2, s12[i], azi2[i])
+		}
+	}
+	if !math.IsNaN(s12[7]) {
+		t.Fatalf("expected NaN, got %v", s12[7])
+	}
+	if err := WGS84.InverseColumns(context.Background(), lat1, lon1, lat2,
+		lon2[1:], s12, nil, nil, nil); err != ErrLengthMismatch {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+	if err := WGS84.InverseColumns(context.Background(), lat1, lon1, lat2,
+		lon2, s12[1:], nil, nil, nil); err != ErrLengthMismatch {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+}
+
+func TestDirectColumns(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	const n = 500
+	lat1, lon1 := make([]float64, n), make([]float64, n)
+	azi1, s12 := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		lat1[i], lon1[i] = rng.Float64()*180-90, rng.Float64()*360-180
+		azi1[i], s12[i] = rng.Float64()*360-180, rng.Float64()*2e7
+	}
+	lat2, lon2 := make([]float64, n), make([]float64, n)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := WGS84.DirectColumns(ctx, lat1, lon1, azi1, s12, lat2, lon2,
+		nil, nil); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+	if err := WGS84.DirectColumns(context.Background(), lat1, lon1, azi1,
+		s12, lat2, lon2, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		var lat, lon float64
+		WGS84.Direct(lat1[i], lon1[i], azi1[i], s12[i], &lat, &lon, nil)
+		if lat2[i] != lat || lon2[i] != lon {
+			t.Fatalf("expected '%v, %v', got '%v, %v'", lat, lon, lat2[i], lon2[i])
+		}
+	}
+}
+
+func TestPolygonAreaColumns(t *testing.T) {
+	rings := [][]LatLng{
+		{{0, 0}, {0, 90}, {90, 0}},
+		{},
+		{{10, 10}, {10, 11}, {11, 11}, {11, 10}},
+		{{-40, 170}, {-40, -170}, {-30, -170}, {-30, 170}},
+	}
+	var lats, lons []float64
+	offsets := []int{0}
+	for _, r := range rings {
+		for _, p := range r {
+			lats, lons = append(lats, p.Lat), append(lons, p.Lon)
+		}
+		offsets = append(offsets, len(lats))
+	}
+	e := WGS84.WithAreas(AreaClockwise)
+	area, perim := make([]float64, len(rings)), make([]float64, len(rings))
+	err := e.PolygonAreaColumns(context.Background(), lats, lons, offsets,
+		area, perim, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range rings {
+		a, p := PolygonArea(e, r)
+		if area[i] != a || perim[i] != p {
+			t.Fatalf("%d: expected '%v, %v', got '%v, %v'", i, a, p, area[i], perim[i])
+		}
+	}
+	if err := e.PolygonAreaColumns(context.Background(), lats, lons,
+		[]int{0, 4, 3}, area[:2], nil, nil); err != ErrLengthMismatch {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+	if err := e.PolygonAreaColumns(context.Background(), lats, lons,
+		[]int{0, len(lats) + 1}, nil, nil, nil); err != ErrLengthMismatch {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+	if err := e.PolygonAreaColumns(context.Background(), lats, lons,
+		offsets, area[1:], nil, nil); err != ErrLengthMismatch {
+		t.Fatalf("expected %v, got %v", ErrLengthMismatch, err)
+	}
+}
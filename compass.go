@@ -0,0 +1,90 @@
+package geodesic
+
+import "math"
+
+// AzimuthConvention selects the range of the azimuths reported by an
+// Ellipsoid and the Lines created from it.
+type AzimuthConvention int
+
+const (
+	// AzimuthSigned reports azimuths in the range [-180,+180]. This is the
+	// default.
+	AzimuthSigned AzimuthConvention = iota
+	// AzimuthUnsigned reports azimuths in the range [0,360).
+	AzimuthUnsigned
+)
+
+// apply converts an azimuth to the convention. A nil azi is ignored.
+func (c AzimuthConvention) apply(azi *float64) {
+	if c == AzimuthUnsigned && azi != nil {
+		*azi = Azimuth360(*azi)
+	}
+}
+
+// WithAzimuths returns a copy of the ellipsoid that reports azimuths using
+// the convention. The original ellipsoid is not changed, so this is safe to
+// use on shared values, such as WGS84.
+//
+// The convention applies to the azimuths returned by Ellipsoid.Inverse,
+// Ellipsoid.Direct, and Line.Position.
+func (e *Ellipsoid) WithAzimuths(c AzimuthConvention) *Ellipsoid {
+	ec := *e
+	ec.azimuths = c
+	return &ec
+}
+
+// Azimuth180 reduces an azimuth to the range (-180,+180] (degrees).
+func Azimuth180(azi float64) float64 {
+	azi = math.Remainder(azi, 360)
+	if azi == -180 {
+		return 180
+	}
+	return azi
+}
+
+// Azimuth360 reduces an azimuth to the range [0,360) (degrees).
+func Azimuth360(azi float64) float64 {
+	azi = math.Mod(azi, 360)
+	if azi < 0 {
+		azi += 360
+		if azi == 360 {
+			// a tiny negative value rounded up
+			azi = 0
+		}
+	}
+	return azi
+}
+
+// BackAzimuth returns the reverse direction of an azimuth, in the range
+// (-180,+180] (degrees).
+//
+// Note that the back azimuth of a geodesic at point 1 is not the azimuth
+// at point 2. Use the reverse of the azi2 returned by Ellipsoid.Inverse to
+// head back along the same geodesic.
+func BackAzimuth(azi float64) float64 {
+	return Azimuth180(azi + 180)
+}
+
+var compassNames = [32]string{
+	"N", "NbE", "NNE", "NEbN", "NE", "NEbE", "ENE", "EbN",
+	"E", "EbS", "ESE", "SEbE", "SE", "SEbS", "SSE", "SbE",
+	"S", "SbW", "SSW", "SWbS", "SW", "SWbW", "WSW", "WbS",
+	"W", "WbN", "WNW", "NWbW", "NW", "NWbN", "NNW", "NbW",
+}
+
+// CompassPoint returns the name of the compass point nearest to an azimuth.
+//
+// Param azi is the azimuth (degrees).
+// Param points is the number of compass points to choose from: 4, 8, 16, or
+// 32.
+// Returns the name, such as "N", "NE", "NNE", or "NbE", or an empty string
+// when points is not a supported number.
+func CompassPoint(azi float64, points int) string {
+	switch points {
+	case 4, 8, 16, 32:
+	default:
+		return ""
+	}
+	i := int(math.Floor(Azimuth360(azi)/(360/float64(points))+0.5)) % points
+	return compassNames[i*(32/points)]
+}
@@ -0,0 +1,63 @@
+package geodesic
+
+import "testing"
+
+func TestAzimuthHelpers(t *testing.T) {
+	for _, v := range [][3]float64{
+		// azi, Azimuth180, Azimuth360
+		{0, 0, 0}, {-90, -90, 270}, {180, 180, 180}, {-180, 180, 180},
+		{270, -90, 270}, {360, 0, 0}, {725, 5, 5}, {-1e-20, -1e-20, 0},
+	} {
+		if a := Azimuth180(v[0]); a != v[1] {
+			t.Fatalf("Azimuth180(%v): expected %v, got %v", v[0], v[1], a)
+		}
+		if a := Azimuth360(v[0]); a != v[2] {
+			t.Fatalf("Azimuth360(%v): expected %v, got %v", v[0], v[2], a)
+		}
+	}
+	if a := BackAzimuth(30); a != -150 {
+		t.Fatalf("expected -150, got %v", a)
+	}
+	if a := BackAzimuth(-170); a != 10 {
+		t.Fatalf("expected 10, got %v", a)
+	}
+}
+
+func TestCompassPoint(t *testing.T) {
+	for _, v := range []struct {
+		azi    float64
+		points int
+		name   string
+	}{
+		{0, 16, "N"}, {359, 16, "N"}, {22.5, 16, "NNE"}, {-45, 8, "NW"},
+		{100, 4, "E"}, {11.25, 32, "NbE"}, {191.25, 32, "SbW"},
+		{180, 16, "S"}, {10, 7, ""},
+	} {
+		if name := CompassPoint(v.azi, v.points); name != v.name {
+			t.Fatalf("CompassPoint(%v, %v): expected %q, got %q",
+				v.azi, v.points, v.name, name)
+		}
+	}
+}
+
+func TestWithAzimuths(t *testing.T) {
+	e := WGS84.WithAzimuths(AzimuthUnsigned)
+	var azi1, azi2 float64
+	e.Inverse(10, 10, 0, 0, nil, &azi1, &azi2)
+	if azi1 < 180 || azi1 >= 360 || azi2 < 180 || azi2 >= 360 {
+		t.Fatalf("expected unsigned azimuths, got %v, %v", azi1, azi2)
+	}
+	WGS84.Inverse(10, 10, 0, 0, nil, &azi1, nil)
+	if azi1 >= 0 {
+		t.Fatalf("expected WGS84 to be unchanged, got %v", azi1)
+	}
+	e.Direct(10, 10, -100, 1000, nil, nil, &azi2)
+	if azi2 < 180 {
+		t.Fatalf("expected an unsigned azimuth, got %v", azi2)
+	}
+	l := e.InverseLine(10, 10, 0, 0)
+	l.Position(1000, nil, nil, &azi2)
+	if l.Azi1() < 180 || azi2 < 180 {
+		t.Fatalf("expected unsigned azimuths, got %v, %v", l.Azi1(), azi2)
+	}
+}
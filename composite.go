@@ -0,0 +1,118 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+)
+
+// CompositeRoute is a route made of geodesic and parallel legs.
+type CompositeRoute struct {
+	Waypoints []LatLng  // start, turning points, and end
+	Legs      []float64 // length of each leg between waypoints (meters)
+	Distance  float64   // total length of the route (meters)
+}
+
+// lineVertex returns the distance along the line to the point where the
+// latitude reaches an extreme, northward if sign is positive and southward
+// if negative, searching no further than limit meters.
+func lineVertex(l *Line, sign, limit float64) float64 {
+	s, _ := goldenMin(0, limit, func(s float64) float64 {
+		var lat float64
+		l.Position(s, &lat, nil, nil)
+		return -sign * lat
+	})
+	return s
+}
+
+// CompositeSailing builds a composite sailing route that does not go
+// beyond a limiting latitude.
+//
+// Param lat1 is latitude of point 1 (degrees).
+// Param lon1 is longitude of point 1 (degrees).
+// Param lat2 is latitude of point 2 (degrees).
+// Param lon2 is longitude of point 2 (degrees).
+// Param limit is the limiting latitude (degrees), applied to both
+// hemispheres as a limit on the absolute latitude.
+// Returns the route, or an error wrapping ErrInvalidArgument if limit is
+// not a number, or ErrOutOfRange if point 1 or point 2 is beyond the
+// limit, where no route can keep to it.
+//
+// When the geodesic from point 1 to point 2 stays within the limit, the
+// route is that single geodesic. Otherwise the route follows a geodesic
+// from point 1 that just touches the limiting parallel, runs along the
+// parallel, and leaves it on a geodesic that just touches it on the way to
+// point 2. The parallel is also a rhumb line, so it can be steered on a
+// constant course.
+func (e *Ellipsoid) CompositeSailing(lat1, lon1, lat2, lon2, limit float64,
+) (CompositeRoute, error) {
+	if math.IsNaN(limit) {
+		return CompositeRoute{}, fmt.Errorf("%w: limiting latitude %v",
+			ErrInvalidArgument, limit)
+	}
+	limit = math.Abs(limit)
+	for i, lat := range []float64{lat1, lat2} {
+		if !(math.Abs(lat) <= limit) {
+			return CompositeRoute{}, fmt.Errorf(
+				"%w: point %d at latitude %v is beyond the limit of %v",
+				ErrOutOfRange, i+1, lat, limit)
+		}
+	}
+	p1, p2 := LatLng{lat1, lon1}, LatLng{lat2, lon2}
+	l := e.InverseLine(lat1, lon1, lat2, lon2)
+	s13 := l.Distance()
+	direct := CompositeRoute{
+		Waypoints: []LatLng{p1, p2}, Legs: []float64{s13}, Distance: s13,
+	}
+	// Find the highest latitude reached between the points.
+	sign := 1.0
+	var far float64
+	for _, sg := range []float64{1, -1} {
+		s := lineVertex(&l, sg, s13)
+		var lat float64
+		l.Position(s, &lat, nil, nil)
+		if sg*lat > far {
+			sign, far = sg, sg*lat
+		}
+	}
+	if far <= limit {
+		return direct, nil
+	}
+	east := math.Sin(l.Azi1()*(math.Pi/180)) >= 0
+	f := e.Flattening()
+	reduced := func(lat float64) float64 {
+		return math.Atan((1 - f) * math.Tan(lat*(math.Pi/180)))
+	}
+	var quarter float64
+	e.Inverse(0, 0, 90, 0, &quarter, nil, nil)
+	// vertex returns the point on the limiting parallel that is the vertex
+	// of the geodesic leaving p, heading east or west, along with the
+	// distance from p.
+	vertex := func(p LatLng, east bool) (LatLng, float64) {
+		salp := math.Cos(reduced(limit)) / math.Cos(reduced(p.Lat))
+		azi := math.Asin(math.Min(salp, 1)) * (180 / math.Pi)
+		if sign < 0 {
+			azi = 180 - azi
+		}
+		if !east {
+			azi = -azi
+		}
+		vl := e.LineInit(p.Lat, p.Lon, azi)
+		s := lineVertex(&vl, sign, 2*quarter)
+		var v LatLng
+		vl.Position(s, &v.Lat, &v.Lon, nil)
+		return v, s
+	}
+	v1, d1 := vertex(p1, east)
+	v2, d2 := vertex(p2, !east)
+	var dp float64
+	if east {
+		dp = e.ParallelArcLength(limit*sign, v1.Lon, v2.Lon)
+	} else {
+		dp = e.ParallelArcLength(limit*sign, v2.Lon, v1.Lon)
+	}
+	return CompositeRoute{
+		Waypoints: []LatLng{p1, v1, v2, p2},
+		Legs:      []float64{d1, dp, d2},
+		Distance:  d1 + dp + d2,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCompositeSailing(t *testing.T) {
+	// Cape Town to Melbourne, limited to 50 degrees south.
+	r, err := WGS84.CompositeSailing(-33.9, 18.4, -37.8, 144.9, 50)
+	if err != nil || len(r.Waypoints) != 4 || len(r.Legs) != 3 {
+		t.Fatalf("expected a composite route, got %+v (%v)", r, err)
+	}
+	for _, v := range r.Waypoints[1:3] {
+		if !eqish(v.Lat, -50, 6) {
+			t.Fatalf("expected a turning point on the limit, got %v", v)
+		}
+	}
+	var s12 float64
+	WGS84.Inverse(-33.9, 18.4, -37.8, 144.9, &s12, nil, nil)
+	if r.Distance <= s12 || r.Distance > s12*1.1 {
+		t.Fatalf("unexpected distance %f for a geodesic of %f", r.Distance, s12)
+	}
+	WGS84.Inverse(r.Waypoints[0].Lat, r.Waypoints[0].Lon,
+		r.Waypoints[1].Lat, r.Waypoints[1].Lon, &s12, nil, nil)
+	if !eqish(s12, r.Legs[0], 2) {
+		t.Fatalf("expected %f, got %f", s12, r.Legs[0])
+	}
+	// A route that stays within the limit is a single geodesic.
+	r, _ = WGS84.CompositeSailing(-33.9, 18.4, -37.8, 144.9, 65)
+	if len(r.Waypoints) != 2 {
+		t.Fatalf("expected a direct route, got %+v", r)
+	}
+	// Northern hemisphere, heading west.
+	r, _ = WGS84.CompositeSailing(37.6, -122.4, 35.7, 139.7, 45)
+	if len(r.Waypoints) != 4 || !eqish(r.Waypoints[1].Lat, 45, 6) {
+		t.Fatalf("expected a composite route, got %+v", r)
+	}
+	WGS84.Inverse(37.6, -122.4, 35.7, 139.7, &s12, nil, nil)
+	if r.Distance <= s12 || r.Distance > s12*1.1 {
+		t.Fatalf("unexpected distance %f for a geodesic of %f", r.Distance, s12)
+	}
+
+	// No route keeps to a limit that a point is beyond.
+	for _, v := range [][5]float64{
+		{-55, 18.4, -37.8, 144.9, 50}, {-33.9, 18.4, 60, 144.9, -50},
+		{-33.9, 18.4, -37.8, 144.9, 0}, {91, 0, 0, 10, 90},
+	} {
+		if _, err := WGS84.CompositeSailing(v[0], v[1], v[2], v[3],
+			v[4]); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("%v: expected ErrOutOfRange, got %v", v, err)
+		}
+	}
+	if _, err := WGS84.CompositeSailing(0, 0, 0, 10,
+		math.NaN()); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package geodesic
+
+// PolygonResult is the result of Polygon.ComputeResult.
+type PolygonResult struct {
+	// Area is the area of the polygon (meters-squared), or zero for a
+	// polyline, as for the PolygonResult of v2.
+	Area float64
+	// Perimeter is the perimeter of the polygon or length of the polyline
+	// (meters).
+	Perimeter float64
+	// NumPoints is the number of points added so far.
+	NumPoints int
+	// Closed is true for a polygon and false for a polyline, which has
+	// no area.
+	Closed bool
+	// Reverse and Sign are the interpretation flags the area was computed
+	// with, see Polygon.Compute.
+	Reverse, Sign bool
+	// Pole is the pole inside the polygon, if it goes around the poles.
+	// See Polygon.EnclosedPole.
+	Pole Pole
+}
+
+// ComputeResult computes the results for a polygon, as Polygon.Compute,
+// and returns them together with how they were computed.
+//
+// Param reverse, if set then clockwise (instead of counter-clockwise)
+// traversal counts as a positive area.
+// Param sign, if set then return a signed result for the area if the
+// polygon is traversed in the "wrong" direction instead of returning the
+// area for the rest of the earth.
+//
+// More points can be added to the polygon after this call.
+func (p *Polygon) ComputeResult(reverse, sign bool) PolygonResult {
+	r := PolygonResult{
+		Closed: !p.polyline(), Reverse: reverse, Sign: sign,
+		Pole: p.EnclosedPole(reverse),
+	}
+	r.NumPoints = p.Compute(reverse, sign, &r.Area, &r.Perimeter)
+	return r
+}
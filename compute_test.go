@@ -0,0 +1,41 @@
+package geodesic
+
+import (
+	"testing"
+
+	v2 "github.com/tidwall/geodesic_cgo/v2"
+)
+
+func TestComputeResult(t *testing.T) {
+	p := WGS84.PolygonInit(false)
+	for _, pt := range [][2]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}} {
+		p.AddPoint(pt[0], pt[1])
+	}
+	var area, perimeter float64
+	n := p.Compute(true, true, &area, &perimeter)
+	r := p.ComputeResult(true, true)
+	want := PolygonResult{area, perimeter, n, true, true, true, PoleNone}
+	if r != want {
+		t.Fatalf("expected %+v, got %+v", want, r)
+	}
+	if r.Area >= 0 || r.NumPoints != 4 {
+		t.Fatalf("expected a negative area of 4 points, got %+v", r)
+	}
+
+	l := WGS84.PolygonInit(true)
+	l.AddPoint(0, 0)
+	l.AddPoint(0, 1)
+	r = l.ComputeResult(false, false)
+	if r.Closed || r.Area != 0 || r.NumPoints != 2 ||
+		!eqish(r.Perimeter, 111319.49079327357, 6) {
+		t.Fatalf("expected an open polyline of 111319.49m, got %+v", r)
+	}
+	// The area of a polyline is the same zero as in v2.
+	l2 := v2.WGS84.NewPolygon(true)
+	l2.AddPoint(v2.LatLng{Lat: 0, Lon: 0})
+	l2.AddPoint(v2.LatLng{Lat: 0, Lon: 1})
+	if r2 := l2.Result(false, false); r2.Area != r.Area ||
+		r2.Perimeter != r.Perimeter {
+		t.Fatalf("expected %+v, got %+v", r2, r)
+	}
+}
@@ -0,0 +1,221 @@
+package geodesic
+
+import "math"
+
+// RingContains reports whether a point is inside a ring.
+//
+// Param ring is the vertices of the ring. The last vertex is joined to the
+// first, which should not be repeated.
+// Param p is the point to test.
+//
+// The edges of the ring are geodesics, so a point near a long edge is
+// placed correctly even where a lat/lon test of the same vertices would
+// get it wrong. A ring divides the ellipsoid into two parts and the inside
+// is the smaller of the two, whichever direction the ring is listed in.
+// That part may contain a pole, as for a ring around Antarctica. Points on
+// the boundary may be reported either way. Rings with fewer than three
+// distinct vertices contain nothing.
+func (e *Ellipsoid) RingContains(ring []LatLng, p LatLng) bool {
+	r := e.newRingRegion(ring)
+	return r.contains(p)
+}
+
+// ringEdge is an edge of a ring, prepared for crossing tests.
+type ringEdge struct {
+	a, b           LatLng
+	l              Line
+	s12            float64
+	dlon           float64 // longitude change along the edge (degrees)
+	minLat, maxLat float64
+}
+
+// ringRegion is the inside of a ring, see Ellipsoid.RingContains.
+type ringRegion struct {
+	edges   []ringEdge
+	northIn bool // whether the north pole is inside
+	southIn bool // whether the south pole is inside
+	leftIn  bool // whether the inside is on the left of the edges
+}
+
+func (e *Ellipsoid) newRingEdge(a, b LatLng) ringEdge {
+	ed := ringEdge{a: a, b: b, l: e.InverseLine(a.Lat, a.Lon, b.Lat, b.Lon)}
+	ed.s12 = ed.l.Distance()
+	azi1 := ed.l.Azi1()
+	var azi2 float64
+	ed.l.Position(ed.s12, nil, nil, &azi2)
+	ed.dlon = math.Remainder(b.Lon-a.Lon, 360)
+	if math.Abs(ed.dlon) == 180 {
+		ed.dlon = math.Copysign(180, math.Sin(azi1*(math.Pi/180)))
+	}
+	ed.minLat, ed.maxLat = math.Min(a.Lat, b.Lat), math.Max(a.Lat, b.Lat)
+	// The edge has a vertex, where it is furthest from the equator,
+	// between its ends if it turns from heading north to heading south
+	// or the other way. By Clairaut's relation the vertex is at the
+	// reduced latitude whose cosine is cos(beta1)*|sin(azi1)|.
+	c1 := math.Cos(azi1 * (math.Pi / 180))
+	c2 := math.Cos(azi2 * (math.Pi / 180))
+	if (c1 > 0 && c2 < 0) || (c1 < 0 && c2 > 0) {
+		f := e.Flattening()
+		beta1 := math.Atan((1 - f) * math.Tan(a.Lat*(math.Pi/180)))
+		c := math.Cos(beta1) * math.Abs(math.Sin(azi1*(math.Pi/180)))
+		lat0 := math.Atan2(math.Sqrt(1-c*c), (1-f)*c) * (180 / math.Pi)
+		if c1 > 0 {
+			ed.maxLat = math.Max(ed.maxLat, lat0)
+		} else {
+			ed.minLat = math.Min(ed.minLat, -lat0)
+		}
+	}
+	return ed
+}
+
+// crosses reports whether the edge crosses the meridian at lon. A vertex
+// on the meridian counts as being to the west of it, so a ring through
+// the vertex is counted once. The test only depends on the longitude of
+// each end, which the adjacent edges of a ring agree on exactly.
+func (ed *ringEdge) crosses(lon float64) bool {
+	x1, x2 := normLon(ed.a.Lon-lon), normLon(ed.b.Lon-lon)
+	if (x1 <= 0) == (x2 <= 0) {
+		return false
+	}
+	// The edge goes the short way around, through the meridian at lon
+	// rather than the one opposite, unless it is half way around.
+	d := x2 - x1
+	return math.Abs(d) < 180 || d == ed.dlon
+}
+
+// crossLat returns the latitude at which the edge crosses the meridian at
+// lon, which it must cross.
+func (ed *ringEdge) crossLat(lon float64) float64 {
+	var lat float64
+	ed.l.Position(ed.crossDist(lon), &lat, nil, nil)
+	return lat
+}
+
+// crossDist returns the distance along the edge at which it crosses the
+// meridian at lon, which it must cross. The longitude is monotonic along a
+// geodesic, so a bisection on the distance finds it.
+func (ed *ringEdge) crossDist(lon float64) float64 {
+	x1 := normLon(ed.a.Lon - lon)
+	lo, hi := 0.0, ed.s12
+	for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+		mid := (lo + hi) / 2
+		var lon2 float64
+		ed.l.Position(mid, nil, &lon2, nil)
+		if x := x1 + math.Remainder(lon2-ed.a.Lon, 360); (x <= 0) == (x1 <= 0) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// crossesNorth reports whether the edge crosses the meridian through p to
+// the north of p.
+func (ed *ringEdge) crossesNorth(p LatLng) bool {
+	if !ed.crosses(p.Lon) || p.Lat > ed.maxLat {
+		return false
+	}
+	return p.Lat < ed.minLat || ed.crossLat(p.Lon) > p.Lat
+}
+
+func (e *Ellipsoid) newRingRegion(ring []LatLng) ringRegion {
+	var r ringRegion
+	longest := -1
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		if a == b {
+			continue
+		}
+		r.edges = append(r.edges, e.newRingEdge(a, b))
+		if longest < 0 || r.edges[len(r.edges)-1].s12 > r.edges[longest].s12 {
+			longest = len(r.edges) - 1
+		}
+	}
+	if len(r.edges) < 3 {
+		r.edges = nil
+		return r
+	}
+
+	// A point just to the left of the middle of the longest edge is on the
+	// left of the ring. An odd number of crossings between it and the
+	// north pole puts the pole on the right.
+	ed := &r.edges[longest]
+	var mid LatLng
+	var azi float64
+	ed.l.Position(ed.s12/2, &mid.Lat, &mid.Lon, &azi)
+	var left LatLng
+	e.Direct(mid.Lat, mid.Lon, azi-90, math.Min(ed.s12*1e-6, 1),
+		&left.Lat, &left.Lon, nil)
+	northLeft := !r.odd(left)
+
+	// The inside is the smaller part, which is the left one if the area
+	// without sign, which is that of the part on the left, is at most half.
+	p := e.PolygonInit(false)
+	for _, v := range ring {
+		p.AddPoint(v.Lat, v.Lon)
+	}
+	var area float64
+	p.Compute(false, false, &area, nil)
+	r.leftIn = area <= e.totalArea()/2
+	r.northIn = northLeft == r.leftIn
+
+	// The poles are on the same side if the whole of any one meridian
+	// crosses the ring an even number of times.
+	var n int
+	for i := range r.edges {
+		if r.edges[i].crosses(0) {
+			n++
+		}
+	}
+	r.southIn = r.northIn == (n%2 == 0)
+	return r
+}
+
+// odd reports whether there are an odd number of crossings of the ring on
+// the meridian from p to the north pole.
+func (r *ringRegion) odd(p LatLng) bool {
+	var n int
+	for i := range r.edges {
+		if r.edges[i].crossesNorth(p) {
+			n++
+		}
+	}
+	return n%2 == 1
+}
+
+func (r *ringRegion) contains(p LatLng) bool {
+	if len(r.edges) == 0 {
+		return false
+	}
+	return r.odd(p) != r.northIn
+}
+
+// bounds returns a latitude/longitude rectangle that covers the inside.
+func (r *ringRegion) bounds() Bounds {
+	b := Bounds{MinLat: 90, MaxLat: -90}
+	lon, minLon, maxLon := 0.0, 0.0, 0.0
+	for i := range r.edges {
+		ed := &r.edges[i]
+		b.MinLat = math.Min(b.MinLat, ed.minLat)
+		b.MaxLat = math.Max(b.MaxLat, ed.maxLat)
+		// The longitude is monotonic along an edge, so the unrolled
+		// vertex longitudes give the longitude extent.
+		lon += ed.dlon
+		minLon, maxLon = math.Min(minLon, lon), math.Max(maxLon, lon)
+	}
+	fullLon := maxLon-minLon >= 360
+	if r.northIn {
+		b.MaxLat, fullLon = 90, true
+	}
+	if r.southIn {
+		b.MinLat, fullLon = -90, true
+	}
+	if fullLon || len(r.edges) == 0 {
+		b.MinLon, b.MaxLon = -180, 180
+	} else {
+		b.MinLon = normLon(r.edges[0].a.Lon + minLon)
+		b.MaxLon = normLon(r.edges[0].a.Lon + maxLon)
+	}
+	return b
+}
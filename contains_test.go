@@ -0,0 +1,56 @@
+package geodesic
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingContains(t *testing.T) {
+	square := []LatLng{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}}
+	reversed := slices.Clone(square)
+	slices.Reverse(reversed)
+	for _, ring := range [][]LatLng{square, reversed} {
+		for _, c := range []struct {
+			p  LatLng
+			in bool
+		}{
+			{LatLng{0, 0}, true},
+			{LatLng{9.9, 9.9}, true},
+			{LatLng{0, 20}, false},
+			{LatLng{-20, 0}, false},
+			{LatLng{0, 180}, false},
+			// The northern edge bulges poleward of the parallel at 10.
+			{LatLng{10.1, 0}, true},
+			{LatLng{10.1, 9.9}, false},
+		} {
+			if in := WGS84.RingContains(ring, c.p); in != c.in {
+				t.Fatalf("%v: expected %v, got %v", c.p, c.in, in)
+			}
+		}
+	}
+
+	// Across the antimeridian.
+	ring := []LatLng{{-5, 175}, {-5, -175}, {5, -175}, {5, 175}}
+	if !WGS84.RingContains(ring, LatLng{0, 180}) ||
+		!WGS84.RingContains(ring, LatLng{0, -178}) ||
+		WGS84.RingContains(ring, LatLng{0, 0}) {
+		t.Fatalf("expected the ring across the antimeridian")
+	}
+
+	// Around a pole, in either direction.
+	polar := WGS84.Circle(LatLng{90, 0}, 1e6, 64)
+	for i := 0; i < 2; i++ {
+		if !WGS84.RingContains(polar, LatLng{89, 45}) ||
+			!WGS84.RingContains(polar, LatLng{90, 0}) ||
+			WGS84.RingContains(polar, LatLng{0, 0}) ||
+			WGS84.RingContains(polar, LatLng{-90, 0}) {
+			t.Fatalf("expected the polar polar")
+		}
+		slices.Reverse(polar)
+	}
+
+	if WGS84.RingContains(square[:2], LatLng{0, 0}) ||
+		WGS84.RingContains(nil, LatLng{0, 0}) {
+		t.Fatalf("expected a degenerate ring to contain nothing")
+	}
+}
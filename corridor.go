@@ -0,0 +1,175 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+)
+
+// Corridor returns the ring of a corridor of varying width about a
+// polyline, such as a pipeline right of way or a runway protection zone.
+//
+// Param line is the center line of the corridor.
+// Param widths is the distance from the center line to each side of the
+// corridor at each vertex of line (meters), which must be the same length
+// as line.
+// Param maxSegment is the longest distance between the points along the
+// sides of the corridor (meters).
+// Param segments is the number of points that the rounded ends and outer
+// corners would have around a full circle, as for Ellipsoid.Circle.
+// Returns the ring, counter-clockwise, or ErrLengthMismatch if the widths
+// do not match the line, or ErrInvalidArgument if a width is negative or
+// not a finite number.
+//
+// The width changes linearly with the distance along each segment of the
+// line, from the width at its start to the width at its end, and the sides
+// are at that geodesic distance from the line, square to it. The ends are
+// half circles, and the corners on the outside of a turn are arcs of a
+// circle about the vertex, so that the ring is the region within the width
+// of the line, as with a round ended buffer. The corners on the inside of
+// a turn are mitered. The ring may cross itself if a width is larger than
+// the segments around it, or than the distance around a sharp turn. A
+// single point gives the circle of its width, and no points gives nil.
+func (e *Ellipsoid) Corridor(line []LatLng, widths []float64,
+	maxSegment float64, segments int,
+) ([]LatLng, error) {
+	if len(line) != len(widths) {
+		return nil, ErrLengthMismatch
+	}
+	for i, w := range widths {
+		if !(w >= 0) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("%w: width %v at vertex %d",
+				ErrInvalidArgument, w, i)
+		}
+	}
+	// Repeated vertices have no direction, so the first of them is kept.
+	var pts []LatLng
+	var ws []float64
+	for i, p := range line {
+		if n := len(pts); n > 0 && e.distance(pts[n-1], p) == 0 {
+			continue
+		}
+		pts, ws = append(pts, p), append(ws, widths[i])
+	}
+	if len(pts) == 0 {
+		return nil, nil
+	}
+	if len(pts) == 1 {
+		return e.Circle(pts[0], ws[0], segments), nil
+	}
+	if segments < 4 {
+		segments = 4
+	}
+	step := 360 / float64(segments)
+	n := len(pts) - 1
+	lines := make([]Line, n)
+	azi1 := make([]float64, n) // azimuth leaving the start of each segment
+	azi2 := make([]float64, n) // azimuth arriving at the end of each segment
+	for i := range lines {
+		lines[i] = e.InverseLine(pts[i].Lat, pts[i].Lon, pts[i+1].Lat,
+			pts[i+1].Lon)
+		azi1[i] = lines[i].Azi1()
+		lines[i].Position(lines[i].Distance(), nil, nil, &azi2[i])
+	}
+
+	var ring []LatLng
+	// The right side going forward, then the left side going back.
+	for _, side := range []float64{1, -1} {
+		for k := 0; k < n; k++ {
+			i := k
+			if side < 0 {
+				i = n - 1 - k
+			}
+			ring = e.corridorSide(ring, &lines[i], ws[i], ws[i+1], maxSegment,
+				side)
+			if k == n-1 {
+				break
+			}
+			// The corner at the vertex that the side arrives at.
+			var v int
+			var ain, aout float64
+			if side > 0 {
+				v, ain, aout = i+1, azi2[i], azi1[i+1]
+			} else {
+				v, ain, aout = i, azi1[i]+180, azi2[i-1]+180
+			}
+			ring = e.corridorCorner(ring, pts[v], ws[v], ain, aout, step)
+		}
+		// The half circle about the end that the side arrives at.
+		if side > 0 {
+			ring = e.corridorArc(ring, pts[n], ws[n], azi2[n-1]+90,
+				azi2[n-1]-90, step)
+		} else {
+			ring = e.corridorArc(ring, pts[0], ws[0], azi1[0]-90, azi1[0]-270,
+				step)
+		}
+	}
+	return ring, nil
+}
+
+// corridorSide appends the points of one side of a segment of a corridor,
+// on the right of the direction of travel if side is 1, or the left if it
+// is -1, in which case the segment is traveled backward. The width goes
+// from w1 at the start of l to w2 at its end. The last point is left for
+// the corner or the end that follows.
+func (e *Ellipsoid) corridorSide(ring []LatLng, l *Line, w1, w2,
+	maxSegment, side float64,
+) []LatLng {
+	s := l.Distance()
+	parts := 1
+	if maxSegment > 0 {
+		parts = max(1, int(math.Ceil(s/maxSegment)))
+	}
+	for j := 0; j < parts; j++ {
+		t := float64(j) / float64(parts)
+		if side < 0 {
+			t = 1 - t
+		}
+		var q LatLng
+		var azi float64
+		l.Position(s*t, &q.Lat, &q.Lon, &azi)
+		w := w1 + (w2-w1)*t
+		e.Direct(q.Lat, q.Lon, azi+side*90, w, &q.Lat, &q.Lon, nil)
+		ring = append(ring, q)
+	}
+	return ring
+}
+
+// corridorCorner appends the corner of a corridor at the vertex v, of
+// width w, where the side arrives on the azimuth ain and leaves on aout,
+// with the corridor on its left.
+func (e *Ellipsoid) corridorCorner(ring []LatLng, v LatLng, w, ain, aout,
+	step float64,
+) []LatLng {
+	turn := math.Remainder(aout-ain, 360)
+	if turn <= 0 {
+		// A left turn is on the outside of the corridor.
+		return e.corridorArc(ring, v, w, ain+90, aout+90, step)
+	}
+	// The miter point, where the sides of the two segments meet, which is
+	// limited to twice the width for a turn of more than 120 degrees.
+	d := w / math.Max(math.Cos(turn/2*(math.Pi/180)), 0.5)
+	var q LatLng
+	e.Direct(v.Lat, v.Lon, ain+90+turn/2, d, &q.Lat, &q.Lon, nil)
+	return append(ring, q)
+}
+
+// corridorArc appends the points of the arc of radius w about c, going
+// counter-clockwise from the azimuth from to the azimuth to, in steps of
+// at most step (degrees). The last point of the arc is left for the side
+// that follows.
+func (e *Ellipsoid) corridorArc(ring []LatLng, c LatLng, w, from, to,
+	step float64,
+) []LatLng {
+	sweep := math.Mod(from-to, 360)
+	if sweep < 0 {
+		sweep += 360
+	}
+	parts := int(math.Ceil(sweep / step))
+	for j := 0; j < parts; j++ {
+		var q LatLng
+		azi := from - sweep*float64(j)/float64(parts)
+		e.Direct(c.Lat, c.Lon, azi, w, &q.Lat, &q.Lon, nil)
+		ring = append(ring, q)
+	}
+	return ring
+}
@@ -0,0 +1,76 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCorridor(t *testing.T) {
+	// A runway 3 km long, widening from 150 m at its threshold to 600 m.
+	line := []LatLng{{51.47, -0.49}, {51.47, -0.447}}
+	widths := []float64{150, 600}
+	ring, err := WGS84.Corridor(line, widths, 100, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area, perimeter := PolygonArea(WGS84, ring)
+	s := WGS84.distance(line[0], line[1])
+	// The trapezoid between the ends and the two half circles.
+	want := s*(150+600) + math.Pi*(150*150+600*600)/2
+	if math.Abs(area/want-1) > 0.01 {
+		t.Fatalf("expected an area of about %v, got %v", want, area)
+	}
+	if !(perimeter > 2*s) {
+		t.Fatalf("expected a perimeter over %v, got %v", 2*s, perimeter)
+	}
+	// The points along the sides are at the width from the center line.
+	l := WGS84.InverseLine(line[0].Lat, line[0].Lon, line[1].Lat, line[1].Lon)
+	for _, p := range ring {
+		var ps, azi1 float64
+		WGS84.Inverse(line[0].Lat, line[0].Lon, p.Lat, p.Lon, &ps, &azi1, nil)
+		along := ps * math.Cos((azi1-l.Azi1())*(math.Pi/180))
+		if along < 1 || along > s-1 {
+			continue
+		}
+		var q LatLng
+		l.Position(along, &q.Lat, &q.Lon, nil)
+		w := 150 + 450*along/s
+		if d := WGS84.distance(p, q); math.Abs(d-w) > 0.5 {
+			t.Fatalf("%v: expected %v from the center line, got %v", p, w, d)
+		}
+	}
+
+	// A route with a left and a right turn, of constant width, is about as
+	// large as its length times twice the width.
+	route := []LatLng{{0, 0}, {0, 0.5}, {0, 0.5}, {0.5, 0.5}, {0.5, 1}}
+	ring, err = WGS84.Corridor(route, []float64{1e3, 1e3, 2e3, 1e3, 1e3},
+		1e3, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area, _ = PolygonArea(WGS84, ring)
+	length := PolylineLength(WGS84, route)
+	if want := 2e3 * length; !(area > 0) || math.Abs(area/want-1) > 0.02 {
+		t.Fatalf("expected an area of about %v, got %v", want, area)
+	}
+	for _, p := range ring {
+		var d = math.Inf(1)
+		for i := 1; i < len(route); i++ {
+			d = math.Min(d, WGS84.distance(p, route[i]))
+		}
+		if d < 900 {
+			t.Fatalf("expected %v to be a width from the vertices, got %v", p, d)
+		}
+	}
+
+	if ring, err := WGS84.Corridor(line[:1], widths[:1], 100, 16); err != nil || len(ring) != 16 {
+		t.Fatalf("expected a circle, got %v %v", ring, err)
+	}
+	if _, err := WGS84.Corridor(line, widths[:1], 100, 16); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("expected ErrLengthMismatch, got %v", err)
+	}
+	if _, err := WGS84.Corridor(line, []float64{1, -1}, 100, 16); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+}
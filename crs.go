@@ -0,0 +1,238 @@
+package geodesic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CRS is a projected coordinate reference system, the projection of a
+// datum that the eastings and northings of a data set are on, such as
+// EPSG:32633, the UTM zone 33N of WGS 84.
+//
+// The common systems are registered by their EPSG codes, see LookupCRS,
+// and others may be added with RegisterCRS. The units of the eastings and
+// northings are meters; systems in feet are not registered.
+type CRS struct {
+	// EPSG is the code of the system in the EPSG registry.
+	EPSG int
+	// Name is the name of the system, as in the EPSG registry.
+	Name string
+	// Datum is the datum of the system, on whose ellipsoid the projection
+	// is computed.
+	Datum *Datum
+	// Projection is the projection of the system.
+	Projection Projection
+}
+
+func (c *CRS) String() string {
+	return fmt.Sprintf("EPSG:%d %s", c.EPSG, c.Name)
+}
+
+// Forward returns the easting and northing of a coordinate (meters).
+//
+// Param p is the coordinate, which must be on the datum of the system.
+// Returns the easting and northing, or ErrDatumMismatch if p is on another
+// datum, or an error from the projection.
+func (c *CRS) Forward(p Coord) (x, y float64, err error) {
+	if p.Datum != c.Datum {
+		return 0, 0, fmt.Errorf("%w: %v is not %v", ErrDatumMismatch, p.Datum,
+			c.Datum)
+	}
+	return c.Projection.Project(p.LatLng())
+}
+
+// Reverse returns the coordinate at an easting and northing (meters), on
+// the datum of the system, or an error from the projection.
+func (c *CRS) Reverse(x, y float64) (Coord, error) {
+	p, err := c.Projection.Unproject(x, y)
+	if err != nil {
+		return Coord{}, err
+	}
+	return Coord{c.Datum, p.Lat, p.Lon}, nil
+}
+
+// Length returns the geodesic length of a polyline whose vertices are
+// given in the coordinates of the system (meters), on the ellipsoid of its
+// datum, as Ellipsoid.ProjectedLength.
+func (c *CRS) Length(xy [][2]float64) (float64, error) {
+	return c.Datum.Ellipsoid.ProjectedLength(c.Projection, xy)
+}
+
+// Area returns the geodesic area (meters-squared) and perimeter (meters)
+// of a polygon ring whose vertices are given in the coordinates of the
+// system, on the ellipsoid of its datum, as Ellipsoid.ProjectedArea.
+func (c *CRS) Area(ring [][2]float64) (area, perimeter float64, err error) {
+	return c.Datum.Ellipsoid.ProjectedArea(c.Projection, ring)
+}
+
+// CRSLength returns the geodesic length of a polyline whose vertices are
+// given in projected coordinates, for loading data sets that are in a
+// projected system without unprojecting them first.
+//
+// Param id is the system of the coordinates, as for LookupCRS, such as
+// "EPSG:32633".
+// Param xy is the eastings and northings of the vertices (meters).
+// Returns the length (meters), or an error from LookupCRS or the
+// projection. See CRS.Length.
+func CRSLength(id string, xy [][2]float64) (float64, error) {
+	c, err := LookupCRS(id)
+	if err != nil {
+		return 0, err
+	}
+	return c.Length(xy)
+}
+
+// CRSArea returns the geodesic area and perimeter of a polygon ring whose
+// vertices are given in projected coordinates.
+//
+// Param id is the system of the coordinates, as for LookupCRS.
+// Param ring is the eastings and northings of the vertices (meters).
+// Returns the area (meters-squared) and perimeter (meters), or an error
+// from LookupCRS or the projection. See CRS.Area.
+func CRSArea(id string, ring [][2]float64) (area, perimeter float64,
+	err error,
+) {
+	c, err := LookupCRS(id)
+	if err != nil {
+		return 0, 0, err
+	}
+	return c.Area(ring)
+}
+
+var crsRegistry sync.Map // EPSG code -> *CRS
+
+// RegisterCRS adds a system to the registry of LookupCRS, or replaces the
+// one with the same EPSG code. It is safe for concurrent use.
+func RegisterCRS(c *CRS) {
+	crsRegistry.Store(c.EPSG, c)
+}
+
+// LookupCRS returns a registered projected coordinate reference system.
+//
+// Param id is the EPSG code of the system, with or without the "EPSG:"
+// prefix in either case, such as "EPSG:32633", or a UTM zone of WGS 84,
+// such as "UTM 33N".
+// Returns the system, or an error wrapping ErrText if id is not a code or
+// zone, or ErrInvalidArgument if no system is registered with the code.
+//
+// The registry holds these systems, and those added with RegisterCRS:
+//
+//   - the UTM zones of WGS 84 (EPSG:32601 to 32660 and 32701 to 32760),
+//     NAD83 (26901 to 26923), NAD27 (26701 to 26722), and ETRS89 (25828 to
+//     25838), and UPS North and South (32661 and 32761)
+//   - Web Mercator (3857), the British National Grid (27700), and the polar
+//     stereographic projections of the NSIDC sea ice maps (3413) and of
+//     Antarctica (3031)
+//   - Lambert-93 of France (2154), on ETRS89, which its datum RGF93
+//     realizes, and the Albers projection of the contiguous United States
+//     on NAD83 (5070)
+//   - the State Plane zones of NAD83 in meters of California zone 3
+//     (26943), Florida East (26958), Illinois East (26971), Massachusetts
+//     Mainland (26986), New York East and Long Island (32115 and 32118),
+//     Texas Central (32139), and Washington North (32148)
+func LookupCRS(id string) (*CRS, error) {
+	s := strings.ToUpper(strings.TrimSpace(id))
+	var code int
+	var err error
+	if zone, ok := strings.CutPrefix(s, "UTM"); ok {
+		zone = strings.TrimSpace(zone)
+		var u UTM
+		if u, err = ParseUTM(zone + " 0 0"); err != nil {
+			return nil, fmt.Errorf("%w: %q: bad UTM zone", ErrText, id)
+		}
+		code = 32700 + u.Zone
+		if u.North {
+			code = 32600 + u.Zone
+		}
+	} else {
+		s = strings.TrimPrefix(s, "EPSG:")
+		if code, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("%w: %q: bad EPSG code", ErrText, id)
+		}
+	}
+	if c, ok := crsRegistry.Load(code); ok {
+		return c.(*CRS), nil
+	}
+	return nil, fmt.Errorf("%w: no CRS EPSG:%d", ErrInvalidArgument, code)
+}
+
+// dms returns the degrees of an angle in degrees and minutes.
+func dms(d, m float64) float64 {
+	if d < 0 {
+		return d - m/60
+	}
+	return d + m/60
+}
+
+func init() {
+	for zone := 1; zone <= 60; zone++ {
+		for _, h := range []struct {
+			north bool
+			base  int
+			name  string
+		}{{true, 32600, "N"}, {false, 32700, "S"}} {
+			RegisterCRS(&CRS{h.base + zone,
+				fmt.Sprintf("WGS 84 / UTM zone %d%s", zone, h.name), WGS84Datum,
+				WGS84.UTMProjection(zone, h.north)})
+		}
+	}
+	for _, r := range []struct {
+		d        *Datum
+		base     int
+		from, to int
+	}{{NAD83, 26900, 1, 23}, {NAD27, 26700, 1, 22}, {ETRS89, 25800, 28, 38}} {
+		for zone := r.from; zone <= r.to; zone++ {
+			RegisterCRS(&CRS{r.base + zone,
+				fmt.Sprintf("%s / UTM zone %dN", r.d, zone), r.d,
+				r.d.Ellipsoid.UTMProjection(zone, true)})
+		}
+	}
+	nad83 := NAD83.Ellipsoid
+	tm := func(k0, lat0, lon0, fe, fn float64) Projection {
+		return newGridProjection(nad83.NewTransverseMercator(k0), lat0, lon0,
+			fe, fn)
+	}
+	lcc := func(e *Ellipsoid, lat1, lat2, lat0, lon0, fe, fn float64,
+	) Projection {
+		return newGridProjection(e.NewLambertConformalConic(lat1, lat2, 1),
+			lat0, lon0, fe, fn)
+	}
+	for _, c := range []*CRS{
+		{32661, "WGS 84 / UPS North (E,N)", WGS84Datum,
+			WGS84.UPSProjection(true)},
+		{32761, "WGS 84 / UPS South (E,N)", WGS84Datum,
+			WGS84.UPSProjection(false)},
+		{3857, "WGS 84 / Pseudo-Mercator", WGS84Datum, WebMercator},
+		{27700, "OSGB36 / British National Grid", OSGB36, OSGBProjection},
+		{3413, "WGS 84 / NSIDC Sea Ice Polar Stereographic North",
+			WGS84Datum, polarGrid{ps: WGS84.NewPolarStereographicScale(70, 1),
+				north: true, lon0: -45}},
+		{3031, "WGS 84 / Antarctic Polar Stereographic", WGS84Datum,
+			polarGrid{ps: WGS84.NewPolarStereographicScale(-71, 1)}},
+		{2154, "RGF93 v1 / Lambert-93", ETRS89,
+			lcc(ETRS89.Ellipsoid, 49, 44, 46.5, 3, 700000, 6600000)},
+		{5070, "NAD83 / Conus Albers", NAD83,
+			newGridProjection(nad83.NewAlbersEqualArea(29.5, 45.5), 23, -96,
+				0, 0)},
+		{26943, "NAD83 / California zone 3", NAD83, lcc(nad83, dms(38, 26),
+			dms(37, 4), dms(36, 30), dms(-120, 30), 2000000, 500000)},
+		{26958, "NAD83 / Florida East", NAD83, tm(0.999941177, dms(24, 20), -81,
+			200000, 0)},
+		{26971, "NAD83 / Illinois East", NAD83, tm(0.999975, dms(36, 40),
+			dms(-88, 20), 300000, 0)},
+		{26986, "NAD83 / Massachusetts Mainland", NAD83, lcc(nad83, dms(42, 41),
+			dms(41, 43), 41, dms(-71, 30), 200000, 750000)},
+		{32115, "NAD83 / New York East", NAD83, tm(0.9999, dms(38, 50),
+			dms(-74, 30), 150000, 0)},
+		{32118, "NAD83 / New York Long Island", NAD83, lcc(nad83, dms(41, 2),
+			dms(40, 40), dms(40, 10), -74, 300000, 0)},
+		{32139, "NAD83 / Texas Central", NAD83, lcc(nad83, dms(31, 53),
+			dms(30, 7), dms(29, 40), dms(-100, 20), 700000, 3000000)},
+		{32148, "NAD83 / Washington North", NAD83, lcc(nad83, dms(48, 44),
+			dms(47, 30), 47, dms(-120, 50), 500000, 0)},
+	} {
+		RegisterCRS(c)
+	}
+}
@@ -0,0 +1,131 @@
+package geodesic
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestCRS(t *testing.T) {
+	// EPSG:32633 to a geodesic distance.
+	c, err := LookupCRS("EPSG:32633")
+	if err != nil || c.Datum != WGS84Datum || c.String() !=
+		"EPSG:32633 WGS 84 / UTM zone 33N" {
+		t.Fatalf("expected UTM zone 33N, got %v (%v)", c, err)
+	}
+	p1, err := c.Reverse(500000, 5000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, _ := c.Reverse(510000, 5000000)
+	r, err := CoordInverse(p1, p2)
+	if err != nil || !(r.S12 > 10000 && r.S12 < 10010) {
+		t.Fatalf("expected about 10 km, got %v (%v)", r.S12, err)
+	}
+	want, _ := WGS84.FromUTM(UTM{Zone: 33, North: true, Easting: 500000,
+		Northing: 5000000})
+	if p1.LatLng() != want {
+		t.Fatalf("expected %v, got %v", want, p1)
+	}
+	for _, id := range []string{"32633", "epsg:32633", " UTM 33n ", "utm33N"} {
+		if c2, err := LookupCRS(id); err != nil || c2 != c {
+			t.Fatalf("%q: expected %v, got %v (%v)", id, c, c2, err)
+		}
+	}
+	for _, id := range []string{"", "EPSG:", "EPSG:x", "UTM 61N", "UTM"} {
+		if _, err := LookupCRS(id); !errors.Is(err, ErrText) {
+			t.Fatalf("%q: expected ErrText, got %v", id, err)
+		}
+	}
+	if _, err := LookupCRS("EPSG:4326"); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+	if _, _, err := c.Forward(Coord{NAD83, 45, 15}); !errors.Is(err,
+		ErrDatumMismatch) {
+		t.Fatalf("expected ErrDatumMismatch, got %v", err)
+	}
+
+	// The origins of the grids, and a round trip near them.
+	for _, v := range []struct {
+		code     int
+		lat, lon float64
+		x, y     float64
+	}{
+		{26943, 36.5, -120.5, 2000000, 500000},
+		{26958, 24 + 1/3.0, -81, 200000, 0},
+		{32139, 29 + 2/3.0, -100 - 1/3.0, 700000, 3000000},
+		{32118, 40 + 1/6.0, -74, 300000, 0},
+		{2154, 46.5, 3, 700000, 6600000},
+		{5070, 23, -96, 0, 0},
+		{3413, 90, 0, 0, 0},
+		{3031, -90, 0, 0, 0},
+		{32661, 90, 0, 2000000, 2000000},
+		{27700, 49, -2, 400000, -100000},
+		{26910, 0, -123, 500000, 0},
+		{25832, 0, 9, 500000, 0},
+	} {
+		c, err := LookupCRS(strconv.Itoa(v.code))
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, y, err := c.Forward(Coord{c.Datum, v.lat, v.lon})
+		if err != nil || !eqish(x, v.x, 6) || !eqish(y, v.y, 6) {
+			t.Fatalf("%v: expected %v %v, got %v %v (%v)", c, v.x, v.y, x, y,
+				err)
+		}
+		q, err := c.Reverse(x+12345, y-23456)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x2, y2, err := c.Forward(q)
+		if err != nil || !eqish(x2, x+12345, 6) || !eqish(y2, y-23456, 6) {
+			t.Fatalf("%v: expected %v %v, got %v %v (%v)", c, x+12345,
+				y-23456, x2, y2, err)
+		}
+	}
+	// Grid north of EPSG:3413 is along 45W.
+	c, _ = LookupCRS("EPSG:3413")
+	if x, y, _ := c.Forward(Coord{WGS84Datum, 70, -45}); !eqish(x, 0, 6) ||
+		!(y < 0) {
+		t.Fatalf("expected a point below the pole, got %v %v", x, y)
+	}
+
+	RegisterCRS(&CRS{900913, "Google Mercator", WGS84Datum, WebMercator})
+	if c, err := LookupCRS("EPSG:900913"); err != nil || c.Name !=
+		"Google Mercator" {
+		t.Fatalf("expected the registered CRS, got %v (%v)", c, err)
+	}
+}
+
+func TestCRSMeasures(t *testing.T) {
+	// A 10 km square in UTM zone 33N, and the same on NAD83 / Conus Albers,
+	// whose areas on the map are the geodesic areas.
+	sq := [][2]float64{{500000, 5000000}, {510000, 5000000},
+		{510000, 5010000}, {500000, 5010000}}
+	s, err := CRSLength("EPSG:32633", sq[:2])
+	if want, _ := WGS84.ProjectedLength(WGS84.UTMProjection(33, true),
+		sq[:2]); err != nil || s != want {
+		t.Fatalf("expected %v, got %v (%v)", want, s, err)
+	}
+	area, perim, err := CRSArea("UTM 33N", sq)
+	if err != nil || !eqish(area/(1e8/(0.9996*0.9996)), 1, 3) ||
+		!eqish(perim/(4e4/0.9996), 1, 3) {
+		t.Fatalf("expected a square of a little over 10 km, got %v %v (%v)",
+			area, perim, err)
+	}
+	albers := [][2]float64{{0, 1e6}, {1e4, 1e6}, {1e4, 1.01e6}, {0, 1.01e6}}
+	if area, _, err := CRSArea("EPSG:5070", albers); err != nil ||
+		!eqish(area/1e8, 1, 6) {
+		t.Fatalf("expected an area of 1e8, got %v (%v)", area, err)
+	}
+	if _, err := CRSLength("EPSG:1", sq); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+	if _, _, err := CRSArea("x", sq); !errors.Is(err, ErrText) {
+		t.Fatalf("expected ErrText, got %v", err)
+	}
+	if _, _, err := CRSArea("EPSG:27700", [][2]float64{{-2e6, 0}, {0, 0},
+		{0, 1}}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+package geodesic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrDatumMismatch is returned by the Coord routines for coordinates on
+// different datums, or for a transformation from a datum other than that
+// of the coordinates.
+var ErrDatumMismatch = errors.New("geodesic: coordinates on different datums")
+
+// Datum is a geodetic datum, the ellipsoid and its placement on the earth
+// that latitudes and longitudes are measured on.
+//
+// Datums are told apart by identity, not by their fields: coordinates on
+// NAD83 and on WGS84Datum are on different datums, though both use an
+// ellipsoid of practically the same shape, since the two differ on the
+// ground by a meter or two. Create a Datum once, with NewDatum, and share
+// the pointer.
+type Datum struct {
+	// Name is the name of the datum, for messages.
+	Name string
+	// Ellipsoid is the ellipsoid of the datum, on which the geodesics
+	// between its coordinates are solved.
+	Ellipsoid *Ellipsoid
+}
+
+// NewDatum returns a new datum.
+//
+// Param name is the name of the datum.
+// Param e is the ellipsoid of the datum.
+func NewDatum(name string, e *Ellipsoid) *Datum {
+	return &Datum{Name: name, Ellipsoid: e}
+}
+
+func (d *Datum) String() string {
+	if d == nil {
+		return "untagged"
+	}
+	return d.Name
+}
+
+// Common datums.
+var (
+	// WGS84Datum is the World Geodetic System 1984, on WGS84.
+	WGS84Datum = NewDatum("WGS 84", WGS84)
+	// NAD83 is the North American Datum of 1983, on GRS80.
+	NAD83 = NewDatum("NAD83", NewEllipsoid(6378137, 1/298.257222101))
+	// ETRS89 is the European Terrestrial Reference System 1989, on GRS80.
+	ETRS89 = NewDatum("ETRS89", NAD83.Ellipsoid)
+	// NAD27 is the North American Datum of 1927, on Clarke 1866.
+	NAD27 = NewDatum("NAD27", NewEllipsoid(6378206.4, 1/294.978698214))
+	// OSGB36 is the datum of the Ordnance Survey of Great Britain, on Airy
+	// 1830.
+	OSGB36 = NewDatum("OSGB36", NewEllipsoid(6377563.396, 1/299.3249646))
+	// ED50 is the European Datum of 1950, on International 1924.
+	ED50 = NewDatum("ED50", NewEllipsoid(6378388, 1/297.0))
+)
+
+// Coord is a point tagged with the datum that it is measured on, so that
+// coordinates on different datums are not mixed by mistake.
+//
+// The geodesic routines that take a Coord, such as CoordInverse, return
+// ErrDatumMismatch for coordinates on different datums, which is a silent
+// error of up to hundreds of meters otherwise, and solve the problems on
+// the ellipsoid of the datum. Use Coord.Transform to move coordinates to
+// another datum first. A Coord is deliberately not a Point, so that it
+// cannot be passed to the routines that take any Point, which have no
+// datums to check; Coord.LatLng drops the datum where that is meant.
+type Coord struct {
+	Datum    *Datum
+	Lat, Lon float64 // degrees
+}
+
+// LatLng returns the point of the coordinate, without its datum.
+func (c Coord) LatLng() LatLng {
+	return LatLng{c.Lat, c.Lon}
+}
+
+// coordPoints returns the points of coordinates, without their datums.
+func coordPoints(coords []Coord) []LatLng {
+	pts := make([]LatLng, len(coords))
+	for i, c := range coords {
+		pts[i] = c.LatLng()
+	}
+	return pts
+}
+
+// coordsDatum returns the datum of coordinates, which must all be on the
+// same one.
+func coordsDatum(coords ...Coord) (*Datum, error) {
+	if len(coords) == 0 {
+		return nil, nil
+	}
+	d := coords[0].Datum
+	if d == nil {
+		return nil, fmt.Errorf("%w: untagged coordinate", ErrInvalidArgument)
+	}
+	for i, c := range coords[1:] {
+		if c.Datum != d {
+			return nil, fmt.Errorf("%w: %v and %v at %d", ErrDatumMismatch, d,
+				c.Datum, i+1)
+		}
+	}
+	return d, nil
+}
+
+// CoordInverse solves the inverse geodesic problem between two coordinates
+// on the same datum, as Ellipsoid.CheckedInverse on its ellipsoid.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+// Returns the solution, or ErrDatumMismatch if the points are on different
+// datums, ErrInvalidArgument if they are untagged, or an error from
+// Ellipsoid.CheckedInverse.
+func CoordInverse(p1, p2 Coord) (InverseSolution, error) {
+	d, err := coordsDatum(p1, p2)
+	if err != nil {
+		nan := math.NaN()
+		return InverseSolution{nan, nan, nan}, err
+	}
+	return d.Ellipsoid.CheckedInverse(p1.LatLng(), p2.LatLng())
+}
+
+// CoordDirect solves the direct geodesic problem from a coordinate, as
+// Ellipsoid.CheckedDirect on the ellipsoid of its datum.
+//
+// Param p1 is point 1.
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param s12 is the distance from point 1 to point 2 (meters).
+// Returns point 2, on the datum of p1, and the (forward) azimuth there
+// (degrees), or ErrInvalidArgument if p1 is untagged, or an error from
+// Ellipsoid.CheckedDirect.
+func CoordDirect(p1 Coord, azi1, s12 float64) (Coord, float64, error) {
+	d, err := coordsDatum(p1)
+	if err != nil {
+		return Coord{Lat: math.NaN(), Lon: math.NaN()}, math.NaN(), err
+	}
+	r, err := d.Ellipsoid.CheckedDirect(p1.LatLng(), azi1, s12)
+	return Coord{d, r.Point.Lat, r.Point.Lon}, r.Azi2, err
+}
+
+// CoordPolylineLength returns the geodesic length of a polyline of
+// coordinates on the same datum (meters), as PolylineLength on its
+// ellipsoid, or ErrDatumMismatch if they are on different datums, or
+// ErrInvalidArgument if they are untagged.
+func CoordPolylineLength(pts []Coord) (float64, error) {
+	d, err := coordsDatum(pts...)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if d == nil {
+		return 0, nil
+	}
+	return PolylineLength(d.Ellipsoid, coordPoints(pts)), nil
+}
+
+// CoordPolygonArea returns the area (meters-squared) and perimeter
+// (meters) of a polygon ring of coordinates on the same datum, as
+// CheckedPolygonArea on its ellipsoid, or ErrDatumMismatch if they are on
+// different datums, or ErrInvalidArgument if they are untagged.
+func CoordPolygonArea(ring []Coord) (area, perimeter float64, err error) {
+	d, err := coordsDatum(ring...)
+	if err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+	if d == nil {
+		return 0, 0, ErrDegeneratePolygon
+	}
+	return CheckedPolygonArea(d.Ellipsoid, coordPoints(ring))
+}
+
+// Transformation converts points from one datum to another.
+type Transformation interface {
+	// Source returns the datum that the transformation converts from.
+	Source() *Datum
+	// Target returns the datum that the transformation converts to.
+	Target() *Datum
+	// Transform returns the point on the target datum at a point on the
+	// source datum, or an error wrapping ErrOutOfRange if the point is
+	// outside the area that the transformation covers.
+	Transform(p LatLng) (LatLng, error)
+}
+
+// Transform returns the coordinate on the target datum of a transformation.
+//
+// Param t is the transformation, whose source must be the datum of c.
+// Returns the coordinate on the target datum, or ErrDatumMismatch if the
+// source is not the datum of c, or an error from t.
+func (c Coord) Transform(t Transformation) (Coord, error) {
+	if c.Datum != t.Source() {
+		return c, fmt.Errorf("%w: %v is not %v", ErrDatumMismatch, c.Datum,
+			t.Source())
+	}
+	p, err := t.Transform(c.LatLng())
+	if err != nil {
+		return c, err
+	}
+	return Coord{t.Target(), p.Lat, p.Lon}, nil
+}
+
+// Helmert is a seven parameter Helmert transformation between datums, a
+// similarity transformation of the earth-centered earth-fixed coordinates
+// of the points on their ellipsoids.
+//
+// The rotations follow the position vector convention of EPSG method 1033,
+// used by the Ordnance Survey. The coordinate frame convention of EPSG
+// method 1032 has the opposite signs, so negate them to use its
+// parameters. The points are taken to be on the ellipsoid of the source
+// datum, at a height of zero, which moves their transformed positions by
+// about 2 centimeters for each kilometer of height. The errors of the
+// transformation itself are those of its parameters, which are from a
+// meter to several meters for a national datum as a whole.
+type Helmert struct {
+	From, To   *Datum
+	TX, TY, TZ float64 // translations (meters)
+	RX, RY, RZ float64 // rotations (arc-seconds)
+	S          float64 // scale change (parts per million)
+}
+
+// Source returns the datum that the transformation converts from.
+func (h Helmert) Source() *Datum { return h.From }
+
+// Target returns the datum that the transformation converts to.
+func (h Helmert) Target() *Datum { return h.To }
+
+// Transform returns the point on the target datum at a point on the source
+// datum.
+func (h Helmert) Transform(p LatLng) (LatLng, error) {
+	x, y, z := h.From.Ellipsoid.toECEF(p.Lat, p.Lon, 0)
+	x, y, z = h.apply(x, y, z, 0, 0, 0)
+	lat, lon, _ := h.To.Ellipsoid.fromECEF(x, y, z)
+	return LatLng{lat, lon}, nil
+}
+
+// apply returns the transformed earth-centered earth-fixed coordinates of
+// a point, with the rotations and the scale about the point x0, y0, z0.
+func (h Helmert) apply(x, y, z, x0, y0, z0 float64) (float64, float64, float64) {
+	const sec = math.Pi / (180 * 3600)
+	rx, ry, rz := h.RX*sec, h.RY*sec, h.RZ*sec
+	s := 1 + h.S*1e-6
+	x, y, z = x-x0, y-y0, z-z0
+	return x0 + h.TX + s*(x-rz*y+ry*z),
+		y0 + h.TY + s*(rz*x+y-rx*z),
+		z0 + h.TZ + s*(-ry*x+rx*y+z)
+}
+
+// Inverse returns the transformation the other way, between the same
+// datums, with the signs of the parameters changed.
+//
+// This is the usual way of reversing a Helmert transformation. It is not
+// the exact inverse, but a round trip with the parameters of a national
+// datum comes back within a few millimeters.
+func (h Helmert) Inverse() Helmert {
+	return Helmert{h.To, h.From, -h.TX, -h.TY, -h.TZ, -h.RX, -h.RY, -h.RZ,
+		-h.S}
+}
+
+// MolodenskyBadekas is a Molodensky-Badekas transformation between datums,
+// a Helmert transformation whose rotations and scale are about a point near
+// the area that it covers, rather than the center of the earth.
+//
+// With the rotations about the middle of the area, the parameters are
+// nearly independent of each other, and of the translations, so they can
+// be fitted from a small network of points and rounded without the large
+// changes in position that rounding plain Helmert rotations makes ten
+// thousand kilometers from the pivot. The rotations follow the position
+// vector convention of EPSG method 1063; negate them to use the
+// parameters of the coordinate frame convention of EPSG method 1061. The
+// heights are as for Helmert.
+type MolodenskyBadekas struct {
+	Helmert
+	PX, PY, PZ float64 // earth-centered coordinates of the pivot (meters)
+}
+
+// Transform returns the point on the target datum at a point on the source
+// datum.
+func (m MolodenskyBadekas) Transform(p LatLng) (LatLng, error) {
+	x, y, z := m.From.Ellipsoid.toECEF(p.Lat, p.Lon, 0)
+	x, y, z = m.apply(x, y, z, m.PX, m.PY, m.PZ)
+	lat, lon, _ := m.To.Ellipsoid.fromECEF(x, y, z)
+	return LatLng{lat, lon}, nil
+}
+
+// Inverse returns the transformation the other way, between the same
+// datums, with the signs of the parameters changed and the pivot moved by
+// the translation, to where the transformation takes it. See
+// Helmert.Inverse.
+func (m MolodenskyBadekas) Inverse() MolodenskyBadekas {
+	return MolodenskyBadekas{m.Helmert.Inverse(), m.PX + m.TX, m.PY + m.TY,
+		m.PZ + m.TZ}
+}
@@ -0,0 +1,142 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// wgs84ToOSGB36 is the Helmert transformation of the Ordnance Survey from
+// ETRS89, which is within a meter of WGS84, to OSGB36.
+var wgs84ToOSGB36 = Helmert{WGS84Datum, OSGB36, -446.448, 125.157, -542.060,
+	-0.1502, -0.2470, -0.8421, 20.4894}
+
+func TestCoord(t *testing.T) {
+	p1 := Coord{WGS84Datum, 51.4778, -0.0015}
+	p2 := Coord{WGS84Datum, 48.8584, 2.2945}
+	r, err := CoordInverse(p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := WGS84.CheckedInverse(p1.LatLng(), p2.LatLng())
+	if r != want {
+		t.Fatalf("expected %v, got %v", want, r)
+	}
+	q, azi2, err := CoordDirect(p1, r.Azi1, r.S12)
+	if err != nil || q.Datum != WGS84Datum || !eqish(q.Lat, p2.Lat, 9) ||
+		!eqish(q.Lon, p2.Lon, 9) || !eqish(azi2, r.Azi2, 9) {
+		t.Fatalf("expected %v, got %v %v %v", p2, q, azi2, err)
+	}
+	if s, err := CoordPolylineLength([]Coord{p1, p2}); err != nil || s != PolylineLength(WGS84, []LatLng{p1.LatLng(), p2.LatLng()}) {
+		t.Fatalf("expected %v, got %v %v", r.S12, s, err)
+	}
+	ring := []Coord{p1, p2, {WGS84Datum, 50, 5}}
+	if area, _, err := CoordPolygonArea(ring); err != nil || area == 0 {
+		t.Fatalf("expected an area, got %v %v", area, err)
+	}
+
+	// The same place on a different datum is refused.
+	osgb := Coord{OSGB36, 51.4778, -0.0015}
+	if _, err := CoordInverse(p2, osgb); !errors.Is(err, ErrDatumMismatch) {
+		t.Fatalf("expected ErrDatumMismatch, got %v", err)
+	}
+	if _, err := CoordPolylineLength([]Coord{p1, p2, osgb}); !errors.Is(err, ErrDatumMismatch) {
+		t.Fatalf("expected ErrDatumMismatch, got %v", err)
+	}
+	if _, _, err := CoordPolygonArea(append(ring, osgb)); !errors.Is(err, ErrDatumMismatch) {
+		t.Fatalf("expected ErrDatumMismatch, got %v", err)
+	}
+	if _, err := CoordInverse(Coord{Lat: 1}, Coord{Lat: 2}); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+	if _, _, err := CoordDirect(Coord{WGS84Datum, 91, 0}, 0, 1); !errors.Is(err, ErrInvalidLatitude) {
+		t.Fatalf("expected ErrInvalidLatitude, got %v", err)
+	}
+	// A Coord is not a Point, so it cannot reach the unchecked routines.
+	if _, ok := any(p1).(Point); ok {
+		t.Fatal("expected Coord not to be a Point")
+	}
+	if s, err := CoordPolylineLength(nil); err != nil || s != 0 {
+		t.Fatalf("expected 0, got %v %v", s, err)
+	}
+
+	// Transformed, the points can be measured together.
+	q, err = p1.Transform(wgs84ToOSGB36)
+	if err != nil || q.Datum != OSGB36 {
+		t.Fatalf("expected a point on OSGB36, got %v %v", q, err)
+	}
+	if _, err := CoordInverse(q, osgb); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := osgb.Transform(wgs84ToOSGB36); !errors.Is(err, ErrDatumMismatch) {
+		t.Fatalf("expected ErrDatumMismatch, got %v", err)
+	}
+}
+
+func TestHelmert(t *testing.T) {
+	// The Airy transit circle at Greenwich, which defines the prime
+	// meridian of OSGB36, is about 100 m west of that of WGS84.
+	p := LatLng{51.477811, -0.001475}
+	q, err := wgs84ToOSGB36.Transform(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(math.Abs(q.Lon) < 2e-4) || WGS84.distance(p, q) < 50 {
+		t.Fatalf("expected a point on the meridian, got %v", q)
+	}
+	r, _ := wgs84ToOSGB36.Inverse().Transform(q)
+	if d := WGS84.distance(p, r); d > 0.01 {
+		t.Fatalf("expected a round trip, got %v %v away", r, d)
+	}
+	if inv := wgs84ToOSGB36.Inverse(); inv.Source() != OSGB36 ||
+		inv.Target() != WGS84Datum {
+		t.Fatalf("expected OSGB36 to WGS 84, got %v to %v", inv.Source(),
+			inv.Target())
+	}
+
+	// A translation alone moves the earth-centered coordinates by it.
+	h := Helmert{From: WGS84Datum, To: WGS84Datum, TX: 100, TY: -50, TZ: 20}
+	q, _ = h.Transform(p)
+	x1, y1, z1 := WGS84.toECEF(p.Lat, p.Lon, 0)
+	x2, y2, z2 := WGS84.toECEF(q.Lat, q.Lon, 0)
+	// The height of the moved point is dropped, which moves it along the
+	// normal, so only the horizontal part of the move is kept.
+	if d := math.Hypot(math.Hypot(x2-x1, y2-y1), z2-z1); !(d > 80 && d < 113) {
+		t.Fatalf("expected a move of the translation, got %v", d)
+	}
+}
+
+func TestMolodenskyBadekas(t *testing.T) {
+	// About the center of the earth, it is the Helmert transformation.
+	p := LatLng{51.477811, -0.001475}
+	m := MolodenskyBadekas{Helmert: wgs84ToOSGB36}
+	q1, _ := m.Transform(p)
+	q2, _ := wgs84ToOSGB36.Transform(p)
+	if q1 != q2 {
+		t.Fatalf("expected %v, got %v", q2, q1)
+	}
+
+	// About a pivot, it is the Helmert transformation with the
+	// translation that moves the pivot by the same amount.
+	px, py, pz := WGS84.toECEF(52, -1, 0)
+	m = MolodenskyBadekas{wgs84ToOSGB36, px, py, pz}
+	x, y, z := wgs84ToOSGB36.apply(px, py, pz, 0, 0, 0)
+	h := wgs84ToOSGB36
+	h.TX, h.TY, h.TZ = h.TX+px-(x-h.TX), h.TY+py-(y-h.TY), h.TZ+pz-(z-h.TZ)
+	q1, _ = m.Transform(p)
+	q2, _ = h.Transform(p)
+	if d := WGS84.distance(q1, q2); d > 1e-6 {
+		t.Fatalf("expected %v, got %v, %v away", q2, q1, d)
+	}
+	r, _ := m.Inverse().Transform(q1)
+	if d := WGS84.distance(p, r); d > 0.01 {
+		t.Fatalf("expected a round trip, got %v %v away", r, d)
+	}
+	if m.Source() != WGS84Datum || m.Inverse().Source() != OSGB36 {
+		t.Fatal("expected the datums of the Helmert transformation")
+	}
+	c, err := Coord{WGS84Datum, p.Lat, p.Lon}.Transform(m)
+	if err != nil || c.Datum != OSGB36 || c.LatLng() != q1 {
+		t.Fatalf("expected %v, got %v %v", q1, c, err)
+	}
+}
@@ -0,0 +1,79 @@
+package geodesic
+
+import "math"
+
+// Uncertainty is the uncertainty of a dead reckoning start and course, as
+// independent standard deviations.
+type Uncertainty struct {
+	North, East float64 // of the starting position (meters)
+	Azimuth     float64 // of the azimuth of the course (degrees)
+	Distance    float64 // of the distance traveled (meters)
+}
+
+// ErrorEllipse is the uncertainty of a position, as the standard
+// deviations along its principal axes.
+type ErrorEllipse struct {
+	Major   float64 // standard deviation along the major axis (meters)
+	Minor   float64 // standard deviation along the minor axis (meters)
+	Azimuth float64 // azimuth of the major axis in [0,180) (degrees)
+}
+
+// DeadReckon returns the position reached by traveling a distance along a
+// geodesic, and how the uncertainty of the start and course carries over
+// to it.
+//
+// Param lat1 is the latitude of the starting point (degrees).
+// Param lon1 is the longitude of the starting point (degrees).
+// Param azi1 is the azimuth of the course (degrees).
+// Param s12 is the distance traveled (meters). negative is ok.
+// Param u is the uncertainty of the start and course.
+// Returns the position reached and its error ellipse.
+//
+// The errors are propagated to first order with the reduced length m12 and
+// geodesic scale M12 of the geodesic, see Line.Scales, so the ellipse is
+// accurate while the errors are small compared with the distance to the
+// conjugate point, where m12 vanishes. The azimuth is held fixed relative
+// to north, as with a compass course, so an error in the east position
+// also turns the course by the convergence of the meridians. The starting
+// point should not be at a pole, where that is undefined.
+func (e *Ellipsoid) DeadReckon(lat1, lon1, azi1, s12 float64, u Uncertainty) (LatLng, ErrorEllipse) {
+	l := e.LineInit(lat1, lon1, azi1)
+	var p LatLng
+	var azi2 float64
+	l.Position(s12, &p.Lat, &p.Lon, &azi2)
+	m12, M12, _ := l.Scales(s12)
+
+	sinPhi, cosPhi := sincosd(lat1)
+	e2 := e.Flattening() * (2 - e.Flattening())
+	// The rate at which a course of fixed azimuth turns relative to the
+	// geodesics per meter moved east (1/meters).
+	k := sinPhi / cosPhi * math.Sqrt(1-e2*sinPhi*sinPhi) / e.Radius()
+	sa1, ca1 := sincosd(azi1)
+	sa2, ca2 := sincosd(azi2)
+
+	// Each input moves point 2 along the geodesic and to its right.
+	var cee, cen, cnn float64
+	add := func(sigma, along, right float64) {
+		de := (along*sa2 + right*ca2) * sigma
+		dn := (along*ca2 - right*sa2) * sigma
+		cee += de * de
+		cen += de * dn
+		cnn += dn * dn
+	}
+	add(u.North, ca1, -M12*sa1)
+	add(u.East, sa1, M12*ca1-m12*k)
+	add(u.Azimuth*math.Pi/180, 0, m12)
+	add(u.Distance, 1, 0)
+
+	mean := (cee + cnn) / 2
+	d := math.Hypot((cee-cnn)/2, cen)
+	ell := ErrorEllipse{
+		Major: math.Sqrt(mean + d),
+		Minor: math.Sqrt(math.Max(mean-d, 0)),
+	}
+	// The angle of the major axis counter-clockwise from east, converted to
+	// an azimuth.
+	theta := math.Atan2(2*cen, cee-cnn) / 2 * 180 / math.Pi
+	ell.Azimuth = math.Mod(90-theta+180, 180)
+	return p, ell
+}
@@ -0,0 +1,72 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDeadReckon(t *testing.T) {
+	const lat1, lon1, azi1, s12 = 50.0, -4.0, 250.0, 3e6
+	var want LatLng
+	WGS84.Direct(lat1, lon1, azi1, s12, &want.Lat, &want.Lon, nil)
+	// Each uncertainty alone gives a degenerate ellipse along the
+	// displacement that a small error of that size causes.
+	var north, east LatLng
+	WGS84.Direct(lat1, lon1, 0, 1, &north.Lat, &north.Lon, nil)
+	WGS84.Direct(lat1, lon1, 90, 1, &east.Lat, &east.Lon, nil)
+	for _, c := range []struct {
+		u                    Uncertainty
+		dlat, dlon, dazi, ds float64
+	}{
+		{u: Uncertainty{North: 1}, dlat: north.Lat - lat1},
+		{u: Uncertainty{East: 1}, dlon: east.Lon - lon1},
+		{u: Uncertainty{Azimuth: 1e-5}, dazi: 1e-5},
+		{u: Uncertainty{Distance: 1}, ds: 1},
+	} {
+		p, ell := WGS84.DeadReckon(lat1, lon1, azi1, s12, c.u)
+		if p != want {
+			t.Fatalf("expected %v, got %v", want, p)
+		}
+		var q LatLng
+		WGS84.Direct(lat1+c.dlat, lon1+c.dlon, azi1+c.dazi, s12+c.ds,
+			&q.Lat, &q.Lon, nil)
+		var d, azi float64
+		WGS84.Inverse(p.Lat, p.Lon, q.Lat, q.Lon, &d, &azi, nil)
+		if !(math.Abs(ell.Major-d) < 1e-3*d) || !(ell.Minor < 1e-6*d) {
+			t.Fatalf("%+v: expected a %vm line, got %+v", c.u, d, ell)
+		}
+		if !(math.Abs(math.Remainder(ell.Azimuth-azi, 180)) < 0.1) {
+			t.Fatalf("%+v: expected azimuth %v, got %v", c.u, azi, ell.Azimuth)
+		}
+	}
+
+	// Independent errors add in quadrature.
+	_, ell := WGS84.DeadReckon(lat1, lon1, azi1, s12,
+		Uncertainty{North: 10, East: 10, Azimuth: 0.5, Distance: 100})
+	_, ea := WGS84.DeadReckon(lat1, lon1, azi1, s12, Uncertainty{Azimuth: 0.5})
+	if !(ell.Major >= ea.Major && ell.Minor > 0 && ell.Minor < ell.Major) {
+		t.Fatalf("expected a larger ellipse than %+v, got %+v", ea, ell)
+	}
+	if ell.Azimuth < 0 || ell.Azimuth >= 180 {
+		t.Fatalf("expected an azimuth in [0,180), got %v", ell.Azimuth)
+	}
+
+	// No uncertainty.
+	if _, ell := WGS84.DeadReckon(lat1, lon1, azi1, s12, Uncertainty{}); ell.Major != 0 || ell.Minor != 0 {
+		t.Fatalf("expected no uncertainty, got %+v", ell)
+	}
+}
+
+func TestScales(t *testing.T) {
+	l := WGS84.LineInit(0, 0, 90)
+	m12, M12, M21 := l.Scales(1e6)
+	// Close to the spherical values along the equator.
+	b := WGS84.Radius()
+	if !(math.Abs(m12-b*math.Sin(1e6/b)) < 1e3) ||
+		!(math.Abs(M12-math.Cos(1e6/b)) < 1e-2) || M12 != M21 {
+		t.Fatalf("expected spherical-like scales, got %v, %v, %v", m12, M12, M21)
+	}
+	if m12, M12, _ := l.Scales(0); m12 != 0 || M12 != 1 {
+		t.Fatalf("expected 0 and 1, got %v and %v", m12, M12)
+	}
+}
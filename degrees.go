@@ -0,0 +1,56 @@
+package geodesic
+
+import "math"
+
+// MetersPerDegreeLat returns the length of a degree of latitude at lat,
+// along the meridian (meters).
+//
+// Param lat is the latitude (degrees).
+//
+// This is the meridional radius of curvature in meters per degree, which
+// on WGS84 grows from 110574 m at the equator to 111694 m at the poles, in
+// place of the 111320 m, or 60 nautical miles, of a sphere. Multiplying a
+// small change of latitude by it gives the distance along the meridian to
+// about the square of the change in radians, relative.
+func (e *Ellipsoid) MetersPerDegreeLat(lat float64) float64 {
+	a, f := e.Radius(), e.Flattening()
+	e2 := f * (2 - f)
+	sphi := math.Sin(lat * (math.Pi / 180))
+	w := 1 - e2*sphi*sphi
+	return a * (1 - e2) / (w * math.Sqrt(w)) * (math.Pi / 180)
+}
+
+// MetersPerDegreeLon returns the length of a degree of longitude at lat,
+// along the parallel (meters).
+//
+// Param lat is the latitude (degrees).
+//
+// This is the radius of the parallel in meters per degree, which on WGS84
+// is 111319 m at the equator and zero at the poles. Changes of longitude
+// of any size scale exactly by it along the parallel, as for
+// Ellipsoid.ParallelArcLength, though the parallel is not the shortest
+// path between two points on it.
+func (e *Ellipsoid) MetersPerDegreeLon(lat float64) float64 {
+	return e.parallelRadius(lat) * (math.Pi / 180)
+}
+
+// ArcToMeters returns the distance along the mean sphere of the ellipsoid
+// spanned by a central angle (meters). See Ellipsoid.ChordToArc.
+//
+// Param arc is the central angle (degrees).
+//
+// This is the conversion to use for a distance given in degrees, such as a
+// search radius, where no direction is known. For a direction along a
+// meridian or a parallel, use Ellipsoid.MetersPerDegreeLat or
+// Ellipsoid.MetersPerDegreeLon.
+func (e *Ellipsoid) ArcToMeters(arc float64) float64 {
+	return arc * (math.Pi / 180) * e.meanRadius()
+}
+
+// MetersToArc returns the central angle spanned by a distance along the
+// mean sphere of the ellipsoid (degrees). See Ellipsoid.ArcToMeters.
+//
+// Param s is the distance (meters).
+func (e *Ellipsoid) MetersToArc(s float64) float64 {
+	return s / e.meanRadius() * (180 / math.Pi)
+}
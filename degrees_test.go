@@ -0,0 +1,54 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMetersPerDegree(t *testing.T) {
+	for _, c := range []struct{ lat, dlat, dlon float64 }{
+		{0, 110574.2758, 111319.4908},
+		{45, 111131.7774, 78846.8351},
+		{90, 111693.9796, 0},
+		{-60, 111412.2875, 55800.0016},
+	} {
+		if d := WGS84.MetersPerDegreeLat(c.lat); !eqish(d, c.dlat, 3) {
+			t.Fatalf("%v: expected %v per degree of latitude, got %v", c.lat,
+				c.dlat, d)
+		}
+		if d := WGS84.MetersPerDegreeLon(c.lat); !eqish(d, c.dlon, 3) {
+			t.Fatalf("%v: expected %v per degree of longitude, got %v", c.lat,
+				c.dlon, d)
+		}
+	}
+	// A small step along the meridian and the parallel agrees with the
+	// geodesic distance.
+	for _, lat := range []float64{-80, -33, 0, 12, 51.5, 89} {
+		s := WGS84.Distance(lat-0.0005, 10, lat+0.0005, 10).Meters()
+		if d := WGS84.MetersPerDegreeLat(lat) * 0.001; math.Abs(d-s) > 1e-6 {
+			t.Fatalf("%v: expected %v along the meridian, got %v", lat, s, d)
+		}
+		s = WGS84.ParallelArcLength(lat, 10, 10.001)
+		if d := WGS84.MetersPerDegreeLon(lat) * 0.001; !eqish(d, s, 9) {
+			t.Fatalf("%v: expected %v along the parallel, got %v", lat, s, d)
+		}
+	}
+}
+
+func TestArcToMeters(t *testing.T) {
+	moon := NewEllipsoid(1737400, 0)
+	if s := moon.ArcToMeters(180); !eqish(s, math.Pi*1737400, 6) {
+		t.Fatalf("expected %v, got %v", math.Pi*1737400, s)
+	}
+	if a := WGS84.MetersToArc(WGS84.ArcToMeters(12.5)); !eqish(a, 12.5, 12) {
+		t.Fatalf("expected 12.5, got %v", a)
+	}
+	// A degree of arc is within twice the flattening of the length of a
+	// degree of latitude anywhere.
+	s := WGS84.ArcToMeters(1)
+	for _, lat := range []float64{0, 45, 90} {
+		if d := WGS84.MetersPerDegreeLat(lat); math.Abs(s/d-1) > 2*WGS84.Flattening() {
+			t.Fatalf("%v: expected about %v, got %v", lat, d, s)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package geodesic
+
+import "math"
+
+// greatCircle returns a function that gives the point at fraction t of the
+// way along the spherical great circle from p1 to p2, treating the
+// latitudes and longitudes as spherical coordinates.
+func greatCircle(p1, p2 LatLng) func(t float64) LatLng {
+	vec := func(p LatLng) [3]float64 {
+		sphi, cphi := math.Sincos(p.Lat * (math.Pi / 180))
+		slam, clam := math.Sincos(p.Lon * (math.Pi / 180))
+		return [3]float64{cphi * clam, cphi * slam, sphi}
+	}
+	a, b := vec(p1), vec(p2)
+	dot := a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+	omega := math.Acos(math.Max(-1, math.Min(1, dot)))
+	so := math.Sin(omega)
+	return func(t float64) LatLng {
+		ka, kb := 1-t, t
+		if so > 1e-15 {
+			ka, kb = math.Sin((1-t)*omega)/so, math.Sin(t*omega)/so
+		}
+		x := ka*a[0] + kb*b[0]
+		y := ka*a[1] + kb*b[1]
+		z := ka*a[2] + kb*b[2]
+		return LatLng{
+			Lat: math.Atan2(z, math.Hypot(x, y)) * (180 / math.Pi),
+			Lon: math.Atan2(y, x) * (180 / math.Pi),
+		}
+	}
+}
+
+// GreatCircleDeviation returns the largest separation between the
+// spherical great circle path and the ellipsoidal geodesic joining two
+// points.
+//
+// Param lat1 is latitude of point 1 (degrees).
+// Param lon1 is longitude of point 1 (degrees).
+// Param lat2 is latitude of point 2 (degrees).
+// Param lon2 is longitude of point 2 (degrees).
+// Returns the maximum deviation (meters).
+//
+// The great circle is drawn through the same latitudes and longitudes
+// treated as spherical coordinates, as a spherical approximation would do.
+// The deviation at each point of the great circle is its geodesic distance
+// to the nearest point on the geodesic. This helps to decide whether a
+// spherical approximation is good enough for a given route. The result is
+// not meaningful for nearly antipodal points, where the great circle itself
+// is not well defined.
+func (e *Ellipsoid) GreatCircleDeviation(lat1, lon1, lat2, lon2 float64) float64 {
+	p1, p2 := LatLng{lat1, lon1}, LatLng{lat2, lon2}
+	gc := greatCircle(p1, p2)
+	dev := func(t float64) float64 {
+		_, dist, _ := e.segmentNearest(gc(t), p1, p2)
+		return dist
+	}
+	// Sample the path, then refine around the largest sample.
+	const n = 32
+	best, bestDev := 0, 0.0
+	for i := 1; i < n; i++ {
+		if d := dev(float64(i) / n); d > bestDev {
+			best, bestDev = i, d
+		}
+	}
+	if best == 0 {
+		return 0
+	}
+	_, fx := goldenMin(float64(best-1)/n, float64(best+1)/n,
+		func(t float64) float64 { return -dev(t) })
+	return math.Max(bestDev, -fx)
+}
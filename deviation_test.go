@@ -0,0 +1,27 @@
+package geodesic
+
+import "testing"
+
+func TestGreatCircleDeviation(t *testing.T) {
+	// Along the equator and meridians the two paths agree.
+	if d := WGS84.GreatCircleDeviation(0, 0, 0, 60); d > 1e-6 {
+		t.Fatalf("expected no deviation, got %f", d)
+	}
+	if d := WGS84.GreatCircleDeviation(-30, 10, 50, 10); d > 1e-6 {
+		t.Fatalf("expected no deviation, got %f", d)
+	}
+	// Transcontinental routes differ by kilometers.
+	d := WGS84.GreatCircleDeviation(40.64, -73.78, 51.47, -0.45)
+	if d < 1000 || d > 20000 {
+		t.Fatalf("unexpected deviation %f", d)
+	}
+	// On a sphere the two paths are the same.
+	sphere := NewEllipsoid(6371000, 0)
+	if d := sphere.GreatCircleDeviation(40.64, -73.78, 51.47, -0.45); d > 1e-3 {
+		t.Fatalf("expected no deviation, got %f", d)
+	}
+	// Short routes deviate much less than long ones.
+	if short := WGS84.GreatCircleDeviation(40, 10, 41, 11); short >= d/100 {
+		t.Fatalf("expected a small deviation, got %f", short)
+	}
+}
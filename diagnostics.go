@@ -0,0 +1,75 @@
+package geodesic
+
+import v2 "github.com/tidwall/geodesic_cgo/v2"
+
+// InverseMethod is the way that the inverse problem was solved, see
+// Ellipsoid.InverseDiagnostics.
+type InverseMethod = v2.InverseMethod
+
+const (
+	// InverseMeridian is a geodesic along a meridian, or over a pole,
+	// which is solved directly.
+	InverseMeridian = v2.InverseMeridian
+	// InverseEquator is a geodesic along the equator, which is solved
+	// directly.
+	InverseEquator = v2.InverseEquator
+	// InverseShort is a short geodesic, solved directly from the starting
+	// guess of the iteration, which is accurate enough for it.
+	InverseShort = v2.InverseShort
+	// InverseIterative is a geodesic solved by Newton's method on the
+	// azimuth at its start.
+	InverseIterative = v2.InverseIterative
+)
+
+// InverseDiagnostics is how the solver arrived at the solution of an
+// inverse problem, see InverseDiagnostics.Err.
+type InverseDiagnostics = v2.InverseDiagnostics
+
+// InverseDiagnostics solves the inverse geodesic problem, as
+// Ellipsoid.Inverse, and also returns how it was solved.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param lat2 is the latitude of point 2 (degrees).
+// Param lon2 is the longitude of point 2 (degrees).
+// Out param s12 is the distance from point 1 to point 2 (meters).
+// Out param azi1 is the azimuth at point 1 (degrees).
+// Out param azi2 is the (forward) azimuth at point 2 (degrees).
+// Returns the diagnostics of the solution.
+//
+// The problem is solved by the pure Go port of the C routines, which
+// follows them step for step, so the diagnostics are those of the C
+// routines, and the results agree with Ellipsoid.Inverse to the last bit
+// or so. For the Earth, the iteration takes 3 or 4 steps on average and at
+// most about 20, and never bisects. Bisections happen on eccentric
+// ellipsoids, with flattenings of a few tenths or prolate ones, for nearly
+// antipodal points, where the solution is ill-conditioned. Points that are
+// not valid give NaN results and the zero diagnostics.
+//
+// A solution that did not converge is the best found in the limit of
+// steps and may be off by a lot more than the usual nanometers, see
+// InverseDiagnostics.Err; it is worth logging, and checking with
+// NewExactEllipsoid if it matters. The diagnostics of an exact ellipsoid
+// are those of the series routines, not of the exact routines that its
+// Inverse uses.
+func (e *Ellipsoid) InverseDiagnostics(lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2 *float64,
+) InverseDiagnostics {
+	lat1, lat2 = e.latitudes.fix(lat1), e.latitudes.fix(lat2)
+	var caps v2.Caps
+	if s12 != nil {
+		caps |= v2.Distance
+	}
+	if azi1 != nil || azi2 != nil {
+		caps |= v2.Azimuth
+	}
+	r, d, _ := e.g.InverseDiagnostics(v2.LatLng{Lat: lat1, Lon: lon1},
+		v2.LatLng{Lat: lat2, Lon: lon2}, caps)
+	set(s12, r.Distance)
+	set(azi1, r.Azi1)
+	set(azi2, r.Azi2)
+	e.coincident.apply(lat1, lon1, lat2, lon2, azi1, azi2)
+	e.azimuths.apply(azi1)
+	e.azimuths.apply(azi2)
+	return d
+}
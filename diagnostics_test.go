@@ -0,0 +1,82 @@
+package geodesic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInverseDiagnostics(t *testing.T) {
+	// The results are those of Inverse.
+	for _, c := range [][4]float64{
+		{40.64, -73.78, 1.36, 103.99}, {10, 5, 80, 5}, {0, 0, 0, 100},
+		{0, 0, 0.5, 179.7}, {1, 1, 1.000001, 1.000001},
+	} {
+		var s12, azi1, azi2, s12x, azi1x, azi2x float64
+		WGS84.Inverse(c[0], c[1], c[2], c[3], &s12, &azi1, &azi2)
+		WGS84.InverseDiagnostics(c[0], c[1], c[2], c[3], &s12x, &azi1x,
+			&azi2x)
+		if !eqish(s12, s12x, 7) || !eqish(azi1, azi1x, 9) ||
+			!eqish(azi2, azi2x, 9) {
+			t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+				s12, azi1, azi2, s12x, azi1x, azi2x)
+		}
+	}
+
+	// The methods.
+	for _, c := range []struct {
+		p      [4]float64
+		method InverseMethod
+	}{
+		{[4]float64{10, 5, 80, 5}, InverseMeridian},
+		{[4]float64{80, 5, 80, -175}, InverseMeridian},
+		{[4]float64{0, 0, 0, 100}, InverseEquator},
+		{[4]float64{1, 1, 1.000001, 1.000001}, InverseShort},
+		{[4]float64{40.64, -73.78, 1.36, 103.99}, InverseIterative},
+	} {
+		d := WGS84.InverseDiagnostics(c.p[0], c.p[1], c.p[2], c.p[3], nil,
+			nil, nil)
+		if d.Method != c.method || !d.Converged {
+			t.Fatalf("expected %v, got %v", c.method, d)
+		}
+		if c.method != InverseIterative && (d.Iterations != 0 ||
+			d.Bisections != 0) {
+			t.Fatalf("expected no iterations, got %v", d)
+		}
+		if c.method == InverseIterative && !(d.Iterations > 0 &&
+			d.Iterations < 20 && d.Bisections == 0) {
+			t.Fatalf("expected a few iterations, got %v", d)
+		}
+	}
+	if s := InverseIterative.String(); s != "iterative" {
+		t.Fatalf("expected 'iterative', got '%s'", s)
+	}
+
+	// Nearly antipodal points on an eccentric ellipsoid need bisections,
+	// and still converge on the geodesic, to within the accuracy of the
+	// series for such a flattening, which is less with fewer terms.
+	e := NewEllipsoid(6.4e6, 0.5)
+	var s12, azi1 float64
+	d := e.InverseDiagnostics(30, 0, -30.2, 179.8, &s12, &azi1, nil)
+	if d.Method != InverseIterative || d.Bisections == 0 || !d.Converged {
+		t.Fatalf("expected bisections, got %v", d)
+	}
+	var p LatLng
+	e.Direct(30, 0, azi1, s12, &p.Lat, &p.Lon, nil)
+	prec := 5
+	if SeriesOrder() != 6 {
+		prec = 4
+	}
+	if !eqish(p.Lat, -30.2, prec) || !eqish(p.Lon, 179.8, prec) {
+		t.Fatalf("expected '-30.2, 179.8', got %v", p)
+	}
+}
+
+func TestInverseDiagnosticsErr(t *testing.T) {
+	if err := (InverseDiagnostics{Method: InverseShort, Converged: true}).Err(); err != nil {
+		t.Fatal(err)
+	}
+	d := InverseDiagnostics{Method: InverseIterative, Iterations: 83}
+	if err := d.Err(); !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("expected ErrNotConverged, got %v", err)
+	}
+}
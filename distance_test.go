@@ -0,0 +1,44 @@
+package geodesic
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	d := 3 * NauticalMile
+	if d.Meters() != 5556 || d.NauticalMiles() != 3 {
+		t.Fatalf("unexpected conversion %v", d)
+	}
+	if !eqish((10 * Mile).Kilometers(), 16.09344, 9) {
+		t.Fatalf("expected %f, got %f", 16.09344, (10 * Mile).Kilometers())
+	}
+	if !eqish((Mile).Feet(), 5280, 9) {
+		t.Fatalf("expected %f, got %f", 5280.0, Mile.Feet())
+	}
+	var s12 float64
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s12, nil, nil)
+	if d := WGS84.Distance(40.64, -73.78, 1.36, 103.99); d.Meters() != s12 {
+		t.Fatalf("expected %f, got %f", s12, d.Meters())
+	}
+}
+
+func TestDistance3D(t *testing.T) {
+	// Straight up.
+	if d := WGS84.Distance3D(30, 40, 100, 30, 40, 1100); !eqish(float64(d), 1000, 8) {
+		t.Fatalf("expected 1000, got %v", d)
+	}
+	// Close points on the surface match the geodesic distance.
+	var lat, lon float64
+	WGS84.Direct(45, 0, 60, 3000, &lat, &lon, nil)
+	if d := WGS84.Distance3D(45, 0, 0, lat, lon, 0); !eqish(float64(d), 3000, 3) {
+		t.Fatalf("expected 3000, got %v", d)
+	}
+	// Far apart, the chord cuts through the ellipsoid.
+	WGS84.Direct(45, 0, 90, 1e6, &lat, &lon, nil)
+	if d := WGS84.Distance3D(45, 0, 0, lat, lon, 0); !(d < 1e6-1000) {
+		t.Fatalf("expected under %v, got %v", 1e6-1000, d)
+	}
+	// Antipodes through the center.
+	d := WGS84.Distance3D(0, 0, 0, 0, 180, 0)
+	if !eqish(float64(d), 2*WGS84.Radius(), 6) {
+		t.Fatalf("expected %v, got %v", 2*WGS84.Radius(), d)
+	}
+}
@@ -0,0 +1,51 @@
+package geodesic
+
+import "math"
+
+// toECEF converts geodetic coordinates to earth-centered earth-fixed
+// cartesian coordinates (meters).
+func (e *Ellipsoid) toECEF(lat, lon, h float64) (x, y, z float64) {
+	a, f := e.Radius(), e.Flattening()
+	e2 := f * (2 - f)
+	sphi, cphi := math.Sincos(lat * (math.Pi / 180))
+	if math.Abs(lat) == 90 {
+		cphi = 0
+	}
+	slam, clam := math.Sincos(lon * (math.Pi / 180))
+	n := a / math.Sqrt(1-e2*sphi*sphi)
+	x = (n + h) * cphi * clam
+	y = (n + h) * cphi * slam
+	z = (n*(1-e2) + h) * sphi
+	return x, y, z
+}
+
+// fromECEF converts earth-centered earth-fixed cartesian coordinates
+// (meters) to geodetic coordinates, using Heikkinen's closed form solution.
+func (e *Ellipsoid) fromECEF(x, y, z float64) (lat, lon, h float64) {
+	a, f := e.Radius(), e.Flattening()
+	b := a * (1 - f)
+	e2 := f * (2 - f)
+	ep2 := e2 / ((1 - f) * (1 - f))
+	p := math.Hypot(x, y)
+	lon = math.Atan2(y, x) * (180 / math.Pi)
+	if p == 0 {
+		// On the polar axis.
+		return math.Copysign(90, z), lon, math.Abs(z) - b
+	}
+	ff := 54 * b * b * z * z
+	g := p*p + (1-e2)*z*z - e2*(a*a-b*b)
+	c := e2 * e2 * ff * p * p / (g * g * g)
+	s := math.Cbrt(1 + c + math.Sqrt(c*c+2*c))
+	k := s + 1 + 1/s
+	pp := ff / (3 * k * k * g * g)
+	q := math.Sqrt(1 + 2*e2*e2*pp)
+	r0 := -pp*e2*p/(1+q) + math.Sqrt(math.Max(0,
+		a*a/2*(1+1/q)-pp*(1-e2)*z*z/(q*(1+q))-pp*p*p/2))
+	t := p - e2*r0
+	u := math.Sqrt(t*t + z*z)
+	v := math.Sqrt(t*t + (1-e2)*z*z)
+	z0 := b * b * z / (a * v)
+	h = u * (1 - b*b/(a*v))
+	lat = math.Atan2(z+ep2*z0, p) * (180 / math.Pi)
+	return lat, lon, h
+}
@@ -0,0 +1,20 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestECEF(t *testing.T) {
+	for _, p := range [][3]float64{
+		{0, 0, 0}, {45, 45, 100}, {-33.9, 151.2, -10}, {89.99, 10, 5000},
+		{90, 0, 0}, {-90, 0, 1}, {12.5, -179.9, 9000},
+	} {
+		x, y, z := WGS84.toECEF(p[0], p[1], p[2])
+		lat, lon, h := WGS84.fromECEF(x, y, z)
+		if !eqish(lat, p[0], 9) || !eqish(h, p[2], 6) ||
+			(math.Abs(p[0]) != 90 && !eqish(lon, p[1], 9)) {
+			t.Fatalf("expected %v, got %v", p, [3]float64{lat, lon, h})
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package geodesic
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// ErrorEllipsePoints returns the points of an ellipse on the ellipsoid.
+//
+// Param center is the center of the ellipse.
+// Param semiMajor is the semi-major axis (meters).
+// Param semiMinor is the semi-minor axis (meters).
+// Param orientation is the azimuth of the major axis (degrees).
+// Param segments is the number of points on the ellipse.
+// Returns a sequence of points.
+//
+// The ellipse is drawn in geodesic polar coordinates about the center: each
+// point is at the geodesic distance and azimuth from the center of the
+// point of a plane ellipse at the same polar coordinates. This is how a
+// confidence region, such as the ErrorEllipse of Ellipsoid.DeadReckon,
+// is meant when it is small. The points are at equal steps of the
+// eccentric anomaly, starting at the end of the major axis in the
+// direction of orientation and going clockwise. The first point is not
+// repeated at the end.
+func (e *Ellipsoid) ErrorEllipsePoints(center LatLng, semiMajor, semiMinor, orientation float64, segments int) iter.Seq[LatLng] {
+	return func(yield func(LatLng) bool) {
+		for i := 0; i < segments; i++ {
+			st, ct := math.Sincos(2 * math.Pi * float64(i) / float64(segments))
+			x, y := semiMajor*ct, semiMinor*st
+			azi := orientation + math.Atan2(y, x)*180/math.Pi
+			var p LatLng
+			e.Direct(center.Lat, center.Lon, azi, math.Hypot(x, y),
+				&p.Lat, &p.Lon, nil)
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// ErrorEllipse returns the points of an ellipse on the ellipsoid as a ring.
+// See Ellipsoid.ErrorEllipsePoints.
+func (e *Ellipsoid) ErrorEllipse(center LatLng, semiMajor, semiMinor, orientation float64, segments int) []LatLng {
+	return slices.Collect(e.ErrorEllipsePoints(center, semiMajor, semiMinor,
+		orientation, segments))
+}
@@ -0,0 +1,39 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestErrorEllipse(t *testing.T) {
+	c := LatLng{Lat: 60.1, Lon: 24.9}
+	ring := WGS84.ErrorEllipse(c, 2000, 500, 30, 72)
+	if len(ring) != 72 {
+		t.Fatalf("expected 72 points, got %d", len(ring))
+	}
+	// The axes.
+	for i, want := range []struct{ dist, azi float64 }{
+		{2000, 30}, {500, 120}, {2000, 210}, {500, 300},
+	} {
+		var d, azi float64
+		p := ring[i*18]
+		WGS84.Inverse(c.Lat, c.Lon, p.Lat, p.Lon, &d, &azi, nil)
+		if !eqish(d, want.dist, seriesPrec(6)) ||
+			!eqish(math.Remainder(azi-want.azi, 360), 0, 9) {
+			t.Fatalf("point %d: expected '%v, %v', got '%v, %v'",
+				i*18, want.dist, want.azi, d, azi)
+		}
+	}
+	// The area is close to that of the plane ellipse.
+	area, _ := PolygonArea(WGS84, ring)
+	if want := math.Pi * 2000 * 500; !(math.Abs(-area-want) < 0.01*want) {
+		t.Fatalf("expected a clockwise area of %v, got %v", want, area)
+	}
+	// A circle.
+	circle := WGS84.Circle(c, 1000, 16)
+	for i, p := range WGS84.ErrorEllipse(c, 1000, 1000, 0, 16) {
+		if !eqish(p.Lat, circle[i].Lat, 12) || !eqish(p.Lon, circle[i].Lon, 12) {
+			t.Fatalf("expected %v, got %v", circle[i], p)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package geodesic
+
+import (
+	"errors"
+
+	v2 "github.com/tidwall/geodesic_cgo/v2"
+)
+
+// The errors of the package are sentinel values, or wrap one of them with
+// fmt.Errorf to add detail, so that callers can tell them apart with
+// errors.Is and map them to messages of their own:
+//
+//   - ErrInvalidLatitude for a latitude outside of [-90,+90]
+//   - ErrInvalidLongitude for a longitude that is not a finite number
+//   - ErrInvalidArgument for other arguments that are out of range or not
+//     finite numbers
+//   - ErrOutOfRange for coordinates outside of a zone or projection
+//   - ErrLengthMismatch for inputs that must have the same length
+//   - ErrCoincident for points whose geodesic has no direction
+//   - ErrDatumMismatch for coordinates on different datums
+//   - ErrDegeneratePolygon for a ring that has no area
+//   - ErrNotConverged for an iteration that stopped short of its tolerance
+//   - ErrText for text that cannot be parsed
+//   - ErrGridShift for a grid shift file that cannot be read
+//   - ErrExactUnavailable and ErrInvalidEllipsoid from NewExactEllipsoid
+//
+// The routines that give no error, such as Ellipsoid.Inverse or
+// PolygonArea, do not panic on bad input either. As in the C library, any
+// result that depends on an input out of range is NaN. The checked
+// routines, such as Ellipsoid.CheckedInverse and CheckedPolygonArea, solve
+// the same problems and return errors for those inputs instead.
+var (
+	// ErrInvalidArgument is returned for an argument that is out of its
+	// range or not a finite number, other than a latitude or longitude.
+	ErrInvalidArgument = v2.ErrInvalidArgument
+	// ErrInvalidLongitude is returned by the checked routines for a
+	// longitude that is not a finite number.
+	ErrInvalidLongitude = v2.ErrInvalidLongitude
+	// ErrDegeneratePolygon is returned by CheckedPolygonArea for a ring
+	// with fewer than three distinct vertices, see RingReport.Degenerate.
+	ErrDegeneratePolygon = errors.New("geodesic: degenerate polygon")
+	// ErrNotConverged is returned by InverseDiagnostics.Err for a solution
+	// whose iteration did not converge.
+	ErrNotConverged = v2.ErrNotConverged
+)
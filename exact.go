@@ -0,0 +1,58 @@
+package geodesic
+
+import (
+	"errors"
+
+	v2 "github.com/tidwall/geodesic_cgo/v2"
+)
+
+// The exact routines are optional because they need the GeographicLib C++
+// library, which is not bundled. Build with the geographiclib_exact tag to
+// link against an installed copy:
+//
+//	go build -tags geographiclib_exact
+//
+// The library and its headers must be where the C++ compiler finds them,
+// or be pointed to with CGO_CXXFLAGS and CGO_LDFLAGS.
+
+var (
+	// ErrExactUnavailable is returned by NewExactEllipsoid when the package
+	// was built without the geographiclib_exact tag, or without cgo.
+	ErrExactUnavailable = v2.ErrExactUnavailable
+	// ErrInvalidEllipsoid is returned by NewExactEllipsoid for a radius or
+	// flattening that GeographicLib rejects.
+	ErrInvalidEllipsoid = v2.ErrInvalidEllipsoid
+)
+
+// NewExactEllipsoid initializes a new geodesic ellipsoid object that uses
+// the exact routines of GeographicLib C++, GeodesicExact and
+// PolygonAreaExact, instead of the series expansions of the C library.
+// Param a is the equatorial radius (meters).
+// Param f is the flattening.
+//
+// The series are accurate to round off for |f| < 0.01, which covers the
+// earth and the planets. The exact routines are accurate for any
+// flattening, at a few times the cost, and are meant for very eccentric
+// ellipsoids.
+//
+// The returned ellipsoid has the same methods as one from NewEllipsoid.
+// Ellipsoid.Inverse, Ellipsoid.Direct, Line.Position, and the polygon
+// methods use the exact routines, and so does everything built on them.
+// Unlike with the C library, copies of a Polygon from this ellipsoid share
+// their state, so copy the points rather than the Polygon.
+//
+// Returns ErrInvalidEllipsoid for a radius or flattening that is rejected
+// by GeographicLib. Without the geographiclib_exact build tag, returns
+// ErrExactUnavailable.
+func NewExactEllipsoid(a, f float64) (*Ellipsoid, error) {
+	e := new(Ellipsoid)
+	err := e.init(a, f, &v2.Options{Exact: true})
+	if errors.Is(err, v2.ErrInvalidRadius) ||
+		errors.Is(err, v2.ErrInvalidFlattening) {
+		err = ErrInvalidEllipsoid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
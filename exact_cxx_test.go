@@ -0,0 +1,133 @@
+//go:build cgo && geographiclib_exact
+
+package geodesic
+
+import (
+	"math"
+	"runtime"
+	"testing"
+)
+
+func TestNewExactEllipsoid(t *testing.T) {
+	if _, err := NewExactEllipsoid(-1, 0); err != ErrInvalidEllipsoid {
+		t.Fatalf("expected ErrInvalidEllipsoid, got %v", err)
+	}
+	if _, err := NewExactEllipsoid(6378137, 1); err != ErrInvalidEllipsoid {
+		t.Fatalf("expected ErrInvalidEllipsoid, got %v", err)
+	}
+}
+
+// exactCases are inverse problems that stress the solver: nearly
+// antipodal, polar, equatorial, and tiny separations.
+var exactCases = [][4]float64{
+	{48.522876735459, 0, -48.52287673545898293, 179.599720456223079643},
+	{0, 0, 0.5, 179.5},
+	{-30, 0, 30, 179.99},
+	{90, 0, -90, 0},
+	{-89.9999999, 10, 89.9999999, -170},
+	{0, -179.5, 0, 179.5},
+	{45, 45, 45, 45 + 1e-12},
+	{40.64, -73.78, 1.36, 103.99},
+}
+
+func TestExactMatchesSeries(t *testing.T) {
+	// On the earth the series are accurate to round off, so the two must
+	// agree closely.
+	e, err := NewExactEllipsoid(WGS84.Radius(), WGS84.Flattening())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range exactCases {
+		var s0, s1 float64
+		WGS84.Inverse(c[0], c[1], c[2], c[3], &s0, nil, nil)
+		e.Inverse(c[0], c[1], c[2], c[3], &s1, nil, nil)
+		if !eqish(s0, s1, 6) {
+			t.Fatalf("expected %v, got %v", s0, s1)
+		}
+	}
+	var lat0, lon0, lat1, lon1 float64
+	WGS84.Direct(40.64, -73.78, 45, 1e7, &lat0, &lon0, nil)
+	e.Direct(40.64, -73.78, 45, 1e7, &lat1, &lon1, nil)
+	if !eqish(lat0, lat1, 9) || !eqish(lon0, lon1, 9) {
+		t.Fatalf("expected '%v, %v', got '%v, %v'", lat0, lon0, lat1, lon1)
+	}
+	p0, p1 := WGS84.PolygonInit(false), e.PolygonInit(false)
+	for _, pt := range [][2]float64{{0, 0}, {0, 90}, {90, 0}} {
+		p0.AddPoint(pt[0], pt[1])
+		p1.AddPoint(pt[0], pt[1])
+	}
+	var a0, a1 float64
+	p0.Compute(false, true, &a0, nil)
+	if n := p1.Compute(false, true, &a1, nil); n != 3 {
+		t.Fatalf("expected 3, got %d", n)
+	}
+	if !(math.Abs(a0-a1) <= 1) {
+		t.Fatalf("expected %v, got %v", a0, a1)
+	}
+	p0.TestPoint(10, 45, false, true, &a0, nil)
+	if n := p1.TestPoint(10, 45, false, true, &a1, nil); n != 4 {
+		t.Fatalf("expected 4, got %d", n)
+	}
+	if !(math.Abs(a0-a1) <= 1) {
+		t.Fatalf("expected %v, got %v", a0, a1)
+	}
+	p0.TestEdge(200, 1e6, false, true, &a0, nil)
+	if n := p1.TestEdge(200, 1e6, false, true, &a1, nil); n != 4 {
+		t.Fatalf("expected 4, got %d", n)
+	}
+	if !(math.Abs(a0-a1) <= 1) {
+		t.Fatalf("expected %v, got %v", a0, a1)
+	}
+}
+
+func TestExactEccentric(t *testing.T) {
+	// A very oblate ellipsoid, well outside the range of the series.
+	e, err := NewExactEllipsoid(6.4e6, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s12, azi1 float64
+	e.Inverse(10, 20, -40, 150, &s12, &azi1, nil)
+	var lat2, lon2 float64
+	e.Direct(10, 20, azi1, s12, &lat2, &lon2, nil)
+	if !eqish(lat2, -40, 8) || !eqish(lon2, 150, 8) {
+		t.Fatalf("expected '-40, 150', got '%v, %v'", lat2, lon2)
+	}
+	l := e.InverseLine(10, 20, -40, 150)
+	l.Position(l.Distance(), &lat2, &lon2, nil)
+	if !eqish(lat2, -40, 8) || !eqish(lon2, 150, 8) {
+		t.Fatalf("expected '-40, 150', got '%v, %v'", lat2, lon2)
+	}
+	// On a meridian the polygon of the pole and two equator points is an
+	// eighth of the ellipsoid.
+	p := e.PolygonInit(false)
+	p.AddPoint(90, 0)
+	p.AddPoint(0, 0)
+	p.AddPoint(0, 90)
+	var area float64
+	p.Compute(false, true, &area, nil)
+	if want := e.totalArea() / 8; !(math.Abs(math.Abs(area)-want) <= 1e-9*want) {
+		t.Fatalf("expected %v, got %v", want, area)
+	}
+}
+
+func TestExactPolygonClose(t *testing.T) {
+	e, err := NewExactEllipsoid(WGS84.Radius(), WGS84.Flattening())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		p := e.PolygonInit(false)
+		p.AddPoint(0, 0)
+		p.AddPoint(0, 90)
+		p.AddPoint(90, 0)
+		var area float64
+		p.Compute(false, true, &area, nil)
+		p.Close()
+		if want := e.totalArea() / 8; !(math.Abs(area-want) <= 1e-9*want) {
+			t.Fatalf("expected %v, got %v", want, area)
+		}
+	}
+	// The finalizer then has nothing left to free.
+	runtime.GC()
+}
@@ -0,0 +1,12 @@
+//go:build !(cgo && geographiclib_exact)
+
+package geodesic
+
+import "testing"
+
+func TestNewExactEllipsoidUnavailable(t *testing.T) {
+	e, err := NewExactEllipsoid(6378137, 1/298.257223563)
+	if e != nil || err != ErrExactUnavailable {
+		t.Fatalf("expected ErrExactUnavailable, got %v, %v", e, err)
+	}
+}
@@ -0,0 +1,39 @@
+package geodesic
+
+import "math"
+
+// FrechetDistance returns the discrete Fréchet distance between two tracks.
+//
+// Param a is the first track.
+// Param b is the second track.
+// Returns the distance (meters).
+//
+// The discrete Fréchet distance is the shortest leash that lets two walkers
+// step through the points of each track in order, never going backwards,
+// using the geodesic distance between points. It returns NaN if either
+// track is empty.
+func (e *Ellipsoid) FrechetDistance(a, b []LatLng) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return math.NaN()
+	}
+	prev := make([]float64, len(b))
+	curr := make([]float64, len(b))
+	for i := range a {
+		for j := range b {
+			d := e.distance(a[i], b[j])
+			switch {
+			case i == 0 && j == 0:
+				curr[j] = d
+			case i == 0:
+				curr[j] = math.Max(curr[j-1], d)
+			case j == 0:
+				curr[j] = math.Max(prev[j], d)
+			default:
+				m := math.Min(math.Min(prev[j], prev[j-1]), curr[j-1])
+				curr[j] = math.Max(m, d)
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)-1]
+}
@@ -0,0 +1,29 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFrechetDistance(t *testing.T) {
+	a := []LatLng{{0, 0}, {0, 1}, {0, 2}, {0, 3}}
+	if d := WGS84.FrechetDistance(a, a); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+	// A parallel track one tenth of a degree to the north.
+	b := []LatLng{{0.1, 0}, {0.1, 1}, {0.1, 2}, {0.1, 3}}
+	var s12 float64
+	WGS84.Inverse(0, 0, 0.1, 0, &s12, nil, nil)
+	if d := WGS84.FrechetDistance(a, b); !eqish(d, s12, 7) {
+		t.Fatalf("expected %f, got %f", s12, d)
+	}
+	// Walking the track backwards forces the leash across its whole length.
+	r := []LatLng{a[3], a[2], a[1], a[0]}
+	WGS84.Inverse(0, 0, 0, 3, &s12, nil, nil)
+	if d := WGS84.FrechetDistance(a, r); !eqish(d, s12, 7) {
+		t.Fatalf("expected %f, got %f", s12, d)
+	}
+	if d := WGS84.FrechetDistance(a, nil); !math.IsNaN(d) {
+		t.Fatalf("expected NaN, got %f", d)
+	}
+}
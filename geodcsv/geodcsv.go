@@ -0,0 +1,173 @@
+// Package geodcsv annotates CSV rows of coordinate pairs with the geodesic
+// between them.
+//
+// Each row of the input holds the latitude and longitude of two points in
+// degrees, in any columns. Annotate streams the rows through, writing each
+// one back out with the distance in meters and the azimuths at both ends in
+// degrees appended, so files of any size can be processed in constant
+// memory. The cmd/geodcsv tool wraps it for use from the shell.
+package geodcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+// Columns are the names of the columns appended by Annotate to the header.
+var Columns = []string{"distance", "azi1", "azi2"}
+
+// Options are the options of Annotate.
+type Options struct {
+	// Ellipsoid is the ellipsoid to solve on. Nil uses WGS84.
+	Ellipsoid *geodesic.Ellipsoid
+	// Comma is the field delimiter of both the input and the output. Zero
+	// uses ','.
+	Comma rune
+	// Header is whether the first row is a header, which is written back
+	// with Columns appended.
+	Header bool
+	// Index is the zero-based columns of lat1, lon1, lat2, and lon2, in
+	// that order. The zero value uses the first four columns.
+	Index [4]int
+	// Names, if Header is set, finds the columns of the coordinates by
+	// their names in the header instead. Empty names fall back to Index.
+	Names [4]string
+	// Lenient writes rows whose coordinates are missing or are not numbers
+	// with empty annotations, instead of stopping with an error.
+	Lenient bool
+}
+
+// ErrColumn is returned by Annotate when a column named in Options.Names
+// is not in the header.
+var ErrColumn = errors.New("geodcsv: column not found")
+
+// Annotate reads CSV rows from r and writes them to w with the distance
+// and azimuths between two points appended.
+//
+// Param w is where the annotated rows are written.
+// Param r is where the rows are read from.
+// Param opts is the column mapping and the other options; nil uses the
+// defaults.
+// Returns the number of rows annotated, not counting a header, or the rows
+// that Options.Lenient writes with empty annotations.
+//
+// The distance is in meters and the azimuths are in degrees, as from
+// Ellipsoid.Inverse, written with as many digits as are needed to read
+// back the same float64. Rows may have different numbers of fields. A row
+// with a coordinate that is missing or does not parse stops the stream with
+// an error giving its line, unless Options.Lenient is set. The output is
+// flushed before returning, even on an error.
+func Annotate(w io.Writer, r io.Reader, opts *Options) (int, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Ellipsoid == nil {
+		o.Ellipsoid = geodesic.WGS84
+	}
+	if o.Index == [4]int{} {
+		o.Index = [4]int{0, 1, 2, 3}
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	cw := csv.NewWriter(w)
+	if o.Comma != 0 {
+		cr.Comma, cw.Comma = o.Comma, o.Comma
+	}
+	n, err := annotate(cw, cr, &o)
+	cw.Flush()
+	if err == nil {
+		err = cw.Error()
+	}
+	return n, err
+}
+
+func annotate(cw *csv.Writer, cr *csv.Reader, o *Options) (int, error) {
+	if o.Header {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		for i, name := range o.Names {
+			if name == "" {
+				continue
+			}
+			j := indexOf(rec, name)
+			if j < 0 {
+				return 0, fmt.Errorf("%w: %q", ErrColumn, name)
+			}
+			o.Index[i] = j
+		}
+		if err := cw.Write(append(rec, Columns...)); err != nil {
+			return 0, err
+		}
+	}
+	var n int
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		var v [4]float64
+		err = parseRow(rec, o.Index, &v)
+		if err != nil {
+			if !o.Lenient {
+				line, _ := cr.FieldPos(0)
+				return n, fmt.Errorf("geodcsv: line %d: %w", line, err)
+			}
+			rec = append(rec, "", "", "")
+		} else {
+			var s12, azi1, azi2 float64
+			o.Ellipsoid.Inverse(v[0], v[1], v[2], v[3], &s12, &azi1, &azi2)
+			rec = append(rec, formatFloat(s12), formatFloat(azi1),
+				formatFloat(azi2))
+		}
+		if err := cw.Write(rec); err != nil {
+			return n, err
+		}
+		if err == nil {
+			n++
+		}
+	}
+}
+
+// parseRow parses the coordinates at the columns of index in rec.
+func parseRow(rec []string, index [4]int, v *[4]float64) error {
+	for i, j := range index {
+		if j < 0 || j >= len(rec) {
+			return fmt.Errorf("missing column %d", j)
+		}
+		x, err := strconv.ParseFloat(strings.TrimSpace(rec[j]), 64)
+		if err != nil {
+			return err
+		}
+		v[i] = x
+	}
+	return nil
+}
+
+func indexOf(rec []string, name string) int {
+	for i, s := range rec {
+		if strings.TrimSpace(s) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
@@ -0,0 +1,105 @@
+package geodcsv
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+func TestAnnotate(t *testing.T) {
+	in := "40.64,-73.78,1.36,103.99\n0,0,0,90,extra\n"
+	var out strings.Builder
+	n, err := Annotate(&out, strings.NewReader(in), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2, got %d", n)
+	}
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	f := strings.Split(rows[0], ",")
+	if len(f) != 7 || f[0] != "40.64" {
+		t.Fatalf("expected the row to be kept, got %q", rows[0])
+	}
+	var s12, azi1, azi2 float64
+	geodesic.WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s12, &azi1, &azi2)
+	for i, want := range []float64{s12, azi1, azi2} {
+		if got, _ := strconv.ParseFloat(f[4+i], 64); got != want {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if f := strings.Split(rows[1], ","); len(f) != 8 || f[4] != "extra" {
+		t.Fatalf("expected the extra field to be kept, got %q", rows[1])
+	}
+}
+
+func TestAnnotateColumns(t *testing.T) {
+	in := "id;lon_a;lat_a;lon_b;lat_b\n7;0;0;90;0\n"
+	opts := &Options{
+		Comma:  ';',
+		Header: true,
+		Names:  [4]string{"lat_a", "lon_a", "lat_b", "lon_b"},
+	}
+	var out strings.Builder
+	if _, err := Annotate(&out, strings.NewReader(in), opts); err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if rows[0] != "id;lon_a;lat_a;lon_b;lat_b;distance;azi1;azi2" {
+		t.Fatalf("unexpected header %q", rows[0])
+	}
+	f := strings.Split(rows[1], ";")
+	var s12 float64
+	geodesic.WGS84.Inverse(0, 0, 0, 90, &s12, nil, nil)
+	if f[5] != strconv.FormatFloat(s12, 'f', -1, 64) || f[6] != "90" {
+		t.Fatalf("expected '%v, 90', got '%v, %v'", s12, f[5], f[6])
+	}
+	// The same by index.
+	opts.Names = [4]string{}
+	opts.Index = [4]int{2, 1, 4, 3}
+	var out2 strings.Builder
+	if _, err := Annotate(&out2, strings.NewReader(in), opts); err != nil {
+		t.Fatal(err)
+	}
+	if out2.String() != out.String() {
+		t.Fatalf("expected %q, got %q", out.String(), out2.String())
+	}
+	opts.Names = [4]string{"lat"}
+	if _, err := Annotate(&out, strings.NewReader(in), opts); !errors.Is(err, ErrColumn) {
+		t.Fatalf("expected ErrColumn, got %v", err)
+	}
+}
+
+func TestAnnotateErrors(t *testing.T) {
+	in := "0,0,0,1\n0,x,0,1\n0,0\n1,1,1,1\n"
+	var out strings.Builder
+	n, err := Annotate(&out, strings.NewReader(in), nil)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected an error on line 2, got %v", err)
+	}
+	// The rows before the bad one are written.
+	if n != 1 || strings.Count(out.String(), "\n") != 1 {
+		t.Fatalf("expected 1 row, got %d and %q", n, out.String())
+	}
+	out.Reset()
+	n, err = Annotate(&out, strings.NewReader(in), &Options{Lenient: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if n != 2 || len(rows) != 4 || rows[1] != "0,x,0,1,,," ||
+		rows[2] != "0,0,,," {
+		t.Fatalf("expected bad rows unannotated, got %q", rows)
+	}
+	// An empty input with a header is no rows and no error.
+	n, err = Annotate(&out, strings.NewReader(""), &Options{Header: true})
+	if n != 0 || err != nil {
+		t.Fatalf("expected '0, <nil>', got '%v, %v'", n, err)
+	}
+}
@@ -0,0 +1,237 @@
+// Package geoddist computes the geodesic distances and azimuths between
+// many pairs of points read from a CSV or TSV stream.
+//
+// Each row of the input holds the latitude and longitude of two points in
+// degrees, in any columns. Run reads the rows in batches and solves each
+// batch with Ellipsoid.InverseBatch, so that all of the cores are used,
+// writing one result per row in the order of the input. Unlike geodcsv,
+// which writes each row back out with its annotations, only the results are
+// written. The cmd/geoddist tool wraps it for use from the shell.
+package geoddist
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+// Format is the format of the results written by Run.
+type Format int
+
+const (
+	// FormatCSV writes the results as comma separated values.
+	FormatCSV Format = iota
+	// FormatTSV writes the results as tab separated values.
+	FormatTSV
+	// FormatJSON writes each result as a JSON object on a line of its own,
+	// with "distance", "azi1", and "azi2" members, which are null for
+	// results that are not numbers.
+	FormatJSON
+)
+
+// Options are the options of Run.
+type Options struct {
+	// Ellipsoid is the ellipsoid to solve on. Nil uses WGS84.
+	Ellipsoid *geodesic.Ellipsoid
+	// Comma is the field delimiter of the input. Zero uses ','.
+	Comma rune
+	// Header is whether the first row of the input is a header, which is
+	// skipped. The CSV and TSV results then start with a header of their
+	// own.
+	Header bool
+	// Index is the zero-based columns of lat1, lon1, lat2, and lon2, in
+	// that order. The zero value uses the first four columns.
+	Index [4]int
+	// Unit is the unit of the distances. Zero uses meters.
+	Unit geodesic.Distance
+	// Format is the format of the results.
+	Format Format
+	// Azimuths adds the azimuths at both points to the results, in
+	// degrees.
+	Azimuths bool
+	// Lenient writes empty results, or nulls in JSON, for rows whose
+	// coordinates are missing or are not numbers, instead of stopping with
+	// an error.
+	Lenient bool
+	// BatchSize is the number of rows solved at a time. Zero or less uses
+	// 65536.
+	BatchSize int
+	// Workers is the number of goroutines used for each batch, as for
+	// geodesic.BatchOptions.
+	Workers int
+}
+
+// Run reads rows of coordinate pairs from r and writes the geodesic
+// distance between the points of each to w.
+//
+// Param ctx is checked between chunks of work; when it is done, Run stops
+// and returns the context's error.
+// Param w is where the results are written.
+// Param r is where the rows are read from.
+// Param opts is the column mapping and the other options; nil uses the
+// defaults.
+// Returns the number of rows solved, not counting a header.
+//
+// The results are written with as many digits as are needed to read back
+// the same float64. A row with a coordinate that is missing or does not
+// parse stops the stream with an error giving its line, unless
+// Options.Lenient is set, and the results of the rows before it are
+// written. The output is flushed before returning, even on an error.
+func Run(ctx context.Context, w io.Writer, r io.Reader, opts *Options,
+) (int, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Ellipsoid == nil {
+		o.Ellipsoid = geodesic.WGS84
+	}
+	if o.Index == [4]int{} {
+		o.Index = [4]int{0, 1, 2, 3}
+	}
+	if o.Unit == 0 {
+		o.Unit = geodesic.Meter
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 65536
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+	bw := bufio.NewWriter(w)
+	n, err := run(ctx, bw, cr, &o)
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	return n, err
+}
+
+func run(ctx context.Context, w *bufio.Writer, cr *csv.Reader, o *Options,
+) (int, error) {
+	if o.Header {
+		if _, err := cr.Read(); err == io.EOF {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+		if o.Format != FormatJSON {
+			fields := []string{"distance"}
+			if o.Azimuths {
+				fields = append(fields, "azi1", "azi2")
+			}
+			o.writeRow(w, fields)
+		}
+	}
+	p1 := make([]geodesic.LatLng, 0, o.BatchSize)
+	p2 := make([]geodesic.LatLng, 0, o.BatchSize)
+	var bad []bool // whether each row of the batch failed to parse
+	var n int
+	for {
+		p1, p2, bad = p1[:0], p2[:0], bad[:0]
+		var rerr error
+		for len(p1) < o.BatchSize {
+			rec, err := cr.Read()
+			if err != nil {
+				rerr = err
+				break
+			}
+			var v [4]float64
+			if err := parseRow(rec, o.Index, &v); err != nil {
+				if !o.Lenient {
+					line, _ := cr.FieldPos(0)
+					rerr = fmt.Errorf("geoddist: line %d: %w", line, err)
+					break
+				}
+				bad = append(bad, true)
+			} else {
+				bad = append(bad, false)
+			}
+			p1 = append(p1, geodesic.LatLng{Lat: v[0], Lon: v[1]})
+			p2 = append(p2, geodesic.LatLng{Lat: v[2], Lon: v[3]})
+		}
+		sols, err := o.Ellipsoid.InverseBatch(ctx, p1, p2,
+			&geodesic.BatchOptions{Workers: o.Workers})
+		if err != nil {
+			return n, err
+		}
+		for i, s := range sols {
+			o.writeResult(w, s, bad[i])
+		}
+		n += len(sols)
+		if rerr == io.EOF {
+			return n, nil
+		}
+		if rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
+// writeResult writes the result of one row, or an empty result if the row
+// is bad.
+func (o *Options) writeResult(w *bufio.Writer, s geodesic.InverseSolution,
+	bad bool,
+) {
+	vals := []float64{float64(geodesic.Distance(s.S12) / o.Unit)}
+	if o.Azimuths {
+		vals = append(vals, s.Azi1, s.Azi2)
+	}
+	fields := make([]string, len(vals))
+	for i, x := range vals {
+		fields[i] = strconv.FormatFloat(x, 'f', -1, 64)
+		if bad {
+			fields[i] = ""
+		}
+	}
+	if o.Format != FormatJSON {
+		o.writeRow(w, fields)
+		return
+	}
+	w.WriteString(`{"distance":`)
+	for i, f := range fields {
+		if bad || math.IsNaN(vals[i]) || math.IsInf(vals[i], 0) {
+			f = "null"
+		}
+		if i == 1 {
+			w.WriteString(`,"azi1":`)
+		} else if i == 2 {
+			w.WriteString(`,"azi2":`)
+		}
+		w.WriteString(f)
+	}
+	w.WriteString("}\n")
+}
+
+func (o *Options) writeRow(w *bufio.Writer, fields []string) {
+	sep := ","
+	if o.Format == FormatTSV {
+		sep = "\t"
+	}
+	w.WriteString(strings.Join(fields, sep))
+	w.WriteByte('\n')
+}
+
+// parseRow parses the coordinates at the columns of index in rec.
+func parseRow(rec []string, index [4]int, v *[4]float64) error {
+	for i, j := range index {
+		if j < 0 || j >= len(rec) {
+			return fmt.Errorf("missing column %d", j)
+		}
+		x, err := strconv.ParseFloat(strings.TrimSpace(rec[j]), 64)
+		if err != nil {
+			return err
+		}
+		v[i] = x
+	}
+	return nil
+}
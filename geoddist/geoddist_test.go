@@ -0,0 +1,90 @@
+package geoddist
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+func TestRun(t *testing.T) {
+	// More rows than a batch, to check that the order is kept across
+	// batches.
+	var in strings.Builder
+	for i := 0; i < 25; i++ {
+		fmt.Fprintf(&in, "%d,0,%d,90\n", i, -i)
+	}
+	var out strings.Builder
+	n, err := Run(context.Background(), &out, strings.NewReader(in.String()),
+		&Options{Unit: geodesic.Kilometer, BatchSize: 7, Workers: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if n != 25 || len(rows) != 25 {
+		t.Fatalf("expected 25 rows, got %d and %d", n, len(rows))
+	}
+	for i, row := range rows {
+		var s12 float64
+		geodesic.WGS84.Inverse(float64(i), 0, float64(-i), 90, &s12, nil, nil)
+		if got, _ := strconv.ParseFloat(row, 64); got != s12/1000 {
+			t.Fatalf("row %d: expected %v, got %v", i, s12/1000, row)
+		}
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	in := "lat1\tlon1\tlat2\tlon2\n0\t0\t0\t1\nx\t0\t0\t1\n"
+	var out strings.Builder
+	_, err := Run(context.Background(), &out, strings.NewReader(in),
+		&Options{Comma: '\t', Header: true, Format: FormatTSV, Azimuths: true,
+			Lenient: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s12, azi1, azi2 float64
+	geodesic.WGS84.Inverse(0, 0, 0, 1, &s12, &azi1, &azi2)
+	want := fmt.Sprintf("distance\tazi1\tazi2\n%s\t%s\t%s\n\t\t\n",
+		strconv.FormatFloat(s12, 'f', -1, 64),
+		strconv.FormatFloat(azi1, 'f', -1, 64),
+		strconv.FormatFloat(azi2, 'f', -1, 64))
+	if out.String() != want {
+		t.Fatalf("expected %q, got %q", want, out.String())
+	}
+
+	out.Reset()
+	in = "2,0,2,0\n91,0,0,0\nx,0,0,0\n"
+	_, err = Run(context.Background(), &out, strings.NewReader(in),
+		&Options{Format: FormatJSON, Lenient: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "{\"distance\":0}\n{\"distance\":null}\n{\"distance\":null}\n"
+	if out.String() != want {
+		t.Fatalf("expected %q, got %q", want, out.String())
+	}
+
+	// Without Lenient a bad row stops the stream, after the results of the
+	// rows before it.
+	out.Reset()
+	n, err := Run(context.Background(), &out, strings.NewReader(in),
+		&Options{Index: [4]int{2, 3, 0, 1}})
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected an error at line 3, got %v", err)
+	}
+	if n != 2 || strings.Count(out.String(), "\n") != 2 {
+		t.Fatalf("expected 2 results, got %d: %q", n, out.String())
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Run(ctx, &strings.Builder{}, strings.NewReader("0,0,1,1\n"), nil)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
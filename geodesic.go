@@ -1,6 +1,12 @@
 // package geodesic
 //
-// API for the geodesic routines in Go (cgo-enabled version)
+// API for the geodesic routines in Go
+//
+// This package is the original API, kept unchanged for compatibility. It
+// is a thin wrapper around version 2, github.com/tidwall/geodesic_cgo/v2,
+// which holds the implementation: the bundled C library, the pure Go port
+// of it that builds without cgo use, and the build tags that select
+// between them.
 //
 // This an implementation in Go of the geodesic algorithms described in
 // - C. F. F. Karney, Algorithms for geodesics,
@@ -17,13 +23,11 @@
 // under the MIT License.
 package geodesic
 
-/*
-#cgo CFLAGS: -O3
-#cgo LDFLAGS: -lm
-#include <stdbool.h>
-#include "geodesic.h"
-*/
-import "C"
+import (
+	"math"
+
+	v2 "github.com/tidwall/geodesic_cgo/v2"
+)
 
 // WGS84 conforming ellispoid
 // https://en.wikipedia.org/wiki/World_Geodetic_System
@@ -31,7 +35,11 @@ var WGS84 = NewEllipsoid(6378137.0, float64(1.)/float64(298.257223563))
 
 // Ellipsoid is an object for performing geodesic operations.
 type Ellipsoid struct {
-	g C.struct_geod_geodesic
+	g          v2.Ellipsoid // with the default options of v2
+	azimuths   AzimuthConvention
+	areas      AreaConvention
+	coincident CoincidentPolicy
+	latitudes  LatitudePolicy
 }
 
 // NewEllipsoid initializes a new geodesic ellipsoid object.
@@ -39,10 +47,51 @@ type Ellipsoid struct {
 // Param f is the flattening.
 func NewEllipsoid(radius, flattening float64) *Ellipsoid {
 	e := new(Ellipsoid)
-	C.geod_init(&e.g, C.double(radius), C.double(flattening))
+	e.Init(radius, flattening)
 	return e
 }
 
+// Init initializes an ellipsoid in place, such as one embedded by value in
+// another struct, without the allocation of NewEllipsoid.
+// Param a is the equatorial radius (meters).
+// Param f is the flattening.
+//
+// Any previous state is discarded, including the azimuth and area
+// conventions and the coincident and latitude policies, which are reset to
+// AzimuthSigned, AreaCounterClockwise, CoincidentLibrary, and LatitudeNaN.
+// The ellipsoid must not be in use by another goroutine while it is
+// initialized. A radius that is not a positive number, or a flattening that
+// is not less than one, gives NaN results.
+func (e *Ellipsoid) Init(radius, flattening float64) {
+	e.init(radius, flattening, nil)
+}
+
+// init initializes the ellipsoid with the v2 options, see Ellipsoid.Init.
+func (e *Ellipsoid) init(radius, flattening float64, opts *v2.Options) error {
+	e.azimuths = AzimuthSigned
+	e.areas = AreaCounterClockwise
+	e.coincident = CoincidentLibrary
+	e.latitudes = LatitudeNaN
+	return e.g.Init(radius, flattening, opts)
+}
+
+// Radius returns the equatorial radius of the ellipsoid (meters).
+func (e *Ellipsoid) Radius() float64 {
+	return e.g.Radius()
+}
+
+// Flattening returns the flattening of the ellipsoid.
+func (e *Ellipsoid) Flattening() float64 {
+	return e.g.Flattening()
+}
+
+// set stores x in *p, if p is not nil.
+func set(p *float64, x float64) {
+	if p != nil {
+		*p = x
+	}
+}
+
 // Inverse solve the inverse geodesic problem.
 //
 // Param lat1 is latitude of point 1 (degrees).
@@ -54,10 +103,17 @@ func NewEllipsoid(radius, flattening float64) *Ellipsoid {
 // Out param pazi2 is a pointer to the (forward) azimuth at point 2 (degrees).
 //
 // lat1 and lat2 should be in the range [-90,+90].
-// The values of azi1 and azi2 returned are in the range [-180,+180].
+// The values of azi1 and azi2 returned are in the range [-180,+180], or
+// [0,360) if the ellipsoid was created with WithAzimuths(AzimuthUnsigned).
 // Any of the "return" arguments, ps12, etc., may be replaced with nil, if you
 // do not need some quantities computed.
 //
+// The pointers that are not nil select what is computed, in every backend,
+// as the output mask of geod_geninverse does in the C library, so there is
+// no separate mask argument. Leaving out ps12 skips the distance integral,
+// but the azimuths come out of the solution itself and leaving them out
+// saves little.
+//
 // The solution to the inverse problem is found using Newton's method.  If
 // this fails to converge (this is very unlikely in geodetic applications
 // but does occur for very eccentric ellipsoids), then the bisection method
@@ -66,9 +122,38 @@ func (e *Ellipsoid) Inverse(
 	lat1, lon1, lat2, lon2 float64,
 	s12, azi1, azi2 *float64,
 ) {
-	C.geod_inverse(&e.g,
-		C.double(lat1), C.double(lon1), C.double(lat2), C.double(lon2),
-		(*C.double)(s12), (*C.double)(azi1), (*C.double)(azi2))
+	e.InverseArc(lat1, lon1, lat2, lon2, s12, azi1, azi2)
+}
+
+// InverseArc solves the inverse geodesic problem, as Ellipsoid.Inverse,
+// and also returns the arc length.
+//
+// Returns a12 arc length from point 1 to point 2 (degrees).
+//
+// The arc length is the spherical arc length on the auxiliary sphere. It
+// is the parameter of Line.ArcPosition, and is a cheap measure of angular
+// separation, being 180 degrees for antipodal points on any ellipsoid.
+func (e *Ellipsoid) InverseArc(
+	lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2 *float64,
+) float64 {
+	lat1, lat2 = e.latitudes.fix(lat1), e.latitudes.fix(lat2)
+	caps := v2.Arc
+	if s12 != nil {
+		caps |= v2.Distance
+	}
+	if azi1 != nil || azi2 != nil {
+		caps |= v2.Azimuth
+	}
+	r, _ := e.g.Inverse(v2.LatLng{Lat: lat1, Lon: lon1},
+		v2.LatLng{Lat: lat2, Lon: lon2}, caps)
+	set(s12, r.Distance)
+	set(azi1, r.Azi1)
+	set(azi2, r.Azi2)
+	e.coincident.apply(lat1, lon1, lat2, lon2, azi1, azi2)
+	e.azimuths.apply(azi1)
+	e.azimuths.apply(azi2)
+	return r.Arc
 }
 
 // Direct solves the direct geodesic problem.
@@ -83,16 +168,42 @@ func (e *Ellipsoid) Inverse(
 // Out param pazi2 is a pointer to the (forward) azimuth at point 2 (degrees).
 //
 // lat1 should be in the range [-90,+90].
-// The values of lon2 and azi2 returned are in the range [-180,+180].
+// The values of lon2 and azi2 returned are in the range [-180,+180], or
+// [0,360) for azi2 if the ellipsoid was created with
+// WithAzimuths(AzimuthUnsigned).
 // Any of the "return" arguments, plat2, etc., may be replaced with nil, if you
 // do not need some quantities computed.
+//
+// As for Ellipsoid.Inverse, the pointers that are not nil select what is
+// computed. Asking only for the latitude, or only for the azimuth, skips
+// the longitude series and is about a fifth faster.
 func (e *Ellipsoid) Direct(
 	lat1, lon1, azi1, s12 float64,
 	lat2, lon2, azi2 *float64,
 ) {
-	C.geod_direct(&e.g,
-		C.double(lat1), C.double(lon1), C.double(azi1), C.double(s12),
-		(*C.double)(lat2), (*C.double)(lon2), (*C.double)(azi2))
+	lat1 = e.latitudes.fix(lat1)
+	r, _ := e.g.Direct(v2.LatLng{Lat: lat1, Lon: lon1}, azi1, s12,
+		directCaps(lat2, lon2, azi2))
+	set(lat2, r.Point.Lat)
+	set(lon2, r.Point.Lon)
+	set(azi2, r.Azi2)
+	e.azimuths.apply(azi2)
+}
+
+// directCaps returns the quantities to compute for the out params that are
+// not nil, of Ellipsoid.Direct and Line.Position.
+func directCaps(lat2, lon2, azi2 *float64) v2.Caps {
+	var caps v2.Caps
+	if lat2 != nil {
+		caps |= v2.Latitude
+	}
+	if lon2 != nil {
+		caps |= v2.Longitude
+	}
+	if azi2 != nil {
+		caps |= v2.Azimuth
+	}
+	return caps
 }
 
 // Polygon struct for accumulating information about a geodesic polygon.
@@ -100,7 +211,15 @@ func (e *Ellipsoid) Direct(
 // This must be initialized from Ellipsoid.PolygonInit before use.
 type Polygon struct {
 	e *Ellipsoid
-	p C.struct_geod_polygon
+	p v2.Polygon
+
+	// bad is the number of invalid points and edges that were added,
+	// which v2 refuses. As in the C library they count as points and make
+	// the results NaN.
+	bad, prevBad int
+	last         undoKind // see undo.go
+
+	w, prevW winding // see pole.go
 }
 
 // PolygonInit initializes a polygon.
@@ -117,21 +236,30 @@ type Polygon struct {
 // polygons.  At any point you can ask for the perimeter and area so far.
 func (e *Ellipsoid) PolygonInit(polyline bool) Polygon {
 	var p Polygon
-	if polyline {
-		C.geod_polygon_init(&p.p, 1)
-	} else {
-		C.geod_polygon_init(&p.p, 0)
-	}
-	p.e = e
+	e.polygonInit(&p, polyline)
 	return p
 }
 
+// polygonInit initializes *p in place, see Ellipsoid.PolygonInit.
+func (e *Ellipsoid) polygonInit(p *Polygon, polyline bool) {
+	e.g.InitPolygon(&p.p, polyline)
+	p.e = e
+	p.bad, p.prevBad = 0, 0
+	p.last = undoNone
+	p.w, p.prevW = winding{}, winding{}
+}
+
 // AddPoint adds a point to the polygon or polyline.
 //
 // Param lat is the latitude of the point (degrees).
 // Param lon is the longitude of the point (degrees).
 func (p *Polygon) AddPoint(lat, lon float64) {
-	C.geod_polygon_addpoint(&p.e.g, &p.p, C.double(lat), C.double(lon))
+	lat = p.e.latitudes.fix(lat)
+	p.save(undoPoint)
+	if p.p.AddPoint(v2.LatLng{Lat: lat, Lon: lon}) != nil {
+		p.bad++
+	}
+	p.w.point(lon)
 }
 
 // Compute the results for a polygon
@@ -156,15 +284,22 @@ func (p *Polygon) AddPoint(lat, lon float64) {
 //
 // More points can be added to the polygon after this call.
 func (p *Polygon) Compute(reverse, sign bool, area, perimeter *float64) int {
-	var creverse, csign C.int
-	if reverse {
-		creverse = 1
+	r := p.p.Result(reverse, sign)
+	n := r.NumPoints + p.bad
+	p.out(r, p.bad > 0 && n > 1, area, perimeter)
+	return n
+}
+
+// out stores the results, or NaN for nan, in the out params that are not
+// nil. The area of a polyline is left alone, as in the C library.
+func (p *Polygon) out(r v2.PolygonResult, nan bool, area, perimeter *float64) {
+	if nan {
+		r.Area, r.Perimeter = math.NaN(), math.NaN()
 	}
-	if sign {
-		csign = 1
+	if !p.p.Polyline() {
+		set(area, r.Area)
 	}
-	return int(C.geod_polygon_compute(&p.e.g, &p.p, creverse, csign,
-		(*C.double)(area), (*C.double)(perimeter)))
+	set(perimeter, r.Perimeter)
 }
 
 // AddEdge adds an edge to the polygon or polyline.
@@ -172,10 +307,126 @@ func (p *Polygon) Compute(reverse, sign bool, area, perimeter *float64) int {
 // Param azi is the azimuth at current point (degrees).
 // Param s is the distance from current point to next point (meters).
 func (p *Polygon) AddEdge(azi, s float64) {
-	C.geod_polygon_addedge(&p.e.g, &p.p, C.double(azi), C.double(s))
+	p.save(undoEdge)
+	n := p.p.NumPoints()
+	if err := p.p.AddEdge(azi, s); (err != nil && n > 0) ||
+		(n == 0 && p.bad > 0) {
+		p.bad++
+	}
+	lon := p.p.Current().Lon
+	if p.bad > 0 {
+		lon = math.NaN()
+	}
+	p.w.edge(lon)
+}
+
+// TestPoint returns the results assuming a tentative final test point is
+// added; however, the data for the test point is not saved.
+//
+// Param lat is the latitude of the test point (degrees).
+// Param lon is the longitude of the test point (degrees).
+// Param reverse and sign are as for Polygon.Compute.
+// Out param pA is a pointer to the area of the polygon (meters-squared);
+// Out param pP is a pointer to the perimeter of the polygon or length of the
+// polyline (meters).
+// Returns the number of points.
+//
+// This lets you report a running result for the perimeter and area as the
+// user moves the mouse cursor.  Ordinary floating point arithmetic is used
+// to accumulate the data for the test point; thus the area and perimeter
+// returned are less accurate than if Polygon.AddPoint and Polygon.Compute
+// are used.
+func (p *Polygon) TestPoint(lat, lon float64, reverse, sign bool,
+	area, perimeter *float64,
+) int {
+	lat = p.e.latitudes.fix(lat)
+	r, err := p.p.TestPoint(v2.LatLng{Lat: lat, Lon: lon}, reverse, sign)
+	n := p.p.NumPoints() + p.bad + 1
+	if n == 1 {
+		// the first point, whose results are zero even if it is invalid
+		r = v2.PolygonResult{}
+	}
+	p.out(r, n > 1 && (err != nil || p.bad > 0), area, perimeter)
+	return n
+}
+
+// TestEdge returns the results assuming a tentative final test point is
+// added via an azimuth and distance; however, the data for the test point
+// is not saved.
+//
+// Param azi is the azimuth at current point (degrees).
+// Param s is the distance from current point to final test point (meters).
+// Param reverse and sign are as for Polygon.Compute.
+// Out param pA is a pointer to the area of the polygon (meters-squared);
+// Out param pP is a pointer to the perimeter of the polygon or length of the
+// polyline (meters).
+// Returns the number of points, or 0 and NaN results if the polygon has no
+// points yet.
+//
+// As for Polygon.TestPoint, the results are less accurate than if
+// Polygon.AddEdge and Polygon.Compute are used.
+func (p *Polygon) TestEdge(azi, s float64, reverse, sign bool,
+	area, perimeter *float64,
+) int {
+	r, err := p.p.TestEdge(azi, s, reverse, sign)
+	n := p.p.NumPoints() + p.bad
+	if n == 0 {
+		p.out(r, true, area, perimeter)
+		return 0
+	}
+	p.out(r, err != nil || p.bad > 0, area, perimeter)
+	return n + 1
+}
+
+// polyline reports whether the polygon was initialized as a polyline.
+func (p *Polygon) polyline() bool {
+	return p.p.Polyline()
+}
+
+// save records the state before a change of the kind.
+func (p *Polygon) save(kind undoKind) {
+	p.prevBad = p.bad
+	p.prevW = p.w
+	p.last = kind
+}
+
+// restore reverts the last change if it was of the kind.
+func (p *Polygon) restore(kind undoKind) bool {
+	if p.last != kind {
+		return false
+	}
+	if p.bad == p.prevBad {
+		// the change reached v2
+		switch kind {
+		case undoPoint:
+			p.p.RemoveLastPoint()
+		case undoEdge:
+			p.p.RemoveLastEdge()
+		}
+	}
+	p.bad = p.prevBad
+	p.w = p.prevW
+	p.last = undoNone
+	return true
 }
 
 // Clear the polygon, allowing a new polygon to be started.
 func (p *Polygon) Clear() {
-	C.geod_polygon_clear(&p.p)
+	p.p.Clear()
+	p.bad = 0
+	p.last = undoNone
+	p.w = winding{}
+}
+
+// Close releases the memory held by the polygon. The polygon, and any copy
+// of it, must not be used afterwards.
+//
+// Only a polygon of an ellipsoid from NewExactEllipsoid holds memory
+// outside of Go, a C++ object that is otherwise freed when the garbage
+// collector gets to it. Closing such polygons once done with them keeps
+// the C++ heap from growing when many are made in a loop. For the other
+// polygons Close does nothing but may still be called.
+func (p *Polygon) Close() {
+	p.p.Close()
+	p.e = nil
 }
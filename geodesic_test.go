@@ -1,126 +1,55 @@
 package geodesic
 
 import (
-	"encoding/binary"
-	"fmt"
 	"math"
-	"math/rand"
 	"os"
 	"testing"
-	"time"
-)
-
-const testDataPath = "test.data"
-
-func TestGenerateData(t *testing.T) {
-	if os.Getenv("GENDATA") != "1" {
-		fmt.Printf("Use \"GENDATA=1 go test\" to generate a new test.data file.\n")
-		return
-	}
-	var data []byte
-	rand.Seed(time.Now().UnixNano())
-	for i := 0; i < 5000; i++ {
-		lat1 := rand.Float64()*180 - 90
-		lon1 := rand.Float64()*360 - 180
-		lat2 := rand.Float64()*180 - 90
-		lon2 := rand.Float64()*360 - 180
-		var s12, azi1, azi2 float64
-		WGS84.Inverse(lat1, lon1, lat2, lon2, &s12, &azi1, &azi2)
-		data = append(data, 'I')
-		data = appendFloats(data, lat1, lon1, lat2, lon2, s12, azi1, azi2)
-	}
-	for i := 0; i < 100; i++ {
-		lat1 := rand.Float64()*180 - 90
-		lon1 := rand.Float64()*360 - 180
-		steps := rand.Intn(10) + 4        // 4 - 14 steps
-		dist := rand.Float64()*20000 + 10 // 10-20000 kilometers
-		p := WGS84.PolygonInit(false)
-		data = append(data, 'P')
-		mark := len(data)
-		data = append(data, 0)
-		// generate the circle
-		for azi := 0.0; azi <= 360.0; azi += 360.0 / float64(steps) {
-			var lat2, lon2 float64
-			WGS84.Direct(lat1, lon1, azi, dist, &lat2, &lon2, nil)
-			p.AddPoint(lat2, lon2)
-			data = appendFloats(data, lat2, lon2)
-			data[mark]++
-		}
-		var area, peri float64
-		p.Compute(false, false, &area, &peri)
-		data = appendFloats(data, area, peri)
-		p.Compute(true, false, &area, &peri)
-		data = appendFloats(data, area, peri)
-		p.Compute(true, true, &area, &peri)
-		data = appendFloats(data, area, peri)
-		p.Compute(false, true, &area, &peri)
-		data = appendFloats(data, area, peri)
-	}
 
-	if err := os.WriteFile("test.data", data, 0666); err != nil {
-		t.Fatal(err)
-	}
+	"github.com/tidwall/geodesic_cgo/internal/gendata"
+)
 
-}
+// testDataPaths are the reference data files, the legacy binary test.data
+// and the text file from cmd/gentestdata.
+var testDataPaths = []string{"test.data", "testdata/geodesic.txt"}
 
-func appendFloats(dst []byte, x ...float64) []byte {
-	for _, x := range x {
-		dst = append(dst, 0, 0, 0, 0, 0, 0, 0, 0)
-		binary.LittleEndian.PutUint64(dst[len(dst)-8:], math.Float64bits(x))
-	}
-	return dst
-}
 func eqish(x, y float64, prec int) bool {
 	return math.Abs(x-y) < float64(1.0)/math.Pow10(prec)
 }
 
-func readFloats(src []byte, count int) []float64 {
-	vals := make([]float64, count)
-	for i := 0; i < count; i++ {
-		vals[i] = math.Float64frombits(binary.LittleEndian.Uint64(src[i*8:]))
-	}
-	return vals
-}
-
 func TestInput(t *testing.T) {
-	data, err := os.ReadFile(testDataPath)
-	if err != nil {
-		t.Fatal(err)
-	}
-	for i := 0; i < len(data); {
-		if data[i] == 'I' {
-			v := readFloats(data[i+1:], 7)
-			i += 1 + 7*8
-			testInverse(t, v[0], v[1], v[2], v[3], v[4], v[5], v[6])
-			testDirect(t, v[0], v[1], v[2], v[3], v[4], v[5], v[6])
-		} else if data[i] == 'P' {
-			i++
-			npoints := int(data[i])
-			i++
-			points := readFloats(data[i:], npoints*2)
-			i += npoints * 2 * 8
-			vals := readFloats(data[i:], 8)
-			i += 8 * 8
-			testPolygon(t, points, vals)
-		} else {
-			t.Fatalf("invalid %s", testDataPath)
+	fullOrder(t)
+	for _, path := range testDataPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := gendata.Read(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		for _, c := range d.Inverse {
+			testInverse(t, c.Lat1, c.Lon1, c.Lat2, c.Lon2, c.S12, c.Azi1, c.Azi2)
+			testDirect(t, c.Lat1, c.Lon1, c.Lat2, c.Lon2, c.S12, c.Azi1, c.Azi2)
+		}
+		for _, p := range d.Polygons {
+			testPolygon(t, p)
 		}
 	}
 }
 
-func testPolygon(t *testing.T, points []float64, vals []float64) {
+func testPolygon(t *testing.T, poly gendata.Polygon) {
 	p := WGS84.PolygonInit(false)
-	for i := 0; i < len(points); i += 2 {
-		p.AddPoint(points[i+0], points[i+1])
-	}
-	retvals := make([]float64, 8)
-	p.Compute(false, false, &retvals[0], &retvals[1])
-	p.Compute(true, false, &retvals[2], &retvals[3])
-	p.Compute(true, true, &retvals[4], &retvals[5])
-	p.Compute(false, true, &retvals[6], &retvals[7])
-	for i := 0; i < len(vals); i++ {
-		if !eqish(vals[i], retvals[i], 3) {
-			t.Fatalf("expected %f, got %f", vals, retvals)
+	for _, pt := range poly.Points {
+		p.AddPoint(pt[0], pt[1])
+	}
+	for i, flags := range gendata.PolygonFlags {
+		var area, perimeter float64
+		p.Compute(flags[0], flags[1], &area, &perimeter)
+		if !eqish(area, poly.Results[i][0], 3) ||
+			!eqish(perimeter, poly.Results[i][1], 3) {
+			t.Fatalf("expected %f, got %f", poly.Results[i],
+				[2]float64{area, perimeter})
 		}
 	}
 }
@@ -142,3 +71,119 @@ func testDirect(t *testing.T, lat1, lon1, lat2, lon2, s12, azi1, azi2 float64) {
 			lat2, lon2, azi2, lat2ret, lon2ret, azi2ret)
 	}
 }
+
+func TestInit(t *testing.T) {
+	var v struct {
+		name string
+		e    Ellipsoid
+	}
+	v.e.Init(WGS84.Radius(), WGS84.Flattening())
+	var s0, s1 float64
+	WGS84.Inverse(10, 20, 30, 40, &s0, nil, nil)
+	v.e.Inverse(10, 20, 30, 40, &s1, nil, nil)
+	if s0 != s1 {
+		t.Fatalf("expected %v, got %v", s0, s1)
+	}
+	// Init resets the options of a copy.
+	e := *WGS84.WithAzimuths(AzimuthUnsigned)
+	e.Init(6378137, 0)
+	var azi1 float64
+	e.Inverse(0, 0, 0, -10, nil, &azi1, nil)
+	if azi1 != -90 || e.Flattening() != 0 {
+		t.Fatalf("expected -90 and 0, got %v and %v", azi1, e.Flattening())
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		v.e.Init(6378137, 1/298.257223563)
+	}); n != 0 {
+		t.Fatalf("expected 0 allocations, got %v", n)
+	}
+}
+
+func TestInverseArc(t *testing.T) {
+	var s12, azi1, azi2 float64
+	a12 := WGS84.InverseArc(40.64, -73.78, 1.36, 103.99, &s12, &azi1, &azi2)
+	var s, z1, z2 float64
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s, &z1, &z2)
+	if s12 != s || azi1 != z1 || azi2 != z2 {
+		t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+			s, z1, z2, s12, azi1, azi2)
+	}
+	l := WGS84.InverseLine(40.64, -73.78, 1.36, 103.99)
+	if !eqish(a12, l.Arc(), 12) {
+		t.Fatalf("expected %v, got %v", l.Arc(), a12)
+	}
+	if a := WGS84.InverseArc(0, 0, 0, 180, nil, nil, nil); a != 180 {
+		t.Fatalf("expected 180, got %v", a)
+	}
+	if a := WGS84.InverseArc(10, 20, 10, 20, nil, nil, nil); a != 0 {
+		t.Fatalf("expected 0, got %v", a)
+	}
+}
+
+func TestPartialOutputs(t *testing.T) {
+	// Leaving outputs out skips their computation, but must not change the
+	// others.
+	var s12, azi1, azi2 float64
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s12, &azi1, &azi2)
+	var s, z1, z2 float64
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s, nil, nil)
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, nil, &z1, nil)
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, nil, nil, &z2)
+	if s != s12 || z1 != azi1 || z2 != azi2 {
+		t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+			s12, azi1, azi2, s, z1, z2)
+	}
+	var lat2, lon2 float64
+	WGS84.Direct(40.64, -73.78, azi1, s12, &lat2, &lon2, &azi2)
+	var lat, lon, azi float64
+	WGS84.Direct(40.64, -73.78, azi1, s12, &lat, nil, nil)
+	WGS84.Direct(40.64, -73.78, azi1, s12, nil, &lon, nil)
+	WGS84.Direct(40.64, -73.78, azi1, s12, nil, nil, &azi)
+	if lat != lat2 || lon != lon2 || azi != azi2 {
+		t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+			lat2, lon2, azi2, lat, lon, azi)
+	}
+}
+
+func TestPolygonTest(t *testing.T) {
+	pts := [][2]float64{{0, 0}, {0, 90}, {45, 45}, {80, -20}}
+	for _, polyline := range []bool{false, true} {
+		p := WGS84.PolygonInit(polyline)
+		var area, perim float64
+		if n := p.TestPoint(10, 10, false, true, &area, &perim); n != 1 ||
+			area != 0 || perim != 0 {
+			t.Fatalf("expected '1, 0, 0', got '%v, %v, %v'", n, area, perim)
+		}
+		if n := p.TestEdge(10, 10, false, true, nil, &perim); n != 0 ||
+			!math.IsNaN(perim) {
+			t.Fatalf("expected '0, NaN', got '%v, %v'", n, perim)
+		}
+		for _, pt := range pts {
+			// Adding the test point to a copy gives the same results, up
+			// to the round off of the plainer accumulation.
+			q := p
+			var qarea, qperim float64
+			q.AddPoint(pt[0], pt[1])
+			nq := q.Compute(true, false, &qarea, &qperim)
+			n := p.TestPoint(pt[0], pt[1], true, false, &area, &perim)
+			if n != nq || !eqish(area, qarea, 3) || !eqish(perim, qperim, 6) {
+				t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+					nq, qarea, qperim, n, area, perim)
+			}
+			p.AddPoint(pt[0], pt[1])
+		}
+		q := p
+		var qarea, qperim float64
+		q.AddEdge(120, 3e6)
+		nq := q.Compute(false, true, &qarea, &qperim)
+		n := p.TestEdge(120, 3e6, false, true, &area, &perim)
+		if n != nq || !eqish(area, qarea, 3) || !eqish(perim, qperim, 6) {
+			t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+				nq, qarea, qperim, n, area, perim)
+		}
+		// The tests leave the polygon as it was.
+		if n := p.Compute(false, true, nil, nil); n != len(pts) {
+			t.Fatalf("expected %d, got %d", len(pts), n)
+		}
+	}
+}
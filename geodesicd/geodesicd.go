@@ -0,0 +1,388 @@
+// Package geodesicd serves the geodesic routines as a small JSON over HTTP
+// service, for systems that are not written in Go and have no bindings of
+// their own.
+//
+// A Server is an http.Handler, so it can be mounted on any mux or served
+// alone:
+//
+//	http.ListenAndServe(":8080", geodesicd.New(geodesic.WGS84))
+//
+// Each endpoint takes a POST of either one JSON object or an array of them,
+// and answers with one result object or an array of results in the same
+// order. Arrays are solved in parallel.
+//
+//	POST /inverse  {"lat1", "lon1", "lat2", "lon2"} -> {"s12", "azi1", "azi2"}
+//	POST /direct   {"lat1", "lon1", "azi1", "s12"}  -> {"lat2", "lon2", "azi2"}
+//	POST /polygon  {"points": [[lat, lon], ...], "polyline"}
+//	               -> {"area", "perimeter", "points"}
+//	POST /buffer   {"lat", "lon", "radius", "segments"}
+//	               -> {"ring": [[lat, lon], ...]}
+//
+// Angles are in degrees and lengths are in meters. A request that is not
+// valid gets a 400 response, or 413 if it is too large, with a body of
+// {"error": message}. So does a request that stops because its context is
+// done, with 499 if the client went away and 503 if its deadline passed,
+// and a result that cannot be encoded, such as a NaN, with 500.
+//
+// Only JSON is served; a gRPC front end would need a dependency that the
+// geodesic module does not otherwise have, and can be put in front of the
+// same Server by the program that embeds it.
+package geodesicd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+// Server is the HTTP handler of the service. The fields must not be
+// changed once it is serving.
+type Server struct {
+	// Ellipsoid is the ellipsoid to solve on.
+	Ellipsoid *geodesic.Ellipsoid
+	// MaxBatch is the most problems allowed in one request. Zero or less
+	// uses 10000.
+	MaxBatch int
+	// MaxBodyBytes is the largest request body allowed. Zero or less uses
+	// 8 MiB.
+	MaxBodyBytes int64
+	// MaxSegments is the most segments allowed in a buffer ring. Zero or
+	// less uses 4096.
+	MaxSegments int
+	// Workers is the number of goroutines used for each batch, as for
+	// geodesic.BatchOptions.
+	Workers int
+
+	once sync.Once
+	mux  *http.ServeMux
+}
+
+// New returns a server for the ellipsoid with the default limits.
+func New(e *geodesic.Ellipsoid) *Server {
+	return &Server{Ellipsoid: e}
+}
+
+// InverseRequest is a problem for /inverse.
+type InverseRequest struct {
+	Lat1 float64 `json:"lat1"`
+	Lon1 float64 `json:"lon1"`
+	Lat2 float64 `json:"lat2"`
+	Lon2 float64 `json:"lon2"`
+}
+
+// InverseResponse is a result from /inverse.
+type InverseResponse struct {
+	S12  float64 `json:"s12"`
+	Azi1 float64 `json:"azi1"`
+	Azi2 float64 `json:"azi2"`
+}
+
+// DirectRequest is a problem for /direct.
+type DirectRequest struct {
+	Lat1 float64 `json:"lat1"`
+	Lon1 float64 `json:"lon1"`
+	Azi1 float64 `json:"azi1"`
+	S12  float64 `json:"s12"`
+}
+
+// DirectResponse is a result from /direct.
+type DirectResponse struct {
+	Lat2 float64 `json:"lat2"`
+	Lon2 float64 `json:"lon2"`
+	Azi2 float64 `json:"azi2"`
+}
+
+// PolygonRequest is a problem for /polygon.
+type PolygonRequest struct {
+	Points   [][2]float64 `json:"points"`   // [lat, lon] vertices
+	Polyline bool         `json:"polyline"` // a polyline, which has no area
+}
+
+// PolygonResponse is a result from /polygon. The area follows the
+// convention of the server's ellipsoid and is omitted for a polyline.
+type PolygonResponse struct {
+	Area      *float64 `json:"area,omitempty"`
+	Perimeter float64  `json:"perimeter"`
+	Points    int      `json:"points"`
+}
+
+// BufferRequest is a problem for /buffer, a geodesic circle around a
+// point.
+type BufferRequest struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Radius   float64 `json:"radius"`
+	Segments int     `json:"segments"` // zero uses 64
+}
+
+// BufferResponse is a result from /buffer.
+type BufferResponse struct {
+	Ring [][2]float64 `json:"ring"` // [lat, lon] vertices, not closed
+}
+
+// errTooLarge is the error for a request over one of the limits.
+var errTooLarge = errors.New("request too large")
+
+// errTrailing is the error for a body with more after the request.
+var errTrailing = errors.New("unexpected data after the request")
+
+// statusClientClosedRequest is the status, outside of the standard ones,
+// that nginx and others log for a client that went away before the answer.
+const statusClientClosedRequest = 499
+
+// ServeHTTP serves the endpoints of the service.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.once.Do(func() {
+		s.mux = http.NewServeMux()
+		s.mux.HandleFunc("POST /inverse", handle(s, s.inverse))
+		s.mux.HandleFunc("POST /direct", handle(s, s.direct))
+		s.mux.HandleFunc("POST /polygon", handle(s, s.polygon))
+		s.mux.HandleFunc("POST /buffer", handle(s, s.buffer))
+	})
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle returns a handler that decodes one request or an array of them,
+// solves them with fn, and encodes the results in the same shape.
+func handle[Req, Resp any](s *Server,
+	fn func(ctx context.Context, reqs []Req) ([]Resp, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		maxBody := s.MaxBodyBytes
+		if maxBody <= 0 {
+			maxBody = 8 << 20
+		}
+		var body json.RawMessage
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+		err := dec.Decode(&body)
+		var tooLarge *http.MaxBytesError
+		if err == nil {
+			// The request must be the whole body.
+			if _, err = dec.Token(); err == io.EOF {
+				err = nil
+			} else if !errors.As(err, &tooLarge) {
+				err = errTrailing
+			}
+		}
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, errTooLarge)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		body = bytes.TrimSpace(body)
+		batch := len(body) > 0 && body[0] == '['
+		var reqs []Req
+		if batch {
+			err = json.Unmarshal(body, &reqs)
+		} else {
+			reqs = make([]Req, 1)
+			err = json.Unmarshal(body, &reqs[0])
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		maxBatch := s.MaxBatch
+		if maxBatch <= 0 {
+			maxBatch = 10000
+		}
+		if len(reqs) > maxBatch {
+			writeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Errorf("%w: %d problems, at most %d", errTooLarge,
+					len(reqs), maxBatch))
+			return
+		}
+		resps, err := fn(r.Context(), reqs)
+		switch {
+		case errors.Is(err, errTooLarge):
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		case errors.Is(err, context.Canceled):
+			writeError(w, statusClientClosedRequest, err)
+			return
+		case errors.Is(err, context.DeadlineExceeded):
+			writeError(w, http.StatusServiceUnavailable, err)
+			return
+		case err != nil:
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if batch {
+			writeJSON(w, http.StatusOK, resps)
+		} else {
+			writeJSON(w, http.StatusOK, resps[0])
+		}
+	}
+}
+
+func (s *Server) opts() *geodesic.BatchOptions {
+	return &geodesic.BatchOptions{Workers: s.Workers}
+}
+
+func (s *Server) inverse(ctx context.Context, reqs []InverseRequest,
+) ([]InverseResponse, error) {
+	p1 := make([]geodesic.LatLng, len(reqs))
+	p2 := make([]geodesic.LatLng, len(reqs))
+	for i, q := range reqs {
+		if err := checkLat(q.Lat1, q.Lat2); err != nil {
+			return nil, err
+		}
+		p1[i] = geodesic.LatLng{Lat: q.Lat1, Lon: q.Lon1}
+		p2[i] = geodesic.LatLng{Lat: q.Lat2, Lon: q.Lon2}
+	}
+	sols, err := s.Ellipsoid.InverseBatch(ctx, p1, p2, s.opts())
+	if err != nil {
+		return nil, err
+	}
+	resps := make([]InverseResponse, len(sols))
+	for i, r := range sols {
+		resps[i] = InverseResponse{S12: r.S12, Azi1: r.Azi1, Azi2: r.Azi2}
+	}
+	return resps, nil
+}
+
+func (s *Server) direct(ctx context.Context, reqs []DirectRequest,
+) ([]DirectResponse, error) {
+	p1 := make([]geodesic.LatLng, len(reqs))
+	azi1 := make([]float64, len(reqs))
+	s12 := make([]float64, len(reqs))
+	for i, q := range reqs {
+		if err := checkLat(q.Lat1); err != nil {
+			return nil, err
+		}
+		p1[i] = geodesic.LatLng{Lat: q.Lat1, Lon: q.Lon1}
+		azi1[i], s12[i] = q.Azi1, q.S12
+	}
+	sols, err := s.Ellipsoid.DirectBatch(ctx, p1, azi1, s12, s.opts())
+	if err != nil {
+		return nil, err
+	}
+	resps := make([]DirectResponse, len(sols))
+	for i, r := range sols {
+		resps[i] = DirectResponse{Lat2: r.Point.Lat, Lon2: r.Point.Lon,
+			Azi2: r.Azi2}
+	}
+	return resps, nil
+}
+
+func (s *Server) polygon(ctx context.Context, reqs []PolygonRequest,
+) ([]PolygonResponse, error) {
+	resps := make([]PolygonResponse, len(reqs))
+	// The polygons go to the batch as columns, and the polylines, which
+	// only have a length, are measured here.
+	var lats, lons []float64
+	offsets := []int{0}
+	var index []int
+	for i, q := range reqs {
+		resps[i].Points = len(q.Points)
+		pts := make([]geodesic.LatLng, len(q.Points))
+		for j, p := range q.Points {
+			if err := checkLat(p[0]); err != nil {
+				return nil, err
+			}
+			pts[j] = geodesic.LatLng{Lat: p[0], Lon: p[1]}
+		}
+		if q.Polyline {
+			resps[i].Perimeter = geodesic.PolylineLength(s.Ellipsoid, pts)
+			continue
+		}
+		for _, p := range pts {
+			lats, lons = append(lats, p.Lat), append(lons, p.Lon)
+		}
+		offsets = append(offsets, len(lats))
+		index = append(index, i)
+	}
+	area := make([]float64, len(index))
+	perim := make([]float64, len(index))
+	err := s.Ellipsoid.PolygonAreaColumns(ctx, lats, lons, offsets, area,
+		perim, s.opts())
+	if err != nil {
+		return nil, err
+	}
+	for k, i := range index {
+		resps[i].Area, resps[i].Perimeter = &area[k], perim[k]
+	}
+	return resps, nil
+}
+
+func (s *Server) buffer(ctx context.Context, reqs []BufferRequest,
+) ([]BufferResponse, error) {
+	maxSegments := s.MaxSegments
+	if maxSegments <= 0 {
+		maxSegments = 4096
+	}
+	for _, q := range reqs {
+		if err := checkLat(q.Lat); err != nil {
+			return nil, err
+		}
+		if !(q.Radius >= 0) || math.IsInf(q.Radius, 0) {
+			return nil, fmt.Errorf("invalid radius %v", q.Radius)
+		}
+		if q.Segments < 0 {
+			return nil, fmt.Errorf("invalid segments %d", q.Segments)
+		}
+		if q.Segments > maxSegments {
+			return nil, fmt.Errorf("%w: %d segments, at most %d",
+				errTooLarge, q.Segments, maxSegments)
+		}
+	}
+	resps := make([]BufferResponse, len(reqs))
+	for i, q := range reqs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		segments := q.Segments
+		if segments == 0 {
+			segments = 64
+		}
+		ring := s.Ellipsoid.Circle(geodesic.LatLng{Lat: q.Lat, Lon: q.Lon},
+			q.Radius, segments)
+		resps[i].Ring = make([][2]float64, len(ring))
+		for j, p := range ring {
+			resps[i].Ring[j] = [2]float64{p.Lat, p.Lon}
+		}
+	}
+	return resps, nil
+}
+
+// checkLat returns an error if a latitude is not in [-90,+90]. JSON has
+// no NaN, so the values are always numbers.
+func checkLat(lats ...float64) error {
+	for _, lat := range lats {
+		if !(lat >= -90 && lat <= 90) {
+			return fmt.Errorf("latitude %v out of range", lat)
+		}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+// writeJSON writes v with the status code, or a 500 error if v cannot be
+// encoded. It is encoded before anything is written, so that a failure
+// does not leave a truncated body behind a success status.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(buf.Bytes())
+}
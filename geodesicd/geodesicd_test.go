@@ -0,0 +1,186 @@
+package geodesicd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+func post(t *testing.T, s *Server, path, body string, v any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path,
+		strings.NewReader(body)))
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s: %v: %q", path, err, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+func TestInverse(t *testing.T) {
+	s := New(geodesic.WGS84)
+	var s12, azi1, azi2 float64
+	geodesic.WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s12, &azi1, &azi2)
+	var one InverseResponse
+	code := post(t, s, "/inverse",
+		`{"lat1":40.64,"lon1":-73.78,"lat2":1.36,"lon2":103.99}`, &one)
+	if code != http.StatusOK || one != (InverseResponse{s12, azi1, azi2}) {
+		t.Fatalf("expected '200, %v, %v, %v', got '%d, %v'",
+			s12, azi1, azi2, code, one)
+	}
+	var many []InverseResponse
+	code = post(t, s, "/inverse", ` [{"lat1":40.64,"lon1":-73.78,"lat2":1.36,
+		"lon2":103.99},{"lat1":0,"lon1":0,"lat2":0,"lon2":90}]`, &many)
+	if code != http.StatusOK || len(many) != 2 || many[0] != one ||
+		many[1].Azi1 != 90 {
+		t.Fatalf("expected 2 results, got '%d, %v'", code, many)
+	}
+}
+
+func TestDirect(t *testing.T) {
+	s := New(geodesic.WGS84)
+	var lat2, lon2, azi2 float64
+	geodesic.WGS84.Direct(40.64, -73.78, 45, 1e7, &lat2, &lon2, &azi2)
+	var many []DirectResponse
+	code := post(t, s, "/direct",
+		`[{"lat1":40.64,"lon1":-73.78,"azi1":45,"s12":1e7}]`, &many)
+	if code != http.StatusOK || len(many) != 1 ||
+		many[0] != (DirectResponse{lat2, lon2, azi2}) {
+		t.Fatalf("expected '%v, %v, %v', got '%d, %v'", lat2, lon2, azi2,
+			code, many)
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	s := New(geodesic.WGS84)
+	var many []PolygonResponse
+	code := post(t, s, "/polygon", `[
+		{"points":[[0,0],[0,90],[90,0]]},
+		{"points":[[0,0],[0,90]],"polyline":true},
+		{"points":[]}
+	]`, &many)
+	if code != http.StatusOK || len(many) != 3 {
+		t.Fatalf("expected 3 results, got '%d, %v'", code, many)
+	}
+	pts := []geodesic.LatLng{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 90}, {Lat: 90, Lon: 0}}
+	area, perim := geodesic.PolygonArea(geodesic.WGS84, pts)
+	if many[0].Area == nil || *many[0].Area != area ||
+		many[0].Perimeter != perim || many[0].Points != 3 {
+		t.Fatalf("expected '%v, %v', got %v", area, perim, many[0])
+	}
+	length := geodesic.PolylineLength(geodesic.WGS84, pts[:2])
+	if many[1].Area != nil || many[1].Perimeter != length {
+		t.Fatalf("expected a length of %v, got %v", length, many[1])
+	}
+	if many[2].Area == nil || *many[2].Area != 0 || many[2].Points != 0 {
+		t.Fatalf("expected an empty polygon, got %v", many[2])
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	s := New(geodesic.WGS84)
+	var one BufferResponse
+	code := post(t, s, "/buffer",
+		`{"lat":10,"lon":20,"radius":1000,"segments":8}`, &one)
+	if code != http.StatusOK || len(one.Ring) != 8 {
+		t.Fatalf("expected 8 points, got '%d, %v'", code, one)
+	}
+	// The series of fewer terms are good to tens of micrometers.
+	tol := 1e-6
+	if geodesic.SeriesOrder() != 6 {
+		tol = 1e-4
+	}
+	for _, p := range one.Ring {
+		var s12 float64
+		geodesic.WGS84.Inverse(10, 20, p[0], p[1], &s12, nil, nil)
+		if math.Abs(s12-1000) > tol {
+			t.Fatalf("expected 1000, got %v", s12)
+		}
+	}
+	code = post(t, s, "/buffer", `{"lat":10,"lon":20,"radius":1000}`, &one)
+	if code != http.StatusOK || len(one.Ring) != 64 {
+		t.Fatalf("expected 64 points, got '%d, %v'", code, len(one.Ring))
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := &Server{Ellipsoid: geodesic.WGS84, MaxBatch: 2, MaxBodyBytes: 256}
+	var e struct{ Error string }
+	for _, c := range []struct {
+		path, body string
+		code       int
+	}{
+		{"/inverse", `{"lat1":`, http.StatusBadRequest},
+		{"/inverse", `{"lat1":"x"}`, http.StatusBadRequest},
+		{"/inverse", `{"lat1":91}`, http.StatusBadRequest},
+		{"/direct", `[{},{},{}]`, http.StatusRequestEntityTooLarge},
+		{"/polygon", `{"points":[[0,0],[0,` + strings.Repeat(" ", 300) + `1]]}`,
+			http.StatusRequestEntityTooLarge},
+		{"/buffer", `{"radius":-1}`, http.StatusBadRequest},
+		{"/buffer", `{"radius":1,"segments":5000}`,
+			http.StatusRequestEntityTooLarge},
+		{"/buffer", `{"radius":1,"segments":-1}`, http.StatusBadRequest},
+		{"/nothing", `{}`, http.StatusNotFound},
+		{"/inverse", `{"lat1":1} {"lat1":2}`, http.StatusBadRequest},
+		{"/inverse", `{"lat1":1}]`, http.StatusBadRequest},
+		{"/inverse", `[{"lat1":1}] x`, http.StatusBadRequest},
+	} {
+		if code := post(t, s, c.path, c.body, nil); code != c.code {
+			t.Fatalf("%s %s: expected %d, got %d", c.path, c.body, c.code, code)
+		}
+	}
+	if code := post(t, s, "/inverse", `{"lat1":91}`, &e); code != 400 ||
+		!strings.Contains(e.Error, "latitude") {
+		t.Fatalf("expected a latitude error, got %q", e.Error)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/inverse", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
+
+func TestContextDone(t *testing.T) {
+	s := New(geodesic.WGS84)
+	body := `[{"lat1":0,"lon1":0,"lat2":1,"lon2":1}]`
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Time{})
+	defer cancel()
+	for _, c := range []struct {
+		ctx  context.Context
+		code int
+	}{
+		{canceled, statusClientClosedRequest},
+		{expired, http.StatusServiceUnavailable},
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/inverse",
+			strings.NewReader(body))
+		s.ServeHTTP(rec, req.WithContext(c.ctx))
+		if rec.Code != c.code {
+			t.Fatalf("%v: expected %d, got %d", c.ctx.Err(), c.code, rec.Code)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	// A value that cannot be encoded is an error, not a truncated success.
+	rec := httptest.NewRecorder()
+	writeJSON(rec, http.StatusOK, InverseResponse{S12: math.NaN()})
+	var e struct{ Error string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &e); err != nil ||
+		rec.Code != http.StatusInternalServerError || e.Error == "" {
+		t.Fatalf("expected a 500 error, got %d %q", rec.Code, rec.Body.String())
+	}
+}
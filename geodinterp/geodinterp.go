@@ -0,0 +1,289 @@
+// Package geodinterp densifies the edges of GeoJSON and WKT geometries
+// along geodesics.
+//
+// Geometries are usually stored with only their vertices, and drawn by
+// joining the vertices with straight lines on the map, which for long edges
+// is far from the shortest path on the earth. Densify inserts points along
+// the geodesic of every edge of each LineString and Polygon, so that the
+// drawn geometry follows the true geodesics to within the spacing of the
+// points. The cmd/geodinterp tool wraps it for use from the shell.
+package geodinterp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+// Format is the format of the geometries read and written by Densify.
+type Format int
+
+const (
+	// FormatAuto reads GeoJSON if the input starts with '{' or '[', and WKT
+	// otherwise.
+	FormatAuto Format = iota
+	// FormatGeoJSON reads and writes GeoJSON, as in RFC 7946.
+	FormatGeoJSON
+	// FormatWKT reads and writes well-known text, as in OGC 06-103r4.
+	FormatWKT
+)
+
+// Options are the options of Densify.
+type Options struct {
+	// Ellipsoid is the ellipsoid to solve on. Nil uses WGS84.
+	Ellipsoid *geodesic.Ellipsoid
+	// MaxSegment is the longest an edge may be after densifying (meters).
+	// It must be positive.
+	MaxSegment float64
+	// Format is the format of the input, which is also the format of the
+	// output.
+	Format Format
+}
+
+// ErrGeometry is returned by Densify for input that is not a valid
+// geometry.
+var ErrGeometry = errors.New("geodinterp: invalid geometry")
+
+// Densify reads geometries from r and writes them to w with points
+// inserted along the edges of their LineStrings and Polygons.
+//
+// Param w is where the densified geometries are written.
+// Param r is where the geometries are read from.
+// Param opts is the maximum segment length and the other options.
+// Returns an error if the input does not parse, or has a position whose
+// latitude is out of range, wrapping ErrGeometry, or if w or r fails.
+//
+// The input is a sequence of GeoJSON values or of WKT geometries,
+// separated by white space, and each is written on a line of its own.
+// Every edge is split into the fewest equal parts that are no longer than
+// Options.MaxSegment, as by geodesic.Densify. The other ordinates of the
+// positions of an edge, such as heights and measures, are interpolated
+// linearly between its ends, if both have them. Points and MultiPoints are
+// written as they are.
+//
+// GeoJSON Features and FeatureCollections are kept, with their properties,
+// and the members of the objects are written in sorted order. Any bbox
+// members are kept as they are, and so may not cover the geodesics between
+// the vertices. The coordinates of WKT geometries are written as the
+// shortest decimals that read back as the same numbers.
+func Densify(w io.Writer, r io.Reader, opts *Options) error {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Ellipsoid == nil {
+		o.Ellipsoid = geodesic.WGS84
+	}
+	if !(o.MaxSegment > 0) {
+		return fmt.Errorf("geodinterp: invalid maximum segment %v",
+			o.MaxSegment)
+	}
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	if o.Format == FormatAuto {
+		o.Format = FormatWKT
+		if c, err := peek(br); err == nil && (c == '{' || c == '[') {
+			o.Format = FormatGeoJSON
+		}
+	}
+	var err error
+	switch o.Format {
+	case FormatGeoJSON:
+		err = densifyGeoJSON(bw, br, &o)
+	case FormatWKT:
+		err = densifyWKT(bw, br, &o)
+	default:
+		err = fmt.Errorf("geodinterp: unknown format %d", o.Format)
+	}
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// peek returns the first byte of r that is not white space, without
+// reading it.
+func peek(r *bufio.Reader) (byte, error) {
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(c)) {
+			return c, r.UnreadByte()
+		}
+	}
+}
+
+// line returns the positions of a LineString or a ring, which are in
+// longitude, latitude order, densified.
+func (o *Options) line(pts [][]float64) ([][]float64, error) {
+	for _, p := range pts {
+		if len(p) < 2 || !(math.Abs(p[1]) <= 90) || math.IsInf(p[0], 0) ||
+			math.IsNaN(p[0]) {
+			return nil, fmt.Errorf("%w: position %v", ErrGeometry, p)
+		}
+	}
+	if len(pts) < 2 {
+		return pts, nil
+	}
+	out := [][]float64{pts[0]}
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		seg := geodesic.Densify(o.Ellipsoid, []geodesic.LatLng{
+			{Lat: a[1], Lon: a[0]}, {Lat: b[1], Lon: b[0]},
+		}, o.MaxSegment)
+		n := len(seg) - 1
+		for j := 1; j < n; j++ {
+			p := []float64{seg[j].Lon, seg[j].Lat}
+			if len(a) > 2 && len(b) > 2 {
+				f := float64(j) / float64(n)
+				for k := 2; k < min(len(a), len(b)); k++ {
+					p = append(p, a[k]+(b[k]-a[k])*f)
+				}
+			}
+			out = append(out, p)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+// lineDepth returns the depth of the arrays of positions that are
+// LineStrings or rings in the coordinates of a geometry type, which is
+// zero for the types that have none.
+func lineDepth(typ string) int {
+	switch strings.ToUpper(typ) {
+	case "LINESTRING":
+		return 1
+	case "POLYGON", "MULTILINESTRING":
+		return 2
+	case "MULTIPOLYGON":
+		return 3
+	}
+	return 0
+}
+
+func densifyGeoJSON(w *bufio.Writer, r io.Reader, o *Options) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	for {
+		var v any
+		if err := dec.Decode(&v); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("%w: %v", ErrGeometry, err)
+		}
+		v, err := o.geoJSON(v)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		w.Write(b)
+		if err := w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+}
+
+// geoJSON returns the GeoJSON value v with its geometries densified.
+// Values other than objects, and objects that are not GeoJSON, are
+// returned as they are.
+func (o *Options) geoJSON(v any) (any, error) {
+	switch v := v.(type) {
+	case []any:
+		for i := range v {
+			x, err := o.geoJSON(v[i])
+			if err != nil {
+				return nil, err
+			}
+			v[i] = x
+		}
+	case map[string]any:
+		typ, _ := v["type"].(string)
+		switch typ {
+		case "FeatureCollection":
+			return v, o.member(v, "features")
+		case "Feature":
+			return v, o.member(v, "geometry")
+		case "GeometryCollection":
+			return v, o.member(v, "geometries")
+		}
+		depth := lineDepth(typ)
+		if depth == 0 || v["coordinates"] == nil {
+			return v, nil
+		}
+		c, err := o.coordinates(v["coordinates"], depth)
+		if err != nil {
+			return nil, err
+		}
+		v["coordinates"] = c
+	}
+	return v, nil
+}
+
+// member densifies the member of an object that holds its geometries.
+func (o *Options) member(v map[string]any, name string) error {
+	x, ok := v[name]
+	if !ok {
+		return nil
+	}
+	x, err := o.geoJSON(x)
+	if err != nil {
+		return err
+	}
+	v[name] = x
+	return nil
+}
+
+// coordinates returns the GeoJSON coordinates v with the arrays of
+// positions at depth densified.
+func (o *Options) coordinates(v any, depth int) (any, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: coordinates %v", ErrGeometry, v)
+	}
+	if depth > 1 {
+		for i := range arr {
+			x, err := o.coordinates(arr[i], depth-1)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = x
+		}
+		return arr, nil
+	}
+	pts := make([][]float64, len(arr))
+	for i, p := range arr {
+		nums, ok := p.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: position %v", ErrGeometry, p)
+		}
+		for _, n := range nums {
+			x, err := jsonFloat(n)
+			if err != nil {
+				return nil, err
+			}
+			pts[i] = append(pts[i], x)
+		}
+	}
+	return o.line(pts)
+}
+
+func jsonFloat(v any) (float64, error) {
+	if n, ok := v.(json.Number); ok {
+		if x, err := n.Float64(); err == nil {
+			return x, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: ordinate %v", ErrGeometry, v)
+}
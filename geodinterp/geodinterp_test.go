@@ -0,0 +1,160 @@
+package geodinterp
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+// maxEdge returns the length of the longest edge of a line of positions in
+// longitude, latitude order.
+func maxEdge(pts [][]float64) float64 {
+	var longest float64
+	for i := 1; i < len(pts); i++ {
+		s := geodesic.WGS84.Distance(pts[i-1][1], pts[i-1][0], pts[i][1],
+			pts[i][0]).Meters()
+		if s > longest {
+			longest = s
+		}
+	}
+	return longest
+}
+
+// sameNumbers reports whether the numbers in s are close to nums.
+func sameNumbers(s string, nums ...float64) bool {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.-", r)
+	})
+	if len(fields) != len(nums) {
+		return false
+	}
+	for i, f := range fields {
+		x, err := strconv.ParseFloat(f, 64)
+		if err != nil || math.Abs(x-nums[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDensifyGeoJSON(t *testing.T) {
+	in := `{"type":"Feature","properties":{"name":"JFK-SIN","n":1.50},
+		"geometry":{"type":"LineString","coordinates":[[-73.78,40.64,10],
+		[103.99,1.36,30]]}}
+		{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,0]]]}
+		{"type":"MultiPoint","coordinates":[[0,0],[50,0]]}`
+	var out strings.Builder
+	err := Densify(&out, strings.NewReader(in), &Options{MaxSegment: 100e3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+
+	if !strings.Contains(lines[0], `"properties":{"n":1.50,"name":"JFK-SIN"}`) {
+		t.Fatalf("expected the properties to be kept, got %s", lines[0])
+	}
+	var f struct {
+		Geometry struct{ Coordinates [][]float64 }
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &f); err != nil {
+		t.Fatal(err)
+	}
+	c := f.Geometry.Coordinates
+	if len(c) < 150 || maxEdge(c) > 100e3 {
+		t.Fatalf("expected edges up to 100 km, got %d points up to %v",
+			len(c), maxEdge(c))
+	}
+	if c[0][0] != -73.78 || c[len(c)-1][0] != 103.99 {
+		t.Fatalf("expected the vertices to be kept, got %v and %v", c[0],
+			c[len(c)-1])
+	}
+	// The route goes over the pole region, far from the straight line.
+	var north float64
+	for _, p := range c {
+		if len(p) != 3 || p[2] < 10 || p[2] > 30 {
+			t.Fatalf("expected a height between 10 and 30, got %v", p)
+		}
+		north = max(north, p[1])
+	}
+	if north < 70 {
+		t.Fatalf("expected the route to pass north of 70, got %v", north)
+	}
+
+	var poly struct{ Coordinates [][][]float64 }
+	if err := json.Unmarshal([]byte(lines[1]), &poly); err != nil {
+		t.Fatal(err)
+	}
+	ring := poly.Coordinates[0]
+	if len(ring) < 15 || maxEdge(ring) > 100e3 ||
+		ring[0][0] != ring[len(ring)-1][0] {
+		t.Fatalf("expected a closed densified ring, got %v", ring)
+	}
+	if lines[2] != `{"coordinates":[[0,0],[50,0]],"type":"MultiPoint"}` {
+		t.Fatalf("expected the points to be kept, got %s", lines[2])
+	}
+}
+
+func TestDensifyWKT(t *testing.T) {
+	in := "LINESTRING (0 0, 2 0)\n" +
+		"polygon z ((0 0 1, 1 0 3, 1 1 5, 0 0 1), EMPTY)\n" +
+		"GEOMETRYCOLLECTION (POINT (1 2), MULTILINESTRING ((0 0, 0 2)))\n" +
+		"POINT EMPTY"
+	var out strings.Builder
+	err := Densify(&out, strings.NewReader(in), &Options{MaxSegment: 60e3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %q", lines)
+	}
+	// 2 degrees along the equator is about 222.6 km, which is 4 segments.
+	if !strings.HasPrefix(lines[0], "LINESTRING (") ||
+		!sameNumbers(lines[0], 0, 0, 0.5, 0, 1, 0, 1.5, 0, 2, 0) {
+		t.Fatalf("expected 4 segments along the equator, got %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "POLYGON Z ((") ||
+		!strings.HasSuffix(lines[1], ", 0 0 1), EMPTY)") ||
+		!sameNumbers(lines[1][:strings.Index(lines[1], "1 0 3")],
+			0, 0, 1, 0.5, 0, 2) {
+		t.Fatalf("expected a densified polygon, got %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2],
+		"GEOMETRYCOLLECTION (POINT (1 2), MULTILINESTRING ((0 0, 0 ") ||
+		strings.Count(lines[2], ",") != 5 {
+		t.Fatalf("expected a densified collection, got %s", lines[2])
+	}
+	if lines[3] != "POINT EMPTY" {
+		t.Fatalf("expected POINT EMPTY, got %s", lines[3])
+	}
+}
+
+func TestDensifyErrors(t *testing.T) {
+	for _, in := range []string{
+		"LINESTRING (0 0, 1 91)",
+		"LINESTRING (0 0, 1)",
+		"LINESTRING (0 0, 1 1",
+		"LINESTRING 0 0",
+		`{"type":"LineString","coordinates":[[0,0],[1,"a"]]}`,
+		`{"type":"LineString","coordinates":[[0,0],[1,95]]}`,
+		`{"type":"LineString"`,
+	} {
+		var out strings.Builder
+		err := Densify(&out, strings.NewReader(in), &Options{MaxSegment: 1e3})
+		if !errors.Is(err, ErrGeometry) {
+			t.Fatalf("%s: expected ErrGeometry, got %v", in, err)
+		}
+	}
+	err := Densify(&strings.Builder{}, strings.NewReader("POINT (0 0)"), nil)
+	if err == nil || errors.Is(err, ErrGeometry) {
+		t.Fatalf("expected a maximum segment error, got %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package geodinterp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// wktGeometry is a WKT geometry. An empty geometry has neither a body nor
+// geometries.
+type wktGeometry struct {
+	typ   string         // the type, in upper case
+	dim   string         // "", "Z", "M", or "ZM"
+	body  *wktNode       // the coordinates, for types other than collections
+	geoms []*wktGeometry // the geometries of a GEOMETRYCOLLECTION
+}
+
+// wktNode is a parenthesized list of the coordinates of a WKT geometry, or
+// one of its elements, which are lists, positions, or EMPTY.
+type wktNode struct {
+	pos   []float64  // a position, if not nil
+	kids  []*wktNode // the elements of a list
+	empty bool       // an EMPTY element
+}
+
+func densifyWKT(w *bufio.Writer, r *bufio.Reader, o *Options) error {
+	lx := &wktLexer{r: r}
+	for {
+		tok, err := lx.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		g, err := lx.geometry(tok)
+		if err != nil {
+			return err
+		}
+		if err := o.wkt(g); err != nil {
+			return err
+		}
+		g.write(w)
+		if err := w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+}
+
+// wkt densifies the geometry g in place.
+func (o *Options) wkt(g *wktGeometry) error {
+	for _, c := range g.geoms {
+		if err := o.wkt(c); err != nil {
+			return err
+		}
+	}
+	if depth := lineDepth(g.typ); depth > 0 && g.body != nil {
+		return o.wktNode(g.body, depth)
+	}
+	return nil
+}
+
+// wktNode densifies the lists of positions at depth below n.
+func (o *Options) wktNode(n *wktNode, depth int) error {
+	if n.pos != nil {
+		return fmt.Errorf("%w: unexpected position %v", ErrGeometry, n.pos)
+	}
+	if n.empty {
+		return nil
+	}
+	if depth > 1 {
+		for _, k := range n.kids {
+			if err := o.wktNode(k, depth-1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	pts := make([][]float64, len(n.kids))
+	for i, k := range n.kids {
+		if k.pos == nil {
+			return fmt.Errorf("%w: expected a position", ErrGeometry)
+		}
+		pts[i] = k.pos
+	}
+	pts, err := o.line(pts)
+	if err != nil {
+		return err
+	}
+	n.kids = make([]*wktNode, len(pts))
+	for i, p := range pts {
+		n.kids[i] = &wktNode{pos: p}
+	}
+	return nil
+}
+
+func (g *wktGeometry) write(w *bufio.Writer) {
+	w.WriteString(g.typ)
+	if g.dim != "" {
+		w.WriteString(" " + g.dim)
+	}
+	switch {
+	case g.typ == "GEOMETRYCOLLECTION" && g.geoms != nil:
+		w.WriteString(" (")
+		for i, c := range g.geoms {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			c.write(w)
+		}
+		w.WriteByte(')')
+	case g.body != nil:
+		w.WriteByte(' ')
+		g.body.write(w)
+	default:
+		w.WriteString(" EMPTY")
+	}
+}
+
+func (n *wktNode) write(w *bufio.Writer) {
+	switch {
+	case n.empty:
+		w.WriteString("EMPTY")
+	case n.pos != nil:
+		for i, x := range n.pos {
+			if i > 0 {
+				w.WriteByte(' ')
+			}
+			w.WriteString(strconv.FormatFloat(x, 'f', -1, 64))
+		}
+	default:
+		w.WriteByte('(')
+		for i, k := range n.kids {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			k.write(w)
+		}
+		w.WriteByte(')')
+	}
+}
+
+// wktLexer splits WKT into words, numbers, and punctuation.
+type wktLexer struct {
+	r    *bufio.Reader
+	back string // a token pushed back by unread
+}
+
+// next returns the next token, with words in upper case, or io.EOF at the
+// end of the input.
+func (lx *wktLexer) next() (string, error) {
+	if lx.back != "" {
+		tok := lx.back
+		lx.back = ""
+		return tok, nil
+	}
+	c, err := peek(lx.r)
+	if err != nil {
+		return "", err
+	}
+	if strings.IndexByte("(),", c) >= 0 {
+		lx.r.ReadByte()
+		return string(c), nil
+	}
+	var sb strings.Builder
+	for {
+		c, err := lx.r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if strings.IndexByte(" \t\r\n(),", c) >= 0 {
+			lx.r.UnreadByte()
+			break
+		}
+		sb.WriteByte(c)
+	}
+	return strings.ToUpper(sb.String()), nil
+}
+
+func (lx *wktLexer) unread(tok string) {
+	lx.back = tok
+}
+
+// expect reads a token, which must be tok.
+func (lx *wktLexer) expect(tok string) error {
+	t, err := lx.token()
+	if err == nil && t != tok {
+		err = fmt.Errorf("%w: expected %q, got %q", ErrGeometry, tok, t)
+	}
+	return err
+}
+
+// token is like next, but returns an ErrGeometry at the end of the input.
+func (lx *wktLexer) token() (string, error) {
+	tok, err := lx.next()
+	if err == io.EOF {
+		err = fmt.Errorf("%w: unexpected end of input", ErrGeometry)
+	}
+	return tok, err
+}
+
+// geometry parses a geometry whose type is tok.
+func (lx *wktLexer) geometry(tok string) (*wktGeometry, error) {
+	if !isWord(tok) {
+		return nil, fmt.Errorf("%w: expected a geometry type, got %q",
+			ErrGeometry, tok)
+	}
+	g := &wktGeometry{typ: tok}
+	tok, err := lx.token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == "Z" || tok == "M" || tok == "ZM" {
+		g.dim = tok
+		if tok, err = lx.token(); err != nil {
+			return nil, err
+		}
+	}
+	if tok == "EMPTY" {
+		return g, nil
+	}
+	if tok != "(" {
+		return nil, fmt.Errorf("%w: expected \"(\", got %q", ErrGeometry, tok)
+	}
+	if g.typ != "GEOMETRYCOLLECTION" {
+		lx.unread(tok)
+		g.body, err = lx.node()
+		return g, err
+	}
+	g.geoms = []*wktGeometry{}
+	for {
+		tok, err := lx.token()
+		if err != nil {
+			return nil, err
+		}
+		c, err := lx.geometry(tok)
+		if err != nil {
+			return nil, err
+		}
+		g.geoms = append(g.geoms, c)
+		if tok, err = lx.token(); err != nil {
+			return nil, err
+		}
+		if tok == ")" {
+			return g, nil
+		}
+		if tok != "," {
+			return nil, fmt.Errorf("%w: expected \",\", got %q", ErrGeometry,
+				tok)
+		}
+	}
+}
+
+// node parses a list, a position, or EMPTY.
+func (lx *wktLexer) node() (*wktNode, error) {
+	tok, err := lx.token()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case tok == "EMPTY":
+		return &wktNode{empty: true}, nil
+	case tok == "(":
+		n := &wktNode{}
+		for {
+			k, err := lx.node()
+			if err != nil {
+				return nil, err
+			}
+			n.kids = append(n.kids, k)
+			if tok, err = lx.token(); err != nil {
+				return nil, err
+			}
+			if tok == ")" {
+				return n, nil
+			}
+			if tok != "," {
+				return nil, fmt.Errorf("%w: expected \",\", got %q",
+					ErrGeometry, tok)
+			}
+		}
+	}
+	n := &wktNode{}
+	for {
+		x, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expected a number, got %q",
+				ErrGeometry, tok)
+		}
+		n.pos = append(n.pos, x)
+		if tok, err = lx.token(); err != nil {
+			return nil, err
+		}
+		if tok == "," || tok == ")" {
+			lx.unread(tok)
+			return n, nil
+		}
+	}
+}
+
+// isWord reports whether tok is a word, rather than a number or
+// punctuation.
+func isWord(tok string) bool {
+	c := tok[0]
+	return c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,190 @@
+package geodesic
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The GeodTest datasets are GeographicLib's published accuracy corpus for
+// geodesics on WGS84, see
+// https://geographiclib.sourceforge.io/C++/doc/geodesic.html#testgeod
+//
+// They are too large to ship with the package, so the tests below look
+// for one in the testdata directory, or at the path in the GEODTEST
+// environment variable, and are skipped when there is none. Either the
+// plain file or the .gz file as distributed may be used.
+var geodTestPaths = []string{
+	"testdata/GeodTest-short.dat",
+	"testdata/GeodTest-short.dat.gz",
+	"testdata/GeodTest.dat",
+	"testdata/GeodTest.dat.gz",
+}
+
+// geodTestCase is one line of a GeodTest dataset.
+type geodTestCase struct {
+	lat1, lon1, azi1 float64
+	lat2, lon2, azi2 float64
+	s12, a12, m12    float64
+	area             float64
+}
+
+// readGeodTest reads the cases of a GeodTest dataset. Each line holds the
+// ten fields lat1 lon1 azi1 lat2 lon2 azi2 s12 a12 m12 S12, separated by
+// spaces.
+func readGeodTest(r io.Reader) ([]geodTestCase, error) {
+	var cases []geodTestCase
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 10 {
+			return nil, fmt.Errorf("line %d: expected 10 fields, got %d",
+				n, len(fields))
+		}
+		var v [10]float64
+		for i, field := range fields {
+			x, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			v[i] = x
+		}
+		cases = append(cases, geodTestCase{
+			v[0], v[1], v[2], v[3], v[4], v[5], v[6], v[7], v[8], v[9],
+		})
+	}
+	return cases, sc.Err()
+}
+
+// loadGeodTest opens and reads the GeodTest dataset at path, which may be
+// gzipped.
+func loadGeodTest(path string) ([]geodTestCase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+	return readGeodTest(r)
+}
+
+// geodTestCases returns the cases of the first GeodTest dataset found, or
+// skips tb when there is none.
+func geodTestCases(tb testing.TB) []geodTestCase {
+	paths := geodTestPaths
+	if path := os.Getenv("GEODTEST"); path != "" {
+		paths = []string{path}
+	}
+	for _, path := range paths {
+		cases, err := loadGeodTest(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			tb.Fatalf("%s: %v", path, err)
+		}
+		return cases
+	}
+	tb.Skip("no GeodTest dataset found, set GEODTEST to its path")
+	return nil
+}
+
+// checkGeodTest checks the inverse and direct solutions for one case.
+// The tolerances are well above the errors GeographicLib reports for the
+// corpus, so a failure means something is broken rather than imprecise.
+func checkGeodTest(c geodTestCase) error {
+	var s12 float64
+	WGS84.Inverse(c.lat1, c.lon1, c.lat2, c.lon2, &s12, nil, nil)
+	if !(math.Abs(s12-c.s12) <= 1e-6) {
+		return fmt.Errorf("inverse: expected s12 %v, got %v", c.s12, s12)
+	}
+	var lat2, lon2, azi2 float64
+	WGS84.Direct(c.lat1, c.lon1, c.azi1, c.s12, &lat2, &lon2, &azi2)
+	var miss float64
+	WGS84.Inverse(lat2, lon2, c.lat2, c.lon2, &miss, nil, nil)
+	if !(miss <= 1e-6) {
+		return fmt.Errorf("direct: expected '%v, %v', got '%v, %v'",
+			c.lat2, c.lon2, lat2, lon2)
+	}
+	// The azimuth is indeterminate at a pole.
+	if math.Abs(c.lat2) < 90-1e-9 &&
+		!(math.Abs(math.Remainder(azi2-c.azi2, 360)) <= 1e-7) {
+		return fmt.Errorf("direct: expected azi2 %v, got %v", c.azi2, azi2)
+	}
+	return nil
+}
+
+func TestReadGeodTest(t *testing.T) {
+	data := "" +
+		"40 0 51.2 51.6 73.3 107.8 5551759.4 50.0 4836032.7 1.5e13\n" +
+		"\n" +
+		"0 0 90 0 90 90 10018754.171394622 90.3 6367489.5 0\n"
+	cases, err := readGeodTest(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("expected 2, got %d", len(cases))
+	}
+	if cases[0].azi1 != 51.2 || cases[0].area != 1.5e13 ||
+		cases[1].s12 != 10018754.171394622 {
+		t.Fatalf("expected fields in order, got %v", cases)
+	}
+	// The equatorial quarter is exact enough to go through the checks.
+	if err := checkGeodTest(cases[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readGeodTest(strings.NewReader("1 2 3\n")); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestGeodTest(t *testing.T) {
+	cases := geodTestCases(t)
+	var failed int
+	for i, c := range cases {
+		if err := checkGeodTest(c); err != nil {
+			t.Errorf("case %d: %v", i+1, err)
+			if failed++; failed == 10 {
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func BenchmarkGeodTestInverse(b *testing.B) {
+	cases := geodTestCases(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := &cases[i%len(cases)]
+		var s12, azi1, azi2 float64
+		WGS84.Inverse(c.lat1, c.lon1, c.lat2, c.lon2, &s12, &azi1, &azi2)
+	}
+}
+
+func BenchmarkGeodTestDirect(b *testing.B) {
+	cases := geodTestCases(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := &cases[i%len(cases)]
+		var lat2, lon2, azi2 float64
+		WGS84.Direct(c.lat1, c.lon1, c.azi1, c.s12, &lat2, &lon2, &azi2)
+	}
+}
@@ -0,0 +1,187 @@
+package geodesic
+
+import (
+	"math"
+	"time"
+)
+
+// GeofenceEvent is a change of state reported by Geofence.Update.
+type GeofenceEvent int
+
+const (
+	GeofenceNone  GeofenceEvent = iota // no change
+	GeofenceEnter                      // the position moved inside
+	GeofenceExit                       // the position moved outside
+	GeofenceDwell                      // the position stayed inside for the dwell time
+)
+
+// GeofenceOptions are the options of a Geofence.
+type GeofenceOptions struct {
+	// Margin is the hysteresis of the boundary (meters). A position must
+	// be Margin inside the boundary to enter and Margin outside it to
+	// exit, so a stream of noisy fixes along the boundary does not flip
+	// between the two. Zero uses the boundary itself.
+	Margin float64
+	// Dwell is how long a position must stay inside before
+	// GeofenceDwell is reported, once per visit. Zero never reports it.
+	Dwell time.Duration
+}
+
+// Geofence is a polygon or circle boundary that tracks a stream of
+// positions and reports when they enter, exit, or dwell inside it. It is
+// not safe for concurrent use, but any number of fences can share the same
+// Ellipsoid.
+//
+// The boundary and a bounding rectangle of it, grown by the margin, are
+// computed when the fence is created. Positions outside the rectangle are
+// known to be outside by more than the margin without any geodesic
+// solutions, which is the case for most of the positions checked against
+// most fences.
+type Geofence struct {
+	e      *Ellipsoid
+	opts   GeofenceOptions
+	bounds Bounds // the prefilter, see Geofence.Update
+	empty  bool   // whether the boundary has no inside
+
+	// either a ring
+	ring   []LatLng
+	region ringRegion
+
+	// or a circle
+	circle bool
+	center LatLng
+	radius float64
+
+	known, inside, dwelled bool
+	since                  time.Time // time of the last enter
+}
+
+// NewPolygonGeofence returns a fence with a ring boundary.
+//
+// Param ring is the vertices of the ring, as for Ellipsoid.RingContains. The
+// slice is retained and must not be changed while the fence is in use.
+// Param opts are the options, or nil for the defaults.
+//
+// A ring with fewer than three distinct vertices has no inside, so a
+// position is never inside the fence.
+func (e *Ellipsoid) NewPolygonGeofence(ring []LatLng, opts *GeofenceOptions) *Geofence {
+	g := &Geofence{e: e, ring: ring, region: e.newRingRegion(ring)}
+	g.init(opts)
+	if len(g.region.edges) == 0 {
+		g.empty = true
+		return g
+	}
+	g.bounds = e.ExpandBounds(g.region.bounds(), g.opts.Margin)
+	return g
+}
+
+// NewCircleGeofence returns a fence with a geodesic circle boundary.
+//
+// Param center is the center of the circle.
+// Param radius is the radius of the circle (meters).
+// Param opts are the options, or nil for the defaults.
+//
+// A radius of zero or less has no inside, so a position is never inside the
+// fence.
+func (e *Ellipsoid) NewCircleGeofence(center LatLng, radius float64,
+	opts *GeofenceOptions,
+) *Geofence {
+	g := &Geofence{e: e, circle: true, center: center, radius: radius}
+	g.init(opts)
+	if !(radius > 0) {
+		g.empty = true
+		return g
+	}
+	g.bounds = e.ExpandBounds(
+		Bounds{center.Lat, center.Lon, center.Lat, center.Lon},
+		radius+g.opts.Margin)
+	return g
+}
+
+func (g *Geofence) init(opts *GeofenceOptions) {
+	if opts != nil {
+		g.opts = *opts
+	}
+	g.opts.Margin = math.Abs(g.opts.Margin)
+}
+
+// SignedDistance returns the distance from a point to the boundary of the
+// fence, negative inside and positive outside, see
+// Ellipsoid.SignedDistanceToPolygon. It does not use or change the state
+// of the fence.
+func (g *Geofence) SignedDistance(p LatLng) float64 {
+	if g.circle {
+		return g.e.distance(g.center, p) - g.radius
+	}
+	d := g.e.ringBoundaryDistance(p, g.ring)
+	if g.region.contains(p) {
+		return -d
+	}
+	return d
+}
+
+// Contains reports whether a point is inside the boundary of the fence,
+// ignoring the margin. It does not use or change the state of the fence.
+func (g *Geofence) Contains(p LatLng) bool {
+	if g.empty || !g.bounds.contains(p) {
+		return false
+	}
+	if g.circle {
+		return g.e.distance(g.center, p) <= g.radius
+	}
+	return g.region.contains(p)
+}
+
+// Inside reports whether the last position given to Geofence.Update was
+// taken to be inside the fence.
+func (g *Geofence) Inside() bool {
+	return g.inside
+}
+
+// Update moves the tracked position and reports the change of state.
+//
+// Param p is the new position.
+// Param t is the time of the position, which is used for the dwell time.
+// Returns the event, or GeofenceNone if the state did not change.
+//
+// The first position after the fence is created or reset has no earlier
+// state to keep, so it is inside if it is within the boundary, ignoring
+// the margin, and GeofenceEnter is reported if it is. After that a
+// position that is inside must move Margin outside the boundary to exit
+// and one that is outside must move Margin inside to enter. While inside,
+// GeofenceDwell is reported by the first update at least Dwell after the
+// enter.
+func (g *Geofence) Update(p LatLng, t time.Time) GeofenceEvent {
+	inside := g.inside
+	switch {
+	case g.empty || !g.bounds.contains(p):
+		inside = false
+	case !g.known || g.opts.Margin == 0:
+		inside = g.Contains(p)
+	case g.inside:
+		inside = g.SignedDistance(p) <= g.opts.Margin
+	default:
+		inside = g.SignedDistance(p) < -g.opts.Margin
+	}
+	g.known = true
+	switch {
+	case inside && !g.inside:
+		g.inside, g.dwelled, g.since = true, false, t
+		return GeofenceEnter
+	case !inside && g.inside:
+		g.inside = false
+		return GeofenceExit
+	case inside && !g.dwelled && g.opts.Dwell > 0 &&
+		t.Sub(g.since) >= g.opts.Dwell:
+		g.dwelled = true
+		return GeofenceDwell
+	}
+	return GeofenceNone
+}
+
+// Reset forgets the tracked position, so the next Geofence.Update starts
+// afresh.
+func (g *Geofence) Reset() {
+	g.known, g.inside, g.dwelled = false, false, false
+	g.since = time.Time{}
+}
@@ -0,0 +1,103 @@
+package geodesic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeofence(t *testing.T) {
+	// A square about 11 km on a side, and a track heading east across its
+	// west edge at lon 0 with fixes that wobble back and forth by about 5 m.
+	ring := []LatLng{{0, 0}, {0, 0.1}, {0.1, 0.1}, {0.1, 0}}
+	g := WGS84.NewPolygonGeofence(ring, &GeofenceOptions{
+		Margin: 10, Dwell: 40 * time.Second,
+	})
+	t0 := time.Unix(0, 0)
+	type fix struct {
+		lon  float64 // about 0.11 m per 1e-6 degrees
+		want GeofenceEvent
+	}
+	fixes := []fix{
+		{-0.001, GeofenceNone},
+		{-0.00005, GeofenceNone},
+		{0.00005, GeofenceNone},
+		{-0.00005, GeofenceNone},
+		{0.00005, GeofenceNone},
+		{0.00008, GeofenceNone},
+		{0.0002, GeofenceEnter},
+		{-0.00005, GeofenceNone},
+		{0.00005, GeofenceNone},
+		{0.01, GeofenceNone},
+		{0.01, GeofenceDwell},
+		{0.01, GeofenceNone},
+		{-0.0002, GeofenceExit},
+		{0.00005, GeofenceNone},
+	}
+	for i, f := range fixes {
+		ev := g.Update(LatLng{0.05, f.lon}, t0.Add(time.Duration(i)*10*time.Second))
+		if ev != f.want {
+			t.Fatalf("fix %d: expected %v, got %v", i, f.want, ev)
+		}
+	}
+	if g.Inside() {
+		t.Fatalf("expected outside")
+	}
+
+	// The first fix after a reset uses the boundary.
+	g.Reset()
+	if ev := g.Update(LatLng{0.05, 0.00005}, t0); ev != GeofenceEnter {
+		t.Fatalf("expected %v, got %v", GeofenceEnter, ev)
+	}
+	// Far away fixes are rejected by the prefilter.
+	if ev := g.Update(LatLng{40, 100}, t0); ev != GeofenceExit {
+		t.Fatalf("expected %v, got %v", GeofenceExit, ev)
+	}
+	if d := g.SignedDistance(LatLng{0.05, 0.05}); !eqish(d,
+		WGS84.SignedDistanceToPolygon(LatLng{0.05, 0.05}, ring), 6) {
+		t.Fatalf("expected %v, got %v", WGS84.SignedDistanceToPolygon(
+			LatLng{0.05, 0.05}, ring), d)
+	}
+}
+
+func TestCircleGeofence(t *testing.T) {
+	c := LatLng{Lat: 51.5, Lon: -0.1}
+	g := WGS84.NewCircleGeofence(c, 1000, &GeofenceOptions{Margin: 20})
+	at := func(s float64) LatLng {
+		var p LatLng
+		WGS84.Direct(c.Lat, c.Lon, 45, s, &p.Lat, &p.Lon, nil)
+		return p
+	}
+	steps := []struct {
+		s    float64
+		want GeofenceEvent
+	}{
+		{2000, GeofenceNone},
+		{990, GeofenceNone},
+		{970, GeofenceEnter},
+		{1010, GeofenceNone},
+		{1030, GeofenceExit},
+		{5000, GeofenceNone},
+		{0, GeofenceEnter},
+	}
+	for i, st := range steps {
+		if ev := g.Update(at(st.s), time.Time{}); ev != st.want {
+			t.Fatalf("step %d: expected %v, got %v", i, st.want, ev)
+		}
+	}
+	if d := g.SignedDistance(at(1500)); !eqish(d, 500, seriesPrec(6)) {
+		t.Fatalf("expected 500, got %v", d)
+	}
+	if !g.Contains(at(999)) || g.Contains(at(1001)) {
+		t.Fatalf("expected the boundary at 1000 m")
+	}
+
+	// Fences without an inside.
+	for _, g := range []*Geofence{
+		WGS84.NewCircleGeofence(c, 0, nil),
+		WGS84.NewPolygonGeofence([]LatLng{{0, 0}, {1, 1}}, nil),
+	} {
+		if ev := g.Update(c, time.Time{}); ev != GeofenceNone || g.Inside() {
+			t.Fatalf("expected %v, got %v", GeofenceNone, ev)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+module github.com/tidwall/geodesic_cgo/geojsonmeasure
+
+go 1.23
+
+require (
+	github.com/tidwall/geodesic_cgo v1.0.0
+	github.com/tidwall/geojson v1.4.5
+)
+
+require (
+	github.com/tidwall/geoindex v1.4.4 // indirect
+	github.com/tidwall/gjson v1.12.1 // indirect
+	github.com/tidwall/match v1.1.1 // indirect
+	github.com/tidwall/pretty v1.2.0 // indirect
+	github.com/tidwall/rtree v1.3.1 // indirect
+	github.com/tidwall/sjson v1.2.4 // indirect
+)
+
+// The replace builds against the root module of this checkout. The modules
+// that depend on this one ignore it and get the tagged release required
+// above, which is tagged before this module, see CHANGELOG.md.
+replace github.com/tidwall/geodesic_cgo => ../
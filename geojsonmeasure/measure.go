@@ -0,0 +1,161 @@
+// Package geojsonmeasure measures github.com/tidwall/geojson objects on the
+// ellipsoid.
+//
+// The geojson package, used by Tile38, measures with spherical math. These
+// functions return the ellipsoidal geodesic lengths, perimeters, and areas
+// of the same objects. This is a separate module so that the geodesic
+// package itself does not depend on geojson.
+package geojsonmeasure
+
+import (
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+)
+
+// circleSegments is the number of points used to trace the boundary of a
+// geojson.Circle.
+const circleSegments = 360
+
+// walk calls fn on every leaf geometry of obj, unwrapping features and
+// collections.
+func walk(obj geojson.Object, fn func(obj geojson.Object)) {
+	switch g := obj.(type) {
+	case *geojson.Feature:
+		walk(g.Base(), fn)
+	case geojson.Collection:
+		for _, child := range g.Children() {
+			walk(child, fn)
+		}
+	case nil:
+	default:
+		fn(g)
+	}
+}
+
+// series adds the points of s to a new polygon or polyline.
+func series(e *geodesic.Ellipsoid, s geometry.Series, polyline bool) geodesic.Polygon {
+	p := e.PolygonInit(polyline)
+	n := s.NumPoints()
+	if !polyline && n > 1 && s.PointAt(0) == s.PointAt(n-1) {
+		// GeoJSON rings repeat the first point at the end.
+		n--
+	}
+	for i := 0; i < n; i++ {
+		pt := s.PointAt(i)
+		p.AddPoint(pt.Y, pt.X)
+	}
+	return p
+}
+
+// ringArea returns the unsigned area and the perimeter of a ring.
+func ringArea(e *geodesic.Ellipsoid, r geometry.Ring) (area, perimeter float64) {
+	p := series(e, r, false)
+	p.Compute(false, true, &area, &perimeter)
+	if area < 0 {
+		area = -area
+	}
+	return area, perimeter
+}
+
+// circle returns the boundary of a circle as a ring.
+func circle(e *geodesic.Ellipsoid, c *geojson.Circle) geodesic.Polygon {
+	center := c.Center()
+	p := e.PolygonInit(false)
+	for pt := range e.CirclePoints(geodesic.LatLng{Lat: center.Y, Lon: center.X},
+		c.Meters(), circleSegments) {
+		p.AddPoint(pt.Lat, pt.Lon)
+	}
+	return p
+}
+
+// Length returns the total geodesic length of the line strings in obj
+// (meters).
+//
+// Features, multi-geometries, and collections are measured through their
+// children. Points and areal geometries have no length; use Perimeter for
+// the length of the boundary of a polygon.
+func Length(e *geodesic.Ellipsoid, obj geojson.Object) float64 {
+	var total float64
+	walk(obj, func(obj geojson.Object) {
+		if g, ok := obj.(*geojson.LineString); ok {
+			p := series(e, g.Base(), true)
+			var perimeter float64
+			p.Compute(false, false, nil, &perimeter)
+			total += perimeter
+		}
+	})
+	return total
+}
+
+// Area returns the total geodesic area of the areal geometries in obj
+// (meters-squared).
+//
+// Polygon holes are subtracted from their exterior rings, regardless of the
+// winding order of the rings. A geojson.Rect is measured as a latitude and
+// longitude rectangle, with parallels for its northern and southern edges,
+// and a geojson.Circle as a geodesic circle of its radius. Features,
+// multi-geometries, and collections are measured through their children.
+func Area(e *geodesic.Ellipsoid, obj geojson.Object) float64 {
+	var total float64
+	walk(obj, func(obj geojson.Object) {
+		switch g := obj.(type) {
+		case *geojson.Polygon:
+			poly := g.Base()
+			area, _ := ringArea(e, poly.Exterior)
+			for _, hole := range poly.Holes {
+				harea, _ := ringArea(e, hole)
+				area -= harea
+			}
+			total += area
+		case *geojson.Rect:
+			r := g.Base()
+			total += e.RectArea(r.Min.Y, r.Min.X, r.Max.Y, r.Max.X)
+		case *geojson.Circle:
+			// The circle points run clockwise.
+			var area float64
+			p := circle(e, g)
+			p.Compute(true, false, &area, nil)
+			total += area
+		}
+	})
+	return total
+}
+
+// Perimeter returns the total geodesic length of the boundaries of the
+// areal geometries in obj (meters), including polygon holes.
+//
+// See Area for how each type of geometry is treated.
+func Perimeter(e *geodesic.Ellipsoid, obj geojson.Object) float64 {
+	var total float64
+	walk(obj, func(obj geojson.Object) {
+		switch g := obj.(type) {
+		case *geojson.Polygon:
+			poly := g.Base()
+			_, perimeter := ringArea(e, poly.Exterior)
+			total += perimeter
+			for _, hole := range poly.Holes {
+				_, perimeter := ringArea(e, hole)
+				total += perimeter
+			}
+		case *geojson.Rect:
+			r := g.Base()
+			var s12 float64
+			e.Inverse(r.Min.Y, r.Min.X, r.Max.Y, r.Min.X, &s12, nil, nil)
+			total += 2 * s12
+			if r.Max.X-r.Min.X >= 360 {
+				// A rect the width of the world has whole parallels.
+				total += e.ParallelLength(r.Min.Y) + e.ParallelLength(r.Max.Y)
+			} else {
+				total += e.ParallelArcLength(r.Min.Y, r.Min.X, r.Max.X)
+				total += e.ParallelArcLength(r.Max.Y, r.Min.X, r.Max.X)
+			}
+		case *geojson.Circle:
+			var perimeter float64
+			p := circle(e, g)
+			p.Compute(false, false, nil, &perimeter)
+			total += perimeter
+		}
+	})
+	return total
+}
@@ -0,0 +1,83 @@
+package geojsonmeasure
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+)
+
+func parse(t *testing.T, s string) geojson.Object {
+	t.Helper()
+	obj, err := geojson.Parse(s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func eqish(x, y, tol float64) bool {
+	return math.Abs(x-y) <= tol
+}
+
+func TestLength(t *testing.T) {
+	obj := parse(t, `{"type":"Feature","geometry":{"type":"MultiLineString",
+		"coordinates":[[[0,0],[1,0]],[[0,1],[0,2]]]},"properties":{}}`)
+	var s1, s2 float64
+	geodesic.WGS84.Inverse(0, 0, 0, 1, &s1, nil, nil)
+	geodesic.WGS84.Inverse(1, 0, 2, 0, &s2, nil, nil)
+	if d := Length(geodesic.WGS84, obj); !eqish(d, s1+s2, 1e-6) {
+		t.Fatalf("expected %f, got %f", s1+s2, d)
+	}
+	if d := Length(geodesic.WGS84, parse(t, `{"type":"Point","coordinates":[1,2]}`)); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+}
+
+func TestArea(t *testing.T) {
+	obj := parse(t, `{"type":"Polygon","coordinates":[
+		[[0,0],[10,0],[10,10],[0,10],[0,0]],
+		[[2,2],[2,4],[4,4],[4,2],[2,2]]]}`)
+	ext := geodesic.WGS84.PolygonInit(false)
+	for _, p := range [][2]float64{{0, 0}, {0, 10}, {10, 10}, {10, 0}} {
+		ext.AddPoint(p[0], p[1])
+	}
+	hole := geodesic.WGS84.PolygonInit(false)
+	for _, p := range [][2]float64{{2, 2}, {2, 4}, {4, 4}, {4, 2}} {
+		hole.AddPoint(p[0], p[1])
+	}
+	var a1, a2, p1, p2 float64
+	ext.Compute(false, true, &a1, &p1)
+	hole.Compute(false, true, &a2, &p2)
+	if a := Area(geodesic.WGS84, obj); !eqish(a, a1-a2, 1) {
+		t.Fatalf("expected %f, got %f", a1-a2, a)
+	}
+	if p := Perimeter(geodesic.WGS84, obj); !eqish(p, p1+p2, 1e-6) {
+		t.Fatalf("expected %f, got %f", p1+p2, p)
+	}
+	// A small circle is nearly planar.
+	c := geojson.NewCircle(geometry.Point{X: 10, Y: 50}, 1000, 64)
+	want := math.Pi * 1000 * 1000
+	if a := Area(geodesic.WGS84, c); !eqish(a, want, want*1e-3) {
+		t.Fatalf("expected about %f, got %f", want, a)
+	}
+	r := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -180, Y: -90}, Max: geometry.Point{X: 180, Y: 90},
+	})
+	if a := Area(geodesic.WGS84, r); !eqish(a/1e6, 510065621.724, 1e-2) {
+		t.Fatalf("expected %f, got %f", 510065621.724, a/1e6)
+	}
+	// The parallels of a rect the width of the world go all the way around.
+	band := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -180, Y: -10}, Max: geometry.Point{X: 180, Y: 20},
+	})
+	var s12 float64
+	geodesic.WGS84.Inverse(-10, -180, 20, -180, &s12, nil, nil)
+	want = 2*s12 + geodesic.WGS84.ParallelLength(-10) +
+		geodesic.WGS84.ParallelLength(20)
+	if p := Perimeter(geodesic.WGS84, band); !eqish(p, want, 1e-6) {
+		t.Fatalf("expected %f, got %f", want, p)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/tidwall/geodesic_cgo
 
-go 1.17
+go 1.23
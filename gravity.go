@@ -0,0 +1,85 @@
+package geodesic
+
+import "math"
+
+// The geocentric gravitational constant (meters-cubed per second-squared)
+// and angular velocity (radians per second) of the earth, as defined for
+// WGS84. GRS80 uses the same angular velocity and a GM of 3.986005e14.
+const (
+	EarthGM    = 3.986004418e14
+	EarthOmega = 7.292115e-5
+)
+
+// Gravity returns the normal gravity of the earth at a point, which is
+// the gravity of the level ellipsoid, including the centrifugal effect of
+// the rotation (meters per second-squared).
+//
+// Param lat is the latitude of the point (degrees).
+// Param height is the height of the point above the ellipsoid (meters).
+//
+// This is Ellipsoid.NormalGravity with EarthGM and EarthOmega, so it is the
+// WGS84 normal gravity on WGS84. Use NormalGravity directly for GRS80 or
+// other bodies.
+func (e *Ellipsoid) Gravity(lat, height float64) float64 {
+	return e.NormalGravity(lat, height, EarthGM, EarthOmega)
+}
+
+// NormalGravity returns the normal gravity at a point for a body with the
+// shape of the ellipsoid (meters per second-squared).
+//
+// Param lat is the latitude of the point (degrees).
+// Param height is the height of the point above the ellipsoid (meters).
+// Param gm is the geocentric gravitational constant of the body
+// (meters-cubed per second-squared).
+// Param omega is the angular velocity of the body (radians per second).
+//
+// On the surface this is the closed form of Somigliana, with the gravity
+// at the equator and poles given by the theory of the level ellipsoid, as
+// in Moritz, "Geodetic Reference System 1980". Above the surface it uses
+// the second order expansion in height from NIMA TR8350.2, which is meant
+// for heights within the atmosphere rather than for orbits.
+func (e *Ellipsoid) NormalGravity(lat, height, gm, omega float64) float64 {
+	a, f := e.Radius(), e.Flattening()
+	b := a * (1 - f)
+	m := omega * omega * a * a * b / gm
+	// r is e' q0' / q0 where e' is the second eccentricity.
+	r := levelRatio((a*a - b*b) / (b * b))
+	ge := gm / (a * b) * (1 - m - m/6*r)
+	gp := gm / (a * a) * (1 + m/3*r)
+	sphi, cphi := sincosd(lat)
+	g := (a*ge*cphi*cphi + b*gp*sphi*sphi) /
+		math.Sqrt(a*a*cphi*cphi+b*b*sphi*sphi)
+	if height != 0 {
+		g *= 1 - 2/a*(1+f+m-2*f*sphi*sphi)*height + 3*height*height/(a*a)
+	}
+	return g
+}
+
+// levelRatio returns e' q0' / q0 of the level ellipsoid as a function of
+// x = e'^2, where q0 and q0' are the functions of the second eccentricity
+// e' in Moritz. It is 3 for a sphere, and both are tiny near one, so a
+// series is used there.
+func levelRatio(x float64) float64 {
+	if math.Abs(x) < 0.1 {
+		// q0' / x and q0 / (e' x) / 2 as series in x.
+		var num, den float64
+		t := 1.0
+		for k := 1; k <= 30; k++ {
+			d := float64((2*k + 1) * (2*k + 3))
+			num += t * 6 / d
+			den += t * 4 * float64(k) / d
+			t *= -x
+		}
+		return 2 * num / den
+	}
+	// A is atan(e') / e', continued for a prolate ellipsoid.
+	var arc float64
+	if x > 0 {
+		arc = math.Atan(math.Sqrt(x)) / math.Sqrt(x)
+	} else {
+		arc = math.Atanh(math.Sqrt(-x)) / math.Sqrt(-x)
+	}
+	q0 := (1+3/x)*arc - 3/x
+	q1 := 3*(1+1/x)*(1-arc) - 1
+	return 2 * q1 / q0
+}
@@ -0,0 +1,43 @@
+package geodesic
+
+import "testing"
+
+func TestGravity(t *testing.T) {
+	// Equatorial and polar normal gravity from NIMA TR8350.2, table 3.4.
+	if g := WGS84.Gravity(0, 0); !eqish(g, 9.7803253359, 9) {
+		t.Fatalf("expected %v, got %v", 9.7803253359, g)
+	}
+	if g := WGS84.Gravity(90, 0); !eqish(g, 9.8321849378, 9) {
+		t.Fatalf("expected %v, got %v", 9.8321849378, g)
+	}
+	if g := WGS84.Gravity(-90, 0); !eqish(g, 9.8321849378, 9) {
+		t.Fatalf("expected %v, got %v", 9.8321849378, g)
+	}
+	// GRS80 from Moritz.
+	grs80 := NewEllipsoid(6378137, 1/298.257222101)
+	if g := grs80.NormalGravity(0, 0, 3.986005e14, EarthOmega); !eqish(g, 9.7803267715, 9) {
+		t.Fatalf("expected %v, got %v", 9.7803267715, g)
+	}
+	if g := grs80.NormalGravity(90, 0, 3.986005e14, EarthOmega); !eqish(g, 9.8321863685, 9) {
+		t.Fatalf("expected %v, got %v", 9.8321863685, g)
+	}
+	// The free-air gradient is about 0.3086 mGal per meter of height.
+	dg := WGS84.Gravity(45, 0) - WGS84.Gravity(45, 1000)
+	if !eqish(dg, 3.086e-3, 5) {
+		t.Fatalf("expected about %v, got %v", 3.086e-3, dg)
+	}
+
+	// A non-rotating sphere has the gravity of a point mass.
+	moon := NewEllipsoid(1737400, 0)
+	const gm = 4.9028e12
+	if g, want := moon.NormalGravity(30, 0, gm, 0), gm/(1737400.0*1737400); !eqish(g, want, 12) {
+		t.Fatalf("expected %v, got %v", want, g)
+	}
+	// The series and closed form agree where they meet.
+	if r1, r2 := levelRatio(0.1-1e-12), levelRatio(0.1); !eqish(r1, r2, 10) {
+		t.Fatalf("expected %v, got %v", r1, r2)
+	}
+	if r1, r2 := levelRatio(-0.1+1e-12), levelRatio(-0.1); !eqish(r1, r2, 10) {
+		t.Fatalf("expected %v, got %v", r1, r2)
+	}
+}
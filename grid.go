@@ -0,0 +1,110 @@
+package geodesic
+
+import "math"
+
+// GridCell is a cell of a latitude/longitude grid.
+type GridCell struct {
+	Row, Col int     // position in the grid, counting from the south-west
+	Bounds   Bounds  // the cell, bounded by parallels and meridians
+	Area     float64 // exact area of the cell (meters-squared)
+}
+
+// Graticule returns the cells of a regular latitude/longitude grid.
+//
+// Param b is the area to cover.
+// Param latStep is the height of a cell (degrees).
+// Param lonStep is the width of a cell (degrees).
+// Returns the cells, row by row going north and west to east in a row.
+//
+// The grid starts at the south-west corner of b, and the cells of the
+// last row and column are cut short at the edges of b. Each cell carries
+// its area from Ellipsoid.RectArea, so sums over cells can be weighted by
+// area without redoing the zone area math: a one degree cell near a pole
+// is a small fraction of the size of one at the equator. Returns nil if a
+// step is not positive.
+func (e *Ellipsoid) Graticule(b Bounds, latStep, lonStep float64) []GridCell {
+	if !(latStep > 0) || !(lonStep > 0) {
+		return nil
+	}
+	var cells []GridCell
+	lats := gridSteps(b.MinLat, b.MaxLat-b.MinLat, latStep)
+	for i := 0; i+1 < len(lats); i++ {
+		cells = e.appendGridRow(cells, b, i, lats[i], lats[i+1], lonStep)
+	}
+	return cells
+}
+
+// Fishnet returns the cells of a grid with a fixed spacing on the ground.
+//
+// Param b is the area to cover.
+// Param spacing is the height and width of a cell (meters).
+// Returns the cells, row by row going north and west to east in a row.
+//
+// The rows are spacing apart along the meridian. In each row the cells
+// are spacing wide along the parallel through the middle of the row, so
+// rows nearer a pole have fewer and wider cells in longitude and the
+// cells are close to square on the ground everywhere. As for
+// Ellipsoid.Graticule, the cells are bounded by parallels and meridians,
+// those at the edges of b are cut short, and each carries its exact area.
+// Returns nil if spacing is not positive.
+func (e *Ellipsoid) Fishnet(b Bounds, spacing float64) []GridCell {
+	if !(spacing > 0) {
+		return nil
+	}
+	var height float64
+	e.Inverse(b.MinLat, 0, b.MaxLat, 0, &height, nil, nil)
+	n := int(math.Ceil(height/spacing - 1e-9))
+	lats := make([]float64, 0, n+1)
+	for i := 0; i < n; i++ {
+		var lat float64
+		e.Direct(b.MinLat, 0, 0, float64(i)*spacing, &lat, nil, nil)
+		lats = append(lats, lat)
+	}
+	lats = append(lats, b.MaxLat)
+	var cells []GridCell
+	for i := 0; i+1 < len(lats); i++ {
+		r := e.parallelRadius((lats[i] + lats[i+1]) / 2)
+		lonStep := spacing / r * (180 / math.Pi)
+		cells = e.appendGridRow(cells, b, i, lats[i], lats[i+1], lonStep)
+	}
+	return cells
+}
+
+// gridSteps returns the values from start, step apart, up to start+span,
+// which is always the last.
+func gridSteps(start, span, step float64) []float64 {
+	n := int(math.Ceil(span/step - 1e-9))
+	steps := make([]float64, 0, n+1)
+	for i := 0; i < n; i++ {
+		steps = append(steps, start+float64(i)*step)
+	}
+	return append(steps, start+span)
+}
+
+// appendGridRow appends a row of cells between the parallels at minLat
+// and maxLat, across the longitudes of b.
+func (e *Ellipsoid) appendGridRow(cells []GridCell, b Bounds, row int,
+	minLat, maxLat, lonStep float64,
+) []GridCell {
+	// A cell wider than the bounds is cut down to one cell.
+	lonStep = math.Min(lonStep, 360)
+	lons := gridSteps(b.MinLon, b.lonSpan(), lonStep)
+	for j := 0; j+1 < len(lons); j++ {
+		c := GridCell{Row: row, Col: j, Bounds: Bounds{
+			MinLat: minLat, MinLon: gridLon(lons[j]),
+			MaxLat: maxLat, MaxLon: gridLon(lons[j+1]),
+		}}
+		c.Area = e.RectArea(minLat, lons[j], maxLat, lons[j+1])
+		cells = append(cells, c)
+	}
+	return cells
+}
+
+// gridLon reduces a longitude that may be past the antimeridian to the
+// range [-180,+180].
+func gridLon(lon float64) float64 {
+	if lon > 180 {
+		lon -= 360
+	}
+	return lon
+}
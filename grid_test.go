@@ -0,0 +1,95 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGraticule(t *testing.T) {
+	cells := WGS84.Graticule(Bounds{-90, -180, 90, 180}, 10, 10)
+	if len(cells) != 18*36 {
+		t.Fatalf("expected %d, got %d", 18*36, len(cells))
+	}
+	var sum float64
+	for _, c := range cells {
+		sum += c.Area
+	}
+	if !eqish(sum/WGS84.totalArea(), 1, 12) {
+		t.Fatalf("expected %v, got %v", WGS84.totalArea(), sum)
+	}
+	// Cells near the poles are much smaller.
+	if c := cells[0]; c.Row != 0 || c.Col != 0 ||
+		c.Bounds != (Bounds{-90, -180, -80, -170}) ||
+		!(c.Area < cells[9*36].Area/5) {
+		t.Fatalf("unexpected first cell %v", c)
+	}
+
+	// Cut short at the edges, and across the antimeridian.
+	cells = WGS84.Graticule(Bounds{0, 170, 2.5, -175}, 1, 10)
+	if len(cells) != 6 {
+		t.Fatalf("expected 6, got %d", len(cells))
+	}
+	last := cells[len(cells)-1]
+	if last.Row != 2 || last.Col != 1 ||
+		last.Bounds != (Bounds{2, 180, 2.5, -175}) {
+		t.Fatalf("unexpected last cell %v", last)
+	}
+	if a := WGS84.RectArea(2, 180, 2.5, -175); last.Area != a {
+		t.Fatalf("expected %v, got %v", a, last.Area)
+	}
+
+	if WGS84.Graticule(Bounds{0, 0, 1, 1}, 0, 1) != nil {
+		t.Fatalf("expected nil")
+	}
+}
+
+func TestFishnet(t *testing.T) {
+	b := Bounds{40, -10, 70, 30}
+	const spacing = 100e3
+	cells := WGS84.Fishnet(b, spacing)
+	var sum float64
+	for _, c := range cells {
+		sum += c.Area
+		mid := (c.Bounds.MinLat + c.Bounds.MaxLat) / 2
+		var height float64
+		WGS84.Inverse(c.Bounds.MinLat, 0, c.Bounds.MaxLat, 0, &height, nil, nil)
+		width := WGS84.ParallelArcLength(mid, c.Bounds.MinLon, c.Bounds.MaxLon)
+		tol := seriesTolerance(1e-6)
+		if height > spacing+tol || width > spacing+tol {
+			t.Fatalf("%v: expected at most %v, got %v by %v",
+				c, spacing, height, width)
+		}
+		// Only the last row and column are cut short.
+		prec := seriesPrec(6)
+		if c.Bounds.MaxLat < b.MaxLat && !eqish(height, spacing, prec) ||
+			c.Bounds.MaxLon < b.MaxLon && !eqish(width, spacing, prec) {
+			t.Fatalf("%v: expected %v, got %v by %v", c, spacing, height, width)
+		}
+	}
+	if a := WGS84.RectArea(b.MinLat, b.MinLon, b.MaxLat, b.MaxLon); !eqish(sum/a, 1, seriesPrec(12)) {
+		t.Fatalf("expected %v, got %v", a, sum)
+	}
+	// The northern rows have fewer cells.
+	last := cells[len(cells)-1].Row
+	var n0, n1 int
+	for _, c := range cells {
+		switch c.Row {
+		case 0:
+			n0++
+		case last:
+			n1++
+		}
+	}
+	if !(n1 < n0) {
+		t.Fatalf("expected fewer cells in the north, got %d and %d", n0, n1)
+	}
+
+	// Up to the pole.
+	cells = WGS84.Fishnet(Bounds{89, 0, 90, 10}, 50e3)
+	if c := cells[len(cells)-1]; c.Bounds.MaxLat != 90 || math.IsNaN(c.Area) {
+		t.Fatalf("unexpected last cell %v", c)
+	}
+	if WGS84.Fishnet(b, -1) != nil {
+		t.Fatalf("expected nil")
+	}
+}
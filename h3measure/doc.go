@@ -0,0 +1,14 @@
+// Package h3measure measures github.com/uber/h3-go cells and edges on the
+// ellipsoid.
+//
+// H3 places its cells on a sphere, and the areas and edge lengths it
+// reports, including the published averages per resolution, are
+// spherical. These functions treat the boundary vertices of a cell as
+// geodetic coordinates and return the ellipsoidal area and lengths of the
+// geodesic polygon through them, which is useful for checking how far the
+// spherical figures are from the ground truth on a given ellipsoid.
+//
+// This is a separate module so that the geodesic package itself does not
+// depend on h3-go. The h3-go bindings need cgo, so the functions are only
+// built when cgo is enabled.
+package h3measure
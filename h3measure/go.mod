@@ -0,0 +1,16 @@
+module github.com/tidwall/geodesic_cgo/h3measure
+
+// Unlike the root module, this one needs go 1.24, because
+// github.com/uber/h3-go/v4 v4.5.0 declares it and the go command does not
+// allow a module to declare an older version than its dependencies.
+go 1.24
+
+require (
+	github.com/tidwall/geodesic_cgo v1.0.0
+	github.com/uber/h3-go/v4 v4.5.0
+)
+
+// The replace builds against the root module of this checkout. The modules
+// that depend on this one ignore it and get the tagged release required
+// above, which is tagged before this module, see CHANGELOG.md.
+replace github.com/tidwall/geodesic_cgo => ../
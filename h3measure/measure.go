@@ -0,0 +1,91 @@
+//go:build cgo
+
+package h3measure
+
+import (
+	"math"
+
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/uber/h3-go/v4"
+)
+
+// ring converts an H3 boundary to points.
+func ring(b h3.CellBoundary) []geodesic.LatLng {
+	pts := make([]geodesic.LatLng, len(b))
+	for i, v := range b {
+		pts[i] = geodesic.LatLng{Lat: v.Lat, Lon: v.Lng}
+	}
+	return pts
+}
+
+// polygon returns the area and perimeter of a boundary, which H3 lists
+// counter-clockwise, whatever the area convention of e.
+func polygon(e *geodesic.Ellipsoid, b h3.CellBoundary) (area, perimeter float64) {
+	p := e.PolygonInit(false)
+	for _, v := range b {
+		p.AddPoint(v.Lat, v.Lng)
+	}
+	p.Compute(false, true, &area, &perimeter)
+	return math.Abs(area), perimeter
+}
+
+// CellArea returns the area of an H3 cell on the ellipsoid
+// (meters-squared).
+//
+// The boundary of the cell, which for some cells has extra vertices where
+// it crosses an icosahedron edge, is taken as a geodesic polygon. Returns
+// any error from h3-go.
+func CellArea(e *geodesic.Ellipsoid, c h3.Cell) (float64, error) {
+	b, err := c.Boundary()
+	if err != nil {
+		return 0, err
+	}
+	area, _ := polygon(e, b)
+	return area, nil
+}
+
+// CellPerimeter returns the perimeter of an H3 cell on the ellipsoid
+// (meters). See CellArea.
+func CellPerimeter(e *geodesic.Ellipsoid, c h3.Cell) (float64, error) {
+	b, err := c.Boundary()
+	if err != nil {
+		return 0, err
+	}
+	_, perimeter := polygon(e, b)
+	return perimeter, nil
+}
+
+// EdgeLength returns the length of an H3 directed edge on the ellipsoid
+// (meters).
+//
+// The edge is the geodesic polyline through its boundary vertices. Returns
+// any error from h3-go.
+func EdgeLength(e *geodesic.Ellipsoid, edge h3.DirectedEdge) (float64, error) {
+	b, err := edge.Boundary()
+	if err != nil {
+		return 0, err
+	}
+	return geodesic.PolylineLength(e, ring(b)), nil
+}
+
+// CellEdgeLengths returns the lengths of the edges of an H3 cell on the
+// ellipsoid, in the order of the cell's directed edges (meters). Pentagons
+// have five edges and hexagons six.
+func CellEdgeLengths(e *geodesic.Ellipsoid, c h3.Cell) ([]float64, error) {
+	edges, err := c.DirectedEdges()
+	if err != nil {
+		return nil, err
+	}
+	lengths := make([]float64, 0, len(edges))
+	for _, edge := range edges {
+		if edge == 0 {
+			continue // the missing edge of a pentagon
+		}
+		s, err := EdgeLength(e, edge)
+		if err != nil {
+			return nil, err
+		}
+		lengths = append(lengths, s)
+	}
+	return lengths, nil
+}
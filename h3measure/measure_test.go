@@ -0,0 +1,71 @@
+//go:build cgo
+
+package h3measure
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo"
+	"github.com/uber/h3-go/v4"
+)
+
+func TestCellArea(t *testing.T) {
+	e := geodesic.WGS84
+	c, err := h3.LatLngToCell(h3.NewLatLng(37.775, -122.418), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area, err := CellArea(e, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// H3 uses a sphere, which differs from the ellipsoid by well under a
+	// percent for a cell this size.
+	sphere, err := h3.CellAreaM2(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(area/sphere-1) > 0.01 {
+		t.Fatalf("expected about %v, got %v", sphere, area)
+	}
+
+	// Reversing the convention does not change the sign.
+	cw := e.WithAreas(geodesic.AreaClockwise)
+	if area2, _ := CellArea(cw, c); area2 != area {
+		t.Fatalf("expected %v, got %v", area, area2)
+	}
+
+	perimeter, err := CellPerimeter(e, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lengths, err := CellEdgeLengths(e, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lengths) != 6 {
+		t.Fatalf("expected 6 edges, got %d", len(lengths))
+	}
+	var sum float64
+	for _, s := range lengths {
+		sum += s
+	}
+	if math.Abs(sum-perimeter) > 1e-6 {
+		t.Fatalf("expected %v, got %v", perimeter, sum)
+	}
+}
+
+func TestPentagonEdges(t *testing.T) {
+	pentagons, err := h3.Pentagons(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lengths, err := CellEdgeLengths(geodesic.WGS84, pentagons[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lengths) != 5 {
+		t.Fatalf("expected 5 edges, got %d", len(lengths))
+	}
+}
@@ -0,0 +1,137 @@
+package geodesic
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo/internal/gendata"
+)
+
+// TestHardCases checks the corpus of hard cases in testdata/hard.txt,
+// written by "go run ./cmd/gentestdata -hard", against the values the C
+// library gave when it was written.
+func TestHardCases(t *testing.T) {
+	fullOrder(t)
+	f, err := os.Open("testdata/hard.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := gendata.Read(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Inverse) == 0 || len(d.Polygons) == 0 {
+		t.Fatalf("expected inverse problems and polygons, got %d and %d",
+			len(d.Inverse), len(d.Polygons))
+	}
+	for _, c := range d.Inverse {
+		// The azimuths of the shortest geodesics depend on the rounding of
+		// the coordinates, about a nanometer, so they are checked less
+		// tightly.
+		var s12, azi1, azi2 float64
+		WGS84.Inverse(c.Lat1, c.Lon1, c.Lat2, c.Lon2, &s12, &azi1, &azi2)
+		atol := 1e-7 + 1e-6/c.S12
+		if !eqish(s12, c.S12, 7) || !sameAzimuth(azi1, c.Azi1, atol) ||
+			!sameAzimuth(azi2, c.Azi2, atol) {
+			t.Fatalf("%v: expected %v %v %v, got %v %v %v", c, c.S12, c.Azi1,
+				c.Azi2, s12, azi1, azi2)
+		}
+		// The longitude of a point at a pole means nothing, so Direct is
+		// checked by the distance to point 2.
+		var p LatLng
+		WGS84.Direct(c.Lat1, c.Lon1, c.Azi1, c.S12, &p.Lat, &p.Lon, nil)
+		if dist := WGS84.distance(p, LatLng{c.Lat2, c.Lon2}); dist > 1e-7 {
+			t.Fatalf("%v: expected to land on point 2, got %v, %v away", c, p,
+				dist)
+		}
+	}
+	// The areas are sums of terms as large as the area of the earth, whose
+	// ulp is 0.0625 m^2, so they are checked to a few of its ulps.
+	for _, poly := range d.Polygons {
+		p := WGS84.PolygonInit(false)
+		for _, pt := range poly.Points {
+			p.AddPoint(pt[0], pt[1])
+		}
+		for i, flags := range gendata.PolygonFlags {
+			var area, perimeter float64
+			p.Compute(flags[0], flags[1], &area, &perimeter)
+			want := poly.Results[i]
+			if math.Abs(area-want[0]) > 0.5 ||
+				!eqish(perimeter, want[1], 7) {
+				t.Fatalf("%v: expected %f, got %f", poly.Points, want,
+					[2]float64{area, perimeter})
+			}
+		}
+		p.Close()
+	}
+}
+
+// TestGeodSolve checks the cases of the GeodSolve tests of GeographicLib
+// that cover the hard cases, with the values and tolerances published
+// there.
+func TestGeodSolve(t *testing.T) {
+	prolate150 := NewEllipsoid(6.4e6, -1/150.0)
+	prolate300 := NewEllipsoid(6.4e6, -1/300.0)
+	for _, c := range []struct {
+		name                   string
+		e                      *Ellipsoid
+		lat1, lon1, lat2, lon2 float64
+		s12, azi1, azi2        float64
+		stol, atol             float64
+	}{
+		{"0", WGS84, 40.6, -73.8, 49.01666667, 2.55,
+			5853226, 53.47022, 111.59367, 0.5, 0.5e-5},
+		{"2", prolate150, 0.07476, 0, -0.07476, 180,
+			20106193, 90.00078, 90.00078, 0.5, 0.5e-5},
+		{"2", prolate150, 0.1, 0, -0.1, 180,
+			20106193, 90.00105, 90.00105, 0.5, 0.5e-5},
+		{"4", WGS84, 36.493349428792, 0, 36.49334942879201, .0000008,
+			0.072, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"6", WGS84, 88.202499451857, 0, -88.202499451857, 179.981022032992859592,
+			20003898.214, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"6", WGS84, 89.262080389218, 0, -89.262080389218, 179.992207982775375662,
+			20003925.854, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"6", WGS84, 89.333123580033, 0, -89.333123580032997687, 179.99295812360148422,
+			20003926.881, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"9", WGS84, 56.320923501171, 0, -56.320923501171, 179.664747671772880215,
+			19993558.287, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"10", WGS84, 52.784459512564, 0, -52.784459512563990912, 179.634407464943777557,
+			19991596.095, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"11", WGS84, 48.522876735459, 0, -48.52287673545898293, 179.599720456223079643,
+			19989144.774, math.NaN(), math.NaN(), 0.5e-3, 0},
+		{"33", WGS84, 0, 0, 0, 179, 19926189, 90, 90, 0.5, 0.5e-5},
+		{"33", WGS84, 0, 0, 0, 179.5, 19980862, 55.96650, 124.03350, 0.5, 0.5e-5},
+		{"33", WGS84, 0, 0, 0, 180, 20003931, 0, 180, 0.5, 0.5e-5},
+		{"33", WGS84, 0, 0, 1, 180, 19893357, 0, 180, 0.5, 0.5e-5},
+		{"33", prolate300, 0, 0, 0, 179, 19994492, 90, 90, 0.5, 0.5e-5},
+		{"33", prolate300, 0, 0, 0, 180, 20106193, 90, 90, 0.5, 0.5e-5},
+		{"33", prolate300, 0, 0, 1, 180, 20027270, 0, 180, 0.5, 0.5e-5},
+	} {
+		var s12, azi1, azi2 float64
+		c.e.Inverse(c.lat1, c.lon1, c.lat2, c.lon2, &s12, &azi1, &azi2)
+		if math.Abs(s12-c.s12) > c.stol ||
+			!math.IsNaN(c.azi1) && math.Abs(azi1-c.azi1) > c.atol ||
+			!math.IsNaN(c.azi2) && math.Abs(azi2-c.azi2) > c.atol {
+			t.Fatalf("GeodSolve%s: expected %v %v %v, got %v %v %v", c.name,
+				c.s12, c.azi1, c.azi2, s12, azi1, azi2)
+		}
+	}
+
+	// GeodSolve1 and GeodSolve5, which are direct problems. The second
+	// ends at the pole, where the longitude and azimuth may be either of
+	// two pairs.
+	var lat2, lon2, azi2 float64
+	WGS84.Direct(40.63972222, -73.77888889, 53.5, 5850e3, &lat2, &lon2, &azi2)
+	if !eqish(lat2, 49.01467, 5) || !eqish(lon2, 2.56106, 5) ||
+		!eqish(azi2, 111.62947, 5) {
+		t.Fatalf("GeodSolve1: expected 49.01467 2.56106 111.62947, got %v %v %v",
+			lat2, lon2, azi2)
+	}
+	WGS84.Direct(0.01777745589997, 30, 0, 10e6, &lat2, &lon2, &azi2)
+	if !eqish(lat2, 90, 5) || !(eqish(lon2, -150, 5) && eqish(math.Abs(azi2), 180, 5) ||
+		eqish(lon2, 30, 5) && eqish(azi2, 0, 5)) {
+		t.Fatalf("GeodSolve5: expected the pole, got %v %v %v", lat2, lon2, azi2)
+	}
+}
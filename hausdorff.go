@@ -0,0 +1,45 @@
+package geodesic
+
+import "math"
+
+// DirectedHausdorffDistance returns the directed Hausdorff distance from the
+// points in a to the points in b.
+//
+// Param a is the source point set.
+// Param b is the target point set.
+// Returns the distance (meters).
+//
+// This is the largest geodesic distance from a point in a to its nearest
+// point in b. It returns NaN if either set is empty. To compare polylines
+// rather than their vertices, densify them first with Ellipsoid.Resample.
+func (e *Ellipsoid) DirectedHausdorffDistance(a, b []LatLng) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return math.NaN()
+	}
+	var worst float64
+	for _, p := range a {
+		nearest := math.Inf(1)
+		for _, q := range b {
+			d := e.distance(p, q)
+			if d < nearest {
+				nearest = d
+				if nearest <= worst {
+					// This point cannot raise the maximum.
+					break
+				}
+			}
+		}
+		if nearest > worst {
+			worst = nearest
+		}
+	}
+	return worst
+}
+
+// HausdorffDistance returns the symmetric Hausdorff distance between the
+// points in a and the points in b, which is the larger of the two directed
+// distances. See Ellipsoid.DirectedHausdorffDistance.
+func (e *Ellipsoid) HausdorffDistance(a, b []LatLng) float64 {
+	return math.Max(e.DirectedHausdorffDistance(a, b),
+		e.DirectedHausdorffDistance(b, a))
+}
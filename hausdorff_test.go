@@ -0,0 +1,25 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHausdorffDistance(t *testing.T) {
+	a := []LatLng{{0, 0}, {0, 1}, {0, 2}}
+	b := []LatLng{{0, 0}, {0, 1}, {0, 2}, {1, 1}}
+	if d := WGS84.DirectedHausdorffDistance(a, b); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+	var s12 float64
+	WGS84.Inverse(1, 1, 0, 1, &s12, nil, nil)
+	if d := WGS84.DirectedHausdorffDistance(b, a); !eqish(d, s12, 7) {
+		t.Fatalf("expected %f, got %f", s12, d)
+	}
+	if d := WGS84.HausdorffDistance(a, b); !eqish(d, s12, 7) {
+		t.Fatalf("expected %f, got %f", s12, d)
+	}
+	if d := WGS84.HausdorffDistance(a, nil); !math.IsNaN(d) {
+		t.Fatalf("expected NaN, got %f", d)
+	}
+}
@@ -0,0 +1,35 @@
+package geodesic
+
+// DirectWithAzimuths solves the direct geodesic problem, as
+// Ellipsoid.Direct, and also reports the azimuth of the geodesic at a
+// number of distances along the way.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+// Param at is the distances from point 1 of the intermediate points
+// (meters). negative is ok.
+// Out param lat2 is a pointer to the latitude of point 2 (degrees).
+// Out param lon2 is a pointer to the longitude of point 2 (degrees).
+// Out param azi2 is a pointer to the (forward) azimuth at point 2 (degrees).
+// Returns the (forward) azimuth at each distance of at, in order
+// (degrees).
+//
+// The course along a geodesic changes continuously, so this gives the
+// heading to steer at each point of a leg, such as at fixed times of a
+// simulation, with the line set up only once. The distances need not be in
+// order, nor within [0,s12]. The azimuths follow the convention of the
+// ellipsoid, see Ellipsoid.WithAzimuths. Any of the "return" arguments,
+// lat2, etc., may be replaced with nil.
+func (e *Ellipsoid) DirectWithAzimuths(lat1, lon1, azi1, s12 float64,
+	at []float64, lat2, lon2, azi2 *float64,
+) []float64 {
+	l := e.DirectLine(lat1, lon1, azi1, s12)
+	l.Position(s12, lat2, lon2, azi2)
+	azis := make([]float64, len(at))
+	for i, s := range at {
+		l.Position(s, nil, nil, &azis[i])
+	}
+	return azis
+}
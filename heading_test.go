@@ -0,0 +1,43 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDirectWithAzimuths(t *testing.T) {
+	at := []float64{0, 1e6, 5e6, -2e6, 12e6}
+	var lat2, lon2, azi2 float64
+	azis := WGS84.DirectWithAzimuths(40.64, -73.78, 30, 1e7, at, &lat2, &lon2,
+		&azi2)
+	var wlat2, wlon2, wazi2 float64
+	WGS84.Direct(40.64, -73.78, 30, 1e7, &wlat2, &wlon2, &wazi2)
+	if lat2 != wlat2 || lon2 != wlon2 || azi2 != wazi2 {
+		t.Fatalf("expected %v %v %v, got %v %v %v", wlat2, wlon2, wazi2,
+			lat2, lon2, azi2)
+	}
+	if len(azis) != len(at) || !eqish(azis[0], 30, seriesPrec(12)) {
+		t.Fatalf("expected %d azimuths starting at 30, got %v", len(at), azis)
+	}
+	for i, s := range at {
+		var want float64
+		WGS84.Direct(40.64, -73.78, 30, s, nil, nil, &want)
+		if !eqish(azis[i], want, 10) {
+			t.Fatalf("%v: expected %v, got %v", s, want, azis[i])
+		}
+	}
+
+	// The azimuths follow the convention of the ellipsoid, and nil outputs
+	// are fine.
+	azis = WGS84.WithAzimuths(AzimuthUnsigned).DirectWithAzimuths(0, 0, -90,
+		1e6, []float64{5e5}, nil, nil, nil)
+	if !eqish(azis[0], 270, 10) {
+		t.Fatalf("expected 270, got %v", azis)
+	}
+	if azis := WGS84.DirectWithAzimuths(0, 0, 10, 1, nil, nil, nil, nil); len(azis) != 0 {
+		t.Fatalf("expected no azimuths, got %v", azis)
+	}
+	if azis := WGS84.DirectWithAzimuths(91, 0, 10, 1, []float64{0}, nil, nil, nil); !math.IsNaN(azis[0]) {
+		t.Fatalf("expected NaN, got %v", azis)
+	}
+}
@@ -0,0 +1,50 @@
+package geodesic
+
+import "cmp"
+
+// DistanceToBox returns the geodesic distance from a point to the nearest
+// point of a latitude/longitude rectangle (meters), which is zero when the
+// point is inside.
+//
+// Param p is the point.
+// Param minLat is the southern latitude (degrees).
+// Param minLon is the western longitude (degrees).
+// Param maxLat is the northern latitude (degrees).
+// Param maxLon is the eastern longitude (degrees).
+//
+// The rectangle runs east from minLon to maxLon, so if minLon is greater
+// than maxLon then the rectangle crosses the antimeridian. No point inside
+// the rectangle is nearer to p than the result, so it is a valid lower
+// bound for pruning spatial index searches of the items within the box.
+func (e *Ellipsoid) DistanceToBox(p LatLng, minLat, minLon, maxLat, maxLon float64) float64 {
+	return e.distanceToBounds(p.Lat, p.Lon, Bounds{minLat, minLon, maxLat, maxLon})
+}
+
+// NearbyDist returns a distance function for the nearest neighbor search of
+// an r-tree, such as the Nearby method of github.com/tidwall/rtree, that
+// orders its rectangles and items by geodesic distance from a point.
+//
+// Param e is the ellipsoid.
+// Param p is the point to search from.
+// Returns the distance function.
+//
+// The rectangles must store longitude as the first dimension and latitude
+// as the second, as in [2]float64{lon, lat}. Items are measured by their
+// rectangles too, so points should be stored as zero sized rectangles.
+func NearbyDist[T any](e *Ellipsoid, p LatLng) func(min, max [2]float64, data T, item bool) float64 {
+	return func(min, max [2]float64, data T, item bool) float64 {
+		if min == max {
+			return e.distance(p, LatLng{min[1], min[0]})
+		}
+		return e.distanceToBounds(p.Lat, p.Lon,
+			Bounds{min[1], min[0], max[1], max[0]})
+	}
+}
+
+// CompareDistance returns a comparison function that orders points by
+// their geodesic distance from p, for use with slices.SortFunc and similar.
+func (e *Ellipsoid) CompareDistance(p LatLng) func(a, b LatLng) int {
+	return func(a, b LatLng) int {
+		return cmp.Compare(e.distance(p, a), e.distance(p, b))
+	}
+}
@@ -0,0 +1,62 @@
+package geodesic
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDistanceToBox(t *testing.T) {
+	p := LatLng{10, 20}
+	if d := WGS84.DistanceToBox(p, 0, 0, 20, 30); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+	// Directly south of the box.
+	var s12 float64
+	WGS84.Inverse(10, 20, 15, 20, &s12, nil, nil)
+	if d := WGS84.DistanceToBox(p, 15, 0, 20, 30); !eqish(d, s12, 6) {
+		t.Fatalf("expected %f, got %f", s12, d)
+	}
+	// The distance is a lower bound for every point in the box.
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		minLat := rng.Float64()*160 - 80
+		minLon := rng.Float64()*360 - 180
+		maxLat := minLat + rng.Float64()*(90-minLat)
+		maxLon := normLon(minLon + rng.Float64()*90)
+		q := LatLng{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+		d := WGS84.DistanceToBox(q, minLat, minLon, maxLat, maxLon)
+		for j := 0; j < 50; j++ {
+			lat := minLat + rng.Float64()*(maxLat-minLat)
+			span := maxLon - minLon
+			if span < 0 {
+				span += 360
+			}
+			lon := normLon(minLon + rng.Float64()*span)
+			if dd := WGS84.distance(q, LatLng{lat, lon}); dd < d-1e-6 {
+				t.Fatalf("point %v is %f from %v, bound is %f",
+					LatLng{lat, lon}, dd, q, d)
+			}
+		}
+	}
+}
+
+func TestNearbyDist(t *testing.T) {
+	dist := NearbyDist[int](WGS84, LatLng{10, 20})
+	if d := dist([2]float64{0, 0}, [2]float64{30, 20}, 0, false); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+	var s12 float64
+	WGS84.Inverse(10, 20, 15, 25, &s12, nil, nil)
+	if d := dist([2]float64{25, 15}, [2]float64{25, 15}, 1, true); d != s12 {
+		t.Fatalf("expected %f, got %f", s12, d)
+	}
+}
+
+func TestCompareDistance(t *testing.T) {
+	pts := []LatLng{{0, 3}, {0, 1}, {0, 2}}
+	slices.SortFunc(pts, WGS84.CompareDistance(LatLng{0, 0}))
+	if pts[0].Lon != 1 || pts[1].Lon != 2 || pts[2].Lon != 3 {
+		t.Fatalf("unexpected order %v", pts)
+	}
+}
@@ -0,0 +1,268 @@
+// Package gendata reads and writes the test data files used by the
+// geodesic tests and written by cmd/gentestdata.
+//
+// Two formats are understood. The legacy binary format, as in test.data,
+// is a sequence of records, each an 'I' or 'P' byte followed by little
+// endian float64s:
+//
+//	I lat1 lon1 lat2 lon2 s12 azi1 azi2
+//	P n (lat lon)*n (area perimeter)*4
+//
+// where n is a single byte. The text format is line based and starts
+// with a version line, "geodesic-testdata 1". Blank lines and lines
+// starting with '#' are ignored, a "seed" line records the seed the data
+// was generated with, and the records are the same as above with the
+// fields separated by spaces and written with enough digits to round
+// trip exactly:
+//
+//	I lat1 lon1 lat2 lon2 s12 azi1 azi2
+//	P n lat1 lon1 ... latn lonn area1 perimeter1 ... area4 perimeter4
+//
+// The four polygon results are those of Compute with (reverse, sign) set
+// to (false, false), (true, false), (true, true), and (false, true).
+package gendata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the text format written by Write.
+const Version = 1
+
+const magic = "geodesic-testdata"
+
+// Compute flags for the four polygon results, in file order.
+var PolygonFlags = [4][2]bool{
+	{false, false}, {true, false}, {true, true}, {false, true},
+}
+
+// Inverse is a solved inverse problem.
+type Inverse struct {
+	Lat1, Lon1, Lat2, Lon2 float64
+	S12, Azi1, Azi2        float64
+}
+
+// Polygon is a polygon with its area and perimeter for each of the
+// PolygonFlags.
+type Polygon struct {
+	Points  [][2]float64
+	Results [4][2]float64
+}
+
+// Data is the content of a test data file.
+type Data struct {
+	// Version is 0 for the legacy binary format.
+	Version  int
+	Seed     int64
+	Inverse  []Inverse
+	Polygons []Polygon
+}
+
+// Read reads test data in either format.
+func Read(r io.Reader) (*Data, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(magic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if string(head) == magic {
+		return readText(br)
+	}
+	src, err := io.ReadAll(br)
+	if err != nil {
+		return nil, err
+	}
+	return readBinary(src)
+}
+
+func readFloats(src []byte, count int) ([]float64, error) {
+	if len(src) < count*8 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	vals := make([]float64, count)
+	for i := 0; i < count; i++ {
+		vals[i] = math.Float64frombits(binary.LittleEndian.Uint64(src[i*8:]))
+	}
+	return vals, nil
+}
+
+func readBinary(src []byte) (*Data, error) {
+	d := new(Data)
+	for i := 0; i < len(src); {
+		switch src[i] {
+		case 'I':
+			v, err := readFloats(src[i+1:], 7)
+			if err != nil {
+				return nil, err
+			}
+			i += 1 + 7*8
+			d.Inverse = append(d.Inverse,
+				Inverse{v[0], v[1], v[2], v[3], v[4], v[5], v[6]})
+		case 'P':
+			if i+1 >= len(src) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			n := int(src[i+1])
+			i += 2
+			v, err := readFloats(src[i:], n*2+8)
+			if err != nil {
+				return nil, err
+			}
+			i += (n*2 + 8) * 8
+			d.Polygons = append(d.Polygons, newPolygon(n, v))
+		default:
+			return nil, fmt.Errorf("invalid record type %q at offset %d",
+				src[i], i)
+		}
+	}
+	return d, nil
+}
+
+// newPolygon makes a polygon of n points from v, which holds the points
+// followed by the results.
+func newPolygon(n int, v []float64) Polygon {
+	p := Polygon{Points: make([][2]float64, n)}
+	for j := range p.Points {
+		p.Points[j] = [2]float64{v[j*2], v[j*2+1]}
+	}
+	for j := range p.Results {
+		p.Results[j] = [2]float64{v[n*2+j*2], v[n*2+j*2+1]}
+	}
+	return p
+}
+
+func readText(r *bufio.Reader) (*Data, error) {
+	d := new(Data)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if n == 1 {
+			if len(fields) != 2 || fields[0] != magic {
+				return nil, errors.New("missing version line")
+			}
+			v, err := strconv.Atoi(fields[1])
+			if err != nil || v < 1 || v > Version {
+				return nil, fmt.Errorf("unsupported version %q", fields[1])
+			}
+			d.Version = v
+			continue
+		}
+		if err := d.parseLine(fields); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if d.Version == 0 {
+		return nil, errors.New("missing version line")
+	}
+	return d, nil
+}
+
+func parseFloats(fields []string) ([]float64, error) {
+	vals := make([]float64, len(fields))
+	for i, field := range fields {
+		x, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = x
+	}
+	return vals, nil
+}
+
+func (d *Data) parseLine(fields []string) error {
+	switch fields[0] {
+	case "seed":
+		if len(fields) != 2 {
+			return errors.New("invalid seed line")
+		}
+		seed, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		d.Seed = seed
+	case "I":
+		if len(fields) != 8 {
+			return fmt.Errorf("expected 7 values, got %d", len(fields)-1)
+		}
+		v, err := parseFloats(fields[1:])
+		if err != nil {
+			return err
+		}
+		d.Inverse = append(d.Inverse,
+			Inverse{v[0], v[1], v[2], v[3], v[4], v[5], v[6]})
+	case "P":
+		if len(fields) < 2 {
+			return errors.New("missing point count")
+		}
+		n, err := strconv.Atoi(fields[1])
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid point count %q", fields[1])
+		}
+		if len(fields) != 2+n*2+8 {
+			return fmt.Errorf("expected %d values, got %d",
+				n*2+8, len(fields)-2)
+		}
+		v, err := parseFloats(fields[2:])
+		if err != nil {
+			return err
+		}
+		d.Polygons = append(d.Polygons, newPolygon(n, v))
+	default:
+		return fmt.Errorf("invalid record type %q", fields[0])
+	}
+	return nil
+}
+
+func appendFloats(dst []byte, x ...float64) []byte {
+	for _, x := range x {
+		dst = append(dst, ' ')
+		dst = strconv.AppendFloat(dst, x, 'g', -1, 64)
+	}
+	return dst
+}
+
+// Write writes d in the current text format.
+func Write(w io.Writer, d *Data) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %d\n", magic, Version)
+	fmt.Fprintf(&buf, "seed %d\n", d.Seed)
+	buf.WriteString("# I lat1 lon1 lat2 lon2 s12 azi1 azi2\n")
+	var line []byte
+	for _, c := range d.Inverse {
+		line = append(line[:0], 'I')
+		line = appendFloats(line,
+			c.Lat1, c.Lon1, c.Lat2, c.Lon2, c.S12, c.Azi1, c.Azi2)
+		buf.Write(append(line, '\n'))
+	}
+	buf.WriteString("# P n lat1 lon1 ... latn lonn " +
+		"area1 perimeter1 ... area4 perimeter4\n")
+	for _, p := range d.Polygons {
+		line = append(line[:0], 'P', ' ')
+		line = strconv.AppendInt(line, int64(len(p.Points)), 10)
+		for _, pt := range p.Points {
+			line = appendFloats(line, pt[0], pt[1])
+		}
+		for _, res := range p.Results {
+			line = appendFloats(line, res[0], res[1])
+		}
+		buf.Write(append(line, '\n'))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,79 @@
+package gendata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testData() *Data {
+	return &Data{
+		Version: Version,
+		Seed:    42,
+		Inverse: []Inverse{
+			{1, 2, 3, 4, 5, 6, 7},
+			{-0.1, 1.0 / 3, math.Pi, -180, 1e-300, 1e7, math.Nextafter(1, 2)},
+		},
+		Polygons: []Polygon{{
+			Points: [][2]float64{{0, 0}, {0, 1}, {1, 1}},
+			Results: [4][2]float64{
+				{1, 2}, {3, 4}, {5, 6}, {7, 8},
+			},
+		}},
+	}
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testData()); err != nil {
+		t.Fatal(err)
+	}
+	d, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(d, testData()) {
+		t.Fatalf("expected %v, got %v", testData(), d)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	var src []byte
+	put := func(x ...float64) {
+		for _, x := range x {
+			src = binary.LittleEndian.AppendUint64(src, math.Float64bits(x))
+		}
+	}
+	src = append(src, 'I')
+	put(1, 2, 3, 4, 5, 6, 7)
+	src = append(src, 'P', 3)
+	put(0, 0, 0, 1, 1, 1, 1, 2, 3, 4, 5, 6, 7, 8)
+	d, err := Read(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testData()
+	want.Version, want.Seed, want.Inverse = 0, 0, want.Inverse[:1]
+	if !reflect.DeepEqual(d, want) {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	if _, err := Read(bytes.NewReader(src[:20])); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestTextErrors(t *testing.T) {
+	for _, src := range []string{
+		"geodesic-testdata 2\n",
+		"geodesic-testdata 1\nI 1 2 3\n",
+		"geodesic-testdata 1\nP 2 0 0 1 1\n",
+		"geodesic-testdata 1\nX 1\n",
+	} {
+		if _, err := Read(strings.NewReader(src)); err == nil {
+			t.Fatalf("expected error for %q", src)
+		}
+	}
+}
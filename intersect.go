@@ -0,0 +1,209 @@
+package geodesic
+
+import "math"
+
+// RingIntersection is a point where a ring crosses itself.
+type RingIntersection struct {
+	Point LatLng // the crossing point
+	Edges [2]int // indices of the first vertices of the two edges, in order
+}
+
+// SegmentIntersection returns the point where the geodesic segment from a1
+// to b1 crosses the geodesic segment from a2 to b2.
+//
+// Returns false if the segments do not cross, if either is a single point,
+// or if they lie along the same geodesic, in which case they may overlap
+// rather than cross. Segments that touch at an endpoint cross there.
+//
+// The crossing is found on the sphere and then refined on the ellipsoid
+// until both segments pass within a nanometer of it, or the errors of the
+// series for a build with fewer terms, see SeriesOrder.
+func (e *Ellipsoid) SegmentIntersection(a1, b1, a2, b2 LatLng) (LatLng, bool) {
+	s1, s2 := e.newIsectEdge(a1, b1), e.newIsectEdge(a2, b2)
+	return e.intersectEdges(&s1, &s2)
+}
+
+// RingSelfIntersections returns the points where a ring crosses itself.
+//
+// Param ring is the vertices of the polygon. There's no need to "close" the
+// polygon by repeating the first vertex.
+// Returns the crossings of each pair of edges that are not adjacent, ordered
+// by Edges.
+//
+// Polygon.Compute accumulates the area of a self-intersecting ring
+// "algebraically", so the areas of the loops of a figure-8 partially
+// cancel. Check that a ring has no crossings before taking its area as
+// that of a simple polygon. Adjacent edges meet at their shared vertex,
+// which is not a crossing, and edges that overlap along the same geodesic,
+// such as a spike that doubles back, are not detected.
+func (e *Ellipsoid) RingSelfIntersections(ring []LatLng) []RingIntersection {
+	var res []RingIntersection
+	e.ringIntersections(ring, func(x RingIntersection) bool {
+		res = append(res, x)
+		return true
+	})
+	return res
+}
+
+// IsSimpleRing reports whether a ring does not cross itself. It stops at
+// the first crossing, so it is cheaper than RingSelfIntersections. See
+// RingSelfIntersections.
+func (e *Ellipsoid) IsSimpleRing(ring []LatLng) bool {
+	simple := true
+	e.ringIntersections(ring, func(RingIntersection) bool {
+		simple = false
+		return false
+	})
+	return simple
+}
+
+func (e *Ellipsoid) ringIntersections(ring []LatLng, yield func(RingIntersection) bool) {
+	if n := len(ring); n > 1 && ring[0] == ring[n-1] {
+		ring = ring[:n-1]
+	}
+	n := len(ring)
+	if n < 4 {
+		// A triangle has no edges that are not adjacent.
+		return
+	}
+	edges := make([]isectEdge, n)
+	for i := range edges {
+		edges[i] = e.newIsectEdge(ring[i], ring[(i+1)%n])
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue // adjacent through the closing vertex
+			}
+			if p, ok := e.intersectEdges(&edges[i], &edges[j]); ok {
+				if !yield(RingIntersection{Point: p, Edges: [2]int{i, j}}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// isectEdge is a geodesic segment prepared for intersection tests.
+type isectEdge struct {
+	l    Line
+	a, b [3]float64 // unit vectors of the endpoints on the sphere
+	n    [3]float64 // unit normal of the great circle through a and b
+	ab   float64    // angle from a to b on the sphere (radians)
+}
+
+func (e *Ellipsoid) newIsectEdge(a, b LatLng) isectEdge {
+	s := isectEdge{
+		l: e.InverseLine(a.Lat, a.Lon, b.Lat, b.Lon),
+		a: unitVector(a), b: unitVector(b),
+	}
+	s.n = vecCross(s.a, s.b)
+	sin := vecNorm(s.n)
+	if sin > 0 {
+		s.n = vecScale(s.n, 1/sin)
+	}
+	s.ab = math.Atan2(sin, vecDot(s.a, s.b))
+	return s
+}
+
+// frac returns the position of the point x, which is on the great circle
+// of the edge, as a fraction of the way from a to b.
+func (s *isectEdge) frac(x [3]float64) float64 {
+	return math.Atan2(vecDot(vecCross(s.a, x), s.n), vecDot(s.a, x)) / s.ab
+}
+
+// outside returns how far a fraction is outside of [0,1].
+func outside(t float64) float64 {
+	return math.Max(-t, t-1)
+}
+
+func (e *Ellipsoid) intersectEdges(s1, s2 *isectEdge) (LatLng, bool) {
+	L1, L2 := s1.l.Distance(), s2.l.Distance()
+	if !(L1 > 0 && L2 > 0) {
+		return LatLng{}, false
+	}
+	// Start from the crossing of the great circles that is nearer to the
+	// segments.
+	x := vecCross(s1.n, s2.n)
+	if sin := vecNorm(x); sin > 1e-12 {
+		x = vecScale(x, 1/sin)
+	} else {
+		return LatLng{}, false // the same great circle
+	}
+	t1, t2 := s1.frac(x), s2.frac(x)
+	if u1, u2 := s1.frac(vecScale(x, -1)), s2.frac(vecScale(x, -1)); math.Max(
+		outside(u1), outside(u2)) < math.Max(outside(t1), outside(t2)) {
+		t1, t2 = u1, u2
+	}
+	// The geodesics are within a fraction of a percent of the great
+	// circles, so a crossing well beyond either end is not a crossing.
+	const margin = 0.05
+	if outside(t1) > margin || outside(t2) > margin {
+		return LatLng{}, false
+	}
+	// Newton's method on the distances along the segments. Each step solves
+	// for where the lines through the current points, in their current
+	// directions, cross in the tangent plane at the point on segment 1.
+	s12, s22 := t1*L1, t2*L2
+	var p1, p2 LatLng
+	for i := 0; i < 32; i++ {
+		var beta1, beta2 float64
+		s1.l.Position(s12, &p1.Lat, &p1.Lon, &beta1)
+		s2.l.Position(s22, &p2.Lat, &p2.Lon, &beta2)
+		var d, alpha1, alpha2 float64
+		e.Inverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, &d, &alpha1, &alpha2)
+		if d < seriesTolerance(1e-9) {
+			break
+		}
+		// Carry the direction of segment 2 over to the point on segment 1.
+		beta2 -= alpha2 - alpha1
+		ux1, uy1 := sincosd(beta1)
+		ux2, uy2 := sincosd(beta2)
+		rx, ry := sincosd(alpha1)
+		rx, ry = rx*d, ry*d
+		det := uy1*ux2 - ux1*uy2
+		if math.Abs(det) < 1e-12 {
+			return LatLng{}, false // parallel, so they do not cross here
+		}
+		s12 += (ux2*ry - uy2*rx) / det
+		s22 += (ux1*ry - uy1*rx) / det
+		if math.Abs(s12-L1/2) > L1 || math.Abs(s22-L2/2) > L2 {
+			return LatLng{}, false // diverged far from the segments
+		}
+	}
+	tol := seriesTolerance(1e-6)
+	if !(s12 >= -tol && s12 <= L1+tol && s22 >= -tol && s22 <= L2+tol) {
+		return LatLng{}, false
+	}
+	return p1, true
+}
+
+func sincosd(deg float64) (sin, cos float64) {
+	return math.Sincos(deg * math.Pi / 180)
+}
+
+func unitVector(p LatLng) [3]float64 {
+	slat, clat := sincosd(p.Lat)
+	slon, clon := sincosd(p.Lon)
+	return [3]float64{clat * clon, clat * slon, slat}
+}
+
+func vecCross(a, b [3]float64) [3]float64 {
+	return [3]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func vecDot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func vecNorm(a [3]float64) float64 {
+	return math.Sqrt(vecDot(a, a))
+}
+
+func vecScale(a [3]float64, k float64) [3]float64 {
+	return [3]float64{a[0] * k, a[1] * k, a[2] * k}
+}
@@ -0,0 +1,93 @@
+package geodesic
+
+import "testing"
+
+// onSegment reports whether p is within a micrometer of the segment.
+func onSegment(e *Ellipsoid, p, a, b LatLng) bool {
+	_, dist, _ := e.segmentNearest(p, a, b)
+	return dist < seriesTolerance(1e-6)
+}
+
+func TestSegmentIntersection(t *testing.T) {
+	a1, b1 := LatLng{Lat: -10, Lon: 0}, LatLng{Lat: 10, Lon: 0}
+	a2, b2 := LatLng{Lat: 0, Lon: -10}, LatLng{Lat: 0, Lon: 10}
+	p, ok := WGS84.SegmentIntersection(a1, b1, a2, b2)
+	if !ok || !eqish(p.Lat, 0, 12) || !eqish(p.Lon, 0, 12) {
+		t.Fatalf("expected '0, 0', got '%v, %v' %v", p.Lat, p.Lon, ok)
+	}
+
+	// Long segments, where the geodesics are far from the great circles.
+	for _, c := range [][4]LatLng{
+		{{Lat: 40.64, Lon: -73.78}, {Lat: 51.47, Lon: -0.45},
+			{Lat: 60, Lon: -40}, {Lat: 30, Lon: -30}},
+		{{Lat: -33.9, Lon: 151.2}, {Lat: 1.36, Lon: 103.99},
+			{Lat: -30, Lon: 110}, {Lat: -10, Lon: 150}},
+		{{Lat: 70, Lon: 170}, {Lat: 70, Lon: -100},
+			{Lat: 60, Lon: -150}, {Lat: 85, Lon: -160}},
+	} {
+		p, ok := WGS84.SegmentIntersection(c[0], c[1], c[2], c[3])
+		if !ok || !onSegment(WGS84, p, c[0], c[1]) ||
+			!onSegment(WGS84, p, c[2], c[3]) {
+			t.Fatalf("%v: expected a crossing on both, got %v %v", c, p, ok)
+		}
+	}
+
+	// Touching at an endpoint.
+	p, ok = WGS84.SegmentIntersection(a1, b1, LatLng{Lat: 10, Lon: 0},
+		LatLng{Lat: 10, Lon: 20})
+	if !ok || !eqish(p.Lat, 10, 9) || !eqish(p.Lon, 0, 9) {
+		t.Fatalf("expected '10, 0', got '%v, %v' %v", p.Lat, p.Lon, ok)
+	}
+
+	for _, c := range [][4]LatLng{
+		// short of each other
+		{a1, b1, {Lat: 0, Lon: 1}, {Lat: 0, Lon: 10}},
+		// parallel on the equator
+		{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 10}, {Lat: 0, Lon: 5}, {Lat: 0, Lon: 15}},
+		// a single point
+		{a1, a1, a2, b2},
+	} {
+		if p, ok := WGS84.SegmentIntersection(c[0], c[1], c[2], c[3]); ok {
+			t.Fatalf("%v: expected no crossing, got %v", c, p)
+		}
+	}
+}
+
+func TestRingSelfIntersections(t *testing.T) {
+	square := []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	if x := WGS84.RingSelfIntersections(square); len(x) != 0 ||
+		!WGS84.IsSimpleRing(square) {
+		t.Fatalf("expected a simple ring, got %v", x)
+	}
+	closed := append(square, square[0])
+	if !WGS84.IsSimpleRing(closed) {
+		t.Fatalf("expected a closed simple ring")
+	}
+
+	bowtie := []LatLng{{0, 0}, {1, 1}, {0, 1}, {1, 0}}
+	x := WGS84.RingSelfIntersections(bowtie)
+	if len(x) != 1 || x[0].Edges != [2]int{0, 2} ||
+		WGS84.IsSimpleRing(bowtie) {
+		t.Fatalf("expected one crossing of edges 0 and 2, got %v", x)
+	}
+	if !onSegment(WGS84, x[0].Point, bowtie[0], bowtie[1]) ||
+		!onSegment(WGS84, x[0].Point, bowtie[2], bowtie[3]) ||
+		!eqish(x[0].Point.Lat, 0.5, 3) || !eqish(x[0].Point.Lon, 0.5, 3) {
+		t.Fatalf("expected a crossing near '0.5, 0.5', got %v", x[0].Point)
+	}
+
+	// A star that crosses itself five times.
+	var star []LatLng
+	for i := 0; i < 5; i++ {
+		var p LatLng
+		WGS84.Direct(45, 10, float64(i*144), 100000, &p.Lat, &p.Lon, nil)
+		star = append(star, p)
+	}
+	if x := WGS84.RingSelfIntersections(star); len(x) != 5 {
+		t.Fatalf("expected 5 crossings, got %v", x)
+	}
+
+	if !WGS84.IsSimpleRing(square[:3]) {
+		t.Fatalf("expected a triangle to be simple")
+	}
+}
@@ -0,0 +1,214 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// The invariants in this file are properties that every part of the API
+// must keep for random problems, whatever the backend: the C library with
+// cgo, the Go port without it, or the exact routines with the
+// geographiclib_exact tag. Running them under each build checks the
+// backends for consistency without any reference data. GEODINVARIANTS sets
+// the number of random problems per invariant and ellipsoid, which is 200
+// by default and 20 with -short.
+
+// invariantEllipsoids are the ellipsoids the invariants are checked on.
+func invariantEllipsoids() map[string]*Ellipsoid {
+	es := map[string]*Ellipsoid{
+		"WGS84":   WGS84,
+		"oblate":  NewEllipsoid(6.4e6, 1.0/150),
+		"prolate": NewEllipsoid(6.4e6, -1.0/150),
+	}
+	if e, err := NewExactEllipsoid(WGS84.Radius(), WGS84.Flattening()); err == nil {
+		es["exact"] = e
+	}
+	return es
+}
+
+// invariantCount is the number of random problems per invariant.
+func invariantCount(t *testing.T) int {
+	if s := os.Getenv("GEODINVARIANTS"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			t.Fatalf("GEODINVARIANTS: expected a positive count, got %q", s)
+		}
+		return n
+	}
+	if testing.Short() {
+		return 20
+	}
+	return 200
+}
+
+// invariantPoint returns a random point, uniform on the sphere.
+func invariantPoint(rng *rand.Rand) LatLng {
+	return LatLng{
+		Lat: math.Asin(2*rng.Float64()-1) * (180 / math.Pi),
+		Lon: rng.Float64()*360 - 180,
+	}
+}
+
+// invariantMaxS12 is a distance below which a geodesic on the ellipsoids of
+// invariantEllipsoids is the shortest path, and so is what Inverse finds.
+const invariantMaxS12 = 1.9e7
+
+// invariantTol is the allowed error of a distance or position that is the
+// result of a few solutions of length up to s (meters).
+func invariantTol(s float64) float64 {
+	return seriesTolerance(1e-7 + 1e-13*math.Abs(s))
+}
+
+var invariants = []struct {
+	name  string
+	check func(t *testing.T, e *Ellipsoid, rng *rand.Rand)
+}{
+	{"DirectOfInverse", func(t *testing.T, e *Ellipsoid, rng *rand.Rand) {
+		// Direct with the solution of Inverse lands on point 2 with the
+		// same azimuth.
+		p1, p2 := invariantPoint(rng), invariantPoint(rng)
+		var s12, azi1, azi2 float64
+		e.Inverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, &s12, &azi1, &azi2)
+		var q LatLng
+		var qazi2 float64
+		e.Direct(p1.Lat, p1.Lon, azi1, s12, &q.Lat, &q.Lon, &qazi2)
+		if d := e.distance(p2, q); d > invariantTol(s12) {
+			t.Fatalf("%v to %v: expected to land on point 2, got %v, %v away",
+				p1, p2, q, d)
+		}
+		if math.Abs(p2.Lat) < 89 && !sameAzimuth(azi2, qazi2, 1e-8) {
+			t.Fatalf("%v to %v: expected azi2 %v, got %v", p1, p2, azi2, qazi2)
+		}
+	}},
+	{"InverseOfDirect", func(t *testing.T, e *Ellipsoid, rng *rand.Rand) {
+		// Inverse of the result of Direct gives back the distance, for
+		// geodesics short enough to be the shortest path.
+		p1 := invariantPoint(rng)
+		azi1 := rng.Float64()*360 - 180
+		s12 := rng.Float64() * invariantMaxS12
+		var p2 LatLng
+		e.Direct(p1.Lat, p1.Lon, azi1, s12, &p2.Lat, &p2.Lon, nil)
+		var s float64
+		e.Inverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, &s, nil, nil)
+		if math.Abs(s-s12) > invariantTol(s12) {
+			t.Fatalf("%v, %v, %v: expected %v, got %v", p1, azi1, s12, s12, s)
+		}
+	}},
+	{"Reversal", func(t *testing.T, e *Ellipsoid, rng *rand.Rand) {
+		// Swapping the points reverses the geodesic: the distance is the
+		// same and the azimuths are swapped and turned around.
+		p1, p2 := invariantPoint(rng), invariantPoint(rng)
+		var s12, azi1, azi2, s21, azi1r, azi2r float64
+		e.Inverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, &s12, &azi1, &azi2)
+		e.Inverse(p2.Lat, p2.Lon, p1.Lat, p1.Lon, &s21, &azi1r, &azi2r)
+		if math.Abs(s12-s21) > invariantTol(s12) {
+			t.Fatalf("%v to %v: expected %v both ways, got %v", p1, p2, s12, s21)
+		}
+		// Nearly antipodal points may have several shortest geodesics, and
+		// the azimuths at a pole are ill-conditioned.
+		if s12 < invariantMaxS12 && math.Abs(p1.Lat) < 89 &&
+			math.Abs(p2.Lat) < 89 && (!sameAzimuth(azi1r, azi2+180, 1e-8) ||
+			!sameAzimuth(azi2r, azi1+180, 1e-8)) {
+			t.Fatalf("%v to %v: expected %v %v reversed, got %v %v",
+				p1, p2, azi1, azi2, azi1r, azi2r)
+		}
+		// Going back from point 2 along the same geodesic returns to
+		// point 1.
+		var q LatLng
+		e.Direct(p2.Lat, p2.Lon, azi2, -s12, &q.Lat, &q.Lon, nil)
+		if d := e.distance(p1, q); d > invariantTol(s12) {
+			t.Fatalf("%v to %v: expected to return to point 1, got %v, %v away",
+				p1, p2, q, d)
+		}
+	}},
+	{"LineSplit", func(t *testing.T, e *Ellipsoid, rng *rand.Rand) {
+		// A point along the geodesic splits its length in two.
+		p1, p2 := invariantPoint(rng), invariantPoint(rng)
+		l := e.InverseLine(p1.Lat, p1.Lon, p2.Lat, p2.Lon)
+		s13 := rng.Float64() * l.Distance()
+		var p3 LatLng
+		l.Position(s13, &p3.Lat, &p3.Lon, nil)
+		s := e.distance(p1, p3) + e.distance(p3, p2)
+		if math.Abs(s-l.Distance()) > invariantTol(l.Distance()) {
+			t.Fatalf("%v to %v at %v: expected %v, got %v", p1, p2, s13,
+				l.Distance(), s)
+		}
+		var q LatLng
+		l.ArcPosition(l.Arc(), &q.Lat, &q.Lon, nil)
+		if d := e.distance(p2, q); d > invariantTol(l.Distance()) {
+			t.Fatalf("%v to %v: expected the arc to end at point 2, got %v",
+				p1, p2, q)
+		}
+	}},
+	{"AreaSplit", func(t *testing.T, e *Ellipsoid, rng *rand.Rand) {
+		// The area of a polygon that is star shaped about a center is the
+		// sum of the areas of the triangles that fan out from the center,
+		// and its perimeter is the sum of the lengths of its edges.
+		// Reversing it negates its signed area.
+		c := invariantPoint(rng)
+		c.Lat = math.Max(-80, math.Min(80, c.Lat))
+		n := 3 + rng.Intn(8)
+		ring := make([]LatLng, n)
+		for i := range ring {
+			azi := (float64(i) + rng.Float64()*0.8) * 360 / float64(n)
+			s := 1e4 + rng.Float64()*2e6
+			e.Direct(c.Lat, c.Lon, azi, s, &ring[i].Lat, &ring[i].Lon, nil)
+		}
+		area, perimeter := e.invariantArea(ring)
+		var sum, length float64
+		for i := range ring {
+			j := (i + 1) % n
+			a, _ := e.invariantArea([]LatLng{c, ring[i], ring[j]})
+			sum += a
+			length += e.distance(ring[i], ring[j])
+		}
+		if math.Abs(area-sum) > 1e-9*math.Abs(area)+1 {
+			t.Fatalf("%v: expected the area %v, got %v from the fan", ring,
+				area, sum)
+		}
+		if math.Abs(perimeter-length) > invariantTol(perimeter) {
+			t.Fatalf("%v: expected the perimeter %v, got %v from the edges",
+				ring, perimeter, length)
+		}
+		rev := make([]LatLng, n)
+		for i := range ring {
+			rev[i] = ring[n-1-i]
+		}
+		if ra, _ := e.invariantArea(rev); math.Abs(ra+area) > 1e-9*math.Abs(area)+1 {
+			t.Fatalf("%v: expected the reversed area %v, got %v", ring, -area, ra)
+		}
+	}},
+}
+
+// invariantArea returns the signed area and perimeter of a ring.
+func (e *Ellipsoid) invariantArea(ring []LatLng) (area, perimeter float64) {
+	p := e.PolygonInit(false)
+	defer p.Close()
+	for _, q := range ring {
+		p.AddPoint(q.Lat, q.Lon)
+	}
+	p.Compute(false, true, &area, &perimeter)
+	return area, perimeter
+}
+
+// sameAzimuth reports whether two azimuths are within tol (degrees).
+func sameAzimuth(x, y, tol float64) bool {
+	return math.Abs(math.Remainder(x-y, 360)) <= tol
+}
+
+func TestInvariants(t *testing.T) {
+	n := invariantCount(t)
+	for name, e := range invariantEllipsoids() {
+		for _, inv := range invariants {
+			t.Run(name+"/"+inv.name, func(t *testing.T) {
+				rng := rand.New(rand.NewSource(1))
+				for i := 0; i < n; i++ {
+					inv.check(t, e, rng)
+				}
+			})
+		}
+	}
+}
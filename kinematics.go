@@ -0,0 +1,145 @@
+package geodesic
+
+import "math"
+
+// Kinematics is the motion of a track at one of its points, from
+// Ellipsoid.TrackKinematics.
+type Kinematics struct {
+	Speed        float64 // ground speed (meters/second)
+	Acceleration float64 // change of the speed (meters/second^2)
+	Bearing      float64 // direction of travel in [0,360) (degrees)
+	TurnRate     float64 // clockwise change of the bearing (degrees/second)
+}
+
+// KinematicsOptions are the options of Ellipsoid.TrackKinematics.
+type KinematicsOptions struct {
+	// Window is the number of points that each result is averaged over,
+	// centered on its point, to smooth the noise of the fixes. Zero or one
+	// does not smooth, and an even window is taken as the next odd one.
+	Window int
+}
+
+// TrackKinematics returns the speed, acceleration, bearing, and turn rate
+// of a track at each of its points.
+//
+// Param track is the points of the track, whose times must increase.
+// Param opts are the options, or nil for the defaults, which do not
+// smooth.
+// Returns the motion at each point, in order.
+//
+// Each segment between consecutive points is the geodesic between them,
+// so the speeds are its length over its time, and the bearings are its
+// azimuths at its ends, which turn along a long segment as the meridians
+// converge, unlike a bearing taken on a map. At each point the speed is
+// the length of the segments on either side over their time, the bearing
+// is midway between the azimuths arriving and leaving, and the
+// acceleration and turn rate are the changes of the speed and azimuth
+// from the segment arriving to the segment leaving, over the time between
+// their middles. The first and last points take them from the one segment
+// or the nearest point that has two. A segment of no length has no
+// direction, so the bearing is that of the segment on the other side, or
+// of the point before if neither moves, and it does not turn; the first
+// bearing is NaN until the track moves. A segment of no time gives
+// infinite or NaN results about it.
+//
+// Smoothing averages the speeds, accelerations, and turn rates over a
+// window of points, and the bearings as directions, weighted by the
+// speeds, with the window shortened to fit at the ends of the track.
+func (e *Ellipsoid) TrackKinematics(track []TimedLatLng,
+	opts *KinematicsOptions,
+) []Kinematics {
+	n := len(track)
+	out := make([]Kinematics, n)
+	if n == 0 {
+		return out
+	}
+	// The length, speed, and azimuths at the ends of each segment.
+	type segment struct {
+		s, v, azi1, azi2, dt float64
+	}
+	segs := make([]segment, n-1)
+	for i := range segs {
+		a, b := track[i], track[i+1]
+		sg := &segs[i]
+		e.Inverse(a.Lat, a.Lon, b.Lat, b.Lon, &sg.s, &sg.azi1, &sg.azi2)
+		sg.dt = b.Time.Sub(a.Time).Seconds()
+		sg.v = sg.s / sg.dt
+	}
+	bearing := math.NaN()
+	for i := range out {
+		k := &out[i]
+		var in, on *segment // the segments arriving and leaving
+		if i > 0 {
+			in = &segs[i-1]
+		}
+		if i < n-1 {
+			on = &segs[i]
+		}
+		switch {
+		case in != nil && on != nil:
+			k.Speed = (in.s + on.s) / (in.dt + on.dt)
+			mid := (in.dt + on.dt) / 2
+			k.Acceleration = (on.v - in.v) / mid
+			if in.s > 0 && on.s > 0 {
+				turn := math.Remainder(on.azi1-in.azi2, 360)
+				bearing = in.azi2 + turn/2
+				k.TurnRate = turn / mid
+			} else if in.s > 0 {
+				bearing = in.azi2
+			} else if on.s > 0 {
+				bearing = on.azi1
+			}
+		case on != nil:
+			k.Speed = on.v
+			if on.s > 0 {
+				bearing = on.azi1
+			}
+		case in != nil:
+			k.Speed = in.v
+			if in.s > 0 {
+				bearing = in.azi2
+			}
+		}
+		k.Bearing = Azimuth360(bearing)
+	}
+	// The ends take the changes of the points next to them.
+	if n > 2 {
+		out[0].Acceleration, out[0].TurnRate = out[1].Acceleration,
+			out[1].TurnRate
+		out[n-1].Acceleration, out[n-1].TurnRate = out[n-2].Acceleration,
+			out[n-2].TurnRate
+	}
+	if opts != nil && opts.Window > 1 {
+		out = smoothKinematics(out, opts.Window/2)
+	}
+	return out
+}
+
+// smoothKinematics returns the kinematics averaged over the points within
+// half of each point, on either side.
+func smoothKinematics(ks []Kinematics, half int) []Kinematics {
+	out := make([]Kinematics, len(ks))
+	for i := range ks {
+		lo, hi := max(0, i-half), min(len(ks)-1, i+half)
+		var sx, sy float64
+		k := &out[i]
+		for j := lo; j <= hi; j++ {
+			k.Speed += ks[j].Speed
+			k.Acceleration += ks[j].Acceleration
+			k.TurnRate += ks[j].TurnRate
+			if !math.IsNaN(ks[j].Bearing) {
+				s, c := sincosd(ks[j].Bearing)
+				sx, sy = sx+ks[j].Speed*s, sy+ks[j].Speed*c
+			}
+		}
+		m := float64(hi - lo + 1)
+		k.Speed /= m
+		k.Acceleration /= m
+		k.TurnRate /= m
+		k.Bearing = ks[i].Bearing
+		if sx != 0 || sy != 0 {
+			k.Bearing = Azimuth360(math.Atan2(sx, sy) * (180 / math.Pi))
+		}
+	}
+	return out
+}
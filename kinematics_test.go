@@ -0,0 +1,79 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTrackKinematics(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	// A turn of 3 degrees a second at 10 meters a second, with the bearings
+	// midway through the turns at each point, then a straight geodesic near
+	// the pole, whose azimuths change along it, at a speed that grows by 10
+	// meters a second every 1000 seconds.
+	p, azi := LatLng{52, 5}, 350.0
+	track := []TimedLatLng{{p, t0}}
+	for i := 1; i <= 20; i++ {
+		WGS84.Direct(p.Lat, p.Lon, azi, 10, &p.Lat, &p.Lon, &azi)
+		azi += 3
+		track = append(track, TimedLatLng{p, t0.Add(time.Duration(i) *
+			time.Second)})
+	}
+	ks := WGS84.TrackKinematics(track[:20], nil)
+	for i, k := range ks {
+		prec := seriesPrec(6)
+		if !eqish(k.Speed, 10, prec) || !eqish(k.Acceleration, 0, prec) ||
+			!eqish(k.TurnRate, 3, prec) {
+			t.Fatalf("%d: expected 10 m/s and 3 degrees/s, got %+v", i, k)
+		}
+	}
+	if !eqish(ks[0].Bearing, 350, 6) || !eqish(ks[4].Bearing, 0.5, 3) {
+		t.Fatalf("expected bearings of 350 and 0.5, got %v and %v", ks[0].Bearing,
+			ks[4].Bearing)
+	}
+	p, azi = LatLng{89, 0}, 90.0
+	track = []TimedLatLng{{p, t0}}
+	for i := 1; i <= 10; i++ {
+		WGS84.Direct(p.Lat, p.Lon, azi, float64(10000*i), &p.Lat, &p.Lon, &azi)
+		track = append(track, TimedLatLng{p, t0.Add(time.Duration(i) *
+			1000 * time.Second)})
+	}
+	ks = WGS84.TrackKinematics(track, nil)
+	for i, k := range ks[1:] {
+		if !eqish(k.TurnRate, 0, 9) || !eqish(k.Acceleration, 0.01, 9) {
+			t.Fatalf("%d: expected no turn and 0.01 m/s^2, got %+v", i+1, k)
+		}
+	}
+	if !(math.Abs(math.Remainder(ks[10].Bearing-ks[0].Bearing, 360)) > 30) {
+		t.Fatalf("expected the azimuth to change, got %v and %v",
+			ks[0].Bearing, ks[10].Bearing)
+	}
+
+	// A stop, whose bearing carries over, and smoothing across north.
+	still := []TimedLatLng{
+		{LatLng{0, 0}, t0}, {LatLng{0, 0}, t0.Add(time.Second)},
+		{LatLng{0.0001, 0.00001}, t0.Add(2 * time.Second)},
+		{LatLng{0.0001, 0.00001}, t0.Add(3 * time.Second)},
+		{LatLng{0.0002, 0}, t0.Add(4 * time.Second)},
+	}
+	ks = WGS84.TrackKinematics(still, nil)
+	if !math.IsNaN(ks[0].Bearing) || ks[0].Speed != 0 ||
+		!eqish(ks[2].Bearing, ks[1].Bearing, 9) || ks[2].TurnRate != 0 ||
+		!(ks[3].Bearing > 270 && ks[3].Bearing < 360) {
+		t.Fatalf("bad kinematics of a stop: %+v", ks)
+	}
+	sm := WGS84.TrackKinematics(still, &KinematicsOptions{Window: 4})
+	if !(sm[2].Bearing < 3 || sm[2].Bearing > 357) ||
+		!eqish(sm[2].Speed, (ks[0].Speed+ks[1].Speed+ks[2].Speed+ks[3].Speed+
+			ks[4].Speed)/5, 12) {
+		t.Fatalf("expected a smoothed bearing near north, got %+v", sm[2])
+	}
+	if ks := WGS84.TrackKinematics(still[:1], nil); len(ks) != 1 ||
+		ks[0].Speed != 0 || !math.IsNaN(ks[0].Bearing) {
+		t.Fatalf("expected no motion, got %+v", ks)
+	}
+	if ks := WGS84.TrackKinematics(nil, nil); len(ks) != 0 {
+		t.Fatalf("expected nothing, got %+v", ks)
+	}
+}
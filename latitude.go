@@ -0,0 +1,124 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+
+	v2 "github.com/tidwall/geodesic_cgo/v2"
+)
+
+// ErrInvalidLatitude is returned by Ellipsoid.CheckedInverse and
+// Ellipsoid.CheckedDirect for a latitude outside of [-90,+90], after the
+// LatitudePolicy of the ellipsoid is applied.
+var ErrInvalidLatitude = v2.ErrInvalidLatitude
+
+// LatitudeClampTolerance is how far outside of [-90,+90] a latitude may be
+// and still be clamped by the LatitudeClamp policy (degrees). It is about a
+// meter, which covers the rounding of converted or averaged positions and
+// the noise of a position fix near a pole.
+const LatitudeClampTolerance = v2.LatitudeClampTolerance
+
+// LatitudePolicy selects what an Ellipsoid does with latitudes outside of
+// [-90,+90].
+//
+// Under either policy, a latitude that is still out of range makes
+// Ellipsoid.CheckedInverse and Ellipsoid.CheckedDirect return
+// ErrInvalidLatitude, for callers that would rather have an error than a
+// NaN.
+type LatitudePolicy int
+
+const (
+	// LatitudeNaN passes the latitudes to the geodesic routines as they
+	// are, which return NaN for any result that depends on a latitude out
+	// of range. This is the default.
+	LatitudeNaN LatitudePolicy = iota
+	// LatitudeClamp clamps latitudes within LatitudeClampTolerance of the
+	// range to -90 or +90, and treats those farther out as LatitudeNaN
+	// does.
+	LatitudeClamp
+)
+
+// WithLatitudes returns a copy of the ellipsoid that handles latitudes
+// outside of [-90,+90] using the policy. The original ellipsoid is not
+// changed, so this is safe to use on shared values, such as WGS84.
+//
+// The policy applies to the latitudes given to Ellipsoid.Inverse,
+// Ellipsoid.InverseArc, Ellipsoid.Direct, the Line constructors, and
+// Polygon.AddPoint and Polygon.TestPoint, and so to everything built on
+// them.
+func (e *Ellipsoid) WithLatitudes(p LatitudePolicy) *Ellipsoid {
+	ec := *e
+	ec.latitudes = p
+	return &ec
+}
+
+// fix returns the latitude to use for lat under the policy.
+func (p LatitudePolicy) fix(lat float64) float64 {
+	if p == LatitudeClamp && math.Abs(lat) > 90 &&
+		math.Abs(lat) <= 90+LatitudeClampTolerance {
+		return math.Copysign(90, lat)
+	}
+	return lat
+}
+
+// check returns ErrInvalidLatitude if lat is out of range after the policy
+// is applied.
+func (p LatitudePolicy) check(lat float64) error {
+	if !(math.Abs(p.fix(lat)) <= 90) {
+		return ErrInvalidLatitude
+	}
+	return nil
+}
+
+// CheckedInverse solves the inverse geodesic problem, as Ellipsoid.Inverse,
+// and reports the problems that the policies of the ellipsoid make errors.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+// Returns the solution, or an error: ErrInvalidLatitude if a latitude is
+// out of range or ErrInvalidLongitude if a longitude is not a finite
+// number, in which case the solution is NaN, or ErrCoincident if the
+// points are the same under the CoincidentStrict policy, in which case the
+// solution is still returned, with NaN azimuths.
+func (e *Ellipsoid) CheckedInverse(p1, p2 LatLng) (InverseSolution, error) {
+	var r InverseSolution
+	e.Inverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, &r.S12, &r.Azi1, &r.Azi2)
+	p1, err := e.checkPoint(p1)
+	if err != nil {
+		return r, err
+	}
+	if p2, err = e.checkPoint(p2); err != nil {
+		return r, err
+	}
+	if e.coincident == CoincidentStrict &&
+		coincidentPoints(p1.Lat, p1.Lon, p2.Lat, p2.Lon) {
+		return r, ErrCoincident
+	}
+	return r, nil
+}
+
+// CheckedDirect solves the direct geodesic problem, as Ellipsoid.Direct,
+// and reports the problems that the policies of the ellipsoid make errors.
+//
+// Param p1 is point 1.
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param s12 is the distance from point 1 to point 2 (meters).
+// Returns the solution, or an error, in which case the solution is NaN:
+// ErrInvalidLatitude if the latitude of p1 is out of range,
+// ErrInvalidLongitude if its longitude is not a finite number, or
+// ErrInvalidArgument if azi1 or s12 is not a finite number.
+func (e *Ellipsoid) CheckedDirect(p1 LatLng, azi1, s12 float64,
+) (DirectSolution, error) {
+	var r DirectSolution
+	e.Direct(p1.Lat, p1.Lon, azi1, s12, &r.Point.Lat, &r.Point.Lon, &r.Azi2)
+	if _, err := e.checkPoint(p1); err != nil {
+		return r, err
+	}
+	if math.IsNaN(azi1) || math.IsInf(azi1, 0) {
+		return r, fmt.Errorf("%w: azimuth %v", ErrInvalidArgument, azi1)
+	}
+	if math.IsNaN(s12) || math.IsInf(s12, 0) {
+		return r, fmt.Errorf("%w: distance %v", ErrInvalidArgument, s12)
+	}
+	return r, nil
+}
@@ -0,0 +1,94 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLatitudePolicy(t *testing.T) {
+	noisy := 90 + LatitudeClampTolerance/2
+	var s12, lat2, lon2 float64
+	WGS84.Inverse(noisy, 0, 0, 0, &s12, nil, nil)
+	if !math.IsNaN(s12) {
+		t.Fatalf("expected NaN, got %v", s12)
+	}
+	if _, err := WGS84.CheckedInverse(LatLng{0, 0}, LatLng{-noisy, 0}); !errors.Is(err, ErrInvalidLatitude) {
+		t.Fatalf("expected ErrInvalidLatitude, got %v", err)
+	}
+
+	e := WGS84.WithLatitudes(LatitudeClamp)
+	e.Inverse(noisy, 0, 0, 0, &s12, nil, nil)
+	if want := WGS84.distance(LatLng{90, 0}, LatLng{0, 0}); s12 != want {
+		t.Fatalf("expected %v, got %v", want, s12)
+	}
+	e.Direct(-noisy, 0, 0, 1000, &lat2, &lon2, nil)
+	var wlat2, wlon2 float64
+	WGS84.Direct(-90, 0, 0, 1000, &wlat2, &wlon2, nil)
+	if lat2 != wlat2 || lon2 != wlon2 {
+		t.Fatalf("expected %v %v, got %v %v", wlat2, wlon2, lat2, lon2)
+	}
+	l := e.InverseLine(noisy, 10, 0, 10)
+	if l.Lat1() != 90 {
+		t.Fatalf("expected 90, got %v", l.Lat1())
+	}
+	r, err := e.CheckedInverse(LatLng{noisy, 0}, LatLng{0, 0})
+	if err != nil || r.S12 != s12 {
+		t.Fatalf("expected %v, got %v %v", s12, r, err)
+	}
+	d, err := e.CheckedDirect(LatLng{-noisy, 0}, 0, 1000)
+	if err != nil || d.Point.Lat != wlat2 {
+		t.Fatalf("expected %v, got %v %v", wlat2, d, err)
+	}
+
+	// Clamping only covers the tolerance.
+	far := 90 + 2*LatitudeClampTolerance
+	e.Inverse(far, 0, 0, 0, &s12, nil, nil)
+	if !math.IsNaN(s12) {
+		t.Fatalf("expected NaN, got %v", s12)
+	}
+	if _, err := e.CheckedDirect(LatLng{far, 0}, 0, 1000); !errors.Is(err, ErrInvalidLatitude) {
+		t.Fatalf("expected ErrInvalidLatitude, got %v", err)
+	}
+
+	// The checked routines report the other bad inputs too.
+	for _, v := range []struct {
+		p1, p2 LatLng
+		err    error
+	}{
+		{LatLng{0, math.NaN()}, LatLng{1, 1}, ErrInvalidLongitude},
+		{LatLng{0, 0}, LatLng{1, math.Inf(1)}, ErrInvalidLongitude},
+		{LatLng{0, 0}, LatLng{math.NaN(), 1}, ErrInvalidLatitude},
+	} {
+		if r, err := WGS84.CheckedInverse(v.p1, v.p2); !errors.Is(err, v.err) || !math.IsNaN(r.S12) {
+			t.Fatalf("expected %v and NaN, got %v %v", v.err, r, err)
+		}
+	}
+	for _, v := range []struct {
+		p1       LatLng
+		azi, s12 float64
+		err      error
+	}{
+		{LatLng{0, math.NaN()}, 0, 1000, ErrInvalidLongitude},
+		{LatLng{0, 0}, math.NaN(), 1000, ErrInvalidArgument},
+		{LatLng{0, 0}, math.Inf(-1), 1000, ErrInvalidArgument},
+		{LatLng{0, 0}, 0, math.NaN(), ErrInvalidArgument},
+		{LatLng{0, 0}, 0, math.Inf(1), ErrInvalidArgument},
+	} {
+		if d, err := WGS84.CheckedDirect(v.p1, v.azi, v.s12); !errors.Is(err, v.err) || !math.IsNaN(d.Point.Lat) {
+			t.Fatalf("expected %v and NaN, got %v %v", v.err, d, err)
+		}
+	}
+
+	// Polygons clamp their points too.
+	p := e.PolygonInit(false)
+	defer p.Close()
+	for _, lat := range []float64{noisy, 0, 0} {
+		p.AddPoint(lat, 90*float64(p.Compute(false, false, nil, nil)))
+	}
+	var area float64
+	p.Compute(false, false, &area, nil)
+	if want := WGS84.totalArea() / 8; !eqish(area/want, 1, 9) {
+		t.Fatalf("expected %v, got %v", want, area)
+	}
+}
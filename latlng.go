@@ -0,0 +1,27 @@
+package geodesic
+
+// LatLng is a point on the ellipsoid.
+type LatLng struct {
+	Lat float64 // latitude (degrees)
+	Lon float64 // longitude (degrees)
+}
+
+// distance returns the geodesic distance between two points (meters).
+func (e *Ellipsoid) distance(p, q LatLng) float64 {
+	var s12 float64
+	e.Inverse(p.Lat, p.Lon, q.Lat, q.Lon, &s12, nil, nil)
+	return s12
+}
+
+// LatLon returns the latitude and longitude of the point (degrees), which
+// makes LatLng a Point.
+func (p LatLng) LatLon() (lat, lon float64) {
+	return p.Lat, p.Lon
+}
+
+// Point is any coordinate type that can report its latitude and longitude.
+// The convenience functions, such as PolylineLength, accept slices of any
+// Point type, so there is no need to copy points into a []LatLng first.
+type Point interface {
+	LatLon() (lat, lon float64)
+}
@@ -0,0 +1,124 @@
+package geodesic
+
+import "math"
+
+// LambertConformalConic is the Lambert conformal conic projection of an
+// ellipsoid, which is used for aeronautical charts, for the State Plane
+// zones that are wide from east to west, and for many national grids. It
+// is safe for concurrent use.
+//
+// The cone of the projection cuts the ellipsoid on two standard parallels,
+// on which the scale is the same, or touches it on one, and the scale
+// grows away from them. The projection is computed in closed form, by way
+// of the isometric latitude, which is accurate to rounding away from the
+// poles; the standard parallels should not be so close together that
+// their isometric latitudes differ by only a few bits.
+type LambertConformalConic struct {
+	conformal
+	a float64
+	n float64 // the cone constant, the sine of the latitude of least scale
+	r float64 // the radius of the equator on the map (meters)
+}
+
+// NewLambertConformalConic returns the Lambert conformal conic projection
+// of the ellipsoid.
+//
+// Param lat1 is the first standard parallel (degrees).
+// Param lat2 is the second standard parallel (degrees), which is the same
+// as lat1 for a projection with one standard parallel.
+// Param k is the scale on the standard parallels, which is 1 for most
+// projections with two standard parallels.
+//
+// The standard parallels must be in the same hemisphere, and not both on
+// the equator, where the cone becomes a cylinder; Forward and Reverse then
+// return NaNs.
+func (e *Ellipsoid) NewLambertConformalConic(lat1, lat2, k float64,
+) *LambertConformalConic {
+	lc := &LambertConformalConic{conformal: newConformal(e.Flattening()),
+		a: e.Radius()}
+	m1, psi1 := lc.parallel(lat1)
+	if lat1 == lat2 {
+		lc.n, _ = sincosd(lat1)
+	} else {
+		m2, psi2 := lc.parallel(lat2)
+		lc.n = math.Log(m1/m2) / (psi2 - psi1)
+	}
+	if !(math.Abs(lc.n) > 0) || lat1*lat2 < 0 {
+		lc.n = math.NaN()
+	}
+	// The scale on the first parallel, n*r*exp(-n*psi1)/(a*m1), is k.
+	lc.r = k * lc.a * m1 * math.Exp(lc.n*psi1) / lc.n
+	return lc
+}
+
+// parallel returns the radius of the parallel at a latitude over a, and the
+// isometric latitude.
+func (lc *LambertConformalConic) parallel(lat float64) (m, psi float64) {
+	sphi, cphi := sincosd(lat)
+	m = cphi / math.Sqrt(1-lc.e2*sphi*sphi)
+	if math.Abs(lat) == 90 {
+		return 0, math.Copysign(math.Inf(1), lat)
+	}
+	return m, math.Asinh(lc.taupf(sphi / cphi))
+}
+
+// ConeConstant returns the ratio of the angle between two meridians on the
+// map to the difference of their longitudes, which is the sine of the
+// latitude at which the scale is least.
+func (lc *LambertConformalConic) ConeConstant() float64 {
+	return lc.n
+}
+
+// Forward projects a point.
+//
+// Param lon0 is the central meridian (degrees).
+// Param lat is the latitude of the point (degrees).
+// Param lon is the longitude of the point (degrees).
+// Returns the easting x and northing y of the point (meters), the meridian
+// convergence gamma, which is the bearing of grid north clockwise from
+// true north (degrees), and the scale k.
+//
+// The origin of x and y is on the central meridian at the equator, with no
+// false easting or northing. For an origin at a latitude lat0, as most
+// grids have, subtract the northing of the point at lat0 on the central
+// meridian. The pole in the hemisphere of the standard parallels is at the
+// apex of the cone, and the other pole is at infinity; the scale is
+// infinite at both.
+func (lc *LambertConformalConic) Forward(lon0, lat, lon float64,
+) (x, y, gamma, k float64) {
+	if !(math.Abs(lat) <= 90) {
+		nan := math.NaN()
+		return nan, nan, nan, nan
+	}
+	m, psi := lc.parallel(lat)
+	rho := lc.r * math.Exp(-lc.n*psi)
+	theta := lc.n * math.Remainder(lon-lon0, 360)
+	s, c := sincosd(theta)
+	x, y = rho*s, lc.r-rho*c
+	k = math.Inf(1)
+	if m != 0 {
+		k = lc.n * rho / (lc.a * m)
+	}
+	return x, y, theta, k
+}
+
+// Reverse unprojects a point.
+//
+// Param lon0 is the central meridian (degrees).
+// Param x is the easting of the point (meters).
+// Param y is the northing of the point (meters).
+// Returns the latitude and longitude of the point (degrees), the meridian
+// convergence gamma (degrees), and the scale k, see
+// LambertConformalConic.Forward.
+func (lc *LambertConformalConic) Reverse(lon0, x, y float64,
+) (lat, lon, gamma, k float64) {
+	sn := math.Copysign(1, lc.n)
+	dy := lc.r - y
+	rho := sn * math.Hypot(x, dy)
+	theta := math.Atan2(sn*x, sn*dy) * (180 / math.Pi)
+	psi := math.Log(lc.r/rho) / lc.n
+	lat = math.Atan(lc.tauf(math.Sinh(psi))) * (180 / math.Pi)
+	lon = normLon(lon0 + theta/lc.n)
+	_, _, gamma, k = lc.Forward(lon0, lat, lon)
+	return lat, lon, gamma, k
+}
@@ -0,0 +1,87 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLambertConformalConic(t *testing.T) {
+	clarke := NewEllipsoid(6378206.4, 1/294.9786982)
+	// The examples of EPSG Guidance Note 7-2: Jamaica 1969 with one
+	// standard parallel, and Texas South Central, on NAD27, with two and a
+	// grid in US survey feet.
+	lc := clarke.NewLambertConformalConic(18, 18, 1)
+	_, y0, _, _ := lc.Forward(-77, 18, -77)
+	lat := 17 + 55/60.0 + 55.80/3600
+	lon := -(76 + 56/60.0 + 37.26/3600)
+	x, y, _, _ := lc.Forward(-77, lat, lon)
+	if !eqish(x+250000, 255966.58, 2) || !eqish(y-y0+150000, 142493.51, 2) {
+		t.Fatalf("expected '255966.58, 142493.51', got '%v, %v'", x+250000,
+			y-y0+150000)
+	}
+	lat2, lon2, _, _ := lc.Reverse(-77, x, y)
+	if !eqish(lat2, lat, 10) || !eqish(lon2, lon, 10) {
+		t.Fatalf("expected '%v, %v', got '%v, %v'", lat, lon, lat2, lon2)
+	}
+	const ft = 1200.0 / 3937
+	lc = clarke.NewLambertConformalConic(28+23/60.0, 30+17/60.0, 1)
+	_, y0, _, _ = lc.Forward(-99, 27+50/60.0, -99)
+	x, y, _, _ = lc.Forward(-99, 28.5, -96)
+	if !eqish(x/ft+2000000, 2963503.91, 2) || !eqish((y-y0)/ft, 254759.80, 2) {
+		t.Fatalf("expected '2963503.91, 254759.80', got '%v, %v'",
+			x/ft+2000000, (y-y0)/ft)
+	}
+	for _, p := range []float64{28 + 23/60.0, 30 + 17/60.0} {
+		if _, _, _, k := lc.Forward(-99, p, -90); !eqish(k, 1, 12) {
+			t.Fatalf("expected a scale of 1 on the standard parallel, got %v",
+				k)
+		}
+	}
+	if _, _, _, k := lc.Forward(-99, math.Asin(lc.ConeConstant())*(180/math.Pi),
+		-99); !(k < 1) {
+		t.Fatalf("expected the least scale, got %v", k)
+	}
+	if x, y, _, _ := lc.Forward(-99, 90, 10); x != 0 || !eqish(y, lc.r, 6) {
+		t.Fatalf("expected the apex, got %v, %v", x, y)
+	}
+	for _, lat := range [][2]float64{{0, 0}, {-30, 30}} {
+		lc := WGS84.NewLambertConformalConic(lat[0], lat[1], 1)
+		if x, _, _, _ := lc.Forward(0, 10, 10); !math.IsNaN(x) {
+			t.Fatalf("%v: expected NaN, got %v", lat, x)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, lc := range []*LambertConformalConic{
+		WGS84.NewLambertConformalConic(33, 45, 1),
+		WGS84.NewLambertConformalConic(-35, -15, 0.9999),
+		WGS84.NewLambertConformalConic(60, 60, 0.999),
+	} {
+		for i := 0; i < 300; i++ {
+			lat := rng.Float64()*170 - 85
+			lon := rng.Float64()*300 - 150
+			x, y, gamma, k := lc.Forward(0, lat, lon)
+			lat2, lon2, gamma2, k2 := lc.Reverse(0, x, y)
+			if !eqish(lat, lat2, 9) || !eqish(lon, lon2, 9) ||
+				!eqish(gamma, gamma2, 9) || !eqish(k/k2, 1, 9) {
+				t.Fatalf("expected '%v, %v, %v, %v', got '%v, %v, %v, %v'",
+					lat, lon, gamma, k, lat2, lon2, gamma2, k2)
+			}
+			// The scale is the ratio of a short step on the map to the
+			// same step on the ellipsoid, and grid north is gamma east of
+			// true north.
+			var lat3, lon3 float64
+			WGS84.Direct(lat, lon, 0, 1, &lat3, &lon3, nil)
+			x3, y3, _, _ := lc.Forward(0, lat3, lon3)
+			if ratio := math.Hypot(x3-x, y3-y); !eqish(ratio/k, 1, seriesPrec(5)) {
+				t.Fatalf("expected a scale of %v, got %v", k, ratio)
+			}
+			if azi := math.Atan2(x3-x, y3-y) * (180 / math.Pi); !eqish(
+				math.Remainder(azi+gamma, 360), 0, 4) {
+				t.Fatalf("expected grid north %v from true north, got %v",
+					gamma, -azi)
+			}
+		}
+	}
+}
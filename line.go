@@ -0,0 +1,163 @@
+package geodesic
+
+import v2 "github.com/tidwall/geodesic_cgo/v2"
+
+// Line struct for computing many points along a single geodesic.
+// This must be initialized from Ellipsoid.LineInit, Ellipsoid.DirectLine,
+// or Ellipsoid.InverseLine before use.
+type Line struct {
+	l        v2.Line
+	azimuths AzimuthConvention
+}
+
+// LineInit initializes a geodesic line starting at a point with an azimuth.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param azi1 is the azimuth at point 1 (degrees).
+//
+// lat1 should be in the range [-90,+90].
+// When initialized by this function, point 3 is undefined and
+// Line.Distance() returns NaN.
+func (e *Ellipsoid) LineInit(lat1, lon1, azi1 float64) Line {
+	var l Line
+	e.lineInit(&l, lat1, lon1, azi1)
+	return l
+}
+
+// lineInit initializes *l in place, see Ellipsoid.LineInit.
+func (e *Ellipsoid) lineInit(l *Line, lat1, lon1, azi1 float64) {
+	lat1 = e.latitudes.fix(lat1)
+	e.g.InitLine(&l.l, v2.LatLng{Lat: lat1, Lon: lon1}, azi1)
+	l.azimuths = e.azimuths
+}
+
+// DirectLine initializes a geodesic line in terms of the direct geodesic
+// problem.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+//
+// Point 3 of the line is set to point 2 of the direct geodesic problem.
+func (e *Ellipsoid) DirectLine(lat1, lon1, azi1, s12 float64) Line {
+	var l Line
+	e.directLine(&l, lat1, lon1, azi1, s12)
+	return l
+}
+
+// directLine initializes *l in place, see Ellipsoid.DirectLine.
+func (e *Ellipsoid) directLine(l *Line, lat1, lon1, azi1, s12 float64) {
+	lat1 = e.latitudes.fix(lat1)
+	e.g.InitDirectLine(&l.l, v2.LatLng{Lat: lat1, Lon: lon1}, azi1, s12)
+	l.azimuths = e.azimuths
+}
+
+// InverseLine initializes a geodesic line in terms of the inverse geodesic
+// problem.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param lat2 is the latitude of point 2 (degrees).
+// Param lon2 is the longitude of point 2 (degrees).
+//
+// Point 3 of the line is set to point 2 of the inverse geodesic problem.
+func (e *Ellipsoid) InverseLine(lat1, lon1, lat2, lon2 float64) Line {
+	var l Line
+	e.inverseLine(&l, lat1, lon1, lat2, lon2)
+	return l
+}
+
+// inverseLine initializes *l in place, see Ellipsoid.InverseLine.
+func (e *Ellipsoid) inverseLine(l *Line, lat1, lon1, lat2, lon2 float64) {
+	lat1, lat2 = e.latitudes.fix(lat1), e.latitudes.fix(lat2)
+	e.g.InitInverseLine(&l.l, v2.LatLng{Lat: lat1, Lon: lon1},
+		v2.LatLng{Lat: lat2, Lon: lon2})
+	l.azimuths = e.azimuths
+}
+
+// Position computes the position along the line.
+//
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+// Out param lat2 is a pointer to the latitude of point 2 (degrees).
+// Out param lon2 is a pointer to the longitude of point 2 (degrees).
+// Out param azi2 is a pointer to the (forward) azimuth at point 2 (degrees).
+//
+// The values of lon2 and azi2 returned are in the range [-180,+180], or
+// [0,360) for azi2 if the ellipsoid uses AzimuthUnsigned.
+// Any of the "return" arguments, lat2, etc., may be replaced with nil, if you
+// do not need some quantities computed.
+func (l *Line) Position(s12 float64, lat2, lon2, azi2 *float64) {
+	r, _ := l.l.Position(s12, directCaps(lat2, lon2, azi2))
+	set(lat2, r.Point.Lat)
+	set(lon2, r.Point.Lon)
+	set(azi2, r.Azi2)
+	l.azimuths.apply(azi2)
+}
+
+// ArcPosition computes the position along the line in terms of the
+// spherical arc length.
+//
+// Param a12 is the arc length from point 1 to point 2 (degrees). negative
+// is ok.
+// Out param lat2 is a pointer to the latitude of point 2 (degrees).
+// Out param lon2 is a pointer to the longitude of point 2 (degrees).
+// Out param azi2 is a pointer to the (forward) azimuth at point 2 (degrees).
+//
+// The arc length is measured on the auxiliary sphere, so equal arc steps
+// are nearly, but not exactly, equal distances on the ground.
+// Any of the "return" arguments, lat2, etc., may be replaced with nil.
+func (l *Line) ArcPosition(a12 float64, lat2, lon2, azi2 *float64) {
+	r, _ := l.l.ArcPosition(a12, directCaps(lat2, lon2, azi2))
+	set(lat2, r.Point.Lat)
+	set(lon2, r.Point.Lon)
+	set(azi2, r.Azi2)
+	l.azimuths.apply(azi2)
+}
+
+// Scales computes the reduced length and geodesic scales at a position
+// along the line.
+//
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+// Returns m12 reduced length of the geodesic (meters).
+// Returns M12 geodesic scale of point 2 relative to point 1 (dimensionless).
+// Returns M21 geodesic scale of point 1 relative to point 2 (dimensionless).
+//
+// These are the Jacobian of the geodesic. Turning the line at point 1 by a
+// small angle dazi1 (radians) moves point 2 sideways by m12 dazi1, and
+// moving point 1 sideways by dt, keeping the line parallel, moves point 2
+// sideways by M12 dt. M21 is the same with the roles of the points
+// swapped.
+func (l *Line) Scales(s12 float64) (m12, M12, M21 float64) {
+	r, _ := l.l.Scales(s12)
+	return r.ReducedLength, r.M12, r.M21
+}
+
+// Lat1 returns the latitude of point 1 (degrees).
+func (l *Line) Lat1() float64 {
+	return l.l.Point1().Lat
+}
+
+// Lon1 returns the longitude of point 1 (degrees).
+func (l *Line) Lon1() float64 {
+	return l.l.Point1().Lon
+}
+
+// Azi1 returns the azimuth at point 1 (degrees).
+func (l *Line) Azi1() float64 {
+	azi1 := l.l.Azi1()
+	l.azimuths.apply(&azi1)
+	return azi1
+}
+
+// Distance returns the distance from point 1 to point 3 (meters).
+func (l *Line) Distance() float64 {
+	return l.l.Distance()
+}
+
+// Arc returns the arc length from point 1 to point 3 (degrees). Like
+// Line.Distance, it is NaN for a line from Ellipsoid.LineInit.
+func (l *Line) Arc() float64 {
+	return l.l.Arc()
+}
@@ -0,0 +1,93 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	l := WGS84.InverseLine(40.64, -73.78, 1.36, 103.99)
+	var s12, azi1, azi2 float64
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s12, &azi1, &azi2)
+	if !eqish(l.Distance(), s12, 7) || !eqish(l.Azi1(), azi1, 7) {
+		t.Fatalf("expected '%f, %f', got '%f, %f'",
+			s12, azi1, l.Distance(), l.Azi1())
+	}
+	for i := 0; i <= 100; i++ {
+		d := l.Distance() * float64(i) / 100
+		var lat2, lon2, azi2 float64
+		var lat2ret, lon2ret, azi2ret float64
+		WGS84.Direct(40.64, -73.78, azi1, d, &lat2, &lon2, &azi2)
+		l.Position(d, &lat2ret, &lon2ret, &azi2ret)
+		if !eqish(lat2ret, lat2, 7) || !eqish(lon2ret, lon2, 7) ||
+			!eqish(azi2ret, azi2, 7) {
+			t.Fatalf("expected '%f, %f, %f', got '%f, %f, %f'",
+				lat2, lon2, azi2, lat2ret, lon2ret, azi2ret)
+		}
+	}
+	l = WGS84.LineInit(10, 20, 30)
+	if !math.IsNaN(l.Distance()) {
+		t.Fatalf("expected NaN, got %f", l.Distance())
+	}
+	l = WGS84.DirectLine(10, 20, 30, 5000)
+	if l.Distance() != 5000 || l.Lat1() != 10 || l.Lon1() != 20 {
+		t.Fatalf("unexpected line %v, %v, %v", l.Distance(), l.Lat1(), l.Lon1())
+	}
+}
+
+func TestLinePoints(t *testing.T) {
+	l := WGS84.InverseLine(0, 0, 0, 1)
+	var pts []LatLng
+	for p := range l.Points(10000) {
+		pts = append(pts, p)
+	}
+	if len(pts) != 13 || pts[0] != (LatLng{0, 0}) ||
+		!eqish(pts[12].Lon, 1, 9) {
+		t.Fatalf("unexpected points %v", pts)
+	}
+	// An open ended line runs until the caller stops.
+	l = WGS84.LineInit(0, 0, 90)
+	n := 0
+	for range l.Points(1000) {
+		n++
+		if n == 1000 {
+			break
+		}
+	}
+	if n != 1000 {
+		t.Fatalf("expected 1000 points, got %d", n)
+	}
+	n = 0
+	for range l.Points(0) {
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 point, got %d", n)
+	}
+}
+
+func TestArcPosition(t *testing.T) {
+	l := WGS84.InverseLine(40.64, -73.78, 1.36, 103.99)
+	if a := l.Arc(); !(a > 0 && a < 180) {
+		t.Fatalf("expected an arc in (0, 180), got %v", a)
+	}
+	var lat, lon float64
+	l.ArcPosition(l.Arc(), &lat, &lon, nil)
+	if !eqish(lat, 1.36, 9) || !eqish(lon, 103.99, 9) {
+		t.Fatalf("expected '1.36, 103.99', got '%v, %v'", lat, lon)
+	}
+	// On the equator the arc length is the longitude difference on the
+	// auxiliary sphere, which is stretched by 1/(1-f).
+	e := WGS84.InverseLine(0, 0, 0, 10)
+	if want := 10 / (1 - WGS84.Flattening()); !eqish(e.Arc(), want, 9) {
+		t.Fatalf("expected %v, got %v", want, e.Arc())
+	}
+	e.ArcPosition(5, &lat, &lon, nil)
+	if !eqish(lat, 0, 12) || !(lon > 4.9 && lon < 5.1) {
+		t.Fatalf("expected about '0, 5', got '%v, %v'", lat, lon)
+	}
+	l0 := WGS84.LineInit(0, 0, 90)
+	if a := l0.Arc(); !math.IsNaN(a) {
+		t.Fatalf("expected NaN, got %v", a)
+	}
+}
@@ -0,0 +1,245 @@
+// Package magnetic computes the geomagnetic field from a spherical harmonic
+// model such as the World Magnetic Model (WMM), so that the true azimuths
+// of the geodesic package can be turned into magnetic bearings.
+//
+// The package does not ship any coefficients. The WMM is published by
+// NOAA's National Centers for Environmental Information and the British
+// Geological Survey as a WMM.COF file, which is read with ReadCOF, and a
+// new model is released every five years. IGRF coefficients in the same
+// format may also be used.
+package magnetic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/geodesic_cgo"
+)
+
+// refRadius is the geomagnetic reference radius of the WMM (meters).
+const refRadius = 6371200
+
+// Model is a spherical harmonic model of the main geomagnetic field.
+type Model struct {
+	Name  string  // such as "WMM-2025"
+	Epoch float64 // decimal year of the coefficients
+	N     int     // degree of the model
+
+	// Gauss coefficients and their yearly change (nanotesla), indexed by
+	// degree and order.
+	g, h, gdot, hdot [][]float64
+}
+
+// ReadCOF reads a model in the WMM.COF format: a header line with the
+// epoch and model name, followed by one line of "n m g h gdot hdot" per
+// coefficient, and optionally ended by a line of 9s.
+func ReadCOF(r io.Reader) (*Model, error) {
+	sc := bufio.NewScanner(r)
+	m := new(Model)
+	type coef struct {
+		n, m int
+		v    [4]float64
+	}
+	var coefs []coef
+	header := false
+scan:
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		switch {
+		case len(fields) == 0:
+			continue
+		case strings.HasPrefix(fields[0], "9999"):
+			break scan
+		case !header:
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: missing epoch and name", line)
+			}
+			epoch, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			m.Epoch, m.Name = epoch, fields[1]
+			header = true
+			continue
+		}
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("line %d: expected 6 fields, got %d",
+				line, len(fields))
+		}
+		var c coef
+		var err error
+		if c.n, err = strconv.Atoi(fields[0]); err == nil {
+			c.m, err = strconv.Atoi(fields[1])
+		}
+		for i := 0; err == nil && i < 4; i++ {
+			c.v[i], err = strconv.ParseFloat(fields[2+i], 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if c.n < 1 || c.m < 0 || c.m > c.n {
+			return nil, fmt.Errorf("line %d: invalid degree and order %d %d",
+				line, c.n, c.m)
+		}
+		coefs = append(coefs, c)
+		m.N = max(m.N, c.n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, errors.New("missing header line")
+	}
+	if len(coefs) == 0 {
+		return nil, errors.New("no coefficients")
+	}
+	tri := func() [][]float64 {
+		t := make([][]float64, m.N+1)
+		for n := range t {
+			t[n] = make([]float64, n+1)
+		}
+		return t
+	}
+	m.g, m.h, m.gdot, m.hdot = tri(), tri(), tri(), tri()
+	for _, c := range coefs {
+		m.g[c.n][c.m], m.h[c.n][c.m] = c.v[0], c.v[1]
+		m.gdot[c.n][c.m], m.hdot[c.n][c.m] = c.v[2], c.v[3]
+	}
+	return m, nil
+}
+
+// Field is the geomagnetic field at a point.
+type Field struct {
+	X, Y, Z     float64 // north, east, and down components (nanotesla)
+	H, F        float64 // horizontal and total intensity (nanotesla)
+	Declination float64 // angle of H east of true north (degrees)
+	Inclination float64 // angle of the field below horizontal (degrees)
+}
+
+// MagneticAzimuth converts a true azimuth, such as one from the geodesic
+// package, to a magnetic bearing at the point of the field (degrees). The
+// result is in the range [-180,+180].
+func (f Field) MagneticAzimuth(azi float64) float64 {
+	return math.Remainder(azi-f.Declination, 360)
+}
+
+// TrueAzimuth converts a magnetic bearing to a true azimuth (degrees). The
+// result is in the range [-180,+180].
+func (f Field) TrueAzimuth(bearing float64) float64 {
+	return math.Remainder(bearing+f.Declination, 360)
+}
+
+// DecimalYear returns t as a decimal year, such as 2025.5 for the middle
+// of 2025, which is the time scale of the models.
+func DecimalYear(t time.Time) float64 {
+	t = t.UTC()
+	start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	return float64(t.Year()) + float64(t.Sub(start))/float64(end.Sub(start))
+}
+
+// Field returns the field at a point and time.
+//
+// Param lat is the latitude of the point on WGS84 (degrees).
+// Param lon is the longitude of the point (degrees).
+// Param height is the height of the point above WGS84 (meters).
+// Param year is the time as a decimal year, see DecimalYear.
+//
+// The coefficients are moved from the epoch of the model to the year with
+// their linear rates of change. The WMM is only valid for the five years
+// from its epoch, and it is the caller's choice whether to use it outside
+// of that. The declination is undefined at the geographic poles, where it
+// is NaN, and is poorly determined near the magnetic poles.
+func (m *Model) Field(lat, lon, height, year float64) Field {
+	// Geocentric spherical coordinates.
+	a, f := geodesic.WGS84.Radius(), geodesic.WGS84.Flattening()
+	e2 := f * (2 - f)
+	sphi, cphi := math.Sincos(lat * (math.Pi / 180))
+	if math.Abs(lat) == 90 {
+		cphi = 0
+	}
+	rc := a / math.Sqrt(1-e2*sphi*sphi)
+	p := (rc + height) * cphi
+	z := (rc*(1-e2) + height) * sphi
+	r := math.Hypot(p, z)
+	phic := math.Asin(z / r)
+	x, s := math.Sin(phic), math.Cos(phic)
+
+	// Schmidt semi-normalized associated Legendre functions of sin(phic),
+	// and their derivatives with respect to the colatitude.
+	n1 := m.N + 1
+	pnm := make([]float64, n1*n1)
+	dnm := make([]float64, n1*n1)
+	at := func(n, mm int) int { return n*n1 + mm }
+	pnm[at(0, 0)] = 1
+	for n := 1; n <= m.N; n++ {
+		for mm := 0; mm <= n; mm++ {
+			switch {
+			case n == mm && n == 1:
+				pnm[at(1, 1)], dnm[at(1, 1)] = s, x
+			case n == mm:
+				k := math.Sqrt(float64(2*n-1) / float64(2*n))
+				pp, dp := pnm[at(n-1, n-1)], dnm[at(n-1, n-1)]
+				pnm[at(n, n)] = k * s * pp
+				dnm[at(n, n)] = k * (x*pp + s*dp)
+			default:
+				k := math.Sqrt(float64(n*n - mm*mm))
+				p1, d1 := pnm[at(n-1, mm)], dnm[at(n-1, mm)]
+				var p2, d2 float64
+				if n >= mm+2 {
+					k2 := math.Sqrt(float64((n-1)*(n-1) - mm*mm))
+					p2, d2 = k2*pnm[at(n-2, mm)], k2*dnm[at(n-2, mm)]
+				}
+				pnm[at(n, mm)] = (float64(2*n-1)*x*p1 - p2) / k
+				dnm[at(n, mm)] = (float64(2*n-1)*(x*d1-s*p1) - d2) / k
+			}
+		}
+	}
+
+	// Field in geocentric north, east, and down.
+	dt := year - m.Epoch
+	var xs, ys, zs float64
+	ar := refRadius / r
+	arn := ar * ar
+	for n := 1; n <= m.N; n++ {
+		arn *= ar
+		for mm := 0; mm <= n; mm++ {
+			g := m.g[n][mm] + dt*m.gdot[n][mm]
+			h := m.h[n][mm] + dt*m.hdot[n][mm]
+			sml, cml := math.Sincos(float64(mm) * lon * (math.Pi / 180))
+			gh := g*cml + h*sml
+			xs += arn * gh * dnm[at(n, mm)]
+			ys += arn * float64(mm) * (g*sml - h*cml) * pnm[at(n, mm)]
+			zs -= arn * float64(n+1) * gh * pnm[at(n, mm)]
+		}
+	}
+	ys /= s
+
+	// Rotate to the ellipsoidal frame.
+	psi := phic - lat*(math.Pi/180)
+	spsi, cpsi := math.Sincos(psi)
+	var fld Field
+	fld.X = xs*cpsi - zs*spsi
+	fld.Y = ys
+	fld.Z = xs*spsi + zs*cpsi
+	fld.H = math.Hypot(fld.X, fld.Y)
+	fld.F = math.Hypot(fld.H, fld.Z)
+	fld.Declination = math.Atan2(fld.Y, fld.X) * (180 / math.Pi)
+	fld.Inclination = math.Atan2(fld.Z, fld.H) * (180 / math.Pi)
+	if cphi == 0 {
+		fld.Declination = math.NaN()
+	}
+	return fld
+}
+
+// Declination returns the declination at a point and time, the angle of
+// magnetic north east of true north (degrees). See Model.Field.
+func (m *Model) Declination(lat, lon, height float64, t time.Time) float64 {
+	return m.Field(lat, lon, height, DecimalYear(t)).Declination
+}
@@ -0,0 +1,165 @@
+package magnetic
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCOF is a made up degree 2 model in the WMM.COF format.
+const testCOF = `    2020.0            TEST-2020        01/01/2020
+  1  0  -29000.0       0.0        5.0        0.0
+  1  1   -1500.0    4600.0        8.0      -25.0
+  2  0   -2500.0       0.0      -11.0        0.0
+  2  1    3000.0   -2900.0       -7.0      -30.0
+  2  2    1700.0    -700.0       -2.0      -20.0
+999999999999999999999999999999999999999999999999
+999999999999999999999999999999999999999999999999
+`
+
+func readTestModel(t *testing.T) *Model {
+	t.Helper()
+	m, err := ReadCOF(strings.NewReader(testCOF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func eqish(x, y float64, prec int) bool {
+	return math.Abs(x-y) < math.Pow10(-prec)
+}
+
+func TestReadCOF(t *testing.T) {
+	m := readTestModel(t)
+	if m.Name != "TEST-2020" || m.Epoch != 2020 || m.N != 2 {
+		t.Fatalf("unexpected header %v %v %v", m.Name, m.Epoch, m.N)
+	}
+	if m.g[1][1] != -1500 || m.h[2][2] != -700 || m.hdot[1][1] != -25 {
+		t.Fatalf("unexpected coefficients")
+	}
+	for _, bad := range []string{
+		"",
+		"2020.0\n",
+		"2020.0 X\n",
+		"2020.0 X\n1 0 1 2 3\n",
+		"2020.0 X\n1 2 1 2 3 4\n",
+		"x X\n1 0 1 2 3 4\n",
+	} {
+		if _, err := ReadCOF(strings.NewReader(bad)); err == nil {
+			t.Fatalf("%q: expected error", bad)
+		}
+	}
+}
+
+// schmidt returns the Schmidt semi-normalized associated Legendre
+// function of degree n <= 2 and order m at cos(theta) = x.
+func schmidt(n, m int, x float64) float64 {
+	s := math.Sqrt(1 - x*x)
+	switch [2]int{n, m} {
+	case [2]int{1, 0}:
+		return x
+	case [2]int{1, 1}:
+		return s
+	case [2]int{2, 0}:
+		return (3*x*x - 1) / 2
+	case [2]int{2, 1}:
+		return math.Sqrt(3) * x * s
+	case [2]int{2, 2}:
+		return math.Sqrt(3) / 2 * s * s
+	}
+	panic("unsupported")
+}
+
+// potential returns the magnetic potential of the model at a point in
+// earth-centered coordinates.
+func (m *Model) potential(x, y, z, year float64) float64 {
+	r := math.Sqrt(x*x + y*y + z*z)
+	ct := z / r
+	lon := math.Atan2(y, x)
+	dt := year - m.Epoch
+	var v float64
+	for n := 1; n <= m.N; n++ {
+		for mm := 0; mm <= n; mm++ {
+			g := m.g[n][mm] + dt*m.gdot[n][mm]
+			h := m.h[n][mm] + dt*m.hdot[n][mm]
+			v += refRadius * math.Pow(refRadius/r, float64(n+1)) *
+				(g*math.Cos(float64(mm)*lon) + h*math.Sin(float64(mm)*lon)) *
+				schmidt(n, mm, ct)
+		}
+	}
+	return v
+}
+
+func TestField(t *testing.T) {
+	m := readTestModel(t)
+	const a, f = 6378137.0, 1 / 298.257223563
+	e2 := f * (2 - f)
+	for _, c := range [][4]float64{
+		{0, 0, 0, 2020},
+		{45, -100, 0, 2022.5},
+		{-33.9, 151.2, 3000, 2024},
+		{80, 20, 10000, 2021},
+		{-70, -60, 0, 2020},
+	} {
+		lat, lon, h, year := c[0], c[1], c[2], c[3]
+		got := m.Field(lat, lon, h, year)
+
+		// The field is minus the gradient of the potential, projected on
+		// north, east, and down at the point.
+		phi, lam := lat*math.Pi/180, lon*math.Pi/180
+		sphi, cphi := math.Sincos(phi)
+		slam, clam := math.Sincos(lam)
+		n := a / math.Sqrt(1-e2*sphi*sphi)
+		p := [3]float64{(n + h) * cphi * clam, (n + h) * cphi * slam,
+			(n*(1-e2) + h) * sphi}
+		var grad [3]float64
+		const d = 1.0
+		for i := range grad {
+			p1, p2 := p, p
+			p1[i] -= d
+			p2[i] += d
+			grad[i] = (m.potential(p2[0], p2[1], p2[2], year) -
+				m.potential(p1[0], p1[1], p1[2], year)) / (2 * d)
+		}
+		north := [3]float64{-sphi * clam, -sphi * slam, cphi}
+		east := [3]float64{-slam, clam, 0}
+		down := [3]float64{-cphi * clam, -cphi * slam, -sphi}
+		dot := func(u [3]float64) float64 {
+			return -(grad[0]*u[0] + grad[1]*u[1] + grad[2]*u[2])
+		}
+		want := [3]float64{dot(north), dot(east), dot(down)}
+		if !eqish(got.X, want[0], 4) || !eqish(got.Y, want[1], 4) ||
+			!eqish(got.Z, want[2], 4) {
+			t.Fatalf("%v: expected %v, got %v %v %v", c, want,
+				got.X, got.Y, got.Z)
+		}
+		decl := math.Atan2(want[1], want[0]) * 180 / math.Pi
+		if !eqish(got.Declination, decl, 6) {
+			t.Fatalf("%v: expected %v, got %v", c, decl, got.Declination)
+		}
+	}
+	if d := m.Field(90, 0, 0, 2020).Declination; !math.IsNaN(d) {
+		t.Fatalf("expected NaN at the pole, got %v", d)
+	}
+}
+
+func TestAzimuths(t *testing.T) {
+	f := Field{Declination: -12}
+	if b := f.MagneticAzimuth(175); b != -173 {
+		t.Fatalf("expected -173, got %v", b)
+	}
+	if a := f.TrueAzimuth(-173); a != 175 {
+		t.Fatalf("expected 175, got %v", a)
+	}
+	m := readTestModel(t)
+	ts := time.Date(2022, 7, 2, 12, 0, 0, 0, time.UTC)
+	want := m.Field(45, -100, 0, DecimalYear(ts)).Declination
+	if d := m.Declination(45, -100, 0, ts); d != want {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	if y := DecimalYear(time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)); !eqish(y, 2024.5, 9) {
+		t.Fatalf("expected 2024.5, got %v", y)
+	}
+}
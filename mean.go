@@ -0,0 +1,39 @@
+package geodesic
+
+import "math"
+
+// MeanPosition returns the geographic mean of a set of points.
+//
+// Param pts is the set of points.
+// Returns the mean position.
+//
+// The points are converted to earth-centered earth-fixed coordinates,
+// averaged, and the average is projected back onto the ellipsoid along the
+// surface normal. Unlike averaging the raw latitudes and longitudes, this
+// works across the antimeridian and near the poles. The mean is undefined
+// when the points balance around the center of the earth, such as two
+// antipodal points, or when pts is empty, and then NaN values are returned.
+func (e *Ellipsoid) MeanPosition(pts []LatLng) LatLng {
+	return e.weightedMean(pts, nil)
+}
+
+// weightedMean returns the geographic mean of the points, each multiplied
+// by the matching weight. A nil weights gives all points the same weight.
+func (e *Ellipsoid) weightedMean(pts []LatLng, weights []float64) LatLng {
+	var sx, sy, sz, n float64
+	for i, p := range pts {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		x, y, z := e.toECEF(p.Lat, p.Lon, 0)
+		sx, sy, sz, n = sx+w*x, sy+w*y, sz+w*z, n+w
+	}
+	sx, sy, sz = sx/n, sy/n, sz/n
+	// Treat averages within a millimeter of the center as undefined.
+	if !(n > 0) || math.Sqrt(sx*sx+sy*sy+sz*sz) < 1e-3 {
+		return LatLng{math.NaN(), math.NaN()}
+	}
+	lat, lon, _ := e.fromECEF(sx, sy, sz)
+	return LatLng{lat, lon}
+}
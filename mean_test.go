@@ -0,0 +1,25 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeanPosition(t *testing.T) {
+	m := WGS84.MeanPosition([]LatLng{{10, 179}, {-10, -179}})
+	if !eqish(m.Lat, 0, 9) || !eqish(math.Abs(m.Lon), 180, 9) {
+		t.Fatalf("expected a point on the antimeridian, got %v", m)
+	}
+	m = WGS84.MeanPosition([]LatLng{{80, 0}, {80, 90}, {80, 180}, {80, -90}})
+	if !eqish(m.Lat, 90, 9) {
+		t.Fatalf("expected the north pole, got %v", m)
+	}
+	m = WGS84.MeanPosition([]LatLng{{30, 40}})
+	if !eqish(m.Lat, 30, 9) || !eqish(m.Lon, 40, 9) {
+		t.Fatalf("expected the point itself, got %v", m)
+	}
+	m = WGS84.MeanPosition([]LatLng{{0, 0}, {0, 180}})
+	if !math.IsNaN(m.Lat) || !math.IsNaN(m.Lon) {
+		t.Fatalf("expected NaN, got %v", m)
+	}
+}
@@ -0,0 +1,179 @@
+package geodesic
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// PolylineLength returns the total geodesic length of a polyline (meters).
+func PolylineLength[P Point](e *Ellipsoid, pts []P) float64 {
+	a := e.getArena()
+	defer putArena(a)
+	p := a.PolygonInit(true)
+	for _, pt := range pts {
+		p.AddPoint(pt.LatLon())
+	}
+	var perimeter float64
+	p.Compute(false, false, nil, &perimeter)
+	return perimeter
+}
+
+// PolygonArea returns the area and perimeter of a polygon ring.
+//
+// Param e is the ellipsoid.
+// Param ring is the vertices of the polygon. There's no need to "close" the
+// polygon by repeating the first vertex.
+// Out area is the area of the polygon (meters-squared), which by default is
+// positive for counter-clockwise rings and negative for clockwise rings.
+// See Ellipsoid.WithAreas for the other conventions.
+// Out perimeter is the perimeter of the polygon (meters).
+func PolygonArea[P Point](e *Ellipsoid, ring []P) (area, perimeter float64) {
+	a := e.getArena()
+	defer putArena(a)
+	p := a.PolygonInit(false)
+	for _, pt := range ring {
+		p.AddPoint(pt.LatLon())
+	}
+	reverse, sign := e.areas.flags()
+	p.Compute(reverse, sign, &area, &perimeter)
+	return area, perimeter
+}
+
+// CheckedPolygonArea returns the area and perimeter of a polygon ring, as
+// PolygonArea, and reports the problems with the ring as errors.
+//
+// Param e is the ellipsoid.
+// Param ring is the vertices of the polygon.
+// Returns the area (meters-squared) and perimeter (meters), or an error:
+// ErrInvalidLatitude if a latitude is out of range under the
+// LatitudePolicy of the ellipsoid, or ErrInvalidLongitude if a longitude is
+// not a finite number, in which case the results are NaN, or
+// ErrDegeneratePolygon if the ring has fewer than three distinct vertices,
+// see CleanRing, in which case the results are still returned. The errors
+// about a vertex are wrapped with its index.
+func CheckedPolygonArea[P Point](e *Ellipsoid, ring []P,
+) (area, perimeter float64, err error) {
+	pts, err := checkPoints(e, ring)
+	if err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+	area, perimeter = PolygonArea(e, pts)
+	if _, r := CleanRing(pts); r.Degenerate {
+		return area, perimeter, ErrDegeneratePolygon
+	}
+	return area, perimeter, nil
+}
+
+// checkPoints returns the points with the LatitudePolicy of the ellipsoid
+// applied, or the error of the first point that is not valid, see
+// Ellipsoid.checkPoint, wrapped with its index.
+func checkPoints[P Point](e *Ellipsoid, pts []P) ([]LatLng, error) {
+	out := make([]LatLng, len(pts))
+	for i, pt := range pts {
+		lat, lon := pt.LatLon()
+		p, err := e.checkPoint(LatLng{lat, lon})
+		if err != nil {
+			return nil, fmt.Errorf("%w at vertex %d", err, i)
+		}
+		out[i] = p
+	}
+	return out, nil
+}
+
+// checkPoint returns p with the LatitudePolicy of the ellipsoid applied, or
+// ErrInvalidLatitude if its latitude is out of range, or
+// ErrInvalidLongitude if its longitude is not a finite number.
+func (e *Ellipsoid) checkPoint(p LatLng) (LatLng, error) {
+	if err := e.latitudes.check(p.Lat); err != nil {
+		return p, err
+	}
+	if math.IsNaN(p.Lon) || math.IsInf(p.Lon, 0) {
+		return p, fmt.Errorf("%w: %v", ErrInvalidLongitude, p.Lon)
+	}
+	return LatLng{e.latitudes.fix(p.Lat), p.Lon}, nil
+}
+
+// Densify returns a polyline with points inserted along each geodesic
+// segment so that no segment is longer than maxSegment.
+//
+// Param e is the ellipsoid.
+// Param pts is the polyline.
+// Param maxSegment is the maximum segment length (meters).
+// Returns the densified polyline, which includes all of the original
+// vertices.
+//
+// Each segment is split into the fewest equal parts that satisfy
+// maxSegment. A non-positive maxSegment returns a copy of the vertices.
+func Densify[P Point](e *Ellipsoid, pts []P, maxSegment float64) []LatLng {
+	return slices.Collect(DensifySeq(e, pts, maxSegment))
+}
+
+// DensifySeq is like Densify, but yields the points one at a time rather
+// than allocating the whole polyline.
+func DensifySeq[P Point](e *Ellipsoid, pts []P, maxSegment float64) iter.Seq[LatLng] {
+	return densify(e, pts, maxSegment, false)
+}
+
+// DensifyArc returns a polyline with points inserted along each geodesic
+// segment so that no segment spans more than maxArc of arc length.
+//
+// Param e is the ellipsoid.
+// Param pts is the polyline.
+// Param maxArc is the maximum arc length of a segment (degrees).
+// Returns the densified polyline, which includes all of the original
+// vertices.
+//
+// The arc length is measured on the auxiliary sphere, as by
+// Line.ArcPosition. Near antipodal segments, where small changes in the
+// endpoints move the geodesic by large distances, equal arc steps sample
+// the geodesic more evenly than equal distances, and some charts are drawn
+// at fixed angular steps. A non-positive maxArc returns a copy of the
+// vertices.
+func DensifyArc[P Point](e *Ellipsoid, pts []P, maxArc float64) []LatLng {
+	return slices.Collect(DensifyArcSeq(e, pts, maxArc))
+}
+
+// DensifyArcSeq is like DensifyArc, but yields the points one at a time
+// rather than allocating the whole polyline.
+func DensifyArcSeq[P Point](e *Ellipsoid, pts []P, maxArc float64) iter.Seq[LatLng] {
+	return densify(e, pts, maxArc, true)
+}
+
+// densify yields the points of DensifySeq, or of DensifyArcSeq when arc is
+// set. Param step is the longest distance, or arc length, between points.
+func densify[P Point](e *Ellipsoid, pts []P, step float64, arc bool) iter.Seq[LatLng] {
+	return func(yield func(LatLng) bool) {
+		a := e.getArena()
+		defer putArena(a)
+		for i, pt := range pts {
+			lat2, lon2 := pt.LatLon()
+			if i > 0 && step > 0 {
+				a.Reset() // the line of the last segment is done with
+				lat1, lon1 := pts[i-1].LatLon()
+				l := a.InverseLine(lat1, lon1, lat2, lon2)
+				total := l.Distance()
+				if arc {
+					total = l.Arc()
+				}
+				n := int(math.Ceil(total / step))
+				for j := 1; j < n; j++ {
+					var p LatLng
+					at := total * float64(j) / float64(n)
+					if arc {
+						l.ArcPosition(at, &p.Lat, &p.Lon, nil)
+					} else {
+						l.Position(at, &p.Lat, &p.Lon, nil)
+					}
+					if !yield(p) {
+						return
+					}
+				}
+			}
+			if !yield(LatLng{lat2, lon2}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// testPoint is a user coordinate type.
+type testPoint struct {
+	name     string
+	lon, lat float64
+}
+
+func (p testPoint) LatLon() (lat, lon float64) { return p.lat, p.lon }
+
+func TestPolylineLength(t *testing.T) {
+	pts := []testPoint{{"a", 0, 0}, {"b", 1, 0}, {"c", 1, 1}}
+	var s1, s2 float64
+	WGS84.Inverse(0, 0, 0, 1, &s1, nil, nil)
+	WGS84.Inverse(0, 1, 1, 1, &s2, nil, nil)
+	if d := PolylineLength(WGS84, pts); !eqish(d, s1+s2, 6) {
+		t.Fatalf("expected %f, got %f", s1+s2, d)
+	}
+	if d := PolylineLength(WGS84, []LatLng{{0, 0}, {0, 1}}); !eqish(d, s1, 6) {
+		t.Fatalf("expected %f, got %f", s1, d)
+	}
+}
+
+func TestPolygonArea(t *testing.T) {
+	ring := []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	area, perimeter := PolygonArea(WGS84, ring)
+	p := WGS84.PolygonInit(false)
+	for _, pt := range ring {
+		p.AddPoint(pt.Lat, pt.Lon)
+	}
+	var parea, pperimeter float64
+	p.Compute(false, true, &parea, &pperimeter)
+	if area != parea || perimeter != pperimeter || area <= 0 {
+		t.Fatalf("expected '%f, %f', got '%f, %f'",
+			parea, pperimeter, area, perimeter)
+	}
+	cw := []LatLng{ring[3], ring[2], ring[1], ring[0]}
+	if area, _ := PolygonArea(WGS84, cw); !eqish(area, -parea, 3) {
+		t.Fatalf("expected %f, got %f", -parea, area)
+	}
+}
+
+func TestCheckedPolygonArea(t *testing.T) {
+	ring := []testPoint{{"a", 0, 0}, {"b", 1, 0}, {"c", 1, 1}, {"d", 0, 1}}
+	area, perimeter, err := CheckedPolygonArea(WGS84, ring)
+	if a, p := PolygonArea(WGS84, ring); err != nil || area != a ||
+		perimeter != p {
+		t.Fatalf("expected '%v, %v', got '%v, %v', %v", a, p, area,
+			perimeter, err)
+	}
+	ring[2].lat = 91
+	if area, _, err := CheckedPolygonArea(WGS84, ring); !errors.Is(err,
+		ErrInvalidLatitude) || !math.IsNaN(area) {
+		t.Fatalf("expected ErrInvalidLatitude, got %v %v", area, err)
+	}
+	ring[2].lat = 90 + LatitudeClampTolerance/2
+	if _, _, err := CheckedPolygonArea(WGS84.WithLatitudes(LatitudeClamp),
+		ring); err != nil {
+		t.Fatal(err)
+	}
+	ring[2].lat, ring[2].lon = 1, math.Inf(1)
+	if _, _, err := CheckedPolygonArea(WGS84, ring); !errors.Is(err,
+		ErrInvalidLongitude) {
+		t.Fatalf("expected ErrInvalidLongitude, got %v", err)
+	}
+	line := []LatLng{{0, 0}, {1, 1}, {1, 1}, {0, 360}}
+	if area, _, err := CheckedPolygonArea(WGS84, line); !errors.Is(err,
+		ErrDegeneratePolygon) || area != 0 {
+		t.Fatalf("expected ErrDegeneratePolygon, got %v %v", area, err)
+	}
+}
+
+func TestDensify(t *testing.T) {
+	pts := []testPoint{{"a", 0, 0}, {"b", 1, 0}, {"c", 1, 0.01}}
+	out := Densify(WGS84, pts, 10000)
+	// 111 km is split into 12 parts and 1.1 km is left as is.
+	if len(out) != 14 {
+		t.Fatalf("expected 14 points, got %d", len(out))
+	}
+	for i := 0; i < len(out)-1; i++ {
+		if d := WGS84.distance(out[i], out[i+1]); d > 10000 {
+			t.Fatalf("segment %d is %f meters", i, d)
+		}
+	}
+	if out[12] != (LatLng{0, 1}) || out[13] != (LatLng{0.01, 1}) {
+		t.Fatalf("expected the original vertices, got %v", out[12:])
+	}
+	if d := PolylineLength(WGS84, out); !eqish(d, PolylineLength(WGS84, pts), 6) {
+		t.Fatalf("expected the same length, got %f", d)
+	}
+}
+
+func TestDensifyArc(t *testing.T) {
+	pts := []LatLng{{0, 0}, {0.5, 179.5}}
+	out := DensifyArc(WGS84, pts, 10)
+	if len(out) < 19 || out[0] != pts[0] || out[len(out)-1] != pts[1] {
+		t.Fatalf("expected at least 19 points including the ends, got %v", out)
+	}
+	// Equal arc steps along the nearly antipodal geodesic.
+	l := WGS84.InverseLine(0, 0, 0.5, 179.5)
+	n := float64(len(out) - 1)
+	for i, p := range out[1 : len(out)-1] {
+		var lat, lon float64
+		l.ArcPosition(l.Arc()*float64(i+1)/n, &lat, &lon, nil)
+		if p != (LatLng{lat, lon}) {
+			t.Fatalf("point %d: expected '%v, %v', got %v", i+1, lat, lon, p)
+		}
+	}
+	if out := DensifyArc(WGS84, pts, 0); len(out) != 2 {
+		t.Fatalf("expected a copy, got %v", out)
+	}
+}
+
+func TestDensifySeq(t *testing.T) {
+	pts := []LatLng{{0, 0}, {0, 1}, {1, 1}}
+	var n int
+	for p := range DensifySeq(WGS84, pts, 1000) {
+		n++
+		if n == 5 {
+			if !eqish(p.Lat, 0, 9) {
+				t.Fatalf("expected a point on the equator, got %v", p)
+			}
+			break
+		}
+	}
+	if n != 5 {
+		t.Fatalf("expected to stop at 5 points, got %d", n)
+	}
+}
@@ -0,0 +1,82 @@
+package geodesic
+
+import "math"
+
+// GeodesicMedian returns the point that minimizes the sum of the weighted
+// geodesic distances to a set of points, also known as the geometric
+// median or Fermat-Weber point.
+//
+// Param pts is the set of points.
+// Param weights holds a non-negative weight for each point, or nil to give
+// all points the same weight.
+// Returns the median position.
+//
+// The solution uses Weiszfeld's algorithm on the ellipsoid, starting at the
+// weighted mean. Each iteration solves the inverse problem from the current
+// estimate to every point and steps along the geodesic toward the weighted
+// average of their directions, until the step is shorter than a millimeter.
+// It returns NaN values if pts is empty or the weights sum to zero.
+func (e *Ellipsoid) GeodesicMedian(pts []LatLng, weights []float64) LatLng {
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+	x := e.weightedMean(pts, weights)
+	if math.IsNaN(x.Lat) {
+		// The mean is undefined, start at the heaviest point instead.
+		best := -1
+		for i := range pts {
+			if best == -1 || weight(i) > weight(best) {
+				best = i
+			}
+		}
+		if best == -1 || !(weight(best) > 0) {
+			return x
+		}
+		x = pts[best]
+	}
+	for iter := 0; iter < 1000; iter++ {
+		// Accumulate the weighted unit vectors from x toward each point,
+		// east and north in the tangent plane.
+		var rx, ry, sw, wat float64
+		for i, p := range pts {
+			w := weight(i)
+			if w == 0 {
+				continue
+			}
+			var s12, azi1 float64
+			e.Inverse(x.Lat, x.Lon, p.Lat, p.Lon, &s12, &azi1, nil)
+			if s12 < 1e-9 {
+				// x is on this point.
+				wat += w
+				continue
+			}
+			sa, ca := math.Sincos(azi1 * (math.Pi / 180))
+			rx += w * sa
+			ry += w * ca
+			sw += w / s12
+		}
+		if sw == 0 {
+			break
+		}
+		if wat > 0 && math.Hypot(rx, ry) <= wat {
+			// The pull of the other points cannot move x off the point.
+			break
+		}
+		step := math.Hypot(rx, ry) / sw
+		if wat > 0 {
+			// Vardi-Zhang adjustment for an estimate on a point.
+			step *= math.Max(0, 1-wat/math.Hypot(rx, ry))
+		}
+		var next LatLng
+		e.Direct(x.Lat, x.Lon, math.Atan2(rx, ry)*(180/math.Pi), step,
+			&next.Lat, &next.Lon, nil)
+		x = next
+		if step < 1e-3 {
+			break
+		}
+	}
+	return x
+}
@@ -0,0 +1,42 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeodesicMedian(t *testing.T) {
+	pts := []LatLng{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {0.5, 0.5}}
+	m := WGS84.GeodesicMedian(pts, nil)
+	if !eqish(m.Lat, 0.5, 5) || !eqish(m.Lon, 0.5, 5) {
+		t.Fatalf("expected the center point, got %v", m)
+	}
+	sum := func(x LatLng, w []float64) float64 {
+		var total float64
+		for i, p := range pts {
+			total += w[i] * WGS84.distance(x, p)
+		}
+		return total
+	}
+	// A heavy weight pulls the median onto its point.
+	w := []float64{10, 1, 1, 1, 1}
+	m = WGS84.GeodesicMedian(pts, w)
+	if !eqish(m.Lat, 0, 6) || !eqish(m.Lon, 0, 6) {
+		t.Fatalf("expected the heavy point, got %v", m)
+	}
+	// A moderate weight gives an interior optimum.
+	w = []float64{2, 1, 1, 1, 0}
+	m = WGS84.GeodesicMedian(pts, w)
+	best := sum(m, w)
+	for _, azi := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+		var p LatLng
+		WGS84.Direct(m.Lat, m.Lon, azi, 10, &p.Lat, &p.Lon, nil)
+		if sum(p, w) < best {
+			t.Fatalf("found a better point than %v at %v", m, p)
+		}
+	}
+	m = WGS84.GeodesicMedian(nil, nil)
+	if !math.IsNaN(m.Lat) {
+		t.Fatalf("expected NaN, got %v", m)
+	}
+}
@@ -0,0 +1,133 @@
+package geodesic
+
+import (
+	"container/list"
+	"math"
+	"sync"
+)
+
+// InverseCache is a least recently used cache of inverse solutions, for
+// workloads that solve the same problems over and over, such as
+// recomputing an origin-destination matrix as a few of its points change.
+// It is safe for concurrent use.
+type InverseCache struct {
+	e        *Ellipsoid
+	quantum  float64
+	capacity int
+
+	mu      sync.Mutex
+	entries map[inverseKey]*list.Element
+	lru     list.List // of *inverseEntry, most recent first
+	stats   CacheStats
+}
+
+// CacheStats are the counters of an InverseCache.
+type CacheStats struct {
+	Hits   uint64 // calls answered from the cache
+	Misses uint64 // calls that solved a new problem
+	Len    int    // entries in the cache
+}
+
+type inverseKey struct {
+	lat1, lon1, lat2, lon2 uint64
+}
+
+type inverseEntry struct {
+	key             inverseKey
+	s12, azi1, azi2 float64
+}
+
+// NewInverseCache returns a cache of inverse solutions on the ellipsoid.
+//
+// Param capacity is the largest number of solutions to keep. When the
+// cache is full, the least recently used solution is dropped.
+// Param quantum is the grid the coordinates are rounded to before lookup
+// (degrees), or zero to only match exactly equal coordinates.
+//
+// With a quantum, the solutions are those of the rounded coordinates, so
+// the error is up to about quantum times 111 km in each coordinate: a
+// quantum of 1e-5 degrees, about a meter, suits most trip planning.
+// Rounding makes the cache hit even though repeated inputs differ in
+// their last digits, and it makes the result for a pair independent of
+// which nearby pair was seen first. A capacity below one is taken as one.
+func (e *Ellipsoid) NewInverseCache(capacity int, quantum float64) *InverseCache {
+	return &InverseCache{
+		e:        e,
+		quantum:  math.Abs(quantum),
+		capacity: max(capacity, 1),
+		entries:  make(map[inverseKey]*list.Element),
+	}
+}
+
+// quantize rounds a coordinate to the quantum.
+func (c *InverseCache) quantize(x float64) float64 {
+	if c.quantum == 0 {
+		return x
+	}
+	return math.Round(x/c.quantum) * c.quantum
+}
+
+// Inverse solves the inverse geodesic problem, or returns the cached
+// solution of an earlier call with the same, or the same rounded,
+// coordinates. The arguments are as for Ellipsoid.Inverse.
+func (c *InverseCache) Inverse(
+	lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2 *float64,
+) {
+	lat1, lon1 = c.quantize(lat1), c.quantize(lon1)
+	lat2, lon2 = c.quantize(lat2), c.quantize(lon2)
+	key := inverseKey{
+		math.Float64bits(lat1), math.Float64bits(lon1),
+		math.Float64bits(lat2), math.Float64bits(lon2),
+	}
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	var ent *inverseEntry
+	if ok {
+		c.stats.Hits++
+		c.lru.MoveToFront(el)
+		ent = el.Value.(*inverseEntry)
+		c.mu.Unlock()
+	} else {
+		c.stats.Misses++
+		c.mu.Unlock()
+		ent = &inverseEntry{key: key}
+		c.e.Inverse(lat1, lon1, lat2, lon2, &ent.s12, &ent.azi1, &ent.azi2)
+		c.mu.Lock()
+		if _, ok := c.entries[key]; !ok {
+			c.entries[key] = c.lru.PushFront(ent)
+			if c.lru.Len() > c.capacity {
+				old := c.lru.Remove(c.lru.Back()).(*inverseEntry)
+				delete(c.entries, old.key)
+			}
+		}
+		c.mu.Unlock()
+	}
+	if s12 != nil {
+		*s12 = ent.s12
+	}
+	if azi1 != nil {
+		*azi1 = ent.azi1
+	}
+	if azi2 != nil {
+		*azi2 = ent.azi2
+	}
+}
+
+// Stats returns the counters of the cache.
+func (c *InverseCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Len = c.lru.Len()
+	return s
+}
+
+// Reset empties the cache and zeroes its counters.
+func (c *InverseCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.lru.Init()
+	c.stats = CacheStats{}
+}
@@ -0,0 +1,77 @@
+package geodesic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInverseCache(t *testing.T) {
+	c := WGS84.NewInverseCache(2, 0)
+	var s12, azi1, azi2 float64
+	c.Inverse(10, 20, 30, 40, &s12, &azi1, &azi2)
+	var ws12, wazi1, wazi2 float64
+	WGS84.Inverse(10, 20, 30, 40, &ws12, &wazi1, &wazi2)
+	if s12 != ws12 || azi1 != wazi1 || azi2 != wazi2 {
+		t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+			ws12, wazi1, wazi2, s12, azi1, azi2)
+	}
+	c.Inverse(10, 20, 30, 40, &s12, nil, nil)
+	if s12 != ws12 {
+		t.Fatalf("expected %v, got %v", ws12, s12)
+	}
+	if s := c.Stats(); s != (CacheStats{Hits: 1, Misses: 1, Len: 1}) {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+
+	// The least recently used entry is dropped.
+	c.Inverse(1, 2, 3, 4, nil, nil, nil)
+	c.Inverse(10, 20, 30, 40, nil, nil, nil) // hit, now most recent
+	c.Inverse(5, 6, 7, 8, nil, nil, nil)     // drops 1, 2, 3, 4
+	c.Inverse(10, 20, 30, 40, nil, nil, nil) // hit
+	c.Inverse(1, 2, 3, 4, nil, nil, nil)     // miss
+	if s := c.Stats(); s != (CacheStats{Hits: 3, Misses: 4, Len: 2}) {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+	c.Reset()
+	if s := c.Stats(); s != (CacheStats{}) {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+}
+
+func TestInverseCacheQuantum(t *testing.T) {
+	c := WGS84.NewInverseCache(10, 1e-5)
+	var s1, s2, want float64
+	c.Inverse(10.000001, 20, 30, 40.000004, &s1, nil, nil)
+	c.Inverse(10.000003, 20, 30, 39.999996, &s2, nil, nil)
+	WGS84.Inverse(10, 20, 30, 40, &want, nil, nil)
+	if s1 != s2 || !eqish(s1, want, 6) {
+		t.Fatalf("expected %v, got %v and %v", want, s1, s2)
+	}
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 1 {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+}
+
+func TestInverseCacheConcurrent(t *testing.T) {
+	c := WGS84.NewInverseCache(8, 0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				var s12 float64
+				lat := float64(i % 16)
+				c.Inverse(lat, 0, 0, 1, &s12, nil, nil)
+				if want := WGS84.distance(LatLng{lat, 0}, LatLng{0, 1}); s12 != want {
+					t.Errorf("expected %v, got %v", want, s12)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if s := c.Stats(); s.Hits+s.Misses != 8000 || s.Len != 8 {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+}
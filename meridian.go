@@ -0,0 +1,136 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+)
+
+// MeridianCrossing is a point where a geodesic crosses a meridian.
+type MeridianCrossing struct {
+	Point LatLng  // the crossing, whose longitude is that of the meridian
+	S12   float64 // distance from the start of the geodesic (meters)
+	Azi   float64 // azimuth of the geodesic at the crossing (degrees)
+}
+
+// MeridianCrossings returns the points where the geodesic between two points
+// crosses the meridians at a regular longitude interval, as in the
+// crossing table of a flight plan.
+//
+// Param a is the start of the geodesic.
+// Param b is the end of the geodesic.
+// Param step is the longitude interval (degrees), such as 10 for the
+// meridians at 0, 10, 20 and so on.
+// Returns the crossings in order from a to b.
+//
+// Each crossing is solved on the geodesic itself, so its latitude is that
+// of the route and not of a rhumb line or a great circle on a sphere. The
+// meridians through a and b are not included. The longitude changes
+// monotonically along a geodesic, so each meridian is crossed once, but a
+// geodesic over a pole only crosses the meridians of its ends and returns
+// nothing. Returns nil if step is not positive or a longitude is not a
+// finite number, and NaN crossings for a latitude out of range, see
+// Ellipsoid.CheckedMeridianCrossings.
+func (e *Ellipsoid) MeridianCrossings(a, b LatLng, step float64) []MeridianCrossing {
+	if !(step > 0) || math.IsNaN(a.Lon-b.Lon) || math.IsInf(a.Lon-b.Lon, 0) {
+		return nil
+	}
+	ed := e.newRingEdge(a, b)
+	// A meridional geodesic, heading due north or south, either stays on
+	// its meridian or goes over a pole.
+	if azi1 := ed.l.Azi1(); ed.dlon == 0 || azi1 == 0 || math.Abs(azi1) == 180 {
+		return nil
+	}
+	// Walk the meridians from the start in the direction of travel, on the
+	// unrolled longitudes where the end is at a.Lon+dlon.
+	lon0, lon1 := a.Lon, a.Lon+ed.dlon
+	dir := 1.0
+	k0 := math.Floor(lon0/step) + 1
+	if ed.dlon < 0 {
+		dir, k0 = -1, math.Ceil(lon0/step)-1
+	}
+	var xs []MeridianCrossing
+	for k := k0; ; k += dir {
+		lon := k * step
+		if (lon-lon1)*dir >= 0 {
+			break
+		}
+		var x MeridianCrossing
+		x.S12 = ed.crossDist(normLon(lon))
+		ed.l.Position(x.S12, &x.Point.Lat, nil, &x.Azi)
+		x.Point.Lon = normLon(lon)
+		xs = append(xs, x)
+	}
+	return xs
+}
+
+// CheckedMeridianCrossings returns the points where the geodesic between
+// two points crosses the meridians at a regular longitude interval, as
+// Ellipsoid.MeridianCrossings, and reports the problems with its
+// arguments as errors.
+//
+// Param a is the start of the geodesic.
+// Param b is the end of the geodesic.
+// Param step is the longitude interval (degrees).
+// Returns the crossings, or an error: ErrInvalidLatitude if a latitude is
+// out of range under the LatitudePolicy of the ellipsoid,
+// ErrInvalidLongitude if a longitude is not a finite number, or
+// ErrInvalidArgument if step is not positive and finite. The errors about a point are wrapped with its
+// number.
+func (e *Ellipsoid) CheckedMeridianCrossings(a, b LatLng, step float64,
+) ([]MeridianCrossing, error) {
+	a, err := e.checkPoint(a)
+	if err != nil {
+		return nil, fmt.Errorf("%w at point 1", err)
+	}
+	if b, err = e.checkPoint(b); err != nil {
+		return nil, fmt.Errorf("%w at point 2", err)
+	}
+	if !(step > 0) || math.IsInf(step, 1) {
+		return nil, fmt.Errorf("%w: step %v", ErrInvalidArgument, step)
+	}
+	return e.MeridianCrossings(a, b, step), nil
+}
+
+// SplitSegment returns the geodesic between two points split where it
+// crosses the antimeridian or goes over a pole, for drawing on a map of
+// longitude and latitude.
+//
+// Param a is the start of the geodesic.
+// Param b is the end of the geodesic.
+// Returns the parts of the geodesic in order from a to b, with two points
+// each: one part if it crosses neither, or two.
+//
+// Drawn as a straight line between its ends, a geodesic that crosses ±180
+// would go the wrong way across the whole map, so it is split at the
+// crossing, which is solved on the geodesic itself and ends the first part
+// at one of ±180 and starts the second at the other, on the sides that
+// they are drawn on. A geodesic over a pole, from one meridian to the one
+// opposite, is split at the pole instead. The longitudes are in
+// [-180,+180], and an end on the antimeridian is given the sign of the
+// side of the map that the geodesic is on.
+func (e *Ellipsoid) SplitSegment(a, b LatLng) [][]LatLng {
+	ed := e.newRingEdge(a, b)
+	p1, p2 := LatLng{a.Lat, normLon(a.Lon)}, LatLng{b.Lat, normLon(b.Lon)}
+	if azi1 := ed.l.Azi1(); ed.dlon != 0 && (azi1 == 0 || math.Abs(azi1) == 180) {
+		pole := LatLng{90, p1.Lon}
+		if azi1 != 0 {
+			pole.Lat = -90
+		}
+		return [][]LatLng{{p1, pole}, {{pole.Lat, p2.Lon}, p2}}
+	}
+	// On the unrolled longitudes from p1, where the end is at p1.Lon+dlon,
+	// the antimeridian is crossed if the end is past ±180.
+	if p1.Lon == -180 && ed.dlon < 0 {
+		p1.Lon = 180
+	}
+	lon1 := p1.Lon + ed.dlon
+	if lon1 <= 180 && lon1 >= -180 {
+		if lon1 == 180 {
+			p2.Lon = 180
+		}
+		return [][]LatLng{{p1, p2}}
+	}
+	x := LatLng{Lon: math.Copysign(180, ed.dlon)}
+	ed.l.Position(ed.crossDist(180), &x.Lat, nil, nil)
+	return [][]LatLng{{p1, x}, {{x.Lat, -x.Lon}, p2}}
+}
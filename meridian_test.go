@@ -0,0 +1,154 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMeridianCrossings(t *testing.T) {
+	// New York to London crosses the meridians from 70W to 10W going east.
+	jfk, lhr := LatLng{40.64, -73.78}, LatLng{51.47, -0.46}
+	xs := WGS84.MeridianCrossings(jfk, lhr, 10)
+	if len(xs) != 7 {
+		t.Fatalf("expected 7 crossings, got %d", len(xs))
+	}
+	l := WGS84.InverseLine(jfk.Lat, jfk.Lon, lhr.Lat, lhr.Lon)
+	for i, x := range xs {
+		if want := -70 + 10*float64(i); x.Point.Lon != want {
+			t.Fatalf("expected %v, got %v", want, x.Point.Lon)
+		}
+		var lat, lon, azi float64
+		l.Position(x.S12, &lat, &lon, &azi)
+		if !eqish(lat, x.Point.Lat, 9) || !eqish(lon, x.Point.Lon, 9) ||
+			!eqish(azi, x.Azi, 9) {
+			t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+				x.Point.Lat, x.Point.Lon, x.Azi, lat, lon, azi)
+		}
+		if i > 0 && !(x.S12 > xs[i-1].S12) {
+			t.Fatalf("expected increasing distances")
+		}
+	}
+
+	// Going west across the antimeridian, and skipping the meridians at the
+	// ends.
+	xs = WGS84.MeridianCrossings(LatLng{35, 170}, LatLng{45, -160}, 10)
+	if len(xs) != 2 || xs[0].Point.Lon != 180 && xs[0].Point.Lon != -180 ||
+		xs[1].Point.Lon != -170 {
+		t.Fatalf("expected crossings at 180 and -170, got %v", xs)
+	}
+	xs = WGS84.MeridianCrossings(LatLng{45, -160}, LatLng{35, 170}, 10)
+	if len(xs) != 2 || xs[0].Point.Lon != -170 {
+		t.Fatalf("expected crossings at -170 and 180, got %v", xs)
+	}
+
+	// Meridional geodesics and bad steps.
+	if xs := WGS84.MeridianCrossings(LatLng{10, 5}, LatLng{80, 5}, 1); xs != nil {
+		t.Fatalf("expected nil, got %v", xs)
+	}
+	if xs := WGS84.MeridianCrossings(LatLng{80, 5}, LatLng{80, -175}, 1); xs != nil {
+		t.Fatalf("expected nil, got %v", xs)
+	}
+	if xs := WGS84.MeridianCrossings(jfk, lhr, 0); xs != nil {
+		t.Fatalf("expected nil, got %v", xs)
+	}
+	if xs := WGS84.MeridianCrossings(LatLng{0, math.NaN()}, lhr, 10); xs != nil {
+		t.Fatalf("expected nil, got %v", xs)
+	}
+}
+
+func TestCheckedMeridianCrossings(t *testing.T) {
+	jfk, lhr := LatLng{40.64, -73.78}, LatLng{51.47, -0.46}
+	xs, err := WGS84.CheckedMeridianCrossings(jfk, lhr, 10)
+	if err != nil || len(xs) != 7 || xs[0] != WGS84.MeridianCrossings(jfk,
+		lhr, 10)[0] {
+		t.Fatalf("expected 7 crossings, got %v, %v", xs, err)
+	}
+	for _, c := range []struct {
+		a, b LatLng
+		step float64
+		err  error
+	}{
+		{LatLng{91, 0}, lhr, 10, ErrInvalidLatitude},
+		{jfk, LatLng{math.NaN(), 0}, 10, ErrInvalidLatitude},
+		{jfk, LatLng{0, math.Inf(-1)}, 10, ErrInvalidLongitude},
+		{jfk, lhr, 0, ErrInvalidArgument},
+		{jfk, lhr, math.NaN(), ErrInvalidArgument},
+		{jfk, lhr, math.Inf(1), ErrInvalidArgument},
+	} {
+		if xs, err := WGS84.CheckedMeridianCrossings(c.a, c.b,
+			c.step); !errors.Is(err, c.err) || xs != nil {
+			t.Fatalf("%v %v %v: expected %v, got %v, %v", c.a, c.b, c.step,
+				c.err, xs, err)
+		}
+	}
+}
+
+func TestSplitSegment(t *testing.T) {
+	// Going east across the antimeridian, the crossing is that of
+	// MeridianCrossings.
+	a, b := LatLng{35, 170}, LatLng{45, -160}
+	parts := WGS84.SplitSegment(a, b)
+	xs := WGS84.MeridianCrossings(a, b, 10)
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		t.Fatalf("expected two parts, got %v", parts)
+	}
+	if parts[0][0] != a || parts[1][1] != b {
+		t.Fatalf("expected the ends, got %v", parts)
+	}
+	if x := parts[0][1]; x.Lon != 180 || !eqish(x.Lat, xs[0].Point.Lat, 9) {
+		t.Fatalf("expected '%v, 180', got %v", xs[0].Point.Lat, x)
+	}
+	if x := parts[1][0]; x.Lon != -180 || x.Lat != parts[0][1].Lat {
+		t.Fatalf("expected '%v, -180', got %v", parts[0][1].Lat, x)
+	}
+
+	// And going west.
+	parts = WGS84.SplitSegment(b, a)
+	if len(parts) != 2 || parts[0][1].Lon != -180 || parts[1][0].Lon != 180 {
+		t.Fatalf("expected a split from -180 to 180, got %v", parts)
+	}
+	if !eqish(parts[0][1].Lat, xs[0].Point.Lat, 9) {
+		t.Fatalf("expected %v, got %v", xs[0].Point.Lat, parts[0][1].Lat)
+	}
+
+	// No crossing, with the longitudes normalized, and ends on the
+	// antimeridian on the side of the geodesic.
+	parts = WGS84.SplitSegment(LatLng{10, 370}, LatLng{20, 30})
+	if len(parts) != 1 || parts[0][0] != (LatLng{10, 10}) ||
+		parts[0][1] != (LatLng{20, 30}) {
+		t.Fatalf("expected one part, got %v", parts)
+	}
+	parts = WGS84.SplitSegment(LatLng{10, 170}, LatLng{20, -180})
+	if len(parts) != 1 || parts[0][1].Lon != 180 {
+		t.Fatalf("expected an end at 180, got %v", parts)
+	}
+	parts = WGS84.SplitSegment(LatLng{10, 180}, LatLng{20, -170})
+	if len(parts) != 1 || parts[0][0].Lon != -180 {
+		t.Fatalf("expected a start at -180, got %v", parts)
+	}
+	parts = WGS84.SplitSegment(LatLng{10, -180}, LatLng{20, 170})
+	if len(parts) != 1 || parts[0][0].Lon != 180 {
+		t.Fatalf("expected a start at 180, got %v", parts)
+	}
+
+	// Over the north and south poles.
+	parts = WGS84.SplitSegment(LatLng{80, 5}, LatLng{80, -175})
+	want := [][]LatLng{{{80, 5}, {90, 5}}, {{90, -175}, {80, -175}}}
+	if len(parts) != 2 || parts[0][0] != want[0][0] ||
+		parts[0][1] != want[0][1] || parts[1][0] != want[1][0] ||
+		parts[1][1] != want[1][1] {
+		t.Fatalf("expected %v, got %v", want, parts)
+	}
+	parts = WGS84.SplitSegment(LatLng{-80, 5}, LatLng{-70, -175})
+	if len(parts) != 2 || parts[0][1] != (LatLng{-90, 5}) ||
+		parts[1][0] != (LatLng{-90, -175}) {
+		t.Fatalf("expected a split at the south pole, got %v", parts)
+	}
+
+	// Along a meridian.
+	parts = WGS84.SplitSegment(LatLng{10, 5}, LatLng{80, 5})
+	if len(parts) != 1 {
+		t.Fatalf("expected one part, got %v", parts)
+	}
+}
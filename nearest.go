@@ -0,0 +1,44 @@
+package geodesic
+
+import "math"
+
+// PolylineMatch is the position on a polyline nearest to a point.
+type PolylineMatch struct {
+	Point    LatLng  // nearest point on the polyline
+	Segment  int     // index of the first vertex of the nearest segment
+	Distance float64 // distance from the point to Point (meters)
+	Along    float64 // distance from the start of the polyline (meters)
+}
+
+// NearestOnPolyline returns the point on a polyline nearest to p.
+//
+// Param p is the point to snap.
+// Param line is the polyline.
+// Returns the nearest position on the polyline.
+//
+// Each segment is a geodesic between consecutive vertices, and Distance is
+// the geodesic cross-track distance from p to the nearest point. Along is
+// the cumulative geodesic distance along the polyline from its first vertex,
+// which is the chainage of the nearest point. When two segments are equally
+// near, the first one wins. An empty line returns a Segment of -1 and a NaN
+// Distance.
+func (e *Ellipsoid) NearestOnPolyline(p LatLng, line []LatLng) PolylineMatch {
+	switch len(line) {
+	case 0:
+		return PolylineMatch{Segment: -1, Distance: math.NaN()}
+	case 1:
+		return PolylineMatch{Point: line[0], Distance: e.distance(p, line[0])}
+	}
+	best := PolylineMatch{Distance: math.Inf(1)}
+	var start float64
+	for i := 0; i < len(line)-1; i++ {
+		q, dist, along := e.segmentNearest(p, line[i], line[i+1])
+		if dist < best.Distance {
+			best = PolylineMatch{
+				Point: q, Segment: i, Distance: dist, Along: start + along,
+			}
+		}
+		start += e.distance(line[i], line[i+1])
+	}
+	return best
+}
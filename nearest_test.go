@@ -0,0 +1,37 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNearestOnPolyline(t *testing.T) {
+	line := []LatLng{{0, 0}, {0, 1}, {1, 1}}
+	m := WGS84.NearestOnPolyline(LatLng{0.01, 0.5}, line)
+	var s12 float64
+	WGS84.Inverse(0, 0, 0, 0.5, &s12, nil, nil)
+	if m.Segment != 0 || !eqish(m.Along, s12, 4) || !eqish(m.Point.Lat, 0, 9) {
+		t.Fatalf("unexpected match %+v", m)
+	}
+	WGS84.Inverse(0.01, 0.5, 0, 0.5, &s12, nil, nil)
+	if !eqish(m.Distance, s12, 4) {
+		t.Fatalf("expected %f, got %f", s12, m.Distance)
+	}
+	// A point off the second segment.
+	m = WGS84.NearestOnPolyline(LatLng{0.5, 1.01}, line)
+	var s1, s2 float64
+	WGS84.Inverse(0, 0, 0, 1, &s1, nil, nil)
+	WGS84.Inverse(0, 1, 0.5, 1, &s2, nil, nil)
+	if m.Segment != 1 || !eqish(m.Along, s1+s2, 3) {
+		t.Fatalf("unexpected match %+v", m)
+	}
+	// A point past the end snaps to the last vertex.
+	m = WGS84.NearestOnPolyline(LatLng{2, 1}, line)
+	if !eqish(m.Point.Lat, 1, 9) || !eqish(m.Point.Lon, 1, 9) {
+		t.Fatalf("unexpected match %+v", m)
+	}
+	if m := WGS84.NearestOnPolyline(LatLng{}, nil); m.Segment != -1 ||
+		!math.IsNaN(m.Distance) {
+		t.Fatalf("unexpected match %+v", m)
+	}
+}
@@ -0,0 +1,328 @@
+package geodesic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrGridShift is returned by ReadNTv2 for data that is not a valid NTv2
+// grid shift file.
+var ErrGridShift = errors.New("geodesic: invalid grid shift file")
+
+// GridShift is a grid shift transformation between datums, read from an
+// NTv2 file, such as OSTN15_NTv2_OSGBtoETRS.gsb of the Ordnance Survey for
+// OSGB36, or the NTv2_0.gsb of Natural Resources Canada for NAD27.
+//
+// The shifts of latitude and longitude are interpolated bilinearly from
+// the nodes of the densest of the grids of the file that holds the point,
+// as NTv2 specifies, so the transformation reproduces the national grids
+// that the files are made from, to their published accuracy of about 0.1
+// meters for the Ordnance Survey. A GridShift is not changed once read,
+// and so may be used from many goroutines.
+type GridShift struct {
+	from, to *Datum
+	grids    []*shiftGrid // the grids that have no parent
+}
+
+// shiftGrid is a grid of an NTv2 file. The bounds are in arc-seconds, with
+// the longitudes positive to the west as in the file, and the nodes go by
+// rows from the south, and within a row from the east.
+type shiftGrid struct {
+	name, parent           string
+	sLat, nLat, eLon, wLon float64
+	dLat, dLon             float64
+	rows, cols             int
+	shifts                 []float32 // latitude and longitude shift of each node (arc-seconds)
+	children               []*shiftGrid
+}
+
+// ReadNTv2 reads an NTv2 grid shift file.
+//
+// Param r is the file, in either byte order.
+// Param from is the datum that the shifts convert from, the SYSTEM_F of the
+// file.
+// Param to is the datum that they convert to, the SYSTEM_T of the file.
+// Returns the transformation, or an error wrapping ErrGridShift for a file
+// that is not valid, or the error from r.
+//
+// The names of the systems in the file are not checked against the datums,
+// since files name them in many ways.
+func ReadNTv2(r io.Reader, from, to *Datum) (*GridShift, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	rd := ntv2Reader{data: data, order: binary.LittleEndian}
+	if len(data) < 16 || string(data[:8]) != "NUM_OREC" {
+		return nil, fmt.Errorf("%w: no overview header", ErrGridShift)
+	}
+	if rd.order.Uint32(data[8:]) != 11 {
+		rd.order = binary.BigEndian
+	}
+	over, err := rd.header()
+	if err != nil {
+		return nil, err
+	}
+	nfile, err := over.int("NUM_FILE")
+	if err != nil {
+		return nil, err
+	}
+	unit := 1.0
+	switch typ, _ := over.str("GS_TYPE"); typ {
+	case "SECONDS":
+	case "MINUTES":
+		unit = 60
+	case "DEGREES":
+		unit = 3600
+	default:
+		return nil, fmt.Errorf("%w: GS_TYPE %q", ErrGridShift, typ)
+	}
+	g := &GridShift{from: from, to: to}
+	byName := make(map[string]*shiftGrid, nfile)
+	var all []*shiftGrid
+	for i := 0; i < nfile; i++ {
+		sg, err := rd.grid(unit)
+		if err != nil {
+			return nil, fmt.Errorf("%w in grid %d", err, i)
+		}
+		byName[sg.name] = sg
+		all = append(all, sg)
+	}
+	for _, sg := range all {
+		if sg.parent == "NONE" {
+			g.grids = append(g.grids, sg)
+		} else if p := byName[sg.parent]; p != nil && p != sg {
+			p.children = append(p.children, sg)
+		} else {
+			return nil, fmt.Errorf("%w: grid %q has no parent %q",
+				ErrGridShift, sg.name, sg.parent)
+		}
+	}
+	return g, nil
+}
+
+// ntv2Reader reads the records of an NTv2 file, which are 16 bytes each: an
+// 8 byte label and an 8 byte value.
+type ntv2Reader struct {
+	data  []byte
+	off   int
+	order binary.ByteOrder
+}
+
+// ntv2Header is a header of an NTv2 file, its values by label.
+type ntv2Header struct {
+	r      *ntv2Reader
+	values map[string][]byte
+}
+
+// header reads a header, whose first record is the number of its records.
+func (rd *ntv2Reader) header() (ntv2Header, error) {
+	h := ntv2Header{r: rd, values: map[string][]byte{}}
+	n := 11
+	for i := 0; i < n; i++ {
+		if rd.off+16 > len(rd.data) {
+			return h, fmt.Errorf("%w: truncated header", ErrGridShift)
+		}
+		rec := rd.data[rd.off : rd.off+16]
+		rd.off += 16
+		label := string(bytes.TrimRight(rec[:8], " \x00"))
+		h.values[label] = rec[8:]
+		if i == 0 && label == "NUM_OREC" {
+			n = int(int32(rd.order.Uint32(rec[8:])))
+			if n < 1 || n > 64 {
+				return h, fmt.Errorf("%w: %d header records", ErrGridShift, n)
+			}
+		}
+	}
+	return h, nil
+}
+
+func (h ntv2Header) value(label string) ([]byte, error) {
+	v, ok := h.values[label]
+	if !ok {
+		return nil, fmt.Errorf("%w: no %s", ErrGridShift, label)
+	}
+	return v, nil
+}
+
+func (h ntv2Header) int(label string) (int, error) {
+	v, err := h.value(label)
+	if err != nil {
+		return 0, err
+	}
+	return int(int32(h.r.order.Uint32(v))), nil
+}
+
+func (h ntv2Header) float(label string) (float64, error) {
+	v, err := h.value(label)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(h.r.order.Uint64(v)), nil
+}
+
+func (h ntv2Header) str(label string) (string, error) {
+	v, err := h.value(label)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(v, " \x00")), nil
+}
+
+// grid reads a grid, its header and its nodes, whose bounds are in units
+// of unit arc-seconds.
+func (rd *ntv2Reader) grid(unit float64) (*shiftGrid, error) {
+	h, err := rd.header()
+	if err != nil {
+		return nil, err
+	}
+	sg := new(shiftGrid)
+	if sg.name, err = h.str("SUB_NAME"); err != nil {
+		return nil, err
+	}
+	if sg.parent, err = h.str("PARENT"); err != nil {
+		return nil, err
+	}
+	for _, f := range []struct {
+		label string
+		v     *float64
+	}{
+		{"S_LAT", &sg.sLat}, {"N_LAT", &sg.nLat}, {"E_LONG", &sg.eLon},
+		{"W_LONG", &sg.wLon}, {"LAT_INC", &sg.dLat}, {"LONG_INC", &sg.dLon},
+	} {
+		if *f.v, err = h.float(f.label); err != nil {
+			return nil, err
+		}
+		*f.v *= unit
+	}
+	count, err := h.int("GS_COUNT")
+	if err != nil {
+		return nil, err
+	}
+	if !(sg.dLat > 0 && sg.dLon > 0 && sg.nLat >= sg.sLat &&
+		sg.wLon >= sg.eLon) {
+		return nil, fmt.Errorf("%w: bad bounds", ErrGridShift)
+	}
+	sg.rows = int(math.Round((sg.nLat-sg.sLat)/sg.dLat)) + 1
+	sg.cols = int(math.Round((sg.wLon-sg.eLon)/sg.dLon)) + 1
+	if count != sg.rows*sg.cols {
+		return nil, fmt.Errorf("%w: %d nodes for %d rows of %d", ErrGridShift,
+			count, sg.rows, sg.cols)
+	}
+	if rd.off+16*count > len(rd.data) {
+		return nil, fmt.Errorf("%w: truncated grid", ErrGridShift)
+	}
+	// Each node is the shifts of latitude and longitude, and their
+	// accuracies, which are not used, as 4 byte floats.
+	sg.shifts = make([]float32, 2*count)
+	for i := 0; i < count; i++ {
+		rec := rd.data[rd.off+16*i:]
+		sg.shifts[2*i] = math.Float32frombits(rd.order.Uint32(rec))
+		sg.shifts[2*i+1] = math.Float32frombits(rd.order.Uint32(rec[4:]))
+	}
+	rd.off += 16 * count
+	return sg, nil
+}
+
+// contains reports whether the grid holds the point at lat and the west
+// longitude wlon (arc-seconds).
+func (sg *shiftGrid) contains(lat, wlon float64) bool {
+	return lat >= sg.sLat && lat <= sg.nLat && wlon >= sg.eLon &&
+		wlon <= sg.wLon
+}
+
+// shift returns the interpolated shifts of latitude and west longitude at
+// a point that the grid holds (arc-seconds).
+func (sg *shiftGrid) shift(lat, wlon float64) (dlat, dlon float64) {
+	x := (wlon - sg.eLon) / sg.dLon
+	y := (lat - sg.sLat) / sg.dLat
+	i := max(0, min(int(x), sg.cols-2))
+	j := max(0, min(int(y), sg.rows-2))
+	x, y = x-float64(i), y-float64(j)
+	node := func(i, j int) (float64, float64) {
+		i, j = min(i, sg.cols-1), min(j, sg.rows-1)
+		k := 2 * (j*sg.cols + i)
+		return float64(sg.shifts[k]), float64(sg.shifts[k+1])
+	}
+	a1, b1 := node(i, j)
+	a2, b2 := node(i+1, j)
+	a3, b3 := node(i, j+1)
+	a4, b4 := node(i+1, j+1)
+	dlat = a1 + (a2-a1)*x + (a3-a1)*y + (a1-a2-a3+a4)*x*y
+	dlon = b1 + (b2-b1)*x + (b3-b1)*y + (b1-b2-b3+b4)*x*y
+	return dlat, dlon
+}
+
+// Source returns the datum that the transformation converts from.
+func (g *GridShift) Source() *Datum { return g.from }
+
+// Target returns the datum that the transformation converts to.
+func (g *GridShift) Target() *Datum { return g.to }
+
+// Transform returns the point on the target datum at a point on the source
+// datum, or an error wrapping ErrOutOfRange if no grid of the file holds
+// the point.
+func (g *GridShift) Transform(p LatLng) (LatLng, error) {
+	lat, wlon := p.Lat*3600, -normLon(p.Lon)*3600
+	var sg *shiftGrid
+	for _, top := range g.grids {
+		if top.contains(lat, wlon) {
+			sg = top
+			break
+		}
+	}
+	if sg == nil {
+		return LatLng{math.NaN(), math.NaN()}, fmt.Errorf(
+			"%w: %v is outside the grid", ErrOutOfRange, p)
+	}
+	// Descend to the densest grid that holds the point.
+	for found := true; found; {
+		found = false
+		for _, c := range sg.children {
+			if c.contains(lat, wlon) {
+				sg, found = c, true
+				break
+			}
+		}
+	}
+	dlat, dlon := sg.shift(lat, wlon)
+	return LatLng{p.Lat + dlat/3600, p.Lon - dlon/3600}, nil
+}
+
+// Inverse returns the transformation the other way, between the same
+// datums.
+//
+// NTv2 files only hold the shifts one way, so the point that the forward
+// transformation takes to the one given is solved for by iteration, to
+// well within a micrometer.
+func (g *GridShift) Inverse() Transformation {
+	return gridShiftInverse{g}
+}
+
+type gridShiftInverse struct {
+	g *GridShift
+}
+
+func (gi gridShiftInverse) Source() *Datum { return gi.g.to }
+
+func (gi gridShiftInverse) Target() *Datum { return gi.g.from }
+
+func (gi gridShiftInverse) Transform(p LatLng) (LatLng, error) {
+	q := p
+	for i := 0; i < 10; i++ {
+		r, err := gi.g.Transform(q)
+		if err != nil {
+			return r, err
+		}
+		dlat, dlon := p.Lat-r.Lat, math.Remainder(p.Lon-r.Lon, 360)
+		q.Lat, q.Lon = q.Lat+dlat, q.Lon+dlon
+		if math.Abs(dlat) < 1e-12 && math.Abs(dlon) < 1e-12 {
+			break
+		}
+	}
+	return q, nil
+}
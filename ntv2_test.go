@@ -0,0 +1,149 @@
+package geodesic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+// testGrid is a grid of an NTv2 file to write, in degrees, with the
+// shifts of latitude and longitude (arc-seconds, the longitude positive to
+// the west) at each node.
+type testGrid struct {
+	name, parent                   string
+	south, north, east, west, step float64
+	shift                          func(lat, lon float64) (dlat, dlon float64)
+}
+
+// writeNTv2 returns an NTv2 file of the grids.
+func writeNTv2(order binary.ByteOrder, grids []testGrid) []byte {
+	var b bytes.Buffer
+	label := func(s string) {
+		b.WriteString((s + "        ")[:8])
+	}
+	rec := func(l string, v any) {
+		label(l)
+		switch v := v.(type) {
+		case int:
+			binary.Write(&b, order, int32(v))
+			b.Write(make([]byte, 4))
+		case float64:
+			binary.Write(&b, order, v)
+		case string:
+			label(v)
+		}
+	}
+	rec("NUM_OREC", 11)
+	rec("NUM_SREC", 11)
+	rec("NUM_FILE", len(grids))
+	rec("GS_TYPE", "SECONDS")
+	rec("VERSION", "NTv2.0")
+	rec("SYSTEM_F", "FROM")
+	rec("SYSTEM_T", "TO")
+	rec("MAJOR_F", 6378137.0)
+	rec("MINOR_F", 6356752.314)
+	rec("MAJOR_T", 6378137.0)
+	rec("MINOR_T", 6356752.314)
+	for _, g := range grids {
+		rows := int(math.Round((g.north-g.south)/g.step)) + 1
+		cols := int(math.Round((g.east-g.west)/g.step)) + 1
+		rec("SUB_NAME", g.name)
+		rec("PARENT", g.parent)
+		rec("CREATED", "")
+		rec("UPDATED", "")
+		rec("S_LAT", g.south*3600)
+		rec("N_LAT", g.north*3600)
+		rec("E_LONG", -g.east*3600)
+		rec("W_LONG", -g.west*3600)
+		rec("LAT_INC", g.step*3600)
+		rec("LONG_INC", g.step*3600)
+		rec("GS_COUNT", rows*cols)
+		for j := 0; j < rows; j++ {
+			for i := 0; i < cols; i++ {
+				dlat, dlon := g.shift(g.south+float64(j)*g.step,
+					g.east-float64(i)*g.step)
+				binary.Write(&b, order, []float32{float32(dlat),
+					float32(dlon), 0, 0})
+			}
+		}
+	}
+	label("END")
+	b.Write(make([]byte, 8))
+	return b.Bytes()
+}
+
+func TestGridShift(t *testing.T) {
+	// A parent grid whose shifts are linear, so that they interpolate
+	// exactly, and a denser child grid with constant shifts.
+	linear := func(lat, lon float64) (float64, float64) {
+		return 2 + 0.5*(lat-50) - 0.25*lon, -3 + 0.125*(lat-50) + lon
+	}
+	grids := []testGrid{
+		{"PARENT", "NONE", 50, 52, 0, -2, 0.5, linear},
+		{"CHILD", "PARENT", 51, 51.5, -0.5, -1, 0.25,
+			func(lat, lon float64) (float64, float64) { return 5, 7 }},
+	}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian,
+		binary.BigEndian} {
+		g, err := ReadNTv2(bytes.NewReader(writeNTv2(order, grids)), OSGB36,
+			ETRS89)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Source() != OSGB36 || g.Target() != ETRS89 {
+			t.Fatalf("expected OSGB36 to ETRS89, got %v to %v", g.Source(),
+				g.Target())
+		}
+		for _, c := range []struct {
+			p          LatLng
+			dlat, dlon float64
+		}{
+			{LatLng{50.3, -1.7}, 0, 0},
+			{LatLng{51.9, -0.1}, 0, 0},
+			{LatLng{51.2, -0.7}, 5, 7},
+			{LatLng{50, -2}, 0, 0},
+		} {
+			if c.dlat == 0 {
+				c.dlat, c.dlon = linear(c.p.Lat, c.p.Lon)
+			}
+			want := LatLng{c.p.Lat + c.dlat/3600, c.p.Lon - c.dlon/3600}
+			q, err := g.Transform(c.p)
+			if err != nil || !eqish(q.Lat, want.Lat, 9) ||
+				!eqish(q.Lon, want.Lon, 9) {
+				t.Fatalf("%v: expected %v, got %v %v", c.p, want, q, err)
+			}
+			r, err := g.Inverse().Transform(q)
+			if err != nil || !eqish(r.Lat, c.p.Lat, 11) ||
+				!eqish(r.Lon, c.p.Lon, 11) {
+				t.Fatalf("%v: expected a round trip, got %v %v", c.p, r, err)
+			}
+		}
+		if _, err := g.Transform(LatLng{49, -1}); !errors.Is(err,
+			ErrOutOfRange) {
+			t.Fatalf("expected ErrOutOfRange, got %v", err)
+		}
+
+		// As a transformation of coordinates.
+		c, err := Coord{OSGB36, 50.3, -1.7}.Transform(g)
+		if err != nil || c.Datum != ETRS89 {
+			t.Fatalf("expected a point on ETRS89, got %v %v", c, err)
+		}
+		if c, err = c.Transform(g.Inverse()); err != nil || c.Datum != OSGB36 {
+			t.Fatalf("expected a point on OSGB36, got %v %v", c, err)
+		}
+	}
+
+	// Bad files.
+	data := writeNTv2(binary.LittleEndian, grids)
+	for _, bad := range [][]byte{
+		nil, []byte("NTv2"), data[:200], data[:len(data)-200],
+		bytes.Replace(data, []byte("PARENT  PARENT"), []byte("PARENT  NOPE  "), 1),
+		bytes.Replace(data, []byte("SECONDS "), []byte("RADIANS "), 1),
+	} {
+		if _, err := ReadNTv2(bytes.NewReader(bad), OSGB36, ETRS89); !errors.Is(err, ErrGridShift) {
+			t.Fatalf("expected ErrGridShift, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// OSGB is a position on the National Grid of the Ordnance Survey of Great
+// Britain, the transverse Mercator projection of OSGB36 that the Ordnance
+// Survey maps and grid references are on.
+//
+// The easting and northing include the false easting of 400 km and false
+// northing of -100 km, so both are positive across Great Britain.
+type OSGB struct {
+	Easting  float64 // easting (meters)
+	Northing float64 // northing (meters)
+}
+
+// National Grid parameters, as in the OSGB class of GeographicLib.
+const (
+	osgbK0            = 0.9996012717
+	osgbLat0          = 49
+	osgbLon0          = -2
+	osgbFalseEasting  = 4e5
+	osgbFalseNorthing = -1e5
+)
+
+// osgbLetters are the letters of the 500 km and 100 km squares of the grid
+// references, in rows of five from the north west, without I.
+const osgbLetters = "ABCDEFGHJKLMNOPQRSTUVWXYZ"
+
+// osgbTM is the projection of the National Grid, and osgbY0 is the northing
+// of its true origin from the equator.
+var (
+	osgbTM = OSGB36.Ellipsoid.NewTransverseMercator(osgbK0)
+	osgbY0 = osgbOriginNorthing()
+)
+
+func osgbOriginNorthing() float64 {
+	_, y, _, _ := osgbTM.Forward(osgbLon0, osgbLat0, osgbLon0)
+	return y
+}
+
+// osgbInGrid reports whether an easting and northing are in the 500 km
+// squares of the grid references, which run from 1000 km west of the false
+// origin to 1500 km east of it, and from 500 km south to 2000 km north.
+func osgbInGrid(x, y float64) bool {
+	return x >= -1e6 && x < 1.5e6 && y >= -5e5 && y < 2e6
+}
+
+// ToOSGB converts a point to the National Grid.
+//
+// Param p is the point, on the OSGB36 datum. Convert GPS positions, which
+// are on WGS84 or ETRS89, with ETRS89ToOSGB36 first.
+// Returns the grid position, or an error wrapping ErrOutOfRange if p is
+// outside the squares of the grid references.
+func ToOSGB(p LatLng) (OSGB, error) {
+	x, y, _, _ := osgbTM.Forward(osgbLon0, p.Lat, p.Lon)
+	g := OSGB{Easting: x + osgbFalseEasting,
+		Northing: y - osgbY0 + osgbFalseNorthing}
+	if !osgbInGrid(g.Easting, g.Northing) {
+		return OSGB{}, fmt.Errorf("%w: %v is outside the National Grid",
+			ErrOutOfRange, p)
+	}
+	return g, nil
+}
+
+// FromOSGB converts a National Grid position to a point.
+//
+// Param g is the grid position.
+// Returns the point, on the OSGB36 datum, or an error wrapping
+// ErrOutOfRange if g is outside the squares of the grid references.
+func FromOSGB(g OSGB) (LatLng, error) {
+	if !osgbInGrid(g.Easting, g.Northing) {
+		return LatLng{}, fmt.Errorf("%w: easting %v, northing %v",
+			ErrOutOfRange, g.Easting, g.Northing)
+	}
+	var p LatLng
+	p.Lat, p.Lon, _, _ = osgbTM.Reverse(osgbLon0, g.Easting-osgbFalseEasting,
+		g.Northing-osgbFalseNorthing+osgbY0)
+	return p, nil
+}
+
+// GridReference returns the grid reference of the position, such as
+// "TG5140913177".
+//
+// Param prec is the number of digits of each of the easting and northing in
+// the 100 km square, in [0,10]: 5 is to the meter, and 0 only gives the
+// letters of the square.
+// Returns the grid reference, or an error wrapping ErrOutOfRange if g is
+// outside the squares of the grid references.
+//
+// As for ToMGRS, the digits are truncated rather than rounded, so the grid
+// reference names the square that contains the position.
+func (g OSGB) GridReference(prec int) (string, error) {
+	if prec < 0 || prec > 10 {
+		return "", fmt.Errorf("%w: precision %d", ErrOutOfRange, prec)
+	}
+	if !osgbInGrid(g.Easting, g.Northing) {
+		return "", fmt.Errorf("%w: easting %v, northing %v", ErrOutOfRange,
+			g.Easting, g.Northing)
+	}
+	// The 100 km squares from the south west corner of the grid.
+	x, y := g.Easting+1e6, g.Northing+5e5
+	ix, iy := int(math.Floor(x/1e5)), int(math.Floor(y/1e5))
+	var b strings.Builder
+	b.WriteByte(osgbLetters[(4-iy/5)*5+ix/5])
+	b.WriteByte(osgbLetters[(4-iy%5)*5+ix%5])
+	if prec > 0 {
+		scale := math.Pow(10, float64(prec-5))
+		dx := math.Floor(math.Mod(x, 1e5) * scale)
+		dy := math.Floor(math.Mod(y, 1e5) * scale)
+		fmt.Fprintf(&b, "%0*.0f%0*.0f", prec, dx, prec, dy)
+	}
+	return b.String(), nil
+}
+
+// ParseOSGB parses a National Grid reference.
+//
+// Param s is the grid reference, in either case and with or without
+// spaces, such as "TG5140913177" or "TG 51409 13177".
+// Returns the center of the square named by the grid reference, or an
+// error wrapping ErrText if it is not a valid grid reference.
+func ParseOSGB(s string) (OSGB, error) {
+	t := strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	if len(t) < 2 {
+		return OSGB{}, fmt.Errorf("%w: %q: bad letters", ErrText, s)
+	}
+	i1 := strings.IndexByte(osgbLetters, t[0])
+	i2 := strings.IndexByte(osgbLetters, t[1])
+	if i1 < 0 || i2 < 0 {
+		return OSGB{}, fmt.Errorf("%w: %q: bad letters", ErrText, s)
+	}
+	digits := t[2:]
+	if len(digits)%2 != 0 || len(digits) > 20 {
+		return OSGB{}, fmt.Errorf("%w: %q: bad digits", ErrText, s)
+	}
+	prec := len(digits) / 2
+	size := 1e5 / math.Pow(10, float64(prec)) // of the square (meters)
+	var x, y float64
+	if prec > 0 {
+		xd, err1 := strconv.ParseUint(digits[:prec], 10, 64)
+		yd, err2 := strconv.ParseUint(digits[prec:], 10, 64)
+		if err1 != nil || err2 != nil {
+			return OSGB{}, fmt.Errorf("%w: %q: bad digits", ErrText, s)
+		}
+		x, y = float64(xd)*size, float64(yd)*size
+	}
+	ix := 5*(i1%5) + i2%5
+	iy := 5*(4-i1/5) + 4 - i2/5
+	return OSGB{Easting: float64(ix)*1e5 - 1e6 + x + size/2,
+		Northing: float64(iy)*1e5 - 5e5 + y + size/2}, nil
+}
+
+// OSGBProjection is the projection of the National Grid, for use with
+// Ellipsoid.ProjectedLength and Ellipsoid.ProjectedArea on the ellipsoid of
+// OSGB36, and EPSG:27700. See ToOSGB.
+var OSGBProjection Projection = osgbProjection{}
+
+type osgbProjection struct{}
+
+func (osgbProjection) Project(p LatLng) (x, y float64, err error) {
+	g, err := ToOSGB(p)
+	return g.Easting, g.Northing, err
+}
+
+func (osgbProjection) Unproject(x, y float64) (LatLng, error) {
+	return FromOSGB(OSGB{x, y})
+}
+
+// ETRS89ToOSGB36 is the Helmert transformation from ETRS89 to OSGB36 that
+// the Ordnance Survey publishes, which is accurate to a few meters across Great
+// Britain. WGS84 positions from GPS are within a meter of ETRS89 in
+// Europe, so they may be tagged as ETRS89 for it. Use their OSTN15 grid
+// shift file with ReadNTv2 for the accuracy of the National Grid itself.
+var ETRS89ToOSGB36 = Helmert{ETRS89, OSGB36, -446.448, 125.157, -542.060,
+	-0.1502, -0.2470, -0.8421, 20.4894}
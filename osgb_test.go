@@ -0,0 +1,103 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestOSGB(t *testing.T) {
+	// The worked example of the Ordnance Survey's guide to coordinate
+	// systems in Great Britain, at 52°39'27.2531"N 1°43'4.5177"E.
+	p := LatLng{52 + 39/60.0 + 27.2531/3600, 1 + 43/60.0 + 4.5177/3600}
+	g, err := ToOSGB(p)
+	if err != nil || !eqish(g.Easting, 651409.903, 3) ||
+		!eqish(g.Northing, 313177.270, 3) {
+		t.Fatalf("expected 651409.903 313177.270, got %v (%v)", g, err)
+	}
+	q, err := FromOSGB(g)
+	if err != nil || !eqish(q.Lat, p.Lat, 10) || !eqish(q.Lon, p.Lon, 10) {
+		t.Fatalf("expected %v, got %v (%v)", p, q, err)
+	}
+	for _, v := range []struct {
+		prec int
+		ref  string
+	}{
+		{5, "TG5140913177"}, {0, "TG"}, {3, "TG514131"},
+		{6, "TG514099131772"},
+	} {
+		if ref, err := g.GridReference(v.prec); err != nil || ref != v.ref {
+			t.Fatalf("%d: expected %q, got %q (%v)", v.prec, v.ref, ref, err)
+		}
+	}
+	// Parsing gives the center of the square.
+	for _, v := range []struct {
+		ref  string
+		x, y float64
+	}{
+		{"TG 51409 13177", 651409.5, 313177.5}, {"tg5113", 651500, 313500},
+		{"TQ", 550000, 150000}, {"SV0000", 500, 500}, {"HL", 50000, 1250000},
+		{"JM", 650000, 1250000}, {"VZ", -550000, -450000},
+	} {
+		g, err := ParseOSGB(v.ref)
+		if err != nil || g.Easting != v.x || g.Northing != v.y {
+			t.Fatalf("%q: expected %v %v, got %v (%v)", v.ref, v.x, v.y, g, err)
+		}
+	}
+	for _, s := range []string{"", "T", "TI12", "TQ123", "TQ1x", "1Q12"} {
+		if _, err := ParseOSGB(s); !errors.Is(err, ErrText) {
+			t.Fatalf("%q: expected ErrText, got %v", s, err)
+		}
+	}
+	for _, g := range []OSGB{{-1e6 - 1, 0}, {1.5e6, 0}, {0, 2e6}, {0, -5e5 - 1}} {
+		if _, err := g.GridReference(5); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("%v: expected ErrOutOfRange, got %v", g, err)
+		}
+		if _, err := FromOSGB(g); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("%v: expected ErrOutOfRange, got %v", g, err)
+		}
+	}
+	if _, err := ToOSGB(LatLng{0, 0}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		g := OSGB{rng.Float64() * 7e5, rng.Float64() * 13e5}
+		ref, err := g.GridReference(5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, err := ParseOSGB(ref)
+		if err != nil || !(math.Abs(h.Easting-g.Easting) <= 0.5) ||
+			!(math.Abs(h.Northing-g.Northing) <= 0.5) {
+			t.Fatalf("%v: expected the square of %q, got %v (%v)", g, ref, h, err)
+		}
+		p, err := FromOSGB(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, y, err := OSGBProjection.Project(p)
+		if err != nil || !eqish(x, g.Easting, 6) || !eqish(y, g.Northing, 6) {
+			t.Fatalf("expected %v, got %v %v (%v)", g, x, y, err)
+		}
+	}
+
+	// From a GPS position, which is on ETRS89 within a meter, to the grid,
+	// and a distance on the grid.
+	c, err := Coord{ETRS89, p.Lat, p.Lon}.Transform(ETRS89ToOSGB36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, _ := ToOSGB(c.LatLng())
+	if d := OSGB36.Ellipsoid.distance(c.LatLng(), q); !(d > 50 && d < 200) {
+		t.Fatalf("expected a datum shift of about 100 m, got %v", d)
+	}
+	s, err := OSGB36.Ellipsoid.ProjectedLength(OSGBProjection,
+		[][2]float64{{g.Easting, g.Northing}, {g2.Easting, g2.Northing}})
+	if want := OSGB36.Ellipsoid.distance(c.LatLng(), q); err != nil ||
+		!eqish(s, want, 6) {
+		t.Fatalf("expected %v, got %v (%v)", want, s, err)
+	}
+}
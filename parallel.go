@@ -0,0 +1,51 @@
+package geodesic
+
+import "math"
+
+// parallelRadius returns the radius of the circle of latitude at lat
+// (degrees), which is the distance from the point to the polar axis.
+func (e *Ellipsoid) parallelRadius(lat float64) float64 {
+	f := e.Flattening()
+	e2 := f * (2 - f)
+	phi := lat * (math.Pi / 180)
+	sphi, cphi := math.Sincos(phi)
+	if math.Abs(lat) == 90 {
+		cphi = 0
+	}
+	return e.Radius() * cphi / math.Sqrt(1-e2*sphi*sphi)
+}
+
+// ParallelLength returns the circumference of the circle of latitude
+// (parallel) at lat on the ellipsoid.
+//
+// Param lat is the latitude of the parallel (degrees).
+// Returns the length of the parallel (meters).
+//
+// lat should be in the range [-90,+90]. The length is zero at the poles.
+func (e *Ellipsoid) ParallelLength(lat float64) float64 {
+	return 2 * math.Pi * e.parallelRadius(lat)
+}
+
+// ParallelArcLength returns the length of the arc of the parallel at lat,
+// traveling east from lon1 to lon2.
+//
+// Param lat is the latitude of the parallel (degrees).
+// Param lon1 is the starting longitude (degrees).
+// Param lon2 is the ending longitude (degrees).
+// Returns the length of the arc (meters).
+//
+// The arc always runs eastward, so going from lon1 = 170 to lon2 = -170
+// covers 20 degrees of longitude across the antimeridian. Swap the
+// arguments to measure the arc going west. As for Ellipsoid.RectArea, an
+// arc of 360 degrees or more, such as from lon1 = -180 to lon2 = 180, is
+// the whole parallel.
+func (e *Ellipsoid) ParallelArcLength(lat, lon1, lon2 float64) float64 {
+	if lon2-lon1 >= 360 {
+		return e.ParallelLength(lat)
+	}
+	dlon := math.Mod(lon2-lon1, 360)
+	if dlon < 0 {
+		dlon += 360
+	}
+	return e.parallelRadius(lat) * dlon * (math.Pi / 180)
+}
@@ -0,0 +1,51 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParallelLength(t *testing.T) {
+	if !eqish(WGS84.ParallelLength(0), 2*math.Pi*6378137.0, 7) {
+		t.Fatalf("bad equator length")
+	}
+	if WGS84.ParallelLength(90) != 0 || WGS84.ParallelLength(-90) != 0 {
+		t.Fatalf("expected zero length at poles")
+	}
+	// Sum many tiny geodesic steps along the parallel.
+	for _, lat := range []float64{-75, -30, 10, 45, 60} {
+		var sum float64
+		n := 36000
+		for i := 0; i < n; i++ {
+			var s12 float64
+			lon1 := -180 + float64(i)*360/float64(n)
+			WGS84.Inverse(lat, lon1, lat, lon1+360/float64(n), &s12, nil, nil)
+			sum += s12
+		}
+		if !eqish(sum, WGS84.ParallelLength(lat), 1) {
+			t.Fatalf("lat %v: expected %f, got %f",
+				lat, sum, WGS84.ParallelLength(lat))
+		}
+	}
+}
+
+func TestParallelArcLength(t *testing.T) {
+	full := WGS84.ParallelLength(40)
+	arc := WGS84.ParallelArcLength(40, 170, -170)
+	if !eqish(arc, full*20/360, 7) {
+		t.Fatalf("expected %f, got %f", full*20/360, arc)
+	}
+	arc = WGS84.ParallelArcLength(40, -170, 170)
+	if !eqish(arc, full*340/360, 7) {
+		t.Fatalf("expected %f, got %f", full*340/360, arc)
+	}
+	// A full turn is the whole parallel, not an empty arc.
+	for _, lons := range [][2]float64{{-180, 180}, {10, 370}, {0, 720}} {
+		if arc := WGS84.ParallelArcLength(40, lons[0], lons[1]); arc != full {
+			t.Fatalf("%v: expected %f, got %f", lons, full, arc)
+		}
+	}
+	if arc := WGS84.ParallelArcLength(40, 180, -180); arc != 0 {
+		t.Fatalf("expected 0, got %f", arc)
+	}
+}
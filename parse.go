@@ -0,0 +1,313 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CoordinateOrder is the order of the two coordinates in a point written
+// without hemisphere letters, see ParseLatLngOrder.
+type CoordinateOrder int
+
+const (
+	// LatLonOrder reads the latitude first, as people usually write a
+	// point. This is the default.
+	LatLonOrder CoordinateOrder = iota
+	// LonLatOrder reads the longitude first, as GeoJSON and WKT do.
+	LonLatOrder
+)
+
+// ParseLatLng parses a point as people write it, with the latitude first
+// unless hemisphere letters say otherwise. See ParseLatLngOrder.
+func ParseLatLng(s string) (LatLng, error) {
+	return ParseLatLngOrder(s, LatLonOrder)
+}
+
+// ParseLatLngOrder parses a point as people write it.
+//
+// Param s is the text of the point.
+// Param order is the order of coordinates that have no hemisphere letter.
+// Returns the point, or an error wrapping ErrText.
+//
+// Each coordinate may be written in decimal degrees ("-33.8688"), degrees
+// and decimal minutes ("33°52.128'S"), or degrees, minutes, and seconds
+// ("33°52'7.7\"S", "33d52m7.7s", or "33 52 7.7 S"). The degree, minute, and
+// second marks may be the ASCII ones or their typographic forms. A
+// hemisphere letter, N, S, E, or W in either case, may come before or after
+// the numbers instead of a sign, and a coordinate with a letter is placed by
+// it whatever the order, so "151.2E 33.9S" is read correctly. A lowercase s
+// directly after a number of minutes is taken as seconds, not south.
+//
+// The coordinates may be separated by a comma or a semicolon, or only by
+// spaces when that is not ambiguous. The latitude must be in [-90,+90] and
+// the longitude in [-180,+180].
+func ParseLatLngOrder(s string, order CoordinateOrder) (LatLng, error) {
+	toks, err := lexCoords(s)
+	if err != nil {
+		return LatLng{}, fmt.Errorf("%w: %q: %v", ErrText, s, err)
+	}
+	groups, err := splitCoords(toks)
+	if err != nil {
+		return LatLng{}, fmt.Errorf("%w: %q: %v", ErrText, s, err)
+	}
+	var cs [2]coord
+	for i, g := range groups {
+		if cs[i], err = parseCoord(g); err != nil {
+			return LatLng{}, fmt.Errorf("%w: %q: %v", ErrText, s, err)
+		}
+	}
+	lat, lon := cs[0], cs[1]
+	switch {
+	case cs[0].axis == 'N' && cs[1].axis == 'N',
+		cs[0].axis == 'E' && cs[1].axis == 'E':
+		return LatLng{}, fmt.Errorf("%w: %q: two coordinates on the same axis",
+			ErrText, s)
+	case cs[0].axis == 'E' || cs[1].axis == 'N':
+		lat, lon = cs[1], cs[0]
+	case cs[0].axis == 0 && cs[1].axis == 0 && order == LonLatOrder:
+		lat, lon = cs[1], cs[0]
+	}
+	if !(math.Abs(lat.deg) <= 90) {
+		return LatLng{}, fmt.Errorf("%w: %q: latitude out of range", ErrText, s)
+	}
+	if !(math.Abs(lon.deg) <= 180) {
+		return LatLng{}, fmt.Errorf("%w: %q: longitude out of range", ErrText, s)
+	}
+	return LatLng{lat.deg, lon.deg}, nil
+}
+
+// ParseGeoCoords parses a point written as people write it, as UTM, or as
+// MGRS, and converts it to a latitude and longitude on the ellipsoid, in
+// the manner of the GeoCoords class of GeographicLib.
+//
+// Param s is the text, such as "33.3 44.4", "33°18'N 44°24'E",
+// "38n 444140 3684706", or "38SMB4414084706".
+// Returns the point, which for MGRS is the center of the square, or an
+// error wrapping ErrText.
+//
+// The text is tried as MGRS, as a point in the format of ParseLatLng, and
+// then as UTM in the format of ParseUTM. A point in the format of
+// ParseLatLng never reads as UTM, since an easting of many kilometers is
+// not a valid degree count.
+func (e *Ellipsoid) ParseGeoCoords(s string) (LatLng, error) {
+	if p, err := e.FromMGRS(s); err == nil {
+		return p, nil
+	}
+	p, err := ParseLatLng(s)
+	if err == nil {
+		return p, nil
+	}
+	if u, uerr := ParseUTM(s); uerr == nil {
+		p, uerr := e.FromUTM(u)
+		if uerr != nil {
+			return LatLng{}, fmt.Errorf("%w: %q: %v", ErrText, s, uerr)
+		}
+		return p, nil
+	}
+	return LatLng{}, err
+}
+
+// coordToken is a token of a written point.
+type coordToken struct {
+	// kind is '0' for a number, 'd', 'm', or 's' for a degree, minute, or
+	// second mark, 'h' for a hemisphere letter, and ',' for a separator.
+	kind byte
+	num  float64 // for a number
+	text string  // for a number, as written
+	hemi byte    // for a hemisphere, one of NSEW
+}
+
+// lexCoords splits a written point into tokens.
+func lexCoords(s string) ([]coordToken, error) {
+	var toks []coordToken
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == ',' || r == ';':
+			toks = append(toks, coordToken{kind: ','})
+		case r >= '0' && r <= '9' || r == '.' || r == '-' || r == '+':
+			j := i + 1
+			for j < len(rs) && (rs[j] >= '0' && rs[j] <= '9' || rs[j] == '.') {
+				j++
+			}
+			text := string(rs[i:j])
+			x, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad number %q", text)
+			}
+			toks = append(toks, coordToken{kind: '0', num: x, text: text})
+			i = j
+			continue
+		case r == '°' || r == 'º' || r == '˚' || r == 'd' || r == 'D':
+			toks = append(toks, coordToken{kind: 'd'})
+		case r == '\'' || r == '′' || r == '’' || r == 'm' || r == 'M':
+			toks = append(toks, coordToken{kind: 'm'})
+		case r == '"' || r == '″' || r == '”':
+			toks = append(toks, coordToken{kind: 's'})
+		case r == 's' && len(toks) >= 2 && toks[len(toks)-1].kind == '0' &&
+			toks[len(toks)-2].kind == 'm':
+			toks = append(toks, coordToken{kind: 's'})
+		case strings.ContainsRune("NSEWnsew", r):
+			toks = append(toks,
+				coordToken{kind: 'h', hemi: byte(unicode.ToUpper(r))})
+		default:
+			return nil, fmt.Errorf("unexpected %q", r)
+		}
+		i++
+	}
+	return toks, nil
+}
+
+// splitCoords splits the tokens of a written point into those of its two
+// coordinates.
+func splitCoords(toks []coordToken) ([2][]coordToken, error) {
+	var groups [2][]coordToken
+	var seps, hemis, degs, nums int
+	for _, t := range toks {
+		switch t.kind {
+		case ',':
+			seps++
+		case 'h':
+			hemis++
+		case 'd':
+			degs++
+		case '0':
+			nums++
+		}
+	}
+	at := -1 // the index of the first token of the second coordinate
+	switch {
+	case seps > 1:
+		return groups, fmt.Errorf("too many separators")
+	case seps == 1:
+		for i, t := range toks {
+			if t.kind == ',' {
+				groups[0], groups[1] = toks[:i], toks[i+1:]
+				return groups, nil
+			}
+		}
+	case hemis == 2:
+		// The letters either all come first or all come last.
+		prefix := toks[0].kind == 'h'
+		for i, t := range toks {
+			if t.kind == 'h' && i > 0 {
+				at = i
+				if !prefix {
+					at++
+				}
+				break
+			}
+		}
+	case degs == 2:
+		n := 0
+		for i, t := range toks {
+			if t.kind == 'd' {
+				if n++; n == 2 {
+					at = i - 1 // the number before the second mark
+					break
+				}
+			}
+		}
+	case degs == 0 && nums%2 == 0:
+		// Numbers alone split evenly, as in "33 52 151 12".
+		n := 0
+		for i, t := range toks {
+			if t.kind == '0' {
+				if n == nums/2 {
+					at = i
+					break
+				}
+				n++
+			}
+		}
+	}
+	if at <= 0 || at >= len(toks) {
+		return groups, fmt.Errorf("expected two coordinates")
+	}
+	groups[0], groups[1] = toks[:at], toks[at:]
+	return groups, nil
+}
+
+// coord is a parsed coordinate.
+type coord struct {
+	deg  float64 // signed degrees
+	axis byte    // 'N' for a latitude, 'E' for a longitude, or 0 if unknown
+}
+
+// parseCoord parses the tokens of one coordinate.
+func parseCoord(toks []coordToken) (coord, error) {
+	var c coord
+	var hemi byte
+	if len(toks) > 0 && toks[0].kind == 'h' {
+		hemi, toks = toks[0].hemi, toks[1:]
+	} else if n := len(toks); n > 0 && toks[n-1].kind == 'h' {
+		hemi, toks = toks[n-1].hemi, toks[:n-1]
+	}
+	// The parts are degrees, minutes, and seconds, which may be marked.
+	var parts [3]coordToken
+	next := 0 // the part of an unmarked number
+	for i := 0; i < len(toks); i++ {
+		t := toks[i]
+		if t.kind != '0' {
+			return c, fmt.Errorf("unexpected mark")
+		}
+		part := next
+		if i+1 < len(toks) && toks[i+1].kind != '0' {
+			part = strings.IndexByte("dms", toks[i+1].kind)
+			if part < 0 {
+				return c, fmt.Errorf("unexpected mark")
+			}
+			i++
+		}
+		if part < next || part > 2 {
+			return c, fmt.Errorf("parts out of order")
+		}
+		parts[part], next = t, part+1
+	}
+	if next == 0 {
+		return c, fmt.Errorf("missing degrees")
+	}
+	deg, neg := parts[0].num, strings.HasPrefix(parts[0].text, "-")
+	for i, p := range parts[1:next] {
+		if p.text == "" || p.text[0] == '-' || p.text[0] == '+' {
+			return c, fmt.Errorf("bad minutes or seconds")
+		}
+		if !(p.num < 60) {
+			return c, fmt.Errorf("minutes or seconds out of range")
+		}
+		// Only the last part may have a fraction.
+		if prev := parts[i]; strings.Contains(prev.text, ".") {
+			return c, fmt.Errorf("fraction before the last part")
+		}
+		deg = math.Abs(deg) + p.num/math.Pow(60, float64(i+1))
+		if neg {
+			deg = -deg
+		}
+	}
+	switch hemi {
+	case 'S', 'W':
+		if neg || strings.HasPrefix(parts[0].text, "+") {
+			return c, fmt.Errorf("both a sign and a hemisphere")
+		}
+		deg = -deg
+	case 'N', 'E':
+		if neg || strings.HasPrefix(parts[0].text, "+") {
+			return c, fmt.Errorf("both a sign and a hemisphere")
+		}
+	}
+	c.deg = deg
+	switch hemi {
+	case 'N', 'S':
+		c.axis = 'N'
+	case 'E', 'W':
+		c.axis = 'E'
+	}
+	return c, nil
+}
@@ -0,0 +1,60 @@
+package geodesic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLatLng(t *testing.T) {
+	sydney := LatLng{-33.8688, 151.2093}
+	for _, v := range []struct {
+		s    string
+		want LatLng
+	}{
+		{"-33.8688, 151.2093", sydney},
+		{"-33.8688 151.2093", sydney},
+		{"-33.8688;151.2093", sydney},
+		{"33.8688S 151.2093E", sydney},
+		{"S33.8688 E151.2093", sydney},
+		{"151.2093E, 33.8688S", sydney},
+		{"33.8688 S, 151.2093", sydney},
+		{"33°52.128'S 151°12.558'E", LatLng{-(33 + 52.128/60), 151 + 12.558/60}},
+		{"33°52′7.68″S 151°12′33.48″E", LatLng{-(33 + 52/60.0 + 7.68/3600),
+			151 + 12/60.0 + 33.48/3600}},
+		{`-33°52'7.68" 151°12'33.48"`, LatLng{-(33 + 52/60.0 + 7.68/3600),
+			151 + 12/60.0 + 33.48/3600}},
+		{"33d52m7.68s S, 151d12m33.48s E", LatLng{-(33 + 52/60.0 + 7.68/3600),
+			151 + 12/60.0 + 33.48/3600}},
+		{"33 52 7.68 S 151 12 33.48 E", LatLng{-(33 + 52/60.0 + 7.68/3600),
+			151 + 12/60.0 + 33.48/3600}},
+		{"-33 52 151 12", LatLng{-(33 + 52/60.0), 151 + 12/60.0}},
+		{"51.4775n 0.4614w", LatLng{51.4775, -0.4614}},
+		{"+90, -180", LatLng{90, -180}},
+	} {
+		got, err := ParseLatLng(v.s)
+		if err != nil {
+			t.Fatalf("%q: %v", v.s, err)
+		}
+		if !eqish(got.Lat, v.want.Lat, 12) || !eqish(got.Lon, v.want.Lon, 12) {
+			t.Fatalf("%q: expected %v, got %v", v.s, v.want, got)
+		}
+	}
+
+	// The order only applies without hemisphere letters.
+	if got, _ := ParseLatLngOrder("151.2093, -33.8688", LonLatOrder); got != sydney {
+		t.Fatalf("expected %v, got %v", sydney, got)
+	}
+	if got, _ := ParseLatLngOrder("33.8688S 151.2093E", LonLatOrder); got != sydney {
+		t.Fatalf("expected %v, got %v", sydney, got)
+	}
+
+	for _, s := range []string{
+		"", "10", "10, 20, 30", "91, 0", "0, 181", "-33.8688S 151.2093E",
+		"33.8688N 151.2093S", "33°61' 151°", "33.5°10' 151°", "10 x 20",
+		"1.2.3, 4", "33 52 7", "N 10 E",
+	} {
+		if _, err := ParseLatLng(s); !errors.Is(err, ErrText) {
+			t.Fatalf("%q: expected ErrText, got %v", s, err)
+		}
+	}
+}
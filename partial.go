@@ -0,0 +1,179 @@
+package geodesic
+
+import "math"
+
+// DirectToLatitude solves the direct geodesic problem for the point at
+// which a geodesic first reaches a latitude, rather than for a distance.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param lat2 is the latitude to reach (degrees).
+// Out param s12 is a pointer to the distance from point 1 to point 2
+// (meters).
+// Out param lon2 is a pointer to the longitude of point 2 (degrees).
+// Out param azi2 is a pointer to the (forward) azimuth at point 2 (degrees).
+// Returns whether the geodesic reaches the latitude.
+//
+// This answers where a course first crosses a parallel, which is often
+// found by bisection over Ellipsoid.Direct by hand. The geodesic goes back
+// and forth between the latitudes of its vertices, the points furthest
+// from the equator, so a latitude beyond them is never reached, and one
+// between them is reached twice on each circuit of the ellipsoid, of which
+// the first going forward is returned. This is 0 if lat1 is lat2. The
+// crossing is solved on the geodesic, by bisection on the distance between
+// its vertices, to a nanometer. Any of the "return" arguments, s12, etc.,
+// may be replaced with nil.
+func (e *Ellipsoid) DirectToLatitude(lat1, lon1, azi1, lat2 float64,
+	s12, lon2, azi2 *float64,
+) bool {
+	if !(math.Abs(lat2) <= 90) {
+		return false
+	}
+	l := e.LineInit(lat1, lon1, azi1)
+	s, ok := e.latitudeDist(&l, lat2)
+	if !ok {
+		return false
+	}
+	l.Position(s, nil, lon2, azi2)
+	if s12 != nil {
+		*s12 = s
+	}
+	return true
+}
+
+// DirectToLongitude solves the direct geodesic problem for the point at
+// which a geodesic first reaches a longitude, rather than for a distance.
+//
+// Param lat1 is the latitude of point 1 (degrees).
+// Param lon1 is the longitude of point 1 (degrees).
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param lon2 is the longitude to reach (degrees).
+// Out param s12 is a pointer to the distance from point 1 to point 2
+// (meters).
+// Out param lat2 is a pointer to the latitude of point 2 (degrees).
+// Out param azi2 is a pointer to the (forward) azimuth at point 2 (degrees).
+// Returns whether the geodesic reaches the longitude.
+//
+// The longitude changes monotonically along a geodesic, to the east or the
+// west, and every longitude is reached, as by MeridianCrossings, though
+// one just behind lon1 takes a little more than a circuit of the ellipsoid
+// to come around to. A meridional geodesic, heading due north or south,
+// only reaches its own meridian, at the start, and the one opposite, at
+// the pole. This is 0 if lon1 is lon2. Any of the "return" arguments,
+// s12, etc., may be replaced with nil.
+func (e *Ellipsoid) DirectToLongitude(lat1, lon1, azi1, lon2 float64,
+	s12, lat2, azi2 *float64,
+) bool {
+	if math.IsNaN(lon2) || math.IsInf(lon2, 0) {
+		return false
+	}
+	l := e.LineInit(lat1, lon1, azi1)
+	s, ok := e.longitudeDist(&l, lon2)
+	if !ok {
+		return false
+	}
+	l.Position(s, lat2, nil, azi2)
+	if s12 != nil {
+		*s12 = s
+	}
+	return true
+}
+
+// partialStep returns the length of the steps along a geodesic in which to
+// look for a crossing, a quarter of the shortest distance between vertices
+// so that a step holds at most one, and the number of steps that make up
+// a circuit of the ellipsoid.
+func (e *Ellipsoid) partialStep() (h float64, n int) {
+	a := e.Radius()
+	b := a * (1 - e.Flattening())
+	h = math.Pi * math.Min(a, b) / 4
+	return h, int(math.Ceil(2*math.Pi*math.Max(a, b)/h)) + 1
+}
+
+// latitudeDist returns the distance along l to the first point at lat.
+func (e *Ellipsoid) latitudeDist(l *Line, lat float64) (float64, bool) {
+	lat0 := l.Lat1()
+	if lat == lat0 {
+		return 0, true
+	}
+	// The latitude is monotonic between the vertices, so each step is the
+	// part of a band, or two parts if it holds a vertex.
+	ed := ringEdge{l: *l}
+	h, n := e.partialStep()
+	s0, c0 := 0.0, math.Cos(l.Azi1()*(math.Pi/180))
+	for k := 1; k <= n; k++ {
+		s1 := float64(k) * h
+		var lat1, azi1 float64
+		l.Position(s1, &lat1, nil, &azi1)
+		c1 := math.Cos(azi1 * (math.Pi / 180))
+		parts := []bandPart{{&ed, s0, s1, lat0, lat1}}
+		if (c0 > 0 && c1 < 0) || (c0 < 0 && c1 > 0) {
+			sv := vertexDist(l, s0, s1, c0 > 0)
+			var latv float64
+			l.Position(sv, &latv, nil, nil)
+			parts = []bandPart{{&ed, s0, sv, lat0, latv},
+				{&ed, sv, s1, latv, lat1}}
+		}
+		for _, pt := range parts {
+			if (pt.lat0-lat)*(pt.lat1-lat) <= 0 && pt.lat0 != pt.lat1 {
+				return pt.crossing(lat), true
+			}
+		}
+		s0, lat0, c0 = s1, lat1, c1
+	}
+	return 0, false
+}
+
+// longitudeDist returns the distance along l to the first point at lon.
+func (e *Ellipsoid) longitudeDist(l *Line, lon float64) (float64, bool) {
+	lon0, azi1 := l.Lon1(), l.Azi1()
+	dir := 1.0
+	if math.Sin(azi1*(math.Pi/180)) < 0 {
+		dir = -1
+	}
+	// How far to go around in the direction of travel.
+	d := math.Mod(dir*(lon-lon0), 360)
+	if d < 0 {
+		d += 360
+	}
+	if d == 0 {
+		return 0, true
+	}
+	if azi1 == 0 || math.Abs(azi1) == 180 {
+		if d != 180 {
+			return 0, false
+		}
+		// Over the pole, which is the vertex of the geodesic.
+		pole := math.Copysign(90, math.Cos(azi1*(math.Pi/180)))
+		var s float64
+		e.Inverse(l.Lat1(), lon0, pole, lon0, &s, nil, nil)
+		return s, true
+	}
+	// Unroll the longitudes by adding up the changes between steps, which
+	// are less than 180 degrees for steps shorter than between vertices.
+	h, n := e.partialStep()
+	var s0, u0 float64
+	for k := 1; k <= 2*n; k++ {
+		s1 := float64(k) * h
+		var lon1 float64
+		l.Position(s1, nil, &lon1, nil)
+		u1 := u0 + dir*math.Remainder(lon1-lon0, 360)
+		if u1 >= d {
+			lo, hi := s0, s1
+			for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+				mid := (lo + hi) / 2
+				var lon2 float64
+				l.Position(mid, nil, &lon2, nil)
+				if u0+dir*math.Remainder(lon2-lon0, 360) < d {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			return (lo + hi) / 2, true
+		}
+		s0, u0, lon0 = s1, u1, lon1
+	}
+	return 0, false
+}
@@ -0,0 +1,105 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDirectToLatitude(t *testing.T) {
+	// New York to Singapore goes over the north, crossing 60N going north,
+	// then 80N and 60N on the other side.
+	jfk, sin := LatLng{40.64, -73.78}, LatLng{1.36, 103.99}
+	var azi1, s13 float64
+	WGS84.Inverse(jfk.Lat, jfk.Lon, sin.Lat, sin.Lon, &s13, &azi1, nil)
+	for _, lat := range []float64{60, 80, 40.64, -30} {
+		var s12, lon2, azi2 float64
+		if !WGS84.DirectToLatitude(jfk.Lat, jfk.Lon, azi1, lat, &s12, &lon2,
+			&azi2) {
+			t.Fatalf("%v: expected a crossing", lat)
+		}
+		var p LatLng
+		var azi float64
+		WGS84.Direct(jfk.Lat, jfk.Lon, azi1, s12, &p.Lat, &p.Lon, &azi)
+		if !eqish(p.Lat, lat, 9) || !eqish(p.Lon, lon2, 9) ||
+			!eqish(azi, azi2, 9) {
+			t.Fatalf("%v: expected '%v, %v, %v', got '%v, %v, %v'", lat, lat,
+				lon2, azi2, p.Lat, p.Lon, azi)
+		}
+		// The first crossing, going north from the start.
+		if lat == 40.64 && s12 != 0 || lat == 60 && !(azi2 < 90 && s12 < s13/2) {
+			t.Fatalf("%v: expected the first crossing, got %v", lat, s12)
+		}
+		if lat == -30 && !(s12 > s13) {
+			t.Fatalf("%v: expected a crossing past the end, got %v", lat, s12)
+		}
+	}
+
+	// The latitude is only reached on the way back south, past the vertex.
+	var s12, azi2 float64
+	if !WGS84.DirectToLatitude(60, 0, 45, 59, &s12, nil, &azi2) ||
+		!(math.Abs(azi2) > 90) {
+		t.Fatalf("expected a crossing heading south, got %v %v", s12, azi2)
+	}
+
+	// Beyond the vertex and the equator.
+	if WGS84.DirectToLatitude(10, 0, 80, 85, nil, nil, nil) {
+		t.Fatal("expected no crossing past the vertex")
+	}
+	if WGS84.DirectToLatitude(0, 0, 90, 1, nil, nil, nil) {
+		t.Fatal("expected no crossing from the equator")
+	}
+	if WGS84.DirectToLatitude(0, 0, 30, math.NaN(), nil, nil, nil) {
+		t.Fatal("expected no crossing of NaN")
+	}
+}
+
+func TestDirectToLongitude(t *testing.T) {
+	jfk, lhr := LatLng{40.64, -73.78}, LatLng{51.47, -0.46}
+	var azi1 float64
+	WGS84.Inverse(jfk.Lat, jfk.Lon, lhr.Lat, lhr.Lon, nil, &azi1, nil)
+	// The crossings of MeridianCrossings.
+	for _, x := range WGS84.MeridianCrossings(jfk, lhr, 10) {
+		var s12, lat2, azi2 float64
+		if !WGS84.DirectToLongitude(jfk.Lat, jfk.Lon, azi1, x.Point.Lon,
+			&s12, &lat2, &azi2) {
+			t.Fatalf("%v: expected a crossing", x.Point.Lon)
+		}
+		if !eqish(s12, x.S12, 6) || !eqish(lat2, x.Point.Lat, 9) ||
+			!eqish(azi2, x.Azi, 9) {
+			t.Fatalf("expected %v, got '%v, %v, %v'", x, s12, lat2, azi2)
+		}
+	}
+
+	// Around the world going west, to a longitude just east of the start.
+	var s12, lat2 float64
+	if !WGS84.DirectToLongitude(10, 20, -80, 21, &s12, &lat2, nil) {
+		t.Fatal("expected a crossing")
+	}
+	var p LatLng
+	WGS84.Direct(10, 20, -80, s12, &p.Lat, &p.Lon, nil)
+	if !eqish(p.Lon, 21, 9) || !eqish(p.Lat, lat2, 9) ||
+		!(s12 > 2*math.Pi*WGS84.Radius()*0.99) {
+		t.Fatalf("expected a crossing around the world, got %v at %v", p, s12)
+	}
+
+	// Meridional geodesics reach the opposite meridian at the pole.
+	if !WGS84.DirectToLongitude(80, 5, 0, -175, &s12, &lat2, nil) ||
+		!eqish(lat2, 90, 9) {
+		t.Fatalf("expected the north pole, got %v %v", s12, lat2)
+	}
+	var s float64
+	WGS84.Inverse(80, 5, 90, 5, &s, nil, nil)
+	if !eqish(s12, s, 6) {
+		t.Fatalf("expected %v, got %v", s, s12)
+	}
+	if !WGS84.DirectToLongitude(-80, 5, 180, -175, nil, &lat2, nil) ||
+		!eqish(lat2, -90, 9) {
+		t.Fatalf("expected the south pole, got %v", lat2)
+	}
+	if WGS84.DirectToLongitude(80, 5, 0, 10, nil, nil, nil) {
+		t.Fatal("expected no crossing from a meridian")
+	}
+	if !WGS84.DirectToLongitude(80, 5, 30, 365, &s12, nil, nil) || s12 != 0 {
+		t.Fatalf("expected the start, got %v", s12)
+	}
+}
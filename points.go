@@ -0,0 +1,34 @@
+package geodesic
+
+import (
+	"iter"
+	"math"
+)
+
+// Points returns the points along the line at a fixed spacing.
+//
+// Param spacing is the distance between points (meters).
+// Returns a sequence of points, starting at point 1.
+//
+// The sequence yields point 1 and then a point every spacing meters,
+// ending with point 3 of the line, so the last spacing may be shorter. If
+// point 3 is undefined, as for a line from Ellipsoid.LineInit, the sequence
+// does not end on its own and it is up to the caller to stop. A
+// non-positive spacing yields only point 1.
+func (l *Line) Points(spacing float64) iter.Seq[LatLng] {
+	return func(yield func(LatLng) bool) {
+		s13 := l.Distance()
+		for i := 0; ; i++ {
+			s := float64(i) * spacing
+			end := !math.IsNaN(s13) && s >= s13
+			if end {
+				s = s13
+			}
+			var p LatLng
+			l.Position(s, &p.Lat, &p.Lon, nil)
+			if !yield(p) || end || !(spacing > 0) {
+				return
+			}
+		}
+	}
+}
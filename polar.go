@@ -0,0 +1,199 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+)
+
+// PolarStereographic is the polar stereographic projection of an
+// ellipsoid, the basis of UPS and of most maps of the polar regions. It is
+// safe for concurrent use.
+//
+// The projection is conformal, and its scale grows away from the pole, so
+// the scale at the pole is usually set below one, as 0.994 for UPS, or so
+// that the scale is one on a standard parallel, as 70 degrees for the sea
+// ice maps of EPSG:3413. It is computed in closed form, as in the
+// PolarStereographic class of GeographicLib, and is exact to rounding
+// everywhere but the opposite pole.
+type PolarStereographic struct {
+	conformal
+	a, k0 float64
+	c     float64 // (1-f)*exp(e*atanh(e)), the scale of the conformal sphere
+}
+
+// NewPolarStereographic returns the polar stereographic projection of the
+// ellipsoid.
+//
+// Param k0 is the scale at the pole, such as 0.994 for UPS.
+func (e *Ellipsoid) NewPolarStereographic(k0 float64) *PolarStereographic {
+	f := e.Flattening()
+	ps := &PolarStereographic{conformal: newConformal(f), a: e.Radius(),
+		k0: k0}
+	ps.c = (1 - f) * math.Exp(ps.eatanhe(1))
+	return ps
+}
+
+// NewPolarStereographicScale returns the polar stereographic projection of
+// the ellipsoid with a given scale on a parallel.
+//
+// Param lat is the parallel (degrees), in either hemisphere, but not at
+// the equator.
+// Param k is the scale on the parallel, such as 1 to make it the standard
+// parallel.
+func (e *Ellipsoid) NewPolarStereographicScale(lat, k float64,
+) *PolarStereographic {
+	ps := e.NewPolarStereographic(1)
+	_, _, _, k1 := ps.Forward(true, math.Abs(lat), 0)
+	ps.k0 = k / k1
+	return ps
+}
+
+// CentralScale returns the scale at the pole.
+func (ps *PolarStereographic) CentralScale() float64 {
+	return ps.k0
+}
+
+// Forward projects a point.
+//
+// Param north is whether the projection is about the north pole, rather
+// than the south pole.
+// Param lat is the latitude of the point (degrees).
+// Param lon is the longitude of the point (degrees).
+// Returns the easting x and northing y of the point (meters), the meridian
+// convergence gamma, which is the bearing of grid north clockwise from
+// true north (degrees), and the scale k.
+//
+// The origin of x and y is at the pole, with no false easting or northing,
+// and grid north is along the meridian of 0 away from the pole in the
+// north, and towards it in the south. The opposite pole is at infinity,
+// and a latitude outside of [-90,+90] returns NaNs.
+func (ps *PolarStereographic) Forward(north bool, lat, lon float64,
+) (x, y, gamma, k float64) {
+	if !(math.Abs(lat) <= 90) {
+		nan := math.NaN()
+		return nan, nan, nan, nan
+	}
+	if !north {
+		lat = -lat
+	}
+	sphi, cphi := sincosd(lat)
+	if lat == 90 {
+		cphi = 0
+	}
+	tau := sphi / cphi
+	secphi := math.Hypot(1, tau)
+	taup := ps.taupf(tau)
+	rho := math.Hypot(1, taup) + math.Abs(taup)
+	if taup >= 0 {
+		if lat != 90 {
+			rho = 1 / rho
+		} else {
+			rho = 0
+		}
+	}
+	rho *= 2 * ps.k0 * ps.a / ps.c
+	k = ps.k0
+	if lat != 90 {
+		k = rho / ps.a * secphi * math.Sqrt(1-ps.e2+ps.e2/(secphi*secphi))
+	}
+	slam, clam := sincosd(lon)
+	x, y = rho*slam, rho*clam
+	gamma = normLon(lon)
+	if north {
+		y = -y
+	} else {
+		gamma = normLon(-lon)
+	}
+	return x, y, gamma, k
+}
+
+// Reverse unprojects a point.
+//
+// Param north is whether the projection is about the north pole.
+// Param x is the easting of the point (meters).
+// Param y is the northing of the point (meters).
+// Returns the latitude and longitude of the point (degrees), the meridian
+// convergence gamma (degrees), and the scale k, see
+// PolarStereographic.Forward.
+func (ps *PolarStereographic) Reverse(north bool, x, y float64,
+) (lat, lon, gamma, k float64) {
+	rho := math.Hypot(x, y)
+	t := 0x1p-104 // at the pole, which gives a latitude of 90
+	if rho != 0 {
+		t = rho / (2 * ps.k0 * ps.a / ps.c)
+	}
+	taup := (1/t - t) / 2
+	tau := ps.tauf(taup)
+	secphi := math.Hypot(1, tau)
+	k = ps.k0
+	if rho != 0 {
+		k = rho / ps.a * secphi * math.Sqrt(1-ps.e2+ps.e2/(secphi*secphi))
+	}
+	lat = math.Atan(tau) * (180 / math.Pi)
+	if north {
+		lon = math.Atan2(x, -y) * (180 / math.Pi)
+		gamma = lon
+	} else {
+		lat = -lat
+		lon = math.Atan2(x, y) * (180 / math.Pi)
+		gamma = normLon(-lon)
+	}
+	return lat, lon, gamma, k
+}
+
+// UPS parameters.
+const (
+	upsK0          = 0.994
+	upsFalseOrigin = 2e6
+)
+
+// UPSProjection returns the projection of the Universal Polar Stereographic
+// system on the ellipsoid, about one pole, which covers the polar regions
+// that UTM leaves out: north of 84 degrees and south of -80.
+//
+// Param north is whether the projection is about the north pole, rather
+// than the south pole.
+//
+// The eastings and northings include the false easting and northing of
+// 2000 km. Points in the other hemisphere are outside the range of the
+// projection.
+func (e *Ellipsoid) UPSProjection(north bool) Projection {
+	return polarGrid{ps: e.NewPolarStereographic(upsK0), north: north,
+		fe: upsFalseOrigin, fn: upsFalseOrigin}
+}
+
+// polarGrid is a polar stereographic projection about one pole, with grid
+// north along the meridian lon0, and a false easting and northing.
+type polarGrid struct {
+	ps           *PolarStereographic
+	north        bool
+	lon0, fe, fn float64
+}
+
+// inside reports whether a latitude is in the hemisphere of the pole.
+func (g polarGrid) inside(lat float64) bool {
+	if g.north {
+		return lat >= 0 && lat <= 90
+	}
+	return lat <= 0 && lat >= -90
+}
+
+func (g polarGrid) Project(p LatLng) (x, y float64, err error) {
+	if !g.inside(p.Lat) || math.IsNaN(p.Lon) || math.IsInf(p.Lon, 0) {
+		return 0, 0, fmt.Errorf("%w: %v is outside the hemisphere of the "+
+			"projection", ErrOutOfRange, p)
+	}
+	x, y, _, _ = g.ps.Forward(g.north, p.Lat, p.Lon-g.lon0)
+	return x + g.fe, y + g.fn, nil
+}
+
+func (g polarGrid) Unproject(x, y float64) (LatLng, error) {
+	var p LatLng
+	p.Lat, p.Lon, _, _ = g.ps.Reverse(g.north, x-g.fe, y-g.fn)
+	if !g.inside(p.Lat) || math.IsNaN(p.Lon) {
+		return LatLng{}, fmt.Errorf("%w: easting %v, northing %v",
+			ErrOutOfRange, x, y)
+	}
+	p.Lon = normLon(p.Lon + g.lon0)
+	return p, nil
+}
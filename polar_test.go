@@ -0,0 +1,81 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPolarStereographic(t *testing.T) {
+	// The examples of variants A and B of EPSG Guidance Note 7-2, the first
+	// of which is UPS north.
+	ps := WGS84.NewPolarStereographic(0.994)
+	x, y, _, _ := ps.Forward(true, 73, 44)
+	if !eqish(x+2e6, 3320416.75, 2) || !eqish(y+2e6, 632668.43, 2) {
+		t.Fatalf("expected '3320416.75, 632668.43', got '%v, %v'", x+2e6, y+2e6)
+	}
+	x, y, err := WGS84.UPSProjection(true).Project(LatLng{73, 44})
+	if err != nil || !eqish(x, 3320416.75, 2) || !eqish(y, 632668.43, 2) {
+		t.Fatalf("expected '3320416.75, 632668.43', got '%v, %v' (%v)", x, y,
+			err)
+	}
+	ps = WGS84.NewPolarStereographicScale(-71, 1)
+	x, y, _, _ = ps.Forward(false, -75, 120-70)
+	if !eqish(x+6e6, 7255380.79, 2) || !eqish(y+6e6, 7053389.56, 2) {
+		t.Fatalf("expected '7255380.79, 7053389.56', got '%v, %v'", x+6e6, y+6e6)
+	}
+	if _, _, _, k := ps.Forward(false, -71, 10); !eqish(k, 1, 12) {
+		t.Fatalf("expected a scale of 1 on the standard parallel, got %v", k)
+	}
+	if x, y, gamma, k := ps.Forward(false, -90, 30); x != 0 || y != 0 ||
+		gamma != -30 || k != ps.CentralScale() {
+		t.Fatalf("expected the pole at the origin, got %v, %v, %v, %v", x, y,
+			gamma, k)
+	}
+	if x, _, _, _ := ps.Forward(true, 91, 0); !math.IsNaN(x) {
+		t.Fatalf("expected NaN, got %v", x)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		north := i%2 == 0
+		lat := rng.Float64() * 89.9
+		if !north {
+			lat = -lat
+		}
+		lon := rng.Float64()*360 - 180
+		x, y, gamma, k := ps.Forward(north, lat, lon)
+		lat2, lon2, gamma2, k2 := ps.Reverse(north, x, y)
+		if !eqish(lat, lat2, 9) || !eqish(lon, lon2, 9) ||
+			!eqish(gamma, gamma2, 9) || !eqish(k, k2, 9) {
+			t.Fatalf("expected '%v, %v, %v, %v', got '%v, %v, %v, %v'",
+				lat, lon, gamma, k, lat2, lon2, gamma2, k2)
+		}
+		// The scale is the ratio of a short step on the grid to the same
+		// step on the ellipsoid, and grid north is gamma east of true
+		// north.
+		var lat3, lon3 float64
+		WGS84.Direct(lat, lon, 0, 1, &lat3, &lon3, nil)
+		x3, y3, _, _ := ps.Forward(north, lat3, lon3)
+		if ratio := math.Hypot(x3-x, y3-y); !eqish(ratio, k, seriesPrec(5)) {
+			t.Fatalf("expected a scale of %v, got %v", k, ratio)
+		}
+		if azi := math.Atan2(x3-x, y3-y) * (180 / math.Pi); !eqish(
+			math.Remainder(azi+gamma, 360), 0, 4) {
+			t.Fatalf("expected grid north %v from true north, got %v", gamma,
+				-azi)
+		}
+	}
+
+	south := WGS84.UPSProjection(false)
+	if _, _, err := south.Project(LatLng{10, 0}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+	if _, err := south.Unproject(2e6, 2e7); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+	if p, err := south.Unproject(2e6, 2e6); err != nil || p.Lat != -90 {
+		t.Fatalf("expected the south pole, got %v (%v)", p, err)
+	}
+}
@@ -0,0 +1,123 @@
+package geodesic
+
+import "math"
+
+// Pole is a pole of the ellipsoid.
+type Pole int
+
+const (
+	PoleNone  Pole = iota // neither pole
+	PoleNorth             // the north pole
+	PoleSouth             // the south pole
+)
+
+// winding tracks the total longitude change around a polygon, which is
+// ±360 for a ring that goes around the poles and 0 otherwise.
+type winding struct {
+	lon0, lon, sum float64
+	n              int
+}
+
+// point records a point added with Polygon.AddPoint. The edge from the
+// previous point is a shortest geodesic, so it changes the longitude by
+// at most 180 degrees.
+func (w *winding) point(lon float64) {
+	if w.n > 0 {
+		w.sum += math.Remainder(lon-w.lon, 360)
+	} else {
+		w.lon0 = lon
+	}
+	w.lon = lon
+	w.n++
+}
+
+// edge records an edge added with Polygon.AddEdge, given the unrolled
+// longitude at its end.
+func (w *winding) edge(lon float64) {
+	if w.n == 0 {
+		return // AddEdge does nothing without a point
+	}
+	w.sum += lon - w.lon
+	w.lon = lon
+	w.n++
+}
+
+// pole returns the pole on the left of the ring, which is the one inside
+// it when counter-clockwise traversal counts as a positive area.
+func (w *winding) pole() Pole {
+	if w.n < 3 {
+		return PoleNone
+	}
+	switch sum := w.sum + math.Remainder(w.lon0-w.lon, 360); {
+	case sum > 180:
+		return PoleNorth
+	case sum < -180:
+		return PoleSouth
+	}
+	return PoleNone
+}
+
+// EnclosedPole returns the pole that is inside the polygon, or PoleNone if
+// the polygon does not go around the poles. It is always PoleNone for a
+// polyline.
+//
+// Param reverse, if set then clockwise (instead of counter-clockwise)
+// traversal counts as a positive area, which puts the other pole inside.
+//
+// A ring that goes around the poles, such as the boundary of a polar
+// research area, splits the ellipsoid into two parts that each contain a
+// pole. Which one is the polygon depends only on the direction of travel,
+// so a ring that is listed in the "wrong" direction gets the area of the
+// rest of the earth from Polygon.Compute, or with sign set, a large
+// negative area. Use Polygon.PoleArea for the area of the part with the
+// intended pole regardless of the direction.
+func (p *Polygon) EnclosedPole(reverse bool) Pole {
+	if p.polyline() {
+		return PoleNone
+	}
+	pole := p.w.pole()
+	if reverse {
+		switch pole {
+		case PoleNorth:
+			pole = PoleSouth
+		case PoleSouth:
+			pole = PoleNorth
+		}
+	}
+	return pole
+}
+
+// PoleArea returns the area of the part of the ellipsoid inside the ring
+// that contains the pole, whichever direction the ring is listed in
+// (meters-squared).
+//
+// Param pole is the pole that should be inside, PoleNorth or PoleSouth.
+// Returns NaN if the polygon does not go around the poles, is a polyline,
+// or if pole is PoleNone.
+func (p *Polygon) PoleArea(pole Pole) float64 {
+	left := p.EnclosedPole(false)
+	if left == PoleNone || (pole != PoleNorth && pole != PoleSouth) {
+		return math.NaN()
+	}
+	// Without sign, the area is that of the part on the left.
+	var area float64
+	p.Compute(false, false, &area, nil)
+	if pole != left {
+		area = p.e.totalArea() - area
+	}
+	return area
+}
+
+// totalArea returns the total area of the ellipsoid (meters-squared).
+func (e *Ellipsoid) totalArea() float64 {
+	a, f := e.Radius(), e.Flattening()
+	b := a * (1 - f)
+	e2 := f * (2 - f)
+	t := 1.0
+	if e2 > 0 {
+		t = math.Atanh(math.Sqrt(e2)) / math.Sqrt(e2)
+	} else if e2 < 0 {
+		t = math.Atan(math.Sqrt(-e2)) / math.Sqrt(-e2)
+	}
+	return 2 * math.Pi * (a*a + b*b*t)
+}
@@ -0,0 +1,93 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPole(t *testing.T) {
+	// A ring around Antarctica at 60°S, listed eastward, which puts the
+	// north pole on the left.
+	p := WGS84.PolygonInit(false)
+	for lon := -180.0; lon < 180; lon += 10 {
+		p.AddPoint(-60, lon)
+	}
+	if pole := p.EnclosedPole(false); pole != PoleNorth {
+		t.Fatalf("expected PoleNorth, got %v", pole)
+	}
+	if pole := p.EnclosedPole(true); pole != PoleSouth {
+		t.Fatalf("expected PoleSouth, got %v", pole)
+	}
+	if r := p.ComputeResult(true, false); r.Pole != PoleSouth {
+		t.Fatalf("expected PoleSouth, got %v", r.Pole)
+	}
+	south := p.PoleArea(PoleSouth)
+	north := p.PoleArea(PoleNorth)
+	if !eqish(south+north, WGS84.totalArea(), 0) {
+		t.Fatalf("expected the parts to add up to %v, got %v",
+			WGS84.totalArea(), south+north)
+	}
+	// The cap south of 60°S is about 6.7% of the earth.
+	if frac := south / WGS84.totalArea(); !(frac > 0.06 && frac < 0.07) {
+		t.Fatalf("expected about 0.067, got %v", frac)
+	}
+	var area float64
+	p.Compute(true, false, &area, nil)
+	if !eqish(area, south, 0) {
+		t.Fatalf("expected %v, got %v", south, area)
+	}
+
+	// The same ring listed westward gives the same parts.
+	q := WGS84.PolygonInit(false)
+	for lon := 180.0; lon > -180; lon -= 10 {
+		q.AddPoint(-60, lon)
+	}
+	if pole := q.EnclosedPole(false); pole != PoleSouth {
+		t.Fatalf("expected PoleSouth, got %v", pole)
+	}
+	if a := q.PoleArea(PoleSouth); !eqish(a, south, 0) {
+		t.Fatalf("expected %v, got %v", south, a)
+	}
+
+	// A ring built from edges around the north pole.
+	e := WGS84.PolygonInit(false)
+	e.AddPoint(80, 0)
+	for i := 0; i < 8; i++ {
+		var azi float64
+		var s12 float64
+		lon := float64(i+1) * 45
+		WGS84.Inverse(80, lon-45, 80, lon, &s12, &azi, nil)
+		e.AddEdge(azi, s12)
+	}
+	e.RemoveLastEdge() // back to the start through the closing edge
+	if pole := e.EnclosedPole(false); pole != PoleNorth {
+		t.Fatalf("expected PoleNorth, got %v", pole)
+	}
+
+	// Rings that do not go around the poles, and polylines.
+	square := WGS84.PolygonInit(false)
+	for _, pt := range [][2]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}} {
+		square.AddPoint(pt[0], pt[1])
+	}
+	if pole := square.EnclosedPole(false); pole != PoleNone {
+		t.Fatalf("expected PoleNone, got %v", pole)
+	}
+	if a := square.PoleArea(PoleNorth); !math.IsNaN(a) {
+		t.Fatalf("expected NaN, got %v", a)
+	}
+	if a := p.PoleArea(PoleNone); !math.IsNaN(a) {
+		t.Fatalf("expected NaN, got %v", a)
+	}
+	l := WGS84.PolygonInit(true)
+	for lon := -180.0; lon < 180; lon += 10 {
+		l.AddPoint(-60, lon)
+	}
+	if pole := l.EnclosedPole(false); pole != PoleNone {
+		t.Fatalf("expected PoleNone, got %v", pole)
+	}
+
+	p.Clear()
+	if pole := p.EnclosedPole(false); pole != PoleNone {
+		t.Fatalf("expected PoleNone after Clear, got %v", pole)
+	}
+}
@@ -0,0 +1,57 @@
+package geodesic
+
+import "math"
+
+// DistanceToPolygon returns the distance from a point to a ring, which is
+// zero if the point is inside.
+//
+// Param p is the point.
+// Param ring is the vertices of the ring, as for Ellipsoid.RingContains.
+// Returns the distance (meters).
+//
+// The inside is decided by Ellipsoid.RingContains, and outside the
+// distance is the geodesic distance to the nearest point of the boundary,
+// which may be on an edge rather than at a vertex. An empty ring returns
+// NaN.
+func (e *Ellipsoid) DistanceToPolygon(p LatLng, ring []LatLng) float64 {
+	return math.Max(e.SignedDistanceToPolygon(p, ring), 0)
+}
+
+// SignedDistanceToPolygon returns the distance from a point to the
+// boundary of a ring, negative inside and positive outside.
+//
+// Param p is the point.
+// Param ring is the vertices of the ring, as for Ellipsoid.RingContains.
+// Returns the signed distance (meters).
+//
+// This is the measure for a geofence: a point inside that is 50 meters
+// from the boundary is at -50, and the fence can be shrunk or grown by a
+// margin by comparing with it rather than with zero. A ring with fewer
+// than three distinct vertices has no inside, so the distance to its
+// vertices or edge is positive. An empty ring returns NaN.
+func (e *Ellipsoid) SignedDistanceToPolygon(p LatLng, ring []LatLng) float64 {
+	d := e.ringBoundaryDistance(p, ring)
+	if e.RingContains(ring, p) {
+		return -d
+	}
+	return d
+}
+
+// ringBoundaryDistance returns the distance from p to the nearest point of
+// the edges of a ring, or NaN if the ring is empty.
+func (e *Ellipsoid) ringBoundaryDistance(p LatLng, ring []LatLng) float64 {
+	if len(ring) == 0 {
+		return math.NaN()
+	}
+	d := e.distance(p, ring[0])
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		if a == b {
+			continue
+		}
+		if _, ds, _ := e.segmentNearest(p, a, b); ds < d {
+			d = ds
+		}
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceToPolygon(t *testing.T) {
+	// A one degree square on the equator.
+	ring := []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	if d := WGS84.DistanceToPolygon(LatLng{0.5, 0.5}, ring); d != 0 {
+		t.Fatalf("expected 0, got %v", d)
+	}
+	// A degree of latitude is shorter than one of longitude at the
+	// equator, so the equator edge is the nearest.
+	var want float64
+	WGS84.Inverse(0.5, 0.5, 0, 0.5, &want, nil, nil)
+	if d := WGS84.SignedDistanceToPolygon(LatLng{0.5, 0.5}, ring); !eqish(d, -want, 3) {
+		t.Fatalf("expected %v, got %v", -want, d)
+	}
+	// South of the middle of the equator edge, the nearest point is on the
+	// edge and not a vertex.
+	WGS84.Inverse(-0.1, 0.5, 0, 0.5, &want, nil, nil)
+	if d := WGS84.DistanceToPolygon(LatLng{-0.1, 0.5}, ring); !eqish(d, want, 3) {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	// Beyond a corner, the nearest point is the vertex.
+	WGS84.Inverse(-1, -1, 0, 0, &want, nil, nil)
+	if d := WGS84.SignedDistanceToPolygon(LatLng{-1, -1}, ring); !eqish(d, want, 3) {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	// A ring listed the other way has the same inside.
+	rev := []LatLng{{1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	if d := WGS84.SignedDistanceToPolygon(LatLng{0.5, 0.5}, rev); !(d < 0) {
+		t.Fatalf("expected a negative distance, got %v", d)
+	}
+	// Degenerate rings have no inside.
+	WGS84.Inverse(0.5, 0.5, 0, 0, &want, nil, nil)
+	if d := WGS84.SignedDistanceToPolygon(LatLng{0.5, 0.5}, ring[:1]); !eqish(d, want, 3) {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	if d := WGS84.DistanceToPolygon(LatLng{0, 0.5}, ring[:2]); !(d < 1e-3) {
+		t.Fatalf("expected 0, got %v", d)
+	}
+	if d := WGS84.DistanceToPolygon(LatLng{}, nil); !math.IsNaN(d) {
+		t.Fatalf("expected NaN, got %v", d)
+	}
+}
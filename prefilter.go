@@ -0,0 +1,243 @@
+package geodesic
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// ecefBlock holds points as earth-centered coordinates in separate slices,
+// so that the chords from one point to all of them are computed in a
+// single tight loop over contiguous memory. The chord through the
+// ellipsoid is never longer than the geodesic, which makes it a safe
+// prefilter: a point whose chord is beyond a distance is beyond it on the
+// surface too. On a sphere this is the haversine distance in another form.
+type ecefBlock struct {
+	x, y, z []float64
+}
+
+func (e *Ellipsoid) newECEFBlock(pts []LatLng) ecefBlock {
+	b := ecefBlock{
+		x: make([]float64, len(pts)),
+		y: make([]float64, len(pts)),
+		z: make([]float64, len(pts)),
+	}
+	for i, p := range pts {
+		b.x[i], b.y[i], b.z[i] = e.toECEF(p.Lat, p.Lon, 0)
+	}
+	return b
+}
+
+// chord2 sets out[i] to the squared chord from (x, y, z) to point i. On
+// amd64 the chords are computed two at a time with the packed doubles of
+// SSE2, see chord2Block, unless the purego tag is set.
+func (b *ecefBlock) chord2(x, y, z float64, out []float64) {
+	n := len(b.x)
+	chord2Block(x, y, z, b.x[:n], b.y[:n], b.z[:n], out[:n])
+}
+
+// chord2Generic is chord2 in plain Go, for the builds without a vector
+// path and for the points left over by one. The loop is unrolled by four
+// and resliced so that the compiler drops the bounds checks.
+func chord2Generic(x, y, z float64, bx, by, bz, out []float64) {
+	n := len(out)
+	bx, by, bz = bx[:n], by[:n], bz[:n]
+	i := 0
+	for ; i+4 <= n; i += 4 {
+		dx0, dy0, dz0 := bx[i]-x, by[i]-y, bz[i]-z
+		dx1, dy1, dz1 := bx[i+1]-x, by[i+1]-y, bz[i+1]-z
+		dx2, dy2, dz2 := bx[i+2]-x, by[i+2]-y, bz[i+2]-z
+		dx3, dy3, dz3 := bx[i+3]-x, by[i+3]-y, bz[i+3]-z
+		out[i] = dx0*dx0 + dy0*dy0 + dz0*dz0
+		out[i+1] = dx1*dx1 + dy1*dy1 + dz1*dz1
+		out[i+2] = dx2*dx2 + dy2*dy2 + dz2*dz2
+		out[i+3] = dx3*dx3 + dy3*dy3 + dz3*dz3
+	}
+	for ; i < n; i++ {
+		dx, dy, dz := bx[i]-x, by[i]-y, bz[i]-z
+		out[i] = dx*dx + dy*dy + dz*dz
+	}
+}
+
+// chordSlack allows for the rounding of the squared chords, so that the
+// prefilter never drops a point at exactly the threshold.
+const chordSlack = 1 + 1e-12
+
+// chordBound returns the squared chord threshold for a distance.
+func chordBound(dist float64) float64 {
+	if math.IsInf(dist, 1) {
+		return dist
+	}
+	return dist * dist * chordSlack
+}
+
+// Neighbor is a point found by a nearest neighbor search.
+type Neighbor struct {
+	Index    int     // index of the point in the searched set
+	Distance float64 // geodesic distance to the point (meters)
+}
+
+func compareNeighbors(a, b Neighbor) int {
+	if c := cmp.Compare(a.Distance, b.Distance); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Index, b.Index)
+}
+
+// PointSet is a set of points prepared for repeated nearest neighbor and
+// radius searches. It is safe for concurrent use.
+//
+// Every search first computes the chord to all of the points in bulk and
+// discards those that cannot qualify, and only then solves the inverse
+// problem for the rest, so a search of a large set costs little more than
+// the inverse solutions of the few points near the answer. The results
+// are exact: they are the same as solving every point.
+type PointSet struct {
+	e   *Ellipsoid
+	pts []LatLng
+	b   ecefBlock
+}
+
+// NewPointSet prepares a set of points for searching. The slice is
+// retained and must not be changed while the set is in use.
+func (e *Ellipsoid) NewPointSet(pts []LatLng) *PointSet {
+	return &PointSet{e: e, pts: pts, b: e.newECEFBlock(pts)}
+}
+
+// KNearest returns the k points nearest to p, nearest first.
+//
+// Param p is the point to search from.
+// Param k is the number of points to return.
+// Returns up to k neighbors, fewer if the set is smaller. Ties are broken
+// by the lower index.
+func (s *PointSet) KNearest(p LatLng, k int) []Neighbor {
+	n := len(s.pts)
+	k = min(k, n)
+	if k <= 0 {
+		return nil
+	}
+	x, y, z := s.e.toECEF(p.Lat, p.Lon, 0)
+	c2 := make([]float64, n)
+	s.b.chord2(x, y, z, c2)
+	byChord := func(i, j int) int {
+		if c := cmp.Compare(c2[i], c2[j]); c != 0 {
+			return c
+		}
+		return cmp.Compare(i, j)
+	}
+
+	// The k nearest by chord give an upper bound on the distance of the
+	// k-th neighbor, and everything with a longer chord is out.
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	selectFunc(idx, k-1, byChord)
+	best := make([]Neighbor, 0, k+1)
+	for _, i := range idx[:k] {
+		best = append(best, Neighbor{i, s.e.distance(p, s.pts[i])})
+	}
+	slices.SortFunc(best, compareNeighbors)
+	limit := best[k-1].Distance
+	rest := idx[k:]
+	var j int
+	for _, i := range rest {
+		if c2[i] <= chordBound(limit) {
+			rest[j] = i
+			j++
+		}
+	}
+	rest = rest[:j]
+
+	// Refine the survivors in order of chord, stopping once the chord is
+	// beyond the current k-th neighbor.
+	slices.SortFunc(rest, byChord)
+	for _, i := range rest {
+		if c2[i] > chordBound(limit) {
+			break
+		}
+		nb := Neighbor{i, s.e.distance(p, s.pts[i])}
+		if compareNeighbors(nb, best[k-1]) >= 0 {
+			continue
+		}
+		at, _ := slices.BinarySearchFunc(best, nb, compareNeighbors)
+		best = slices.Insert(best, at, nb)[:k]
+		limit = best[k-1].Distance
+	}
+	return best
+}
+
+// Within returns the points within a distance of p, nearest first.
+//
+// Param p is the point to search from.
+// Param radius is the largest distance (meters).
+// Returns the neighbors. Ties are broken by the lower index.
+func (s *PointSet) Within(p LatLng, radius float64) []Neighbor {
+	if !(radius >= 0) {
+		return nil
+	}
+	x, y, z := s.e.toECEF(p.Lat, p.Lon, 0)
+	c2 := make([]float64, len(s.pts))
+	s.b.chord2(x, y, z, c2)
+	var out []Neighbor
+	for i, c := range c2 {
+		if c > chordBound(radius) {
+			continue
+		}
+		if d := s.e.distance(p, s.pts[i]); d <= radius {
+			out = append(out, Neighbor{i, d})
+		}
+	}
+	slices.SortFunc(out, compareNeighbors)
+	return out
+}
+
+// KNearest returns the k points of pts nearest to p, nearest first. See
+// PointSet.KNearest, which avoids preparing the points for every search.
+func (e *Ellipsoid) KNearest(p LatLng, pts []LatLng, k int) []Neighbor {
+	return e.NewPointSet(pts).KNearest(p, k)
+}
+
+// selectFunc reorders s so that s[k] is the element that would be there
+// if s were sorted, with no greater element before it and no lesser one
+// after it (Hoare's quickselect).
+func selectFunc[T any](s []T, k int, cmp func(a, b T) int) {
+	lo, hi := 0, len(s)-1
+	for lo < hi {
+		// The median of three as the pivot avoids the quadratic case on
+		// sorted input.
+		mid := lo + (hi-lo)/2
+		if cmp(s[mid], s[lo]) < 0 {
+			s[mid], s[lo] = s[lo], s[mid]
+		}
+		if cmp(s[hi], s[lo]) < 0 {
+			s[hi], s[lo] = s[lo], s[hi]
+		}
+		if cmp(s[hi], s[mid]) < 0 {
+			s[hi], s[mid] = s[mid], s[hi]
+		}
+		pivot := s[mid]
+		i, j := lo, hi
+		for i <= j {
+			for cmp(s[i], pivot) < 0 {
+				i++
+			}
+			for cmp(s[j], pivot) > 0 {
+				j--
+			}
+			if i <= j {
+				s[i], s[j] = s[j], s[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
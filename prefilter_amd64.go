@@ -0,0 +1,25 @@
+//go:build amd64 && !purego
+
+package geodesic
+
+// chord2Block computes the squared chords of chord2, the pairs of points
+// with chord2SSE2 and an odd point left over with chord2Generic. SSE2 is
+// part of every amd64 processor, so there is no need to detect it.
+func chord2Block(x, y, z float64, bx, by, bz, out []float64) {
+	n := len(out)
+	pairs := n &^ 1
+	if pairs > 0 {
+		chord2SSE2(x, y, z, bx[:pairs], by[:pairs], bz[:pairs], out[:pairs])
+	}
+	if pairs < n {
+		chord2Generic(x, y, z, bx[pairs:n], by[pairs:n], bz[pairs:n],
+			out[pairs:n])
+	}
+}
+
+// chord2SSE2 sets out[i] to the squared chord from (x, y, z) to
+// (bx[i], by[i], bz[i]), two points at a time. The slices must all have
+// the same even length. It is in prefilter_amd64.s.
+//
+//go:noescape
+func chord2SSE2(x, y, z float64, bx, by, bz, out []float64)
@@ -0,0 +1,44 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func chord2SSE2(x, y, z float64, bx, by, bz, out []float64)
+//
+// The differences, squares, and sums are the same operations in the same
+// order as chord2Generic, so the results are the same to the bit on a
+// build that does not fuse them into FMAs.
+TEXT ·chord2SSE2(SB), NOSPLIT, $0-120
+	MOVSD    x+0(FP), X0
+	UNPCKLPD X0, X0
+	MOVSD    y+8(FP), X1
+	UNPCKLPD X1, X1
+	MOVSD    z+16(FP), X2
+	UNPCKLPD X2, X2
+	MOVQ     bx_base+24(FP), SI
+	MOVQ     by_base+48(FP), DI
+	MOVQ     bz_base+72(FP), R8
+	MOVQ     out_base+96(FP), R9
+	MOVQ     out_len+104(FP), CX
+	SHRQ     $1, CX
+	JZ       done
+	XORQ     AX, AX
+
+loop:
+	MOVUPD (SI)(AX*1), X3
+	SUBPD  X0, X3
+	MULPD  X3, X3
+	MOVUPD (DI)(AX*1), X4
+	SUBPD  X1, X4
+	MULPD  X4, X4
+	ADDPD  X4, X3
+	MOVUPD (R8)(AX*1), X5
+	SUBPD  X2, X5
+	MULPD  X5, X5
+	ADDPD  X5, X3
+	MOVUPD X3, (R9)(AX*1)
+	ADDQ   $16, AX
+	DECQ   CX
+	JNZ    loop
+
+done:
+	RET
@@ -0,0 +1,8 @@
+//go:build !amd64 || purego
+
+package geodesic
+
+// chord2Block computes the squared chords of chord2 in plain Go.
+func chord2Block(x, y, z float64, bx, by, bz, out []float64) {
+	chord2Generic(x, y, z, bx, by, bz, out)
+}
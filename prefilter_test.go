@@ -0,0 +1,156 @@
+package geodesic
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// bruteNearest returns all points of pts by distance from p.
+func bruteNearest(p LatLng, pts []LatLng) []Neighbor {
+	out := make([]Neighbor, len(pts))
+	for i, q := range pts {
+		out[i] = Neighbor{i, WGS84.distance(p, q)}
+	}
+	slices.SortFunc(out, compareNeighbors)
+	return out
+}
+
+func TestKNearest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pts := randPoints(rng, 500)
+	// Duplicates tie and are ordered by index.
+	pts = append(pts, pts[:10]...)
+	set := WGS84.NewPointSet(pts)
+	for i := 0; i < 50; i++ {
+		p := randPoints(rng, 1)[0]
+		all := bruteNearest(p, pts)
+		for _, k := range []int{1, 5, 37} {
+			if got := set.KNearest(p, k); !slices.Equal(got, all[:k]) {
+				t.Fatalf("k=%d: expected %v, got %v", k, all[:k], got)
+			}
+		}
+		if got := set.KNearest(pts[3], 2); got[0].Index != 3 || got[1].Index != 503 {
+			t.Fatalf("expected the point and its duplicate, got %v", got)
+		}
+		radius := all[20].Distance
+		n := 21
+		for n < len(all) && all[n].Distance <= radius {
+			n++
+		}
+		if got := set.Within(p, radius); !slices.Equal(got, all[:n]) {
+			t.Fatalf("expected %v, got %v", all[:n], got)
+		}
+	}
+	if got := WGS84.KNearest(LatLng{}, pts[:3], 10); len(got) != 3 {
+		t.Fatalf("expected 3, got %v", got)
+	}
+	if got := WGS84.KNearest(LatLng{}, nil, 1); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+}
+
+func TestSelectFunc(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for n := 1; n < 50; n++ {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = rng.Intn(10)
+		}
+		sorted := slices.Sorted(slices.Values(s))
+		for k := 0; k < n; k++ {
+			c := slices.Clone(s)
+			selectFunc(c, k, func(a, b int) int { return a - b })
+			if c[k] != sorted[k] {
+				t.Fatalf("%v[%d]: expected %d, got %d", s, k, sorted[k], c[k])
+			}
+			for i := range c {
+				if (i < k && c[i] > c[k]) || (i > k && c[i] < c[k]) {
+					t.Fatalf("%v: not partitioned at %d: %v", s, k, c)
+				}
+			}
+		}
+	}
+}
+
+func TestDistanceMatrixMaxDistance(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	from, to := randPoints(rng, 30), randPoints(rng, 40)
+	const max = 5e6
+	var last int
+	m, err := WGS84.DistanceMatrix(context.Background(), from, to,
+		&BatchOptions{MaxDistance: max, Progress: func(done, total int) {
+			last = done
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last != len(from)*len(to) {
+		t.Fatalf("expected progress to %d, got %d", len(from)*len(to), last)
+	}
+	for i := range from {
+		for j := range to {
+			d := WGS84.distance(from[i], to[j])
+			if d > max {
+				d = math.Inf(1)
+			}
+			if m[i][j] != d {
+				t.Fatalf("[%d][%d]: expected %f, got %f", i, j, d, m[i][j])
+			}
+		}
+	}
+}
+
+func TestChord2(t *testing.T) {
+	// The vector path agrees with the plain Go loop for every length, odd
+	// ones included.
+	rng := rand.New(rand.NewSource(1))
+	pts := randPoints(rng, 41)
+	x, y, z := WGS84.toECEF(10, 20, 0)
+	for n := 0; n <= len(pts); n++ {
+		b := WGS84.newECEFBlock(pts[:n])
+		got, want := make([]float64, n), make([]float64, n)
+		b.chord2(x, y, z, got)
+		chord2Generic(x, y, z, b.x, b.y, b.z, want)
+		for i := range want {
+			if math.Abs(got[i]-want[i]) > want[i]*1e-15 {
+				t.Fatalf("%d of %d: expected %v, got %v", i, n, want[i], got[i])
+			}
+		}
+	}
+}
+
+func BenchmarkChord2(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	blk := WGS84.newECEFBlock(randPoints(rng, 10000))
+	x, y, z := WGS84.toECEF(10, 20, 0)
+	out := make([]float64, 10000)
+	b.Run("block", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			blk.chord2(x, y, z, out)
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			chord2Generic(x, y, z, blk.x, blk.y, blk.z, out)
+		}
+	})
+}
+
+func BenchmarkKNearest(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	set := WGS84.NewPointSet(randPoints(rng, 10000))
+	p := randPoints(rng, 1)[0]
+	b.Run("prefilter", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			set.KNearest(p, 10)
+		}
+	})
+	b.Run("brute", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bruteNearest(p, set.pts)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package geodesic
+
+import (
+	"fmt"
+	"math"
+)
+
+// Projection converts between points and the planar coordinates of a map
+// projection, such as a UTM zone or Web Mercator.
+//
+// Lengths and areas measured on projected coordinates are distorted by the
+// scale of the projection, which is 0.9996 to 1.001 across a UTM zone, and
+// grows without bound towards the poles in Web Mercator, where a
+// kilometer on the map at 60° is half a kilometer on the ground.
+// Ellipsoid.ProjectedLength and Ellipsoid.ProjectedArea unproject the
+// coordinates, and measure the geodesics between them instead.
+type Projection interface {
+	// Project returns the easting x and northing y of a point (meters), or
+	// an error wrapping ErrOutOfRange if the point is outside the range of
+	// the projection.
+	Project(p LatLng) (x, y float64, err error)
+	// Unproject returns the point at an easting and northing (meters), or
+	// an error wrapping ErrOutOfRange if they are outside the range of the
+	// projection.
+	Unproject(x, y float64) (LatLng, error)
+}
+
+// UTMProjection returns the projection of a UTM zone on the ellipsoid, in
+// one hemisphere.
+//
+// Param zone is the zone, in [1,60].
+// Param north is whether the northings are those of the northern
+// hemisphere, rather than the southern one with its false northing of
+// 10000 km.
+//
+// The eastings include the false easting of 500 km. Points in the other
+// hemisphere are projected to the same grid, as data sets that straddle
+// the equator are, so their northings are negative, or beyond 10000 km.
+// See Ellipsoid.ToUTMZone for the range of the zone.
+func (e *Ellipsoid) UTMProjection(zone int, north bool) Projection {
+	return utmProjection{e: e, zone: zone, north: north}
+}
+
+type utmProjection struct {
+	e     *Ellipsoid
+	zone  int
+	north bool
+}
+
+func (u utmProjection) Project(p LatLng) (x, y float64, err error) {
+	g, err := u.e.ToUTMZone(p, u.zone)
+	if err != nil {
+		return 0, 0, err
+	}
+	y = g.Northing
+	if g.North && !u.north {
+		y += utmFalseNorthing
+	} else if !g.North && u.north {
+		y -= utmFalseNorthing
+	}
+	return g.Easting, y, nil
+}
+
+func (u utmProjection) Unproject(x, y float64) (LatLng, error) {
+	return u.e.FromUTM(UTM{Zone: u.zone, North: u.north, Easting: x,
+		Northing: y})
+}
+
+// WebMercator is the Web Mercator projection of slippy map tiles, web
+// maps, and EPSG:3857.
+//
+// It is the spherical Mercator projection on a sphere with the radius of
+// WGS84, applied to latitudes and longitudes on WGS84, so it is the same
+// whatever ellipsoid the coordinates are measured on. The eastings span
+// ±20037508.34 m, and the latitudes of the square world map of the tiles
+// are ±85.0511, though any latitude short of the poles can be projected.
+var WebMercator Projection = webMercator{}
+
+// webMercatorRadius is the radius of the sphere of Web Mercator (meters).
+const webMercatorRadius = 6378137
+
+type webMercator struct{}
+
+func (webMercator) Project(p LatLng) (x, y float64, err error) {
+	if !(math.Abs(p.Lat) < 90) || math.IsNaN(p.Lon) || math.IsInf(p.Lon, 0) {
+		return 0, 0, fmt.Errorf("%w: %v is outside Web Mercator",
+			ErrOutOfRange, p)
+	}
+	slat := math.Sin(p.Lat * (math.Pi / 180))
+	x = normLon(p.Lon) * (math.Pi / 180) * webMercatorRadius
+	y = math.Atanh(slat) * webMercatorRadius
+	return x, y, nil
+}
+
+func (webMercator) Unproject(x, y float64) (LatLng, error) {
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+		return LatLng{}, fmt.Errorf("%w: easting %v, northing %v",
+			ErrOutOfRange, x, y)
+	}
+	return LatLng{
+		Lat: math.Atan(math.Sinh(y/webMercatorRadius)) * (180 / math.Pi),
+		Lon: normLon(x / webMercatorRadius * (180 / math.Pi)),
+	}, nil
+}
+
+// meridianProjection is a projection about a central meridian, with its
+// origin at the equator, such as TransverseMercator, LambertConformalConic,
+// and AlbersEqualArea.
+type meridianProjection interface {
+	Forward(lon0, lat, lon float64) (x, y, gamma, k float64)
+	Reverse(lon0, x, y float64) (lat, lon, gamma, k float64)
+}
+
+// gridProjection is a meridianProjection with the origin of a grid, at the
+// latitude lat0 on the central meridian lon0, and a false easting and
+// northing there.
+type gridProjection struct {
+	p      meridianProjection
+	lon0   float64
+	x0, y0 float64 // added to the coordinates of the projection (meters)
+}
+
+func newGridProjection(p meridianProjection, lat0, lon0, fe, fn float64,
+) gridProjection {
+	_, y, _, _ := p.Forward(lon0, lat0, lon0)
+	return gridProjection{p: p, lon0: lon0, x0: fe, y0: fn - y}
+}
+
+func (g gridProjection) Project(p LatLng) (x, y float64, err error) {
+	x, y, _, _ = g.p.Forward(g.lon0, p.Lat, p.Lon)
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+		return 0, 0, fmt.Errorf("%w: %v is outside the projection",
+			ErrOutOfRange, p)
+	}
+	return x + g.x0, y + g.y0, nil
+}
+
+func (g gridProjection) Unproject(x, y float64) (LatLng, error) {
+	var p LatLng
+	p.Lat, p.Lon, _, _ = g.p.Reverse(g.lon0, x-g.x0, y-g.y0)
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lon) {
+		return LatLng{}, fmt.Errorf("%w: easting %v, northing %v",
+			ErrOutOfRange, x, y)
+	}
+	return p, nil
+}
+
+// Unproject returns the points at projected coordinates.
+//
+// Param proj is the projection.
+// Param xy is the eastings and northings (meters).
+// Returns the points, or the first error from proj.
+func Unproject(proj Projection, xy [][2]float64) ([]LatLng, error) {
+	pts := make([]LatLng, len(xy))
+	for i, c := range xy {
+		p, err := proj.Unproject(c[0], c[1])
+		if err != nil {
+			return nil, err
+		}
+		pts[i] = p
+	}
+	return pts, nil
+}
+
+// ProjectedLength returns the geodesic length of a polyline whose vertices
+// are given in projected coordinates (meters).
+//
+// Param proj is the projection of the coordinates.
+// Param xy is the eastings and northings of the vertices (meters).
+// Returns the length, or the first error from proj.
+//
+// The vertices are unprojected and joined by geodesics, as by
+// PolylineLength. For long edges the geodesic differs from the straight
+// line on the map, which is what the edge stands for in some data sets;
+// densify those in the projection first if it matters.
+func (e *Ellipsoid) ProjectedLength(proj Projection, xy [][2]float64,
+) (float64, error) {
+	pts, err := Unproject(proj, xy)
+	if err != nil {
+		return 0, err
+	}
+	return PolylineLength(e, pts), nil
+}
+
+// ProjectedArea returns the geodesic area and perimeter of a polygon ring
+// whose vertices are given in projected coordinates.
+//
+// Param proj is the projection of the coordinates.
+// Param ring is the eastings and northings of the vertices (meters).
+// Returns the area (meters-squared) and perimeter (meters), as by
+// PolygonArea, or the first error from proj.
+//
+// See Ellipsoid.ProjectedLength for how the edges are taken. The sign of
+// the area follows the convention of the ellipsoid, see
+// Ellipsoid.WithAreas; projections keep the orientation of rings.
+func (e *Ellipsoid) ProjectedArea(proj Projection, ring [][2]float64,
+) (area, perimeter float64, err error) {
+	pts, err := Unproject(proj, ring)
+	if err != nil {
+		return 0, 0, err
+	}
+	area, perimeter = PolygonArea(e, pts)
+	return area, perimeter, nil
+}
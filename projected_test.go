@@ -0,0 +1,91 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWebMercator(t *testing.T) {
+	x, y, err := WebMercator.Project(LatLng{85.0511287798066, -180})
+	if err != nil || !eqish(x, -20037508.342789244, 6) ||
+		!eqish(y, 20037508.342789244, 6) {
+		t.Fatalf("expected the corner of the world map, got %v %v %v", x, y,
+			err)
+	}
+	p, err := WebMercator.Unproject(-1e6, 4e6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, y, _ := WebMercator.Project(p); !eqish(x, -1e6, 6) || !eqish(y, 4e6, 6) {
+		t.Fatalf("expected -1e6 4e6, got %v %v", x, y)
+	}
+	if _, _, err := WebMercator.Project(LatLng{90, 0}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+	if _, err := WebMercator.Unproject(math.NaN(), 0); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestUTMProjection(t *testing.T) {
+	proj := WGS84.UTMProjection(33, true)
+	pts := []LatLng{{0.5, 14}, {-0.5, 15}, {52.5, 13.4}}
+	for _, p := range pts {
+		x, y, err := proj.Project(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Lat < 0 && y >= 0 {
+			t.Fatalf("%v: expected a negative northing, got %v", p, y)
+		}
+		q, err := proj.Unproject(x, y)
+		if err != nil || WGS84.distance(p, q) > 1e-6 {
+			t.Fatalf("%v: expected the point back, got %v %v", p, q, err)
+		}
+	}
+	if _, _, err := WGS84.UTMProjection(61, true).Project(pts[0]); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestProjectedMeasures(t *testing.T) {
+	// A square with 100 km sides on the map in UTM zone 33N, near the edge
+	// of the zone where the scale is about 1.0004, and a square of 1000 km
+	// in Web Mercator at 60° north, where the scale is 2.
+	for _, c := range []struct {
+		name      string
+		proj      Projection
+		x0, y0, d float64
+		scaleLo   float64
+		scaleHi   float64
+	}{
+		{"UTM", WGS84.UTMProjection(33, true), 700e3, 5e6, 100e3, 0.999, 1.001},
+		{"WebMercator", WebMercator, 0, 8.4e6, 1e6, 1.7, 2.3},
+	} {
+		ring := [][2]float64{{c.x0, c.y0}, {c.x0 + c.d, c.y0},
+			{c.x0 + c.d, c.y0 + c.d}, {c.x0, c.y0 + c.d}}
+		pts, err := Unproject(c.proj, ring)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := WGS84.ProjectedLength(c.proj, ring)
+		if err != nil || s != PolylineLength(WGS84, pts) {
+			t.Fatalf("%s: expected %v, got %v %v", c.name,
+				PolylineLength(WGS84, pts), s, err)
+		}
+		if scale := 3 * c.d / s; scale < c.scaleLo || scale > c.scaleHi {
+			t.Fatalf("%s: expected a scale in [%v,%v], got %v", c.name,
+				c.scaleLo, c.scaleHi, scale)
+		}
+		area, perimeter, err := WGS84.ProjectedArea(c.proj, ring)
+		wa, wp := PolygonArea(WGS84, pts)
+		if err != nil || area != wa || perimeter != wp || !(area > 0) {
+			t.Fatalf("%s: expected %v %v, got %v %v %v", c.name, wa, wp, area,
+				perimeter, err)
+		}
+	}
+	if _, err := WGS84.ProjectedLength(WebMercator, [][2]float64{{0, math.Inf(1)}}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package geodesic
+
+import "math"
+
+const (
+	degToRad = math.Pi / 180
+	radToDeg = 180 / math.Pi
+)
+
+// scaleOut multiplies the value pointed to by x by k, ignoring nil.
+func scaleOut(x *float64, k float64) {
+	if x != nil {
+		*x *= k
+	}
+}
+
+// InverseRad solves the inverse geodesic problem using radians.
+//
+// This is the same as Ellipsoid.Inverse except that lat1, lon1, lat2,
+// lon2, and the returned azi1 and azi2 are in radians. The values of azi1
+// and azi2 returned are in the range [-π,+π], or [0,2π) if the ellipsoid
+// uses AzimuthUnsigned. Distances are still in meters.
+func (e *Ellipsoid) InverseRad(
+	lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2 *float64,
+) {
+	e.Inverse(lat1*radToDeg, lon1*radToDeg, lat2*radToDeg,
+		lon2*radToDeg, s12, azi1, azi2)
+	scaleOut(azi1, degToRad)
+	scaleOut(azi2, degToRad)
+}
+
+// DirectRad solves the direct geodesic problem using radians.
+//
+// This is the same as Ellipsoid.Direct except that lat1, lon1, azi1, and
+// the returned lat2, lon2, and azi2 are in radians. The values of lon2 and
+// azi2 returned are in the range [-π,+π], or [0,2π) for azi2 if the
+// ellipsoid uses AzimuthUnsigned. Distances are still in meters.
+func (e *Ellipsoid) DirectRad(
+	lat1, lon1, azi1, s12 float64,
+	lat2, lon2, azi2 *float64,
+) {
+	e.Direct(lat1*radToDeg, lon1*radToDeg, azi1*radToDeg, s12,
+		lat2, lon2, azi2)
+	scaleOut(lat2, degToRad)
+	scaleOut(lon2, degToRad)
+	scaleOut(azi2, degToRad)
+}
@@ -0,0 +1,34 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRadians(t *testing.T) {
+	const d2r = math.Pi / 180
+	var s12, azi1, azi2, s12r, azi1r, azi2r float64
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &s12, &azi1, &azi2)
+	WGS84.InverseRad(40.64*d2r, -73.78*d2r, 1.36*d2r, 103.99*d2r,
+		&s12r, &azi1r, &azi2r)
+	if !eqish(s12r, s12, 6) || !eqish(azi1r, azi1*d2r, 12) ||
+		!eqish(azi2r, azi2*d2r, 12) {
+		t.Fatalf("expected '%f, %f, %f', got '%f, %f, %f'",
+			s12, azi1*d2r, azi2*d2r, s12r, azi1r, azi2r)
+	}
+	var lat2, lon2 float64
+	WGS84.DirectRad(40.64*d2r, -73.78*d2r, azi1r, s12r, &lat2, &lon2, nil)
+	if !eqish(lat2, 1.36*d2r, 12) || !eqish(lon2, 103.99*d2r, 12) {
+		t.Fatalf("expected '%f, %f', got '%f, %f'",
+			1.36*d2r, 103.99*d2r, lat2, lon2)
+	}
+	// Due east along the equator, the longitude is the distance over the
+	// equatorial radius, and the azimuth does not change.
+	var azi2e float64
+	WGS84.DirectRad(0, 0, math.Pi/2, 1000, &lat2, &lon2, &azi2e)
+	if lat2 != 0 || !eqish(lon2, 1000/WGS84.Radius(), 12) ||
+		!eqish(azi2e, math.Pi/2, 12) {
+		t.Fatalf("expected '0, %g, %f', got '%g, %g, %f'",
+			1000/WGS84.Radius(), math.Pi/2, lat2, lon2, azi2e)
+	}
+}
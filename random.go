@@ -0,0 +1,108 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+)
+
+// RandomInCircle returns a random point within a geodesic circle.
+//
+// Param center is the center of the circle.
+// Param radius is the radius of the circle (meters).
+// Param rng is the source of randomness.
+// Returns a point at most radius from the center.
+//
+// The points are uniformly distributed by area on the ellipsoid, so a
+// region of the circle that is twice the area of another is twice as
+// likely to get a point. Picking the distance and azimuth uniformly
+// instead would crowd the points around the center, and picking the
+// latitude and longitude uniformly would crowd them toward the poles.
+// A radius of zero or less returns the center.
+func (e *Ellipsoid) RandomInCircle(center LatLng, radius float64, rng *rand.Rand) LatLng {
+	if !(radius > 0) {
+		return center
+	}
+	b := e.ExpandBounds(Bounds{center.Lat, center.Lon, center.Lat, center.Lon},
+		radius)
+	for {
+		p := e.randomInBounds(b, rng)
+		if e.distance(center, p) <= radius {
+			return p
+		}
+	}
+}
+
+// RandomInPolygon returns a random point inside a ring.
+//
+// Param ring is the vertices of the ring, as for Ellipsoid.RingContains.
+// Param rng is the source of randomness.
+// Returns a point inside the ring.
+//
+// The points are uniformly distributed by area on the ellipsoid, see
+// Ellipsoid.RandomInCircle. Points are drawn from a bounding rectangle of
+// the ring and rejected until one is inside, so a ring that fills little
+// of its rectangle, such as a thin diagonal strip, takes longer. A ring
+// with fewer than three distinct vertices has no inside and returns its
+// first vertex, or the zero LatLng if it is empty.
+func (e *Ellipsoid) RandomInPolygon(ring []LatLng, rng *rand.Rand) LatLng {
+	r := e.newRingRegion(ring)
+	if len(r.edges) == 0 {
+		if len(ring) == 0 {
+			return LatLng{}
+		}
+		return ring[0]
+	}
+	b := r.bounds()
+	for {
+		p := e.randomInBounds(b, rng)
+		if r.contains(p) {
+			return p
+		}
+	}
+}
+
+// randomInBounds returns a point uniformly distributed by area in a
+// latitude/longitude rectangle.
+func (e *Ellipsoid) randomInBounds(b Bounds, rng *rand.Rand) LatLng {
+	lon := normLon(b.MinLon + rng.Float64()*b.lonSpan())
+	z0, z1 := e.zoneArea(b.MinLat), e.zoneArea(b.MaxLat)
+	return LatLng{Lat: e.zoneLat(z0+rng.Float64()*(z1-z0), b.MinLat, b.MaxLat),
+		Lon: lon}
+}
+
+// zoneLat is the inverse of zoneArea, returning the latitude in [lo,hi]
+// (degrees) where the zone area is z.
+func (e *Ellipsoid) zoneLat(z, lo, hi float64) float64 {
+	a, f := e.Radius(), e.Flattening()
+	e2 := f * (2 - f)
+	// Start from the latitude on a sphere of the same area and polish with
+	// Newton's method, falling back to bisection near the poles where the
+	// zone area is flat.
+	s := z / e.zoneArea(90)
+	lat := math.Asin(math.Max(-1, math.Min(1, s))) * (180 / math.Pi)
+	lat = math.Max(lo, math.Min(hi, lat))
+	for i := 0; i < 64 && hi-lo > 1e-12; i++ {
+		dz := e.zoneArea(lat) - z
+		if dz == 0 {
+			break
+		}
+		if dz > 0 {
+			hi = lat
+		} else {
+			lo = lat
+		}
+		sphi, cphi := sincosd(lat)
+		w := 1 - e2*sphi*sphi
+		slope := a * a * (1 - e2) * cphi / (w * w) * (math.Pi / 180)
+		next := lat - dz/slope
+		if !(next > lo && next < hi) {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-lat) < 1e-13 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+	return lat
+}
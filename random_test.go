@@ -0,0 +1,78 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRandomInCircle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, center := range []LatLng{{0, 0}, {60, 179}, {89.5, 0}} {
+		const radius = 1e6
+		// A uniform distribution puts points within the inner circle in
+		// proportion to its area.
+		inner, _ := PolygonArea(WGS84, WGS84.Circle(center, radius/2, 360))
+		outer, _ := PolygonArea(WGS84, WGS84.Circle(center, radius, 360))
+		const n = 5000
+		var k int
+		for i := 0; i < n; i++ {
+			p := WGS84.RandomInCircle(center, radius, rng)
+			d := WGS84.distance(center, p)
+			if d > radius {
+				t.Fatalf("expected within %v, got %v", radius, d)
+			}
+			if d <= radius/2 {
+				k++
+			}
+		}
+		if frac := float64(k) / n; math.Abs(frac-inner/outer) > 0.025 {
+			t.Fatalf("%v: expected %v inside, got %v", center, inner/outer, frac)
+		}
+	}
+	p := WGS84.RandomInCircle(LatLng{1, 2}, 0, rng)
+	if p != (LatLng{1, 2}) {
+		t.Fatalf("expected the center, got %v", p)
+	}
+}
+
+func TestRandomInPolygon(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	// A band from 0 to 60 degrees north, densified so that the edges
+	// follow the parallels closely. Uniform by area puts far fewer points
+	// north of 30 than uniform by latitude would.
+	var ring []LatLng
+	for lon := 0.0; lon <= 40; lon += 2 {
+		ring = append(ring, LatLng{0, lon})
+	}
+	for lon := 40.0; lon >= 0; lon -= 2 {
+		ring = append(ring, LatLng{60, lon})
+	}
+	want := WGS84.RectArea(30, 0, 60, 40) / WGS84.RectArea(0, 0, 60, 40)
+	const n = 5000
+	var k int
+	for i := 0; i < n; i++ {
+		p := WGS84.RandomInPolygon(ring, rng)
+		if !WGS84.RingContains(ring, p) {
+			t.Fatalf("expected inside, got %v", p)
+		}
+		if p.Lat > 30 {
+			k++
+		}
+	}
+	if frac := float64(k) / n; math.Abs(frac-want) > 0.025 {
+		t.Fatalf("expected %v north of 30, got %v", want, frac)
+	}
+
+	// Around the south pole.
+	polar := WGS84.Circle(LatLng{-90, 0}, 2e6, 64)
+	for i := 0; i < 100; i++ {
+		if p := WGS84.RandomInPolygon(polar, rng); p.Lat > -70 {
+			t.Fatalf("expected near the south pole, got %v", p)
+		}
+	}
+
+	if p := WGS84.RandomInPolygon(ring[:1], rng); p != ring[0] {
+		t.Fatalf("expected %v, got %v", ring[0], p)
+	}
+}
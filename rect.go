@@ -0,0 +1,87 @@
+package geodesic
+
+import "math"
+
+// zoneArea returns the area between the equator and the parallel at lat
+// (degrees) for a one radian span of longitude (meters-squared). The result
+// is negative for southern latitudes.
+func (e *Ellipsoid) zoneArea(lat float64) float64 {
+	a := e.Radius()
+	f := e.Flattening()
+	e2 := f * (2 - f)
+	sphi := math.Sin(lat * (math.Pi / 180))
+	if math.Abs(lat) == 90 {
+		sphi = math.Copysign(1, lat)
+	}
+	var t float64
+	switch {
+	case e2 > 0:
+		es := math.Sqrt(e2)
+		t = math.Atanh(es*sphi) / es
+	case e2 < 0:
+		es := math.Sqrt(-e2)
+		t = math.Atan(es*sphi) / es
+	default:
+		t = sphi
+	}
+	q := (1 - e2) * (sphi/(1-e2*sphi*sphi) + t)
+	return a * a / 2 * q
+}
+
+// RectArea returns the area of a latitude/longitude rectangle.
+//
+// Param minLat is the southern latitude (degrees).
+// Param minLon is the western longitude (degrees).
+// Param maxLat is the northern latitude (degrees).
+// Param maxLon is the eastern longitude (degrees).
+// Returns the area of the rectangle (meters-squared).
+//
+// The rectangle is bounded by the meridians at minLon and maxLon, which are
+// geodesics, and by the parallels at minLat and maxLat, which are not. The
+// area is computed exactly from the ellipsoid rather than by approximating
+// the parallels with geodesic edges.
+//
+// The rectangle runs east from minLon to maxLon, so if minLon is greater
+// than maxLon then the rectangle crosses the antimeridian. A longitude span
+// of 360 degrees or more covers the full band of latitudes.
+func (e *Ellipsoid) RectArea(minLat, minLon, maxLat, maxLon float64) float64 {
+	dlon := maxLon - minLon
+	if dlon < 0 {
+		dlon += 360
+	}
+	if dlon > 360 {
+		dlon = 360
+	}
+	area := (e.zoneArea(maxLat) - e.zoneArea(minLat)) * dlon * (math.Pi / 180)
+	return math.Abs(area)
+}
+
+// PixelAreas returns the area of a cell in each row of a regular
+// latitude/longitude raster.
+//
+// Param minLat is the southern edge of the raster (degrees).
+// Param maxLat is the northern edge of the raster (degrees).
+// Param resolution is the height and width of a cell (degrees).
+// Returns the area of one cell of each row (meters-squared).
+//
+// The rows are in raster order, starting with the northern row, and every
+// cell of a row has the same area, so weighting per pixel is the area of
+// its row. If the height of the raster is not a multiple of resolution
+// then the southern row is cut short at minLat. Returns nil if resolution
+// is not positive or maxLat is not north of minLat.
+func (e *Ellipsoid) PixelAreas(minLat, maxLat, resolution float64) []float64 {
+	if !(resolution > 0) || !(maxLat > minLat) {
+		return nil
+	}
+	k := resolution * (math.Pi / 180)
+	n := int(math.Ceil((maxLat-minLat)/resolution - 1e-9))
+	areas := make([]float64, n)
+	z1 := e.zoneArea(maxLat)
+	for i := range areas {
+		lat := math.Max(maxLat-float64(i+1)*resolution, minLat)
+		z0 := e.zoneArea(lat)
+		areas[i] = (z1 - z0) * k
+		z1 = z0
+	}
+	return areas
+}
@@ -0,0 +1,63 @@
+package geodesic
+
+import "testing"
+
+func TestRectArea(t *testing.T) {
+	// Total area of the WGS84 ellipsoid.
+	area := WGS84.RectArea(-90, -180, 90, 180)
+	if !eqish(area/1e6, 510065621.724, 2) {
+		t.Fatalf("expected %f, got %f", 510065621.724, area/1e6)
+	}
+	// A rectangle with densified parallels approaches the exact area.
+	minLat, minLon, maxLat, maxLon := 10.0, -20.0, 40.0, 15.0
+	p := WGS84.PolygonInit(false)
+	n := 2000
+	for i := 0; i <= n; i++ {
+		p.AddPoint(minLat, minLon+(maxLon-minLon)*float64(i)/float64(n))
+	}
+	for i := 0; i <= n; i++ {
+		p.AddPoint(maxLat, maxLon-(maxLon-minLon)*float64(i)/float64(n))
+	}
+	var parea float64
+	p.Compute(false, true, &parea, nil)
+	area = WGS84.RectArea(minLat, minLon, maxLat, maxLon)
+	if !eqish(area/parea, 1, 6) {
+		t.Fatalf("expected %f, got %f", parea, area)
+	}
+	// Crossing the antimeridian.
+	a1 := WGS84.RectArea(-5, 170, 5, -170)
+	a2 := WGS84.RectArea(-5, -10, 5, 10)
+	if !eqish(a1, a2, 3) {
+		t.Fatalf("expected %f, got %f", a2, a1)
+	}
+}
+
+func TestPixelAreas(t *testing.T) {
+	areas := WGS84.PixelAreas(-90, 90, 0.25)
+	if len(areas) != 720 {
+		t.Fatalf("expected 720, got %d", len(areas))
+	}
+	var sum float64
+	for i, a := range areas {
+		lat := 90 - float64(i)*0.25
+		if want := WGS84.RectArea(lat-0.25, 0, lat, 0.25); !eqish(a/want, 1, 12) {
+			t.Fatalf("row %d: expected %v, got %v", i, want, a)
+		}
+		sum += a * 1440
+	}
+	if !eqish(sum/WGS84.totalArea(), 1, 12) {
+		t.Fatalf("expected %v, got %v", WGS84.totalArea(), sum)
+	}
+	if areas[0] != areas[719] || !(areas[0] < areas[360]/100) {
+		t.Fatalf("expected small symmetric polar rows, got %v %v %v",
+			areas[0], areas[360], areas[719])
+	}
+	// Cut short in the south.
+	areas = WGS84.PixelAreas(0, 2.5, 1)
+	if len(areas) != 3 || !eqish(areas[2]/WGS84.RectArea(0, 0, 0.5, 1), 1, 12) {
+		t.Fatalf("unexpected %v", areas)
+	}
+	if WGS84.PixelAreas(0, 1, 0) != nil {
+		t.Fatalf("expected nil")
+	}
+}
@@ -0,0 +1,41 @@
+package geodesic
+
+// Resample returns points at a fixed geodesic interval along a polyline.
+//
+// Param track is the polyline to resample.
+// Param interval is the distance between consecutive points (meters).
+// Returns the resampled points.
+//
+// The result starts with the first point of the track, followed by the
+// points at every multiple of interval along the track, measured as the
+// cumulative geodesic distance from the start. The last point of the track
+// is always included, so the final spacing may be shorter than interval.
+// Points within a segment are interpolated along the geodesic between the
+// segment's vertices. A non-positive interval returns a copy of the track.
+func (e *Ellipsoid) Resample(track []LatLng, interval float64) []LatLng {
+	if len(track) < 2 || !(interval > 0) {
+		return append([]LatLng(nil), track...)
+	}
+	out := []LatLng{track[0]}
+	var start float64 // distance of the start of the segment along the track
+	next := interval  // distance of the next point along the track
+	arena := e.getArena()
+	defer putArena(arena)
+	for i := 0; i < len(track)-1; i++ {
+		a, b := track[i], track[i+1]
+		arena.Reset() // the line of the last segment is done with
+		l := arena.InverseLine(a.Lat, a.Lon, b.Lat, b.Lon)
+		s13 := l.Distance()
+		for ; next < start+s13; next += interval {
+			var p LatLng
+			l.Position(next-start, &p.Lat, &p.Lon, nil)
+			out = append(out, p)
+		}
+		start += s13
+	}
+	last := track[len(track)-1]
+	if out[len(out)-1] != last {
+		out = append(out, last)
+	}
+	return out
+}
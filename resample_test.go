@@ -0,0 +1,36 @@
+package geodesic
+
+import "testing"
+
+func TestResample(t *testing.T) {
+	track := []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 3}}
+	var total float64
+	for i := 0; i < len(track)-1; i++ {
+		var s12 float64
+		WGS84.Inverse(track[i].Lat, track[i].Lon,
+			track[i+1].Lat, track[i+1].Lon, &s12, nil, nil)
+		total += s12
+	}
+	out := WGS84.Resample(track, 10000)
+	if out[0] != track[0] || out[len(out)-1] != track[len(track)-1] {
+		t.Fatalf("expected the track endpoints to be kept")
+	}
+	if len(out) != int(total/10000)+2 {
+		t.Fatalf("expected %d points, got %d", int(total/10000)+2, len(out))
+	}
+	// Points within the first segment follow the equator exactly.
+	for _, p := range out[1:10] {
+		if !eqish(p.Lat, 0, 9) {
+			t.Fatalf("expected a point on the equator, got %v", p)
+		}
+	}
+	var s12 float64
+	WGS84.Inverse(out[2].Lat, out[2].Lon, out[3].Lat, out[3].Lon,
+		&s12, nil, nil)
+	if !eqish(s12, 10000, 6) {
+		t.Fatalf("expected %f, got %f", 10000.0, s12)
+	}
+	if len(WGS84.Resample(track, 0)) != len(track) {
+		t.Fatalf("expected a copy of the track")
+	}
+}
@@ -0,0 +1,73 @@
+package geodesic
+
+import "math"
+
+// RingReport describes the defects found and fixed by CleanRing.
+type RingReport struct {
+	// Duplicates is the number of vertices removed because they were the
+	// same as the vertex before them, not counting a closing vertex.
+	Duplicates int
+	// Closed is whether the ring repeated its first vertex at the end,
+	// which was removed.
+	Closed bool
+	// Unwrapped is the number of longitudes changed by a multiple of 360,
+	// see CleanRing.
+	Unwrapped int
+	// Degenerate is whether the cleaned ring has fewer than three
+	// vertices, and so has no area.
+	Degenerate bool
+}
+
+// CleanRing returns a polygon ring without repeated vertices, in the form
+// that PolygonArea and Ellipsoid.PolygonInit expect, and a report of what
+// was changed.
+//
+// Param ring is the vertices of the ring, which may or may not repeat the
+// first vertex at the end.
+// Returns the cleaned ring, which is a new slice, and the report.
+//
+// A vertex that is the same as the one before it, or the closing vertex
+// that is the same as the first, adds an edge of no length, which gives
+// no azimuth for the edge and can throw off the winding of ring tests and
+// the orientation of areas. Vertices are the same under the rule of
+// CoincidentPolicy, so 180 and -180 are the same longitude, but points at
+// a pole with different longitudes are not.
+//
+// The longitudes are also unwrapped, so that each is within 180 of the one
+// before it and the first is in [-180,+180], which makes the ring
+// continuous for planar tools and renderers. This does not change the
+// geodesic area or perimeter of the ring. A ring that is degenerate after
+// cleaning should not be measured, as its area is zero or meaningless.
+func CleanRing(ring []LatLng) ([]LatLng, RingReport) {
+	var r RingReport
+	out := make([]LatLng, 0, len(ring))
+	for _, p := range ring {
+		if n := len(out); n > 0 &&
+			coincidentPoints(out[n-1].Lat, out[n-1].Lon, p.Lat, p.Lon) {
+			r.Duplicates++
+			continue
+		}
+		out = append(out, p)
+	}
+	if n := len(out); n > 1 &&
+		coincidentPoints(out[0].Lat, out[0].Lon, out[n-1].Lat, out[n-1].Lon) {
+		out = out[:n-1]
+		r.Closed = true
+	}
+	for i := range out {
+		lon := out[i].Lon
+		if i == 0 {
+			if !(math.Abs(lon) <= 180) {
+				lon = normLon(lon)
+			}
+		} else if k := math.Round((lon - out[i-1].Lon) / 360); k != 0 {
+			lon -= 360 * k
+		}
+		if lon != out[i].Lon {
+			out[i].Lon = lon
+			r.Unwrapped++
+		}
+	}
+	r.Degenerate = len(out) < 3
+	return out, r
+}
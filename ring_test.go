@@ -0,0 +1,53 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCleanRing(t *testing.T) {
+	ring := []LatLng{
+		{0, 170}, {0, 170}, {10, 179}, {10, -180}, {10, 180}, {0, -170},
+		{90, 0}, {90, 10}, {0, 530},
+	}
+	out, r := CleanRing(ring)
+	want := []LatLng{{0, 170}, {10, 179}, {10, 180}, {0, 190}, {90, 360},
+		{90, 370}}
+	if len(out) != len(want) {
+		t.Fatalf("expected %v, got %v", want, out)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, out)
+		}
+	}
+	// The pole keeps both of its longitudes, the first vertex is not
+	// changed, and 180 and -180 are the same.
+	if r != (RingReport{Duplicates: 2, Closed: true, Unwrapped: 4}) {
+		t.Fatalf("expected 2 duplicates, closed, and 4 unwrapped, got %+v", r)
+	}
+	if ring[0] != (LatLng{0, 170}) || ring[3].Lon != -180 {
+		t.Fatal("expected the ring to be left alone")
+	}
+
+	// Cleaning does not change the geodesic measures.
+	a1, p1 := PolygonArea(WGS84, ring)
+	a2, p2 := PolygonArea(WGS84, out)
+	if !eqish(a1, a2, 3) || !eqish(p1, p2, 6) {
+		t.Fatalf("expected %v %v, got %v %v", a1, p1, a2, p2)
+	}
+
+	for _, c := range [][]LatLng{
+		nil, {{1, 2}}, {{1, 2}, {1, 2}, {1, 362}}, {{1, 2}, {3, 4}, {1, 2}},
+	} {
+		if out, r := CleanRing(c); !r.Degenerate || len(out) >= 3 {
+			t.Fatalf("%v: expected a degenerate ring, got %v %+v", c, out, r)
+		}
+	}
+	if _, r := CleanRing([]LatLng{{0, 0}, {0, 1}, {1, 1}}); r != (RingReport{}) {
+		t.Fatalf("expected a clean ring, got %+v", r)
+	}
+	if out, _ := CleanRing([]LatLng{{0, 540}, {1, 541}, {2, 539}}); out[0].Lon != -180 || math.Abs(out[2].Lon-(-181)) > 1e-12 {
+		t.Fatalf("expected the longitudes from -180, got %v", out)
+	}
+}
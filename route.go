@@ -0,0 +1,81 @@
+package geodesic
+
+import (
+	"math"
+	"time"
+)
+
+// Leg is one leg of a route, the geodesic between two waypoints.
+type Leg struct {
+	From, To LatLng
+	Distance float64 // length of the leg (meters)
+	// Azi1 and Azi2 are the azimuths leaving From and arriving at To
+	// (degrees).
+	Azi1, Azi2 float64
+	// Cumulative is the distance from the start of the route to To
+	// (meters).
+	Cumulative float64
+	Duration   time.Duration // time to travel the leg
+	ETA        time.Duration // time from the start of the route to To
+}
+
+// Route is the summary of a route through waypoints, see
+// Ellipsoid.RouteSummary.
+type Route struct {
+	Legs     []Leg
+	Distance float64       // total length of the route (meters)
+	Duration time.Duration // total time to travel the route
+}
+
+// RouteSummary returns the legs of a route through waypoints, with their
+// lengths, bearings, and times.
+//
+// Param waypoints is the points of the route, in order.
+// Param speed is the speed over the ground (meters per second).
+// Returns the route, with one leg less than the waypoints.
+//
+// Each leg is the shortest geodesic between its waypoints. The initial
+// bearing of a leg, Azi1, is the course to steer when leaving it. On a
+// geodesic the course changes along the way, so the final bearing, Azi2,
+// the course when arriving, generally differs from it, and from the
+// initial bearing of the next leg where the route turns. The azimuths
+// follow the convention of the ellipsoid, see Ellipsoid.WithAzimuths. The
+// ETAs are times since the start, to be added to a time of departure, and
+// are computed from the cumulative distance so that they do not drift over
+// many legs. If speed is not positive the times are all zero.
+func (e *Ellipsoid) RouteSummary(waypoints []LatLng, speed float64) Route {
+	var r Route
+	if len(waypoints) < 2 {
+		return r
+	}
+	r.Legs = make([]Leg, len(waypoints)-1)
+	for i := range r.Legs {
+		l := &r.Legs[i]
+		l.From, l.To = waypoints[i], waypoints[i+1]
+		e.Inverse(l.From.Lat, l.From.Lon, l.To.Lat, l.To.Lon,
+			&l.Distance, &l.Azi1, &l.Azi2)
+		r.Distance += l.Distance
+		l.Cumulative = r.Distance
+		l.ETA = travelTime(l.Cumulative, speed)
+		if i == 0 {
+			l.Duration = l.ETA
+		} else {
+			l.Duration = l.ETA - r.Legs[i-1].ETA
+		}
+	}
+	r.Duration = r.Legs[len(r.Legs)-1].ETA
+	return r
+}
+
+// travelTime returns the time to travel a distance at a speed, or zero if
+// the speed is not positive. Times too long for a time.Duration saturate.
+func travelTime(s, speed float64) time.Duration {
+	if !(speed > 0) {
+		return 0
+	}
+	t := s / speed * float64(time.Second)
+	if t >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(math.Round(t))
+}
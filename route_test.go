@@ -0,0 +1,54 @@
+package geodesic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRouteSummary(t *testing.T) {
+	wps := []LatLng{{40.64, -73.78}, {51.47, -0.45}, {1.36, 103.99}, {1.36, 103.99}}
+	r := WGS84.RouteSummary(wps, 250)
+	if len(r.Legs) != 3 {
+		t.Fatalf("expected 3, got %d", len(r.Legs))
+	}
+	var total float64
+	var elapsed time.Duration
+	for i, l := range r.Legs {
+		var s12, azi1, azi2 float64
+		WGS84.Inverse(wps[i].Lat, wps[i].Lon, wps[i+1].Lat, wps[i+1].Lon,
+			&s12, &azi1, &azi2)
+		if l.Distance != s12 || l.Azi1 != azi1 || l.Azi2 != azi2 {
+			t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+				s12, azi1, azi2, l.Distance, l.Azi1, l.Azi2)
+		}
+		if l.From != wps[i] || l.To != wps[i+1] {
+			t.Fatalf("expected '%v, %v', got '%v, %v'", wps[i], wps[i+1], l.From, l.To)
+		}
+		total += s12
+		elapsed += l.Duration
+		if l.Cumulative != total || l.ETA != elapsed {
+			t.Fatalf("expected '%v, %v', got '%v, %v'", total, elapsed,
+				l.Cumulative, l.ETA)
+		}
+		want := time.Duration(s12 / 250 * float64(time.Second))
+		if d := l.Duration - want; d < -time.Microsecond || d > time.Microsecond {
+			t.Fatalf("expected %v, got %v", want, l.Duration)
+		}
+	}
+	if r.Distance != total || r.Duration != elapsed {
+		t.Fatalf("expected '%v, %v', got '%v, %v'", total, elapsed, r.Distance, r.Duration)
+	}
+	// The last leg has no length.
+	if l := r.Legs[2]; l.Distance != 0 || l.Duration != 0 {
+		t.Fatalf("expected an empty leg, got %v", l)
+	}
+	if r := WGS84.RouteSummary(wps, 0); r.Duration != 0 || r.Distance != total {
+		t.Fatalf("expected no times, got %v", r)
+	}
+	if r := WGS84.RouteSummary(wps[:1], 250); r.Legs != nil || r.Distance != 0 {
+		t.Fatalf("expected an empty route, got %v", r)
+	}
+	if r := WGS84.RouteSummary(wps[:2], 1e-20); r.Duration <= 0 {
+		t.Fatalf("expected a saturated duration, got %v", r.Duration)
+	}
+}
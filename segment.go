@@ -0,0 +1,25 @@
+package geodesic
+
+// segmentNearest returns the point on the geodesic segment from a to b that
+// is nearest to p, along with the distance from p to that point (meters)
+// and the distance of that point from a along the segment (meters).
+func (e *Ellipsoid) segmentNearest(p, a, b LatLng) (q LatLng, dist, along float64) {
+	l := e.InverseLine(a.Lat, a.Lon, b.Lat, b.Lon)
+	s13 := l.Distance()
+	distAt := func(s float64) float64 {
+		var lat, lon, s12 float64
+		l.Position(s, &lat, &lon, nil)
+		e.Inverse(p.Lat, p.Lon, lat, lon, &s12, nil, nil)
+		return s12
+	}
+	along, dist = goldenMin(0, s13, distAt)
+	// The search does not evaluate the endpoints themselves.
+	if d := distAt(0); d <= dist {
+		along, dist = 0, d
+	}
+	if d := distAt(s13); d < dist {
+		along, dist = s13, d
+	}
+	l.Position(along, &q.Lat, &q.Lon, nil)
+	return q, dist, along
+}
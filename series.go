@@ -0,0 +1,33 @@
+package geodesic
+
+import v2 "github.com/tidwall/geodesic_cgo/v2"
+
+// SeriesOrder returns the order of the series expansions used by the
+// geodesic routines, the GEOGRAPHICLIB_GEODESIC_ORDER of the C library.
+//
+// The order is 6 by default, which gives the full double precision
+// accuracy of about 15 nanometers for the ellipsoids of the Earth. A build
+// with the geodesic_order5, geodesic_order4, or geodesic_order3 tag uses
+// fewer terms, trading accuracy for speed, see the SeriesOrder of v2 for
+// the details. The solvers built on the geodesics, such as
+// CircleIntersections and SegmentIntersection, accept their solutions to
+// within those errors. The exact routines of NewExactEllipsoid are not
+// affected.
+func SeriesOrder() int {
+	return v2.SeriesOrder()
+}
+
+// seriesTolerance widens a tolerance of the solvers, in meters, by the
+// errors of the series of the build. A tolerance near the accuracy of
+// order 6 cannot be met with fewer terms, where solving the inverse
+// problem for a direct solution comes back off by a few micrometers with
+// order 4 and by up to a tenth of a millimeter with order 3.
+func seriesTolerance(tol float64) float64 {
+	switch SeriesOrder() {
+	case 4:
+		return tol * 100
+	case 3:
+		return tol * 1e4
+	}
+	return tol
+}
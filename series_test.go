@@ -0,0 +1,57 @@
+package geodesic
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo/internal/gendata"
+)
+
+func TestSeriesOrder(t *testing.T) {
+	// The largest errors in the reference data, which is computed with
+	// order 6, for each order (meters).
+	tol := map[int]float64{6: 2e-8, 5: 2e-8, 4: 1e-6, 3: 1e-4}[SeriesOrder()]
+	if tol == 0 {
+		t.Fatalf("expected an order in [3,6], got %d", SeriesOrder())
+	}
+	f, err := os.Open("testdata/geodesic.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d, err := gendata.Read(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range d.Inverse {
+		var s12 float64
+		WGS84.Inverse(c.Lat1, c.Lon1, c.Lat2, c.Lon2, &s12, nil, nil)
+		if math.Abs(s12-c.S12) > tol {
+			t.Fatalf("expected %v, got %v", c.S12, s12)
+		}
+		var p LatLng
+		WGS84.Direct(c.Lat1, c.Lon1, c.Azi1, c.S12, &p.Lat, &p.Lon, nil)
+		if dist := WGS84.distance(p, LatLng{c.Lat2, c.Lon2}); dist > tol {
+			t.Fatalf("expected %v, got %v, %v away", LatLng{c.Lat2, c.Lon2}, p,
+				dist)
+		}
+	}
+}
+
+// seriesPrec lowers the precision prec of a check with eqish, of a value
+// that is good to the accuracy of order 6, by the digits that the series
+// of the build lose, see seriesTolerance.
+func seriesPrec(prec int) int {
+	return prec - int(math.Round(math.Log10(seriesTolerance(1))))
+}
+
+// fullOrder skips a test of reference values from the C library at order
+// 6 in a build with fewer series terms, whose accuracy TestSeriesOrder
+// checks instead.
+func fullOrder(t *testing.T) {
+	t.Helper()
+	if n := SeriesOrder(); n != 6 {
+		t.Skipf("the reference values are of order 6, built with order %d", n)
+	}
+}
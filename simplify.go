@@ -0,0 +1,42 @@
+package geodesic
+
+// Simplify reduces the number of points in a polyline using the
+// Douglas-Peucker algorithm.
+//
+// Param line is the polyline to simplify.
+// Param tolerance is the maximum allowed deviation (meters).
+// Returns the simplified polyline.
+//
+// The deviation of a point is its geodesic distance to the nearest point of
+// the geodesic segment that replaces it, so the tolerance is in true ground
+// meters at any latitude. The first and last points are always kept.
+func (e *Ellipsoid) Simplify(line []LatLng, tolerance float64) []LatLng {
+	if len(line) < 3 {
+		return append([]LatLng(nil), line...)
+	}
+	keep := make([]bool, len(line))
+	keep[0], keep[len(line)-1] = true, true
+	stack := [][2]int{{0, len(line) - 1}}
+	for len(stack) > 0 {
+		i, j := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+		idx, worst := -1, tolerance
+		for k := i + 1; k < j; k++ {
+			_, dist, _ := e.segmentNearest(line[k], line[i], line[j])
+			if dist > worst {
+				idx, worst = k, dist
+			}
+		}
+		if idx != -1 {
+			keep[idx] = true
+			stack = append(stack, [2]int{i, idx}, [2]int{idx, j})
+		}
+	}
+	var out []LatLng
+	for i, p := range line {
+		if keep[i] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package geodesic
+
+import "testing"
+
+func TestSimplify(t *testing.T) {
+	// Points along a single geodesic collapse to the endpoints.
+	l := WGS84.InverseLine(10, 10, 20, 30)
+	var line []LatLng
+	for i := 0; i <= 20; i++ {
+		var p LatLng
+		l.Position(l.Distance()*float64(i)/20, &p.Lat, &p.Lon, nil)
+		line = append(line, p)
+	}
+	out := WGS84.Simplify(line, 0.001)
+	if len(out) != 2 || out[0] != line[0] || out[1] != line[20] {
+		t.Fatalf("expected the endpoints only, got %v", out)
+	}
+	// Push a point 50 m to the side of the line.
+	var p LatLng
+	var azi float64
+	l.Position(l.Distance()/2, nil, nil, &azi)
+	WGS84.Direct(line[10].Lat, line[10].Lon, azi+90, 50, &p.Lat, &p.Lon, nil)
+	line[10] = p
+	if out := WGS84.Simplify(line, 49); len(out) != 3 || out[1] != p {
+		t.Fatalf("expected the offset point to be kept, got %v", out)
+	}
+	if out := WGS84.Simplify(line, 51); len(out) != 2 {
+		t.Fatalf("expected the endpoints only, got %v", out)
+	}
+}
@@ -0,0 +1,103 @@
+package geodesic
+
+import "math"
+
+// SnapTrack assigns each point of a track to a position on a route.
+//
+// Param track is the sequence of observed points, in travel order.
+// Param route is the polyline that the track follows.
+// Param maxDist is the largest allowed snapping distance (meters).
+// Returns one match per track point.
+//
+// Each track point is matched to a route segment within maxDist, and the
+// matches are chosen together so that the distance along the route (Along)
+// never decreases from one matched point to the next, which keeps a track
+// from jumping back to an earlier part of a route that loops or doubles
+// back. Among all such assignments the one with the smallest total snapping
+// distance is chosen, where an unmatched point costs maxDist. Points that
+// are not matched have a Segment of -1 and a NaN Distance.
+func (e *Ellipsoid) SnapTrack(track, route []LatLng, maxDist float64) []PolylineMatch {
+	type state struct {
+		m    PolylineMatch
+		cost float64 // total cost of the best path ending here
+		back int     // index of the previous state, or -1
+	}
+	if len(route) == 0 {
+		out := make([]PolylineMatch, len(track))
+		for i := range out {
+			out[i] = PolylineMatch{Segment: -1, Distance: math.NaN()}
+		}
+		return out
+	}
+	// Cumulative distance to the start of each segment.
+	starts := make([]float64, len(route))
+	for i := 1; i < len(route); i++ {
+		starts[i] = starts[i-1] + e.distance(route[i-1], route[i])
+	}
+	// Each layer holds the candidate matches for one point, followed by a
+	// skip state for leaving the point unmatched. Skip states carry the
+	// along-track distance of their predecessor.
+	layers := make([][]state, len(track))
+	prev := []state{{m: PolylineMatch{Along: math.Inf(-1)}, back: -1}}
+	for t, p := range track {
+		var layer []state
+		for i := 0; i < len(route)-1 || i == 0; i++ {
+			var m PolylineMatch
+			if len(route) == 1 {
+				m = PolylineMatch{Point: route[0], Distance: e.distance(p, route[0])}
+			} else {
+				q, dist, along := e.segmentNearest(p, route[i], route[i+1])
+				m = PolylineMatch{
+					Point: q, Segment: i, Distance: dist, Along: starts[i] + along,
+				}
+			}
+			if m.Distance > maxDist {
+				continue
+			}
+			best := state{cost: math.Inf(1), back: -1}
+			for j, s := range prev {
+				if s.m.Along <= m.Along && s.cost+m.Distance < best.cost {
+					best = state{m: m, cost: s.cost + m.Distance, back: j}
+				}
+			}
+			if best.back != -1 {
+				layer = append(layer, best)
+			}
+		}
+		skip := state{cost: math.Inf(1), back: -1}
+		for j, s := range prev {
+			if s.cost+maxDist < skip.cost ||
+				(s.cost+maxDist == skip.cost && s.m.Along < skip.m.Along) {
+				skip = state{
+					m: PolylineMatch{
+						Segment: -1, Distance: math.NaN(), Along: s.m.Along,
+					},
+					cost: s.cost + maxDist, back: j,
+				}
+			}
+		}
+		layer = append(layer, skip)
+		layers[t] = layer
+		prev = layer
+	}
+	out := make([]PolylineMatch, len(track))
+	if len(track) == 0 {
+		return out
+	}
+	idx := 0
+	last := layers[len(track)-1]
+	for i := range last {
+		if last[i].cost < last[idx].cost {
+			idx = i
+		}
+	}
+	for t := len(track) - 1; t >= 0; t-- {
+		s := layers[t][idx]
+		out[t] = s.m
+		if s.m.Segment == -1 {
+			out[t].Along = 0
+		}
+		idx = s.back
+	}
+	return out
+}
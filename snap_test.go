@@ -0,0 +1,27 @@
+package geodesic
+
+import "testing"
+
+func TestSnapTrack(t *testing.T) {
+	// A route that goes east and comes back slightly to the north.
+	route := []LatLng{{0, 0}, {0, 1}, {0.002, 1}, {0.002, 0}}
+	// Observations on the way out are ambiguous between the two legs.
+	track := []LatLng{
+		{0.0009, 0.2}, {0.0009, 0.5}, {0.0011, 0.8}, {0.001, 1},
+		{0.0011, 0.6}, {0.0011, 0.3}, {0.5, 0.3},
+	}
+	out := WGS84.SnapTrack(track, route, 500)
+	want := []int{0, 0, 0, 1, 2, 2, -1}
+	for i, m := range out {
+		if m.Segment != want[i] {
+			t.Fatalf("point %d: expected segment %d, got %+v", i, want[i], m)
+		}
+		if i > 0 && m.Segment != -1 && m.Along < out[i-1].Along {
+			t.Fatalf("point %d: along-track distance went backwards", i)
+		}
+	}
+	// Without the monotonic constraint, point 2 would snap to the return leg.
+	if m := WGS84.NearestOnPolyline(track[2], route); m.Segment != 2 {
+		t.Fatalf("expected segment 2, got %+v", m)
+	}
+}
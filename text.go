@@ -0,0 +1,112 @@
+package geodesic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrText is returned, wrapped with the offending text, when unmarshaling
+// a value from text that is not in its text form, or by ParseLatLng for
+// text that is not a point.
+//
+// The text forms of LatLng, InverseSolution, and DirectSolution are their
+// fields, in decimal without an exponent and with the fewest digits that
+// parse back to the same float64, separated by commas, such as
+// "51.5,-0.1" for a LatLng. Unmarshaling also accepts spaces around the
+// numbers, but nothing else. Since encoding/json uses the text form of a
+// type that has one, these values are JSON strings such as "51.5,-0.1".
+var ErrText = errors.New("geodesic: invalid text")
+
+// MarshalText returns the point as "lat,lon", which implements
+// encoding.TextMarshaler.
+func (p LatLng) MarshalText() ([]byte, error) {
+	return appendFields(nil, p.Lat, p.Lon), nil
+}
+
+// UnmarshalText sets the point from "lat,lon", which implements
+// encoding.TextUnmarshaler. The latitude must be in [-90,+90].
+func (p *LatLng) UnmarshalText(text []byte) error {
+	var f [2]float64
+	if err := parseFields(text, f[:]); err != nil {
+		return err
+	}
+	if !validLatLng(f[0], f[1]) {
+		return fmt.Errorf("%w: %q: out of range", ErrText, text)
+	}
+	p.Lat, p.Lon = f[0], f[1]
+	return nil
+}
+
+// MarshalText returns the solution as "s12,azi1,azi2", which implements
+// encoding.TextMarshaler.
+func (r InverseSolution) MarshalText() ([]byte, error) {
+	return appendFields(nil, r.S12, r.Azi1, r.Azi2), nil
+}
+
+// UnmarshalText sets the solution from "s12,azi1,azi2", which implements
+// encoding.TextUnmarshaler.
+func (r *InverseSolution) UnmarshalText(text []byte) error {
+	var f [3]float64
+	if err := parseFields(text, f[:]); err != nil {
+		return err
+	}
+	r.S12, r.Azi1, r.Azi2 = f[0], f[1], f[2]
+	return nil
+}
+
+// MarshalText returns the solution as "lat,lon,azi2", which implements
+// encoding.TextMarshaler.
+func (r DirectSolution) MarshalText() ([]byte, error) {
+	return appendFields(nil, r.Point.Lat, r.Point.Lon, r.Azi2), nil
+}
+
+// UnmarshalText sets the solution from "lat,lon,azi2", which implements
+// encoding.TextUnmarshaler.
+func (r *DirectSolution) UnmarshalText(text []byte) error {
+	var f [3]float64
+	if err := parseFields(text, f[:]); err != nil {
+		return err
+	}
+	if !validLatLng(f[0], f[1]) {
+		return fmt.Errorf("%w: %q: out of range", ErrText, text)
+	}
+	r.Point, r.Azi2 = LatLng{f[0], f[1]}, f[2]
+	return nil
+}
+
+// validLatLng reports whether the latitude is in [-90,+90] and the
+// longitude is finite.
+func validLatLng(lat, lon float64) bool {
+	return math.Abs(lat) <= 90 && math.Abs(lon) <= math.MaxFloat64
+}
+
+// appendFields appends the comma separated text form of the fields.
+func appendFields(b []byte, fields ...float64) []byte {
+	for i, x := range fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendFloat(b, x, 'f', -1, 64)
+	}
+	return b
+}
+
+// parseFields parses exactly len(fields) comma separated numbers.
+func parseFields(text []byte, fields []float64) error {
+	parts := bytes.Split(text, []byte{','})
+	if len(parts) != len(fields) {
+		return fmt.Errorf("%w: %q: expected %d fields", ErrText, text,
+			len(fields))
+	}
+	for i, part := range parts {
+		x, err := strconv.ParseFloat(string(bytes.TrimSpace(part)), 64)
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrText, text)
+		}
+		fields[i] = x
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package geodesic
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = LatLng{}
+	_ encoding.TextUnmarshaler = (*LatLng)(nil)
+	_ encoding.TextMarshaler   = InverseSolution{}
+	_ encoding.TextUnmarshaler = (*InverseSolution)(nil)
+	_ encoding.TextMarshaler   = DirectSolution{}
+	_ encoding.TextUnmarshaler = (*DirectSolution)(nil)
+)
+
+func TestLatLngText(t *testing.T) {
+	p := LatLng{51.4775, -0.461389}
+	b, _ := p.MarshalText()
+	if string(b) != "51.4775,-0.461389" {
+		t.Fatalf("expected %q, got %q", "51.4775,-0.461389", b)
+	}
+	var q LatLng
+	if err := q.UnmarshalText(b); err != nil || q != p {
+		t.Fatalf("expected %v, got %v (%v)", p, q, err)
+	}
+	if err := q.UnmarshalText([]byte(" 10 , 20 ")); err != nil ||
+		q != (LatLng{10, 20}) {
+		t.Fatalf("expected {10 20}, got %v (%v)", q, err)
+	}
+	for _, s := range []string{"", "10", "10,20,30", "91,0", "10,x",
+		"NaN,0", "0,Inf", "10 20"} {
+		if err := q.UnmarshalText([]byte(s)); !errors.Is(err, ErrText) {
+			t.Fatalf("%q: expected ErrText, got %v", s, err)
+		}
+	}
+
+	// The text form works as a flag.
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var origin LatLng
+	fs.TextVar(&origin, "origin", LatLng{}, "origin point")
+	if err := fs.Parse([]string{"-origin", "40.64,-73.78"}); err != nil ||
+		origin != (LatLng{40.64, -73.78}) {
+		t.Fatalf("expected {40.64 -73.78}, got %v (%v)", origin, err)
+	}
+}
+
+func TestSolutionText(t *testing.T) {
+	var inv InverseSolution
+	WGS84.Inverse(40.64, -73.78, 1.36, 103.99, &inv.S12, &inv.Azi1, &inv.Azi2)
+	b, _ := inv.MarshalText()
+	var inv2 InverseSolution
+	if err := inv2.UnmarshalText(b); err != nil || inv2 != inv {
+		t.Fatalf("expected %v, got %v (%v)", inv, inv2, err)
+	}
+	b, _ = InverseSolution{S12: 1e7, Azi1: 45, Azi2: -135.5}.MarshalText()
+	if string(b) != "10000000,45,-135.5" {
+		t.Fatalf("expected %q, got %q", "10000000,45,-135.5", b)
+	}
+
+	dir := DirectSolution{Point: LatLng{-33.9, 151.2}, Azi2: 12.25}
+	b, _ = dir.MarshalText()
+	if string(b) != "-33.9,151.2,12.25" {
+		t.Fatalf("expected %q, got %q", "-33.9,151.2,12.25", b)
+	}
+	var dir2 DirectSolution
+	if err := dir2.UnmarshalText(b); err != nil || dir2 != dir {
+		t.Fatalf("expected %v, got %v (%v)", dir, dir2, err)
+	}
+	if err := dir2.UnmarshalText([]byte("100,0,0")); !errors.Is(err, ErrText) {
+		t.Fatalf("expected ErrText, got %v", err)
+	}
+}
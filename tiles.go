@@ -0,0 +1,225 @@
+package geodesic
+
+import "math"
+
+// Tile is a Web Mercator (slippy map) tile.
+type Tile struct {
+	X, Y, Z int
+}
+
+// Bounds returns the latitude/longitude rectangle covered by the tile.
+func (t Tile) Bounds() Bounds {
+	n := float64(uint64(1) << uint(t.Z))
+	return Bounds{
+		MinLat: tileLat(float64(t.Y+1), n),
+		MinLon: float64(t.X)/n*360 - 180,
+		MaxLat: tileLat(float64(t.Y), n),
+		MaxLon: float64(t.X+1)/n*360 - 180,
+	}
+}
+
+func tileLat(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * (180 / math.Pi)
+}
+
+// tileXY returns the tile coordinates containing the point at zoom level n
+// tiles across, with the latitude clamped to the Web Mercator range.
+func tileXY(lat, lon, n float64) (x, y int) {
+	lat = math.Max(math.Min(lat, 85.0511287798066), -85.0511287798066)
+	slat := math.Sin(lat * (math.Pi / 180))
+	fx := (lon + 180) / 360 * n
+	fy := (0.5 - math.Log((1+slat)/(1-slat))/(4*math.Pi)) * n
+	x = int(math.Max(math.Min(math.Floor(fx), n-1), 0))
+	y = int(math.Max(math.Min(math.Floor(fy), n-1), 0))
+	return x, y
+}
+
+// CircleTiles returns the Web Mercator tiles at the zoom level that
+// intersect a geodesic circle.
+//
+// Param lat is the latitude of the center of the circle (degrees).
+// Param lon is the longitude of the center of the circle (degrees).
+// Param radius is the radius of the circle (meters).
+// Param zoom is the tile zoom level.
+// Returns the intersecting tiles.
+//
+// A tile is included when the geodesic distance from the center of the
+// circle to the nearest point of the tile is no more than radius. Parts of
+// the circle beyond the Web Mercator latitude limits (about ±85.0511
+// degrees) are covered by the northern and southern rows of tiles.
+func (e *Ellipsoid) CircleTiles(lat, lon, radius float64, zoom int) []Tile {
+	b := e.ExpandBounds(Bounds{lat, lon, lat, lon}, radius)
+	return boundsTiles(b, zoom, func(tb Bounds) bool {
+		return e.distanceToBounds(lat, lon, tb) <= radius
+	})
+}
+
+// RingTiles returns the Web Mercator tiles at the zoom level that
+// intersect a ring buffered by a distance, the polygon grown by buffer
+// meters on all sides.
+//
+// Param ring is the vertices of the ring, as for Ellipsoid.RingContains.
+// Param buffer is the non-negative distance to grow the ring by (meters).
+// Param zoom is the tile zoom level.
+// Returns the intersecting tiles, or nil for an empty ring or a buffer
+// that is negative or NaN.
+//
+// A tile is included when a point of it is inside the ring or within
+// buffer of its boundary, that is, when the Ellipsoid.DistanceToPolygon
+// of a point of it is no more than buffer. A ring with fewer than three
+// distinct vertices has no inside, and gives the tiles along its vertices
+// and edges. The candidates come from the bounds of the ring grown by the
+// buffer, and each is decided by the distance from its center, which no
+// point of the tile is farther from than a bound of the size of the tile.
+// A tile that is too close to call is split into quarters, down to a
+// 256th of its width, and the tiles still too close to call then are
+// included, so a tile may be included whose nearest point is beyond the
+// buffer by no more than the size of such a part. Parts of the ring
+// beyond the Web Mercator latitude limits are covered by the northern and
+// southern rows of tiles, as for Ellipsoid.CircleTiles.
+func (e *Ellipsoid) RingTiles(ring []LatLng, buffer float64, zoom int) []Tile {
+	if len(ring) == 0 || !(buffer >= 0) {
+		return nil
+	}
+	r := e.newRingRegion(ring)
+	dist := func(p LatLng) float64 {
+		if r.contains(p) {
+			return 0
+		}
+		return e.ringBoundaryDistance(p, ring)
+	}
+	b := e.ExpandBounds(e.ringTileBounds(&r, ring), buffer)
+	return boundsTiles(b, zoom, func(tb Bounds) bool {
+		return e.nearBounds(tb, buffer, dist, 8)
+	})
+}
+
+// ringTileBounds returns the bounds of a ring, see ringRegion.bounds, or
+// the bounds of its vertices and edges if it has no inside.
+func (e *Ellipsoid) ringTileBounds(r *ringRegion, ring []LatLng) Bounds {
+	if len(r.edges) > 0 {
+		return r.bounds()
+	}
+	var line ringRegion
+	for i, a := range ring {
+		if b := ring[(i+1)%len(ring)]; a != b {
+			line.edges = append(line.edges, e.newRingEdge(a, b))
+		}
+	}
+	if len(line.edges) == 0 {
+		return Bounds{ring[0].Lat, ring[0].Lon, ring[0].Lat, ring[0].Lon}
+	}
+	return line.bounds()
+}
+
+// nearBounds reports whether a point of a rectangle, which does not cross
+// the antimeridian, is within buffer by dist, a distance that changes by
+// no more than the distance between the points, splitting the rectangle
+// into quarters up to depth times while that is too close to call.
+func (e *Ellipsoid) nearBounds(b Bounds, buffer float64,
+	dist func(p LatLng) float64, depth int) bool {
+	c := LatLng{(b.MinLat + b.MaxLat) / 2, (b.MinLon + b.MaxLon) / 2}
+	d := dist(c)
+	if d <= buffer {
+		return true
+	}
+	if d-e.boundsReach(b, c) > buffer {
+		return false
+	}
+	if depth == 0 {
+		return true
+	}
+	for _, q := range [4]Bounds{
+		{b.MinLat, b.MinLon, c.Lat, c.Lon},
+		{b.MinLat, c.Lon, c.Lat, b.MaxLon},
+		{c.Lat, b.MinLon, b.MaxLat, c.Lon},
+		{c.Lat, c.Lon, b.MaxLat, b.MaxLon},
+	} {
+		if e.nearBounds(q, buffer, dist, depth-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// boundsReach returns a distance that no point of a rectangle, which does
+// not cross the antimeridian, is farther than from the point c inside it.
+// A point is reached by going along the meridian of c to its latitude and
+// then along its parallel, which is no shorter than the geodesic.
+func (e *Ellipsoid) boundsReach(b Bounds, c LatLng) float64 {
+	var north, south float64
+	e.Inverse(c.Lat, c.Lon, b.MaxLat, c.Lon, &north, nil, nil)
+	e.Inverse(c.Lat, c.Lon, b.MinLat, c.Lon, &south, nil, nil)
+	// The longest parallel is the one nearest the equator.
+	lat := math.Max(math.Min(0, b.MaxLat), b.MinLat)
+	return math.Max(north, south) +
+		e.MetersPerDegreeLon(lat)*math.Max(c.Lon-b.MinLon, b.MaxLon-c.Lon)
+}
+
+// boundsTiles returns the tiles at the zoom level that cover b and that
+// keep reports true for, given the bounds of the tile, with those of the
+// northern and southern rows extended to the poles.
+func boundsTiles(b Bounds, zoom int, keep func(tb Bounds) bool) []Tile {
+	n := float64(uint64(1) << uint(zoom))
+	x0, y1 := tileXY(b.MinLat, b.MinLon, n)
+	x1, y0 := tileXY(b.MaxLat, b.MaxLon, n)
+	nx := x1 - x0 + 1
+	if b.MinLon > b.MaxLon {
+		// crosses the antimeridian
+		nx += int(n)
+	}
+	if nx > int(n) {
+		nx = int(n)
+	}
+	var tiles []Tile
+	for i := 0; i < nx; i++ {
+		x := (x0 + i) % int(n)
+		for y := y0; y <= y1; y++ {
+			t := Tile{X: x, Y: y, Z: zoom}
+			tb := t.Bounds()
+			if y == 0 {
+				tb.MaxLat = 90
+			}
+			if y == int(n)-1 {
+				tb.MinLat = -90
+			}
+			if keep(tb) {
+				tiles = append(tiles, t)
+			}
+		}
+	}
+	return tiles
+}
+
+// distanceToBounds returns the geodesic distance from a point to the
+// nearest point of a latitude/longitude rectangle, which is zero when the
+// point is inside.
+func (e *Ellipsoid) distanceToBounds(lat, lon float64, b Bounds) float64 {
+	dlon := normLon(lon - b.MinLon)
+	if dlon < 0 {
+		dlon += 360
+	}
+	span := b.lonSpan()
+	if dlon <= span {
+		// The nearest point is on the same meridian.
+		if lat >= b.MinLat && lat <= b.MaxLat {
+			return 0
+		}
+		var s12 float64
+		e.Inverse(lat, lon, math.Max(math.Min(lat, b.MaxLat), b.MinLat), lon,
+			&s12, nil, nil)
+		return s12
+	}
+	// The nearest point is on the closer of the two meridian edges.
+	mlon := b.MinLon
+	if dlon-span < 360-dlon {
+		mlon = b.MaxLon
+	}
+	dist := func(mlat float64) float64 {
+		var s12 float64
+		e.Inverse(lat, lon, mlat, mlon, &s12, nil, nil)
+		return s12
+	}
+	_, fx := goldenMin(b.MinLat, b.MaxLat, dist)
+	return math.Min(fx, math.Min(dist(b.MinLat), dist(b.MaxLat)))
+}
@@ -0,0 +1,118 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCircleTiles(t *testing.T) {
+	tiles := WGS84.CircleTiles(0, 0, 1000, 0)
+	if len(tiles) != 1 || tiles[0] != (Tile{0, 0, 0}) {
+		t.Fatalf("expected the root tile, got %v", tiles)
+	}
+	// A small circle at the corner of four tiles.
+	tiles = WGS84.CircleTiles(0, 0, 1000, 4)
+	if len(tiles) != 4 {
+		t.Fatalf("expected 4 tiles, got %v", tiles)
+	}
+	// A circle in the interior of a tile.
+	b := Tile{X: 300, Y: 400, Z: 10}.Bounds()
+	lat, lon := (b.MinLat+b.MaxLat)/2, (b.MinLon+b.MaxLon)/2
+	tiles = WGS84.CircleTiles(lat, lon, 100, 10)
+	if len(tiles) != 1 || tiles[0] != (Tile{300, 400, 10}) {
+		t.Fatalf("expected a single tile, got %v", tiles)
+	}
+	// Crossing the antimeridian.
+	tiles = WGS84.CircleTiles(0, 179.99, 5000, 3)
+	xs := map[int]bool{}
+	for _, tile := range tiles {
+		xs[tile.X] = true
+	}
+	if !xs[0] || !xs[7] || len(xs) != 2 {
+		t.Fatalf("expected tiles on both sides of the antimeridian, got %v",
+			tiles)
+	}
+	// Every tile must contain a point of the circle within radius.
+	tiles = WGS84.CircleTiles(52.5, 13.4, 25000, 10)
+	for _, tile := range tiles {
+		tb := tile.Bounds()
+		if d := WGS84.distanceToBounds(52.5, 13.4, tb); d > 25000 {
+			t.Fatalf("tile %v is %f meters away", tile, d)
+		}
+	}
+	x, y := tileXY(52.5, 13.4, 1024)
+	var found bool
+	for _, tile := range tiles {
+		found = found || tile == Tile{x, y, 10}
+	}
+	if !found || len(tiles) < 4 {
+		t.Fatalf("expected the center tile and its neighbors, got %v", tiles)
+	}
+}
+
+func TestRingTiles(t *testing.T) {
+	ring := []LatLng{{10, 20}, {10, 21}, {11, 21}, {11, 20}}
+	has := func(tiles []Tile, tile Tile) bool {
+		for _, tl := range tiles {
+			if tl == tile {
+				return true
+			}
+		}
+		return false
+	}
+	// No tile is missed that has a sampled point within the buffer, and
+	// every tile has one within the buffer and the spacing of the samples.
+	for _, buffer := range []float64{0, 20000} {
+		tiles := WGS84.RingTiles(ring, buffer, 7)
+		b := WGS84.ExpandBounds(Bounds{9, 19, 12, 22}, buffer)
+		n := float64(1 << 7)
+		x0, y1 := tileXY(b.MinLat, b.MinLon, n)
+		x1, y0 := tileXY(b.MaxLat, b.MaxLon, n)
+		for x := x0; x <= x1; x++ {
+			for y := y0; y <= y1; y++ {
+				tile := Tile{x, y, 7}
+				tb := tile.Bounds()
+				nearest := math.Inf(1)
+				for i := 0; i <= 8; i++ {
+					for j := 0; j <= 8; j++ {
+						p := LatLng{tb.MinLat + (tb.MaxLat-tb.MinLat)*float64(i)/8,
+							tb.MinLon + (tb.MaxLon-tb.MinLon)*float64(j)/8}
+						nearest = math.Min(nearest, WGS84.DistanceToPolygon(p, ring))
+					}
+				}
+				if got := has(tiles, tile); nearest <= buffer && !got {
+					t.Fatalf("buffer %v: tile %v is %f meters away but missing",
+						buffer, tile, nearest)
+				} else if spacing := (tb.MaxLon - tb.MinLon) / 8 *
+					WGS84.MetersPerDegreeLon(0); got && nearest > buffer+spacing {
+					t.Fatalf("buffer %v: tile %v is %f meters away", buffer,
+						tile, nearest)
+				}
+			}
+		}
+	}
+	// A ring inside a tile.
+	tb := Tile{X: 300, Y: 400, Z: 10}.Bounds()
+	lat, lon := (tb.MinLat+tb.MaxLat)/2, (tb.MinLon+tb.MaxLon)/2
+	small := []LatLng{{lat, lon}, {lat, lon + 0.01}, {lat + 0.01, lon}}
+	if tiles := WGS84.RingTiles(small, 0, 10); len(tiles) != 1 ||
+		tiles[0] != (Tile{300, 400, 10}) {
+		t.Fatalf("expected a single tile, got %v", tiles)
+	}
+	// A single vertex grown by a buffer is a circle.
+	circle := WGS84.CircleTiles(52.5, 13.4, 25000, 10)
+	point := WGS84.RingTiles([]LatLng{{52.5, 13.4}}, 25000, 10)
+	for _, tile := range circle {
+		if !has(point, tile) {
+			t.Fatalf("expected %v in %v", tile, point)
+		}
+	}
+	// A ring around the pole covers the northern row.
+	polar := []LatLng{{80, 0}, {80, 120}, {80, -120}}
+	if tiles := WGS84.RingTiles(polar, 0, 2); len(tiles) != 4 {
+		t.Fatalf("expected the northern row, got %v", tiles)
+	}
+	if WGS84.RingTiles(nil, 0, 3) != nil || WGS84.RingTiles(ring, -1, 3) != nil {
+		t.Fatalf("expected no tiles")
+	}
+}
@@ -0,0 +1,208 @@
+package geodesic
+
+import "math"
+
+// TransverseMercator is the transverse Mercator projection of an
+// ellipsoid, the basis of UTM and of many national grids. It is safe for
+// concurrent use.
+//
+// The projection is computed with Krüger's series to sixth order in the
+// third flattening, as in the TransverseMercator class of GeographicLib,
+// which is accurate to 5 nanometers within 3900 km of the central
+// meridian. It is only defined within 90 degrees of longitude of the
+// central meridian, and the error grows quickly beyond about 4000 km.
+type TransverseMercator struct {
+	conformal
+	a, k0    float64
+	b1       float64 // the rectifying radius over a
+	alp, bet [7]float64
+}
+
+// conformal converts between the latitudes of an ellipsoid and the
+// conformal latitudes of the sphere that the conformal projections are
+// built on, by their tangents. It also holds the eccentricity for the
+// Albers projection.
+type conformal struct {
+	e2, es float64 // the eccentricity squared and its square root
+}
+
+func newConformal(f float64) conformal {
+	e2 := f * (2 - f)
+	return conformal{e2, math.Sqrt(math.Abs(e2))}
+}
+
+// NewTransverseMercator returns the transverse Mercator projection of the
+// ellipsoid.
+//
+// Param k0 is the scale on the central meridian, such as 0.9996 for UTM.
+func (e *Ellipsoid) NewTransverseMercator(k0 float64) *TransverseMercator {
+	f := e.Flattening()
+	tm := &TransverseMercator{conformal: newConformal(f), a: e.Radius(),
+		k0: k0}
+	n := f / (2 - f)
+	n2 := n * n
+	tm.b1 = (1 + n2*(1.0/4+n2*(1.0/64+n2/256))) / (1 + n)
+	var np [7]float64
+	np[0] = 1
+	for i := 1; i < len(np); i++ {
+		np[i] = np[i-1] * n
+	}
+	tm.alp = [7]float64{0,
+		np[1]/2 - np[2]*2/3 + np[3]*5/16 + np[4]*41/180 - np[5]*127/288 +
+			np[6]*7891/37800,
+		np[2]*13/48 - np[3]*3/5 + np[4]*557/1440 + np[5]*281/630 -
+			np[6]*1983433/1935360,
+		np[3]*61/240 - np[4]*103/140 + np[5]*15061/26880 +
+			np[6]*167603/181440,
+		np[4]*49561/161280 - np[5]*179/168 + np[6]*6601661/7257600,
+		np[5]*34729/80640 - np[6]*3418889/1995840,
+		np[6] * 212378941 / 319334400,
+	}
+	tm.bet = [7]float64{0,
+		np[1]/2 - np[2]*2/3 + np[3]*37/96 - np[4]/360 - np[5]*81/512 +
+			np[6]*96199/604800,
+		np[2]/48 + np[3]/15 - np[4]*437/1440 + np[5]*46/105 -
+			np[6]*1118711/3870720,
+		np[3]*17/480 - np[4]*37/840 - np[5]*209/4480 + np[6]*5569/90720,
+		np[4]*4397/161280 - np[5]*11/504 - np[6]*830251/7257600,
+		np[5]*4583/161280 - np[6]*108847/3991680,
+		np[6] * 20648693 / 638668800,
+	}
+	return tm
+}
+
+// CentralScale returns the scale on the central meridian.
+func (tm *TransverseMercator) CentralScale() float64 {
+	return tm.k0
+}
+
+// Forward projects a point.
+//
+// Param lon0 is the central meridian (degrees).
+// Param lat is the latitude of the point (degrees).
+// Param lon is the longitude of the point (degrees).
+// Returns the easting x and northing y of the point (meters), the meridian
+// convergence gamma, which is the bearing of grid north clockwise from
+// true north (degrees), and the scale k.
+//
+// The origin of x and y is on the central meridian at the equator, with no
+// false easting or northing. A point more than 90 degrees of longitude
+// from the central meridian returns NaNs.
+func (tm *TransverseMercator) Forward(lon0, lat, lon float64,
+) (x, y, gamma, k float64) {
+	dlon := math.Remainder(lon-lon0, 360)
+	if !(math.Abs(dlon) <= 90) || !(math.Abs(lat) <= 90) {
+		nan := math.NaN()
+		return nan, nan, nan, nan
+	}
+	sphi, cphi := sincosd(lat)
+	slam, clam := sincosd(dlon)
+	if math.Abs(lat) == 90 {
+		cphi = 0
+	}
+	if math.Abs(dlon) == 90 {
+		clam = 0
+	}
+	// The conformal latitude puts the point on a sphere, where the
+	// projection is the spherical transverse Mercator in xip and etap.
+	tau := sphi / cphi
+	taup := tm.taupf(tau)
+	xip := math.Atan2(taup, clam)
+	etap := math.Asinh(slam / math.Hypot(taup, clam))
+	gamma = math.Atan2(slam*taup, clam*math.Hypot(1, taup))
+	k = math.Sqrt(1-tm.e2*sphi*sphi) * math.Hypot(1, tau) /
+		math.Hypot(taup, clam)
+	if cphi == 0 {
+		// The limits of the above at a pole.
+		gamma = math.Copysign(dlon*(math.Pi/180), lat)
+		k = math.Sqrt(1-tm.e2) * math.Exp(tm.eatanhe(1))
+	}
+	// Krüger's series maps the sphere to the ellipsoid.
+	xi, eta := xip, etap
+	sig, tau2 := 1.0, 0.0
+	for j := 1; j < len(tm.alp); j++ {
+		s, c := math.Sincos(2 * float64(j) * xip)
+		sh, ch := math.Sinh(2*float64(j)*etap), math.Cosh(2*float64(j)*etap)
+		xi += tm.alp[j] * s * ch
+		eta += tm.alp[j] * c * sh
+		sig += 2 * float64(j) * tm.alp[j] * c * ch
+		tau2 += 2 * float64(j) * tm.alp[j] * s * sh
+	}
+	gamma += math.Atan2(tau2, sig)
+	k *= tm.b1 * math.Hypot(sig, tau2)
+	s := tm.k0 * tm.a * tm.b1
+	return s * eta, s * xi, gamma * (180 / math.Pi), tm.k0 * k
+}
+
+// Reverse unprojects a point.
+//
+// Param lon0 is the central meridian (degrees).
+// Param x is the easting of the point (meters).
+// Param y is the northing of the point (meters).
+// Returns the latitude and longitude of the point (degrees), the meridian
+// convergence gamma (degrees), and the scale k, see
+// TransverseMercator.Forward.
+func (tm *TransverseMercator) Reverse(lon0, x, y float64,
+) (lat, lon, gamma, k float64) {
+	s := tm.k0 * tm.a * tm.b1
+	xi, eta := y/s, x/s
+	xip, etap := xi, eta
+	for j := 1; j < len(tm.bet); j++ {
+		sn, c := math.Sincos(2 * float64(j) * xi)
+		sh, ch := math.Sinh(2*float64(j)*eta), math.Cosh(2*float64(j)*eta)
+		xip -= tm.bet[j] * sn * ch
+		etap -= tm.bet[j] * c * sh
+	}
+	sxip, cxip := math.Sincos(xip)
+	shetap := math.Sinh(etap)
+	taup := sxip / math.Hypot(shetap, cxip)
+	lam := math.Atan2(shetap, cxip)
+	lat = math.Atan(tm.tauf(taup)) * (180 / math.Pi)
+	lon = normLon(lon0 + lam*(180/math.Pi))
+	_, _, gamma, k = tm.Forward(lon0, lat, lon)
+	return lat, lon, gamma, k
+}
+
+// eatanhe returns e*atanh(e*x), for the eccentricity e, which is
+// -e*atan(e*x) for a prolate ellipsoid.
+func (c conformal) eatanhe(x float64) float64 {
+	if c.e2 >= 0 {
+		return c.es * math.Atanh(c.es*x)
+	}
+	return -c.es * math.Atan(c.es*x)
+}
+
+// taupf returns the tangent of the conformal latitude for the tangent of
+// the latitude tau.
+func (c conformal) taupf(tau float64) float64 {
+	if math.IsInf(tau, 0) {
+		return tau
+	}
+	tau1 := math.Hypot(1, tau)
+	sig := math.Sinh(c.eatanhe(tau / tau1))
+	return math.Hypot(1, sig)*tau - sig*tau1
+}
+
+// tauf is the inverse of taupf, solved by Newton's method.
+func (c conformal) tauf(taup float64) float64 {
+	const tol = 1.4901161193847656e-09 // sqrt of the machine epsilon / 10
+	e2m := 1 - c.e2
+	tau := taup / e2m
+	if math.Abs(taup) > 70 {
+		tau = taup * math.Exp(c.eatanhe(1))
+	}
+	if !(math.Abs(tau) < 1.3e8) {
+		return tau
+	}
+	stol := tol * math.Max(1, math.Abs(taup))
+	for i := 0; i < 10; i++ {
+		taupa := c.taupf(tau)
+		dtau := (taup - taupa) * (1 + e2m*tau*tau) /
+			(e2m * math.Hypot(1, tau) * math.Hypot(1, taupa))
+		tau += dtau
+		if !(math.Abs(dtau) >= stol) {
+			break
+		}
+	}
+	return tau
+}
@@ -0,0 +1,54 @@
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTransverseMercator(t *testing.T) {
+	// From the documentation of GeoConvert, as UTM zone 38 without the false
+	// easting.
+	tm := WGS84.NewTransverseMercator(0.9996)
+	x, y, gamma, k := tm.Forward(45, 33.3, 44.4)
+	if !eqish(x, 444140.54-5e5, 2) || !eqish(y, 3684706.36, 2) {
+		t.Fatalf("expected '%v, %v', got '%v, %v'", 444140.54-5e5, 3684706.36,
+			x, y)
+	}
+	// Grid north is west of true north to the west of the central meridian.
+	if !(gamma < 0) || !(k > 0.9996 && k < 1) {
+		t.Fatalf("expected a negative convergence and a scale just over "+
+			"0.9996, got %v, %v", gamma, k)
+	}
+	// The central meridian is the meridian distance scaled by k0.
+	var quarter float64
+	WGS84.Inverse(0, 0, 90, 0, &quarter, nil, nil)
+	if _, y, _, k := tm.Forward(0, 90, 0); !eqish(y, 0.9996*quarter, seriesPrec(6)) ||
+		!eqish(k, 0.9996, 12) {
+		t.Fatalf("expected '%v, 0.9996', got '%v, %v'", 0.9996*quarter, y, k)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		lat := rng.Float64()*180 - 90
+		lon := rng.Float64()*60 - 30
+		x, y, gamma, k := tm.Forward(0, lat, lon)
+		lat2, lon2, gamma2, k2 := tm.Reverse(0, x, y)
+		if !eqish(lat, lat2, 9) || !eqish(lon, lon2, 9) ||
+			!eqish(gamma, gamma2, 9) || !eqish(k, k2, 9) {
+			t.Fatalf("expected '%v, %v, %v, %v', got '%v, %v, %v, %v'",
+				lat, lon, gamma, k, lat2, lon2, gamma2, k2)
+		}
+		// The scale is the ratio of a short step on the grid to the same
+		// step on the ellipsoid.
+		var lat3, lon3 float64
+		WGS84.Direct(lat, lon, 30, 1, &lat3, &lon3, nil)
+		x3, y3, _, _ := tm.Forward(0, lat3, lon3)
+		if ratio := math.Hypot(x3-x, y3-y); !eqish(ratio, k, seriesPrec(5)) {
+			t.Fatalf("expected a scale of %v, got %v", k, ratio)
+		}
+	}
+	if x, _, _, _ := tm.Forward(0, 10, 100); !math.IsNaN(x) {
+		t.Fatalf("expected NaN, got %v", x)
+	}
+}
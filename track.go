@@ -0,0 +1,99 @@
+package geodesic
+
+import "time"
+
+// TimedLatLng is a point of a track with the time it was recorded, such as
+// a GPS fix. It is a Point, so tracks may also be measured with the
+// routines that take any Point, such as PolylineLength.
+type TimedLatLng struct {
+	LatLng
+	Time time.Time
+}
+
+// SegmentOptions are the options of Ellipsoid.SegmentTrack.
+type SegmentOptions struct {
+	// StopRadius is how far a track may wander from a point while stopped
+	// there (meters), which should be a little more than the noise of the
+	// fixes.
+	StopRadius float64
+	// StopDuration is how long a track must stay within StopRadius of a
+	// point for a stop. Zero never finds stops.
+	StopDuration time.Duration
+	// MaxGap is the longest time between consecutive fixes within a
+	// segment. Zero never splits at gaps.
+	MaxGap time.Duration
+}
+
+// TrackSegment is a moving segment of a track, from Ellipsoid.SegmentTrack.
+type TrackSegment struct {
+	Start, End int           // indexes of the first and last points of the segment
+	Distance   float64       // geodesic length of the segment (meters)
+	Duration   time.Duration // time from the first point to the last
+}
+
+// SegmentTrack splits a track into the segments in which it is moving,
+// between its stops and the gaps in its fixes.
+//
+// Param track is the points of the track, whose times should not
+// decrease.
+// Param opts are the options, or nil for the defaults, which neither find
+// stops nor split at gaps.
+// Returns the segments, in order.
+//
+// A stop is a run of points that all stay within StopRadius of the first
+// of them, the geodesic distance, for at least StopDuration. The segment
+// before a stop ends at its first point, and the segment after it starts
+// at its last, so the distance wandered within the stop is not counted. A
+// gap is a time between consecutive fixes of more than MaxGap, or a step
+// back in time, and no segment spans one. Segments of a single point, such
+// as a lone fix between two gaps, are left out.
+func (e *Ellipsoid) SegmentTrack(track []TimedLatLng, opts *SegmentOptions,
+) []TrackSegment {
+	var o SegmentOptions
+	if opts != nil {
+		o = *opts
+	}
+	gap := func(i int) bool {
+		dt := track[i+1].Time.Sub(track[i].Time)
+		return dt < 0 || (o.MaxGap > 0 && dt > o.MaxGap)
+	}
+	var segs []TrackSegment
+	start := 0
+	end := func(i int) {
+		if i > start {
+			seg := TrackSegment{Start: start, End: i,
+				Duration: track[i].Time.Sub(track[start].Time)}
+			for k := start; k < i; k++ {
+				seg.Distance += e.distance(track[k].LatLng, track[k+1].LatLng)
+			}
+			segs = append(segs, seg)
+		}
+	}
+	for i := 0; i+1 < len(track); {
+		if gap(i) {
+			end(i)
+			start = i + 1
+			i++
+			continue
+		}
+		if o.StopDuration > 0 {
+			// The run of points that stay near point i.
+			j := i + 1
+			for j < len(track) && !gap(j-1) &&
+				e.distance(track[i].LatLng, track[j].LatLng) <= o.StopRadius {
+				j++
+			}
+			if j-1 > i && track[j-1].Time.Sub(track[i].Time) >= o.StopDuration {
+				end(i)
+				start = j - 1
+				i = j - 1
+				continue
+			}
+		}
+		i++
+	}
+	if len(track) > 0 {
+		end(len(track) - 1)
+	}
+	return segs
+}
@@ -0,0 +1,97 @@
+package geodesic
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// testTrack returns a track of fixes a second apart, which moves north at
+// speed (meters per second) with a jitter of noise (meters) for each
+// number of seconds in legs, stops with the same jitter for the seconds of
+// stops between them, then continues.
+func testTrack(rng *rand.Rand, start time.Time, speed, noise float64,
+	legs, stops []int,
+) []TimedLatLng {
+	var track []TimedLatLng
+	p := LatLng{52, 5}
+	t := start
+	add := func() {
+		var q LatLng
+		WGS84.Direct(p.Lat, p.Lon, rng.Float64()*360, rng.Float64()*noise,
+			&q.Lat, &q.Lon, nil)
+		track = append(track, TimedLatLng{q, t})
+		t = t.Add(time.Second)
+	}
+	for i, n := range legs {
+		for k := 0; k < n; k++ {
+			add()
+			WGS84.Direct(p.Lat, p.Lon, 0, speed, &p.Lat, &p.Lon, nil)
+		}
+		if i < len(stops) {
+			for k := 0; k < stops[i]; k++ {
+				add()
+			}
+		}
+	}
+	return track
+}
+
+func TestSegmentTrack(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	t0 := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	track := testTrack(rng, t0, 10, 3, []int{60, 60, 30}, []int{300, 0})
+	// A gap of ten minutes before the last leg.
+	for i := 420; i < len(track); i++ {
+		track[i].Time = track[i].Time.Add(10 * time.Minute)
+	}
+	opts := &SegmentOptions{StopRadius: 15, StopDuration: 2 * time.Minute,
+		MaxGap: time.Minute}
+	segs := WGS84.SegmentTrack(track, opts)
+	if len(segs) != 3 {
+		t.Fatalf("expected 3 segments, got %v", segs)
+	}
+	for i, want := range []struct {
+		start, end int
+		dist       float64
+	}{{0, 60, 600}, {360, 419, 600}, {420, 449, 290}} {
+		s := segs[i]
+		// The fixes a step or two from the stop are within the stop radius
+		// of it.
+		if !(s.Start >= want.start-2 && s.Start <= want.start+2) ||
+			!(s.End >= want.end-2 && s.End <= want.end+2) {
+			t.Fatalf("%d: expected %d to %d, got %v", i, want.start, want.end,
+				s)
+		}
+		if !(s.Distance > want.dist-30 && s.Distance < want.dist*1.5) {
+			t.Fatalf("%d: expected about %v m, got %v", i, want.dist,
+				s.Distance)
+		}
+		if s.Duration != track[s.End].Time.Sub(track[s.Start].Time) {
+			t.Fatalf("%d: bad duration %v", i, s.Duration)
+		}
+	}
+
+	// Without options the track is one segment, and its length.
+	segs = WGS84.SegmentTrack(track, nil)
+	if len(segs) != 1 || segs[0].Start != 0 || segs[0].End != len(track)-1 ||
+		!eqish(segs[0].Distance, PolylineLength(WGS84, track), 6) {
+		t.Fatalf("expected the whole track, got %v", segs)
+	}
+	// A lone fix between gaps, and a step back in time.
+	lone := []TimedLatLng{
+		{LatLng{0, 0}, t0}, {LatLng{0, 0.001}, t0.Add(time.Second)},
+		{LatLng{0, 0.002}, t0.Add(time.Hour)},
+		{LatLng{0, 0.003}, t0.Add(2 * time.Hour)},
+		{LatLng{0, 0.004}, t0.Add(2*time.Hour + time.Second)},
+		{LatLng{0, 0.005}, t0},
+	}
+	segs = WGS84.SegmentTrack(lone, opts)
+	if len(segs) != 2 || segs[0].End != 1 || segs[1].Start != 3 ||
+		segs[1].End != 4 {
+		t.Fatalf("expected 0 to 1 and 3 to 4, got %v", segs)
+	}
+	if segs := WGS84.SegmentTrack(nil, opts); len(segs) != 0 {
+		t.Fatalf("expected no segments, got %v", segs)
+	}
+}
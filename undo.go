@@ -0,0 +1,31 @@
+package geodesic
+
+// undoKind is the kind of the last change to a Polygon, which is the one
+// that can be undone.
+type undoKind uint8
+
+const (
+	undoNone undoKind = iota
+	undoPoint
+	undoEdge
+)
+
+// RemoveLastPoint removes the point added by the last call to
+// Polygon.AddPoint, restoring the polygon to its state before that call.
+//
+// Only the state before the last change is kept, so one change can be
+// undone. Returns false, leaving the polygon unchanged, if the last change
+// was not Polygon.AddPoint, such as when it was Polygon.AddEdge or
+// Polygon.Clear, or when it was already undone.
+func (p *Polygon) RemoveLastPoint() bool {
+	return p.restore(undoPoint)
+}
+
+// RemoveLastEdge removes the edge added by the last call to
+// Polygon.AddEdge, restoring the polygon to its state before that call.
+//
+// Returns false, leaving the polygon unchanged, if the last change was not
+// Polygon.AddEdge. See Polygon.RemoveLastPoint.
+func (p *Polygon) RemoveLastEdge() bool {
+	return p.restore(undoEdge)
+}
@@ -0,0 +1,50 @@
+package geodesic
+
+import "testing"
+
+func TestRemoveLast(t *testing.T) {
+	p := WGS84.PolygonInit(false)
+	if p.RemoveLastPoint() || p.RemoveLastEdge() {
+		t.Fatalf("expected nothing to remove")
+	}
+	p.AddPoint(0, 0)
+	p.AddPoint(0, 1)
+	p.AddPoint(1, 1)
+	want := p.ComputeResult(false, true)
+	p.AddPoint(5, 5)
+	if !p.RemoveLastPoint() {
+		t.Fatalf("expected the point to be removed")
+	}
+	if r := p.ComputeResult(false, true); r != want {
+		t.Fatalf("expected %+v, got %+v", want, r)
+	}
+	if p.RemoveLastPoint() {
+		t.Fatalf("expected only one step of undo")
+	}
+
+	p.AddEdge(0, 1000)
+	if p.RemoveLastPoint() {
+		t.Fatalf("expected the last change to be an edge")
+	}
+	if !p.RemoveLastEdge() {
+		t.Fatalf("expected the edge to be removed")
+	}
+	if r := p.ComputeResult(false, true); r != want {
+		t.Fatalf("expected %+v, got %+v", want, r)
+	}
+
+	// Removing the first point leaves an empty polygon that takes points
+	// as usual.
+	p.Clear()
+	if p.RemoveLastPoint() {
+		t.Fatalf("expected nothing to remove after Clear")
+	}
+	p.AddPoint(10, 10)
+	p.RemoveLastPoint()
+	p.AddPoint(0, 0)
+	p.AddPoint(0, 1)
+	p.AddPoint(1, 1)
+	if r := p.ComputeResult(false, true); r != want {
+		t.Fatalf("expected %+v, got %+v", want, r)
+	}
+}
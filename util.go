@@ -0,0 +1,36 @@
+package geodesic
+
+import "math"
+
+// normLon reduces a longitude to the range [-180,+180).
+func normLon(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
+
+// goldenMin uses a golden-section search to find the x in [lo,hi] that
+// minimizes f, which should be unimodal over the range.
+func goldenMin(lo, hi float64, f func(x float64) float64) (x, fx float64) {
+	const gr = 0.6180339887498949
+	x1 := hi - gr*(hi-lo)
+	x2 := lo + gr*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+		if f1 > f2 {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + gr*(hi-lo)
+			f2 = f(x2)
+		} else {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - gr*(hi-lo)
+			f1 = f(x1)
+		}
+	}
+	if f1 < f2 {
+		return x1, f1
+	}
+	return x2, f2
+}
@@ -0,0 +1,286 @@
+package geodesic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrOutOfRange is returned, wrapped, for coordinates outside the range
+// of a conversion, such as a latitude outside the UTM zones.
+var ErrOutOfRange = errors.New("geodesic: coordinates out of range")
+
+// UTM is a position in the Universal Transverse Mercator system.
+//
+// The easting includes the false easting of 500 km, and in the southern
+// hemisphere the northing includes the false northing of 10000 km, so both
+// are positive within the zone.
+type UTM struct {
+	Zone     int     // zone, in [1,60]
+	North    bool    // whether the position is in the northern hemisphere
+	Easting  float64 // easting (meters)
+	Northing float64 // northing (meters)
+}
+
+// UTM parameters.
+const (
+	utmK0            = 0.9996
+	utmFalseEasting  = 5e5
+	utmFalseNorthing = 1e7
+)
+
+// utmTM returns the transverse Mercator projection of UTM on the ellipsoid.
+func (e *Ellipsoid) utmTM() *TransverseMercator {
+	return e.NewTransverseMercator(utmK0)
+}
+
+// utmCentralMeridian returns the central meridian of a zone (degrees).
+func utmCentralMeridian(zone int) float64 {
+	return float64(6*zone - 183)
+}
+
+// UTMZone returns the standard UTM zone of a point, which includes the
+// wider zones around Norway and Svalbard, or 0 if the latitude is outside
+// [-80,+84], which is covered by UPS instead.
+func UTMZone(p LatLng) int {
+	if !(p.Lat >= -80 && p.Lat < 84) {
+		return 0
+	}
+	lon := normLon(p.Lon)
+	if lon == 180 {
+		lon = -180
+	}
+	zone := int(math.Floor((lon+180)/6)) + 1
+	switch {
+	case p.Lat >= 56 && p.Lat < 64 && lon >= 3 && lon < 12:
+		zone = 32
+	case p.Lat >= 72 && lon >= 0 && lon < 42:
+		zone = 2*int(math.Floor((lon+3)/12)) + 31
+	}
+	return zone
+}
+
+// ToUTM converts a point to UTM in its standard zone, see UTMZone.
+//
+// Param p is the point.
+// Returns the UTM position, or an error wrapping ErrOutOfRange if p is
+// outside the UTM zones.
+func (e *Ellipsoid) ToUTM(p LatLng) (UTM, error) {
+	zone := UTMZone(p)
+	if zone == 0 {
+		return UTM{}, fmt.Errorf("%w: latitude %v is outside the UTM zones",
+			ErrOutOfRange, p.Lat)
+	}
+	return e.ToUTMZone(p, zone)
+}
+
+// ToUTMZone converts a point to UTM in a given zone, such as the zone of a
+// neighboring point, which keeps a survey that straddles a zone boundary
+// on one grid.
+//
+// Param p is the point.
+// Param zone is the zone, in [1,60].
+// Returns the UTM position, or an error wrapping ErrOutOfRange if the zone
+// is invalid or p is too far outside of it.
+//
+// Points up to about 3000 km from the central meridian of the zone are
+// accepted, though the scale there is far from one.
+func (e *Ellipsoid) ToUTMZone(p LatLng, zone int) (UTM, error) {
+	if zone < 1 || zone > 60 {
+		return UTM{}, fmt.Errorf("%w: zone %d", ErrOutOfRange, zone)
+	}
+	if !(math.Abs(p.Lat) <= 90) ||
+		!(math.Abs(math.Remainder(p.Lon-utmCentralMeridian(zone), 360)) <= 30) {
+		return UTM{}, fmt.Errorf("%w: %v is too far from zone %d",
+			ErrOutOfRange, p, zone)
+	}
+	x, y, _, _ := e.utmTM().Forward(utmCentralMeridian(zone), p.Lat, p.Lon)
+	u := UTM{Zone: zone, North: p.Lat >= 0, Easting: x + utmFalseEasting,
+		Northing: y}
+	if !u.North {
+		u.Northing += utmFalseNorthing
+	}
+	return u, nil
+}
+
+// FromUTM converts a UTM position to a point.
+//
+// Param u is the UTM position.
+// Returns the point, or an error wrapping ErrOutOfRange if the zone is
+// invalid or the easting or northing are far outside of it.
+func (e *Ellipsoid) FromUTM(u UTM) (LatLng, error) {
+	if u.Zone < 1 || u.Zone > 60 {
+		return LatLng{}, fmt.Errorf("%w: zone %d", ErrOutOfRange, u.Zone)
+	}
+	y := u.Northing
+	if !u.North {
+		y -= utmFalseNorthing
+	}
+	x := u.Easting - utmFalseEasting
+	if !(math.Abs(x) <= 3e6) || !(math.Abs(y) <= 1e7) {
+		return LatLng{}, fmt.Errorf("%w: easting %v, northing %v",
+			ErrOutOfRange, u.Easting, u.Northing)
+	}
+	var p LatLng
+	p.Lat, p.Lon, _, _ = e.utmTM().Reverse(utmCentralMeridian(u.Zone), x, y)
+	return p, nil
+}
+
+// String returns the position as "zone hemisphere easting northing", such
+// as "38N 444140.545 3684706.356", with the easting and northing to the
+// millimeter.
+func (u UTM) String() string {
+	h := "S"
+	if u.North {
+		h = "N"
+	}
+	return fmt.Sprintf("%d%s %.3f %.3f", u.Zone, h, u.Easting, u.Northing)
+}
+
+// ParseUTM parses a UTM position as written by UTM.String.
+//
+// Param s is the text, which is the zone and hemisphere, N or S in either
+// case, followed by the easting and northing, such as "38n 444140 3684706".
+// Returns the UTM position, or an error wrapping ErrText.
+func ParseUTM(s string) (UTM, error) {
+	f := strings.Fields(s)
+	if len(f) == 4 {
+		// A space between the zone and the hemisphere.
+		f = append([]string{f[0] + f[1]}, f[2:]...)
+	}
+	if len(f) != 3 || len(f[0]) < 2 {
+		return UTM{}, fmt.Errorf("%w: %q: expected zone, easting, northing",
+			ErrText, s)
+	}
+	var u UTM
+	var err error
+	zone, h := f[0][:len(f[0])-1], f[0][len(f[0])-1]
+	switch h {
+	case 'N', 'n':
+		u.North = true
+	case 'S', 's':
+	default:
+		return UTM{}, fmt.Errorf("%w: %q: bad hemisphere", ErrText, s)
+	}
+	if u.Zone, err = strconv.Atoi(zone); err != nil || u.Zone < 1 ||
+		u.Zone > 60 {
+		return UTM{}, fmt.Errorf("%w: %q: bad zone", ErrText, s)
+	}
+	if u.Easting, err = strconv.ParseFloat(f[1], 64); err != nil {
+		return UTM{}, fmt.Errorf("%w: %q: bad easting", ErrText, s)
+	}
+	if u.Northing, err = strconv.ParseFloat(f[2], 64); err != nil {
+		return UTM{}, fmt.Errorf("%w: %q: bad northing", ErrText, s)
+	}
+	return u, nil
+}
+
+// MGRS letters.
+const (
+	mgrsBands = "CDEFGHJKLMNPQRSTUVWX" // latitude bands, 8 degrees from -80
+	mgrsRows  = "ABCDEFGHJKLMNPQRSTUV" // 100 km rows, repeating every 2000 km
+)
+
+// mgrsCols are the 100 km column letters of the zones, which repeat every
+// three zones.
+var mgrsCols = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+// ToMGRS converts a point to a Military Grid Reference System string.
+//
+// Param p is the point.
+// Param prec is the number of digits of each of the easting and northing in
+// the 100 km square, in [0,10]: 5 is to the meter, and 0 only gives the
+// square.
+// Returns the MGRS string, such as "38SMB4414084706", or an error wrapping
+// ErrOutOfRange if p is outside the UTM zones. The polar regions, which
+// MGRS covers with UPS, are not supported.
+//
+// As the standard requires, the digits are truncated rather than rounded,
+// so the string names the square that contains the point.
+func (e *Ellipsoid) ToMGRS(p LatLng, prec int) (string, error) {
+	if prec < 0 || prec > 10 {
+		return "", fmt.Errorf("%w: precision %d", ErrOutOfRange, prec)
+	}
+	u, err := e.ToUTM(p)
+	if err != nil {
+		return "", err
+	}
+	band := int(math.Floor((p.Lat + 80) / 8))
+	band = min(band, len(mgrsBands)-1) // X runs to 84
+	col := int(math.Floor(u.Easting/1e5)) - 1
+	row := int(math.Floor(u.Northing/1e5)) % 20
+	if u.Zone%2 == 0 {
+		row = (row + 5) % 20
+	}
+	if col < 0 || col > 7 {
+		return "", fmt.Errorf("%w: easting %v", ErrOutOfRange, u.Easting)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d%c%c%c", u.Zone, mgrsBands[band],
+		mgrsCols[(u.Zone-1)%3][col], mgrsRows[row])
+	if prec > 0 {
+		scale := math.Pow(10, float64(prec-5))
+		x := math.Floor(math.Mod(u.Easting, 1e5) * scale)
+		y := math.Floor(math.Mod(u.Northing, 1e5) * scale)
+		fmt.Fprintf(&b, "%0*.0f%0*.0f", prec, x, prec, y)
+	}
+	return b.String(), nil
+}
+
+// FromMGRS converts a Military Grid Reference System string to a point.
+//
+// Param s is the MGRS string, in either case and with or without spaces,
+// such as "38SMB4414084706" or "38S MB 44140 84706".
+// Returns the center of the square named by the string, or an error
+// wrapping ErrText if it is not a valid MGRS string.
+//
+// The polar regions, which MGRS covers with UPS, are not supported.
+func (e *Ellipsoid) FromMGRS(s string) (LatLng, error) {
+	t := strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	i := 0
+	for i < len(t) && i < 2 && t[i] >= '0' && t[i] <= '9' {
+		i++
+	}
+	zone, err := strconv.Atoi(t[:i])
+	if err != nil || zone < 1 || zone > 60 || len(t) < i+3 {
+		return LatLng{}, fmt.Errorf("%w: %q: bad zone", ErrText, s)
+	}
+	band := strings.IndexByte(mgrsBands, t[i])
+	col := strings.IndexByte(mgrsCols[(zone-1)%3], t[i+1])
+	row := strings.IndexByte(mgrsRows, t[i+2])
+	if band < 0 || col < 0 || row < 0 {
+		return LatLng{}, fmt.Errorf("%w: %q: bad letters", ErrText, s)
+	}
+	digits := t[i+3:]
+	if len(digits)%2 != 0 || len(digits) > 20 {
+		return LatLng{}, fmt.Errorf("%w: %q: bad digits", ErrText, s)
+	}
+	prec := len(digits) / 2
+	size := 1e5 / math.Pow(10, float64(prec)) // of the square (meters)
+	var x, y float64
+	if prec > 0 {
+		xd, err1 := strconv.ParseUint(digits[:prec], 10, 64)
+		yd, err2 := strconv.ParseUint(digits[prec:], 10, 64)
+		if err1 != nil || err2 != nil {
+			return LatLng{}, fmt.Errorf("%w: %q: bad digits", ErrText, s)
+		}
+		x, y = float64(xd)*size, float64(yd)*size
+	}
+	if zone%2 == 0 {
+		row = (row + 15) % 20
+	}
+	u := UTM{Zone: zone, North: band >= 10,
+		Easting: float64(col+1)*1e5 + x + size/2}
+	// The row letters repeat every 2000 km, so pick the repeat that lands
+	// nearest the middle of the band.
+	mid := LatLng{Lat: -80 + 8*float64(band) + 4, Lon: utmCentralMeridian(zone)}
+	if band == len(mgrsBands)-1 {
+		mid.Lat += 2 // X runs to 84
+	}
+	m, _ := e.ToUTMZone(mid, zone)
+	n := float64(row)*1e5 + y + size/2
+	u.Northing = n + 2e6*math.Round((m.Northing-n)/2e6)
+	return e.FromUTM(u)
+}
@@ -0,0 +1,126 @@
+package geodesic
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestUTM(t *testing.T) {
+	u, err := WGS84.ToUTM(LatLng{33.3, 44.4})
+	if err != nil || u.Zone != 38 || !u.North ||
+		!eqish(u.Easting, 444140.54, 2) || !eqish(u.Northing, 3684706.36, 2) {
+		t.Fatalf("expected 38N 444140.54 3684706.36, got %v (%v)", u, err)
+	}
+	if s := u.String(); s != "38N 444140.545 3684706.356" {
+		t.Fatalf("expected %q, got %q", "38N 444140.545 3684706.356", s)
+	}
+	u2, err := ParseUTM("38n 444140.545 3684706.356")
+	if err != nil || u2.Zone != 38 || !u2.North || u2.Easting != 444140.545 {
+		t.Fatalf("expected %v, got %v (%v)", u, u2, err)
+	}
+	if _, err := ParseUTM("38 N 444140 3684706"); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"", "38 444140", "61N 1 2", "38X 1 2", "38N x 2"} {
+		if _, err := ParseUTM(s); !errors.Is(err, ErrText) {
+			t.Fatalf("%q: expected ErrText, got %v", s, err)
+		}
+	}
+
+	// Zones, with the exceptions around Norway and Svalbard.
+	for _, v := range []struct {
+		p    LatLng
+		zone int
+	}{
+		{LatLng{0, -180}, 1}, {LatLng{0, 180}, 1}, {LatLng{0, 179.9}, 60},
+		{LatLng{60, 5}, 32}, {LatLng{60, 2}, 31}, {LatLng{78, 8}, 31},
+		{LatLng{78, 10}, 33}, {LatLng{78, 40}, 37}, {LatLng{-33.9, 151.2}, 56},
+		{LatLng{84, 0}, 0}, {LatLng{-80.5, 0}, 0},
+	} {
+		if zone := UTMZone(v.p); zone != v.zone {
+			t.Fatalf("%v: expected zone %d, got %d", v.p, v.zone, zone)
+		}
+	}
+	if _, err := WGS84.ToUTM(LatLng{85, 0}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+	if _, err := WGS84.ToUTMZone(LatLng{0, 0}, 20); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := LatLng{rng.Float64()*164 - 80, rng.Float64()*360 - 180}
+		u, err := WGS84.ToUTM(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.North != (p.Lat >= 0) || !(u.Northing >= 0 && u.Northing <= 1e7) {
+			t.Fatalf("%v: bad northing %v", p, u)
+		}
+		q, err := WGS84.FromUTM(u)
+		if err != nil || !eqish(q.Lat, p.Lat, 9) || !eqish(q.Lon, p.Lon, 9) {
+			t.Fatalf("expected %v, got %v (%v)", p, q, err)
+		}
+	}
+}
+
+func TestMGRS(t *testing.T) {
+	p := LatLng{33.3, 44.4}
+	for _, v := range []struct {
+		prec int
+		want string
+	}{
+		{0, "38SMB"}, {2, "38SMB4484"}, {5, "38SMB4414084706"},
+		{6, "38SMB441405847063"},
+	} {
+		s, err := WGS84.ToMGRS(p, v.prec)
+		if err != nil || s != v.want {
+			t.Fatalf("expected %q, got %q (%v)", v.want, s, err)
+		}
+	}
+	q, err := WGS84.FromMGRS("38s mb 44140 84706")
+	if err != nil || WGS84.distance(p, q) > 1 {
+		t.Fatalf("expected near %v, got %v (%v)", p, q, err)
+	}
+	for _, s := range []string{"", "38", "38SIB", "61SMB", "38SMB123", "38SMBxx"} {
+		if _, err := WGS84.FromMGRS(s); !errors.Is(err, ErrText) {
+			t.Fatalf("%q: expected ErrText, got %v", s, err)
+		}
+	}
+	if _, err := WGS84.ToMGRS(LatLng{89, 0}, 5); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+
+	// Each string names the square that contains the point, in both
+	// hemispheres and in odd and even zones.
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		p := LatLng{rng.Float64()*164 - 80, rng.Float64()*360 - 180}
+		s, err := WGS84.ToMGRS(p, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := WGS84.FromMGRS(s)
+		if err != nil || WGS84.distance(p, q) > 100 {
+			t.Fatalf("%s: expected near %v, got %v (%v)", s, p, q, err)
+		}
+	}
+}
+
+func TestParseGeoCoords(t *testing.T) {
+	want := LatLng{33.3, 44.4}
+	for _, s := range []string{
+		"33.3 44.4", "33°18'N 44°24'E", "38n 444140.545 3684706.356",
+		"38SMB4414084706",
+	} {
+		p, err := WGS84.ParseGeoCoords(s)
+		if err != nil || WGS84.distance(p, want) > 1 {
+			t.Fatalf("%q: expected %v, got %v (%v)", s, want, p, err)
+		}
+	}
+	if _, err := WGS84.ParseGeoCoords("nowhere"); !errors.Is(err, ErrText) {
+		t.Fatalf("expected ErrText, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+//go:build cgo
+
+package geodesic
+
+/*
+#cgo CFLAGS: -O3
+#cgo LDFLAGS: -lm
+#include <math.h>
+#include "geodesic.h"
+
+// The go_ wrappers return the results of the C routines by value, rather
+// than through pointers into Go memory, so that the results of the Go
+// methods do not escape to the heap.
+
+struct go_inverse { double s12, azi1, azi2, a12; };
+
+static struct go_inverse go_inverse(const struct geod_geodesic* g,
+                                    double lat1, double lon1,
+                                    double lat2, double lon2,
+                                    int distance, int azimuth) {
+  struct go_inverse r = { NAN, NAN, NAN, NAN };
+  r.a12 = geod_geninverse(g, lat1, lon1, lat2, lon2,
+                          distance ? &r.s12 : 0,
+                          azimuth ? &r.azi1 : 0, azimuth ? &r.azi2 : 0,
+                          0, 0, 0, 0);
+  return r;
+}
+
+struct go_position { double lat2, lon2, azi2; };
+
+static struct go_position go_direct(const struct geod_geodesic* g,
+                                    double lat1, double lon1,
+                                    double azi1, double s12,
+                                    int lat, int lon, int azi) {
+  struct go_position r = { NAN, NAN, NAN };
+  geod_direct(g, lat1, lon1, azi1, s12,
+              lat ? &r.lat2 : 0, lon ? &r.lon2 : 0, azi ? &r.azi2 : 0);
+  return r;
+}
+
+static struct go_position go_position(const struct geod_geodesicline* l,
+                                      unsigned flags, double s12_a12,
+                                      int lat, int lon, int azi) {
+  struct go_position r = { NAN, NAN, NAN };
+  geod_genposition(l, flags, s12_a12,
+                   lat ? &r.lat2 : 0, lon ? &r.lon2 : 0, azi ? &r.azi2 : 0,
+                   0, 0, 0, 0, 0);
+  return r;
+}
+
+struct go_scales { double m12, M12, M21; };
+
+static struct go_scales go_scales(const struct geod_geodesicline* l,
+                                  double s12) {
+  struct go_scales r;
+  geod_genposition(l, GEOD_NOFLAGS, s12, 0, 0, 0, 0,
+                   &r.m12, &r.M12, &r.M21, 0);
+  return r;
+}
+
+struct go_polygon { double area, perimeter; unsigned num; };
+
+static struct go_polygon go_polygon_compute(const struct geod_geodesic* g,
+                                            const struct geod_polygon* p,
+                                            int reverse, int sign) {
+  struct go_polygon r = { 0, 0, 0 };
+  r.num = geod_polygon_compute(g, p, reverse, sign, &r.area, &r.perimeter);
+  return r;
+}
+
+static struct go_polygon go_polygon_testpoint(const struct geod_geodesic* g,
+                                              const struct geod_polygon* p,
+                                              double lat, double lon,
+                                              int reverse, int sign) {
+  struct go_polygon r = { 0, 0, 0 };
+  r.num = geod_polygon_testpoint(g, p, lat, lon, reverse, sign,
+                                 &r.area, &r.perimeter);
+  return r;
+}
+
+static struct go_polygon go_polygon_testedge(const struct geod_geodesic* g,
+                                             const struct geod_polygon* p,
+                                             double azi, double s,
+                                             int reverse, int sign) {
+  struct go_polygon r = { 0, 0, 0 };
+  r.num = geod_polygon_testedge(g, p, azi, s, reverse, sign,
+                                &r.area, &r.perimeter);
+  return r;
+}
+*/
+import "C"
+
+// core is the backend of an Ellipsoid, the geod_geodesic of the C library,
+// or the exact routines of GeographicLib C++ for Options.Exact. Its
+// methods take and return the raw values of the C routines, without the
+// checks and conventions of the Ellipsoid.
+type core struct {
+	g     C.struct_geod_geodesic
+	exact *exactGeodesic // set for Options.Exact
+}
+
+// init initializes the backend in place.
+func (c *core) init(radius, flattening float64, exact bool) error {
+	C.geod_init(&c.g, C.double(radius), C.double(flattening))
+	c.exact = nil
+	if exact {
+		x, err := newExactGeodesic(radius, flattening)
+		if err != nil {
+			return err
+		}
+		c.exact = x
+	}
+	return nil
+}
+
+// libraryVersion returns the version of the C library from geodesic.h.
+func libraryVersion() (major, minor, patch int) {
+	return C.GEODESIC_VERSION_MAJOR, C.GEODESIC_VERSION_MINOR,
+		C.GEODESIC_VERSION_PATCH
+}
+
+func (c *core) radius() float64 {
+	return float64(c.g.a)
+}
+
+func (c *core) flattening() float64 {
+	return float64(c.g.f)
+}
+
+func cbool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// inverse solves the inverse problem. The caps select what is computed, as
+// the output mask of geod_geninverse does; the arc length is always set.
+func (c *core) inverse(lat1, lon1, lat2, lon2 float64, caps Caps,
+) InverseResult {
+	if c.exact != nil {
+		return c.exact.inverse(lat1, lon1, lat2, lon2, caps)
+	}
+	r := C.go_inverse(&c.g,
+		C.double(lat1), C.double(lon1), C.double(lat2), C.double(lon2),
+		cbool(caps&Distance != 0), cbool(caps&Azimuth != 0))
+	return InverseResult{float64(r.s12), float64(r.azi1), float64(r.azi2),
+		float64(r.a12)}
+}
+
+func directResult(r C.struct_go_position) DirectResult {
+	return DirectResult{LatLng{float64(r.lat2), float64(r.lon2)},
+		float64(r.azi2)}
+}
+
+func (c *core) direct(lat1, lon1, azi1, s12 float64, caps Caps) DirectResult {
+	if c.exact != nil {
+		return c.exact.direct(lat1, lon1, azi1, s12, caps)
+	}
+	return directResult(C.go_direct(&c.g,
+		C.double(lat1), C.double(lon1), C.double(azi1), C.double(s12),
+		cbool(caps&Latitude != 0), cbool(caps&Longitude != 0),
+		cbool(caps&Azimuth != 0)))
+}
+
+// coreLine is the backend of a Line, a geod_geodesicline. For an exact
+// ellipsoid the positions are solved from point 1 by the exact routines,
+// and the geod_geodesicline only holds point 1, the azimuth, and point 3.
+type coreLine struct {
+	l     C.struct_geod_geodesicline
+	exact *exactGeodesic // from the ellipsoid
+}
+
+func (c *core) lineInit(l *coreLine, lat1, lon1, azi1 float64) {
+	C.geod_lineinit(&l.l, &c.g, C.double(lat1), C.double(lon1),
+		C.double(azi1), C.GEOD_ALL)
+	l.exact = c.exact
+}
+
+func (c *core) directLine(l *coreLine, lat1, lon1, azi1, s12 float64) {
+	C.geod_directline(&l.l, &c.g, C.double(lat1), C.double(lon1),
+		C.double(azi1), C.double(s12), C.GEOD_ALL)
+	l.exact = c.exact
+}
+
+func (c *core) inverseLine(l *coreLine, lat1, lon1, lat2, lon2 float64) {
+	if c.exact != nil {
+		// Solve with the exact routines and keep the series line only for
+		// point 1, the azimuth, and the distance.
+		r := c.exact.inverse(lat1, lon1, lat2, lon2, Distance|Azimuth)
+		C.geod_directline(&l.l, &c.g, C.double(lat1), C.double(lon1),
+			C.double(r.Azi1), C.double(r.Distance), C.GEOD_ALL)
+		l.l.a13 = C.double(r.Arc)
+	} else {
+		C.geod_inverseline(&l.l, &c.g, C.double(lat1), C.double(lon1),
+			C.double(lat2), C.double(lon2), C.GEOD_ALL)
+	}
+	l.exact = c.exact
+}
+
+func (l *coreLine) position(s12 float64, caps Caps) DirectResult {
+	if l.exact != nil {
+		return l.exact.direct(float64(l.l.lat1), float64(l.l.lon1),
+			float64(l.l.azi1), s12, caps)
+	}
+	return directResult(C.go_position(&l.l, C.GEOD_NOFLAGS, C.double(s12),
+		cbool(caps&Latitude != 0), cbool(caps&Longitude != 0),
+		cbool(caps&Azimuth != 0)))
+}
+
+func (l *coreLine) arcPosition(a12 float64, caps Caps) DirectResult {
+	if l.exact != nil {
+		return l.exact.arcDirect(float64(l.l.lat1), float64(l.l.lon1),
+			float64(l.l.azi1), a12, caps)
+	}
+	return directResult(C.go_position(&l.l, C.GEOD_ARCMODE, C.double(a12),
+		cbool(caps&Latitude != 0), cbool(caps&Longitude != 0),
+		cbool(caps&Azimuth != 0)))
+}
+
+func (l *coreLine) scales(s12 float64) (m12, M12, M21 float64) {
+	if l.exact != nil {
+		return l.exact.scales(float64(l.l.lat1), float64(l.l.lon1),
+			float64(l.l.azi1), s12)
+	}
+	r := C.go_scales(&l.l, C.double(s12))
+	return float64(r.m12), float64(r.M12), float64(r.M21)
+}
+
+func (l *coreLine) lat1() float64     { return float64(l.l.lat1) }
+func (l *coreLine) lon1() float64     { return float64(l.l.lon1) }
+func (l *coreLine) azi1() float64     { return float64(l.l.azi1) }
+func (l *coreLine) distance() float64 { return float64(l.l.s13) }
+func (l *coreLine) arc() float64      { return float64(l.l.a13) }
+
+// corePolygon is the backend of a Polygon, a geod_polygon, or a
+// GeographicLib::PolygonAreaExact for an exact ellipsoid.
+type corePolygon struct {
+	c    *core
+	p    C.struct_geod_polygon
+	prev C.struct_geod_polygon // p before the last change, see save
+	x    *exactPolygon         // used instead of p for an exact ellipsoid
+}
+
+func (c *core) polygonInit(p *corePolygon, polyline bool) {
+	C.geod_polygon_init(&p.p, cbool(polyline))
+	p.x = nil
+	if c.exact != nil {
+		p.x = c.exact.polygon(polyline)
+	}
+	p.c = c
+}
+
+func (p *corePolygon) addPoint(lat, lon float64) {
+	if p.x != nil {
+		p.x.addPoint(lat, lon)
+		return
+	}
+	C.geod_polygon_addpoint(&p.c.g, &p.p, C.double(lat), C.double(lon))
+}
+
+func (p *corePolygon) addEdge(azi, s float64) {
+	if p.x != nil {
+		p.x.addEdge(azi, s)
+		return
+	}
+	C.geod_polygon_addedge(&p.c.g, &p.p, C.double(azi), C.double(s))
+}
+
+func polygonResult(r C.struct_go_polygon) PolygonResult {
+	return PolygonResult{float64(r.area), float64(r.perimeter), int(r.num)}
+}
+
+func (p *corePolygon) compute(reverse, sign bool) PolygonResult {
+	if p.x != nil {
+		return p.x.compute(reverse, sign)
+	}
+	return polygonResult(C.go_polygon_compute(&p.c.g, &p.p,
+		cbool(reverse), cbool(sign)))
+}
+
+func (p *corePolygon) testPoint(lat, lon float64, reverse, sign bool,
+) PolygonResult {
+	if p.x != nil {
+		return p.x.testPoint(lat, lon, reverse, sign)
+	}
+	return polygonResult(C.go_polygon_testpoint(&p.c.g, &p.p,
+		C.double(lat), C.double(lon), cbool(reverse), cbool(sign)))
+}
+
+func (p *corePolygon) testEdge(azi, s float64, reverse, sign bool,
+) PolygonResult {
+	if p.x != nil {
+		return p.x.testEdge(azi, s, reverse, sign)
+	}
+	return polygonResult(C.go_polygon_testedge(&p.c.g, &p.p,
+		C.double(azi), C.double(s), cbool(reverse), cbool(sign)))
+}
+
+// current returns the last point added, or NaN if there is none. After an
+// edge, the longitude is unrolled, counting the turns around the earth.
+func (p *corePolygon) current() (lat, lon float64) {
+	if p.x != nil {
+		return p.x.current()
+	}
+	return float64(p.p.lat), float64(p.p.lon)
+}
+
+func (p *corePolygon) num() int {
+	if p.x != nil {
+		return p.x.compute(false, false).NumPoints
+	}
+	return int(p.p.num)
+}
+
+func (p *corePolygon) polyline() bool {
+	return p.p.polyline != 0
+}
+
+// save records the state before a change, for restore.
+func (p *corePolygon) save() {
+	if p.x != nil {
+		p.x.save()
+		return
+	}
+	p.prev = p.p
+}
+
+// restore reverts the state to that of the last save.
+func (p *corePolygon) restore() {
+	if p.x != nil {
+		p.x.restore()
+		return
+	}
+	p.p = p.prev
+}
+
+func (p *corePolygon) clear() {
+	if p.x != nil {
+		p.x.clear()
+		return
+	}
+	C.geod_polygon_clear(&p.p)
+}
+
+// free releases the memory held outside of Go, and leaves the polygon
+// unusable.
+func (p *corePolygon) free() {
+	if p.x != nil {
+		p.x.free()
+		p.x = nil
+	}
+	p.c = nil
+}
@@ -0,0 +1,167 @@
+//go:build !cgo
+
+package geodesic
+
+import "github.com/tidwall/geodesic_cgo/v2/internal/geod"
+
+// core is the backend of an Ellipsoid. This build does not have cgo and
+// uses the pure Go port of the geodesic routines, see the cgo build of
+// this type.
+type core struct {
+	g geod.Geodesic
+}
+
+// init initializes the backend in place. The exact routines need cgo.
+func (c *core) init(radius, flattening float64, exact bool) error {
+	c.g.Init(radius, flattening)
+	if exact {
+		return ErrExactUnavailable
+	}
+	return nil
+}
+
+// libraryVersion returns the version of geodesic.c that the Go port
+// follows.
+func libraryVersion() (major, minor, patch int) {
+	return geod.VersionMajor, geod.VersionMinor, geod.VersionPatch
+}
+
+func (c *core) radius() float64 {
+	return c.g.A()
+}
+
+func (c *core) flattening() float64 {
+	return c.g.F()
+}
+
+func (c *core) inverse(lat1, lon1, lat2, lon2 float64, caps Caps,
+) InverseResult {
+	r := nanInverse
+	r.Arc = c.g.GenInverse(lat1, lon1, lat2, lon2,
+		out(caps, Distance, &r.Distance), out(caps, Azimuth, &r.Azi1),
+		out(caps, Azimuth, &r.Azi2), nil, nil, nil, nil)
+	return r
+}
+
+func (c *core) direct(lat1, lon1, azi1, s12 float64, caps Caps) DirectResult {
+	r := nanDirect
+	c.g.Direct(lat1, lon1, azi1, s12, out(caps, Latitude, &r.Point.Lat),
+		out(caps, Longitude, &r.Point.Lon), out(caps, Azimuth, &r.Azi2))
+	return r
+}
+
+// coreLine is the backend of a Line.
+type coreLine struct {
+	l geod.Line
+}
+
+func (c *core) lineInit(l *coreLine, lat1, lon1, azi1 float64) {
+	c.g.LineInit(&l.l, lat1, lon1, azi1, geod.All)
+}
+
+func (c *core) directLine(l *coreLine, lat1, lon1, azi1, s12 float64) {
+	c.g.DirectLine(&l.l, lat1, lon1, azi1, s12, geod.All)
+}
+
+func (c *core) inverseLine(l *coreLine, lat1, lon1, lat2, lon2 float64) {
+	c.g.InverseLine(&l.l, lat1, lon1, lat2, lon2, geod.All)
+}
+
+func (l *coreLine) genPosition(flags int, s12a12 float64, caps Caps,
+) DirectResult {
+	r := nanDirect
+	l.l.GenPosition(flags, s12a12, out(caps, Latitude, &r.Point.Lat),
+		out(caps, Longitude, &r.Point.Lon), out(caps, Azimuth, &r.Azi2),
+		nil, nil, nil, nil, nil)
+	return r
+}
+
+func (l *coreLine) position(s12 float64, caps Caps) DirectResult {
+	return l.genPosition(geod.NoFlags, s12, caps)
+}
+
+func (l *coreLine) arcPosition(a12 float64, caps Caps) DirectResult {
+	return l.genPosition(geod.ArcMode, a12, caps)
+}
+
+func (l *coreLine) scales(s12 float64) (m12, M12, M21 float64) {
+	l.l.GenPosition(geod.NoFlags, s12, nil, nil, nil, nil, &m12, &M12, &M21,
+		nil)
+	return m12, M12, M21
+}
+
+func (l *coreLine) lat1() float64     { return l.l.Lat1 }
+func (l *coreLine) lon1() float64     { return l.l.Lon1 }
+func (l *coreLine) azi1() float64     { return l.l.Azi1 }
+func (l *coreLine) distance() float64 { return l.l.S13 }
+func (l *coreLine) arc() float64      { return l.l.A13 }
+
+// corePolygon is the backend of a Polygon.
+type corePolygon struct {
+	c       *core
+	p, prev geod.Polygon // prev is p before the last change, see save
+}
+
+func (c *core) polygonInit(p *corePolygon, polyline bool) {
+	p.p.Init(polyline)
+	p.c = c
+}
+
+func (p *corePolygon) addPoint(lat, lon float64) {
+	p.p.AddPoint(&p.c.g, lat, lon)
+}
+
+func (p *corePolygon) addEdge(azi, s float64) {
+	p.p.AddEdge(&p.c.g, azi, s)
+}
+
+func (p *corePolygon) compute(reverse, sign bool) PolygonResult {
+	var r PolygonResult
+	r.NumPoints = p.p.Compute(&p.c.g, reverse, sign, &r.Area, &r.Perimeter)
+	return r
+}
+
+func (p *corePolygon) testPoint(lat, lon float64, reverse, sign bool,
+) PolygonResult {
+	var r PolygonResult
+	r.NumPoints = p.p.TestPoint(&p.c.g, lat, lon, reverse, sign,
+		&r.Area, &r.Perimeter)
+	return r
+}
+
+func (p *corePolygon) testEdge(azi, s float64, reverse, sign bool,
+) PolygonResult {
+	var r PolygonResult
+	r.NumPoints = p.p.TestEdge(&p.c.g, azi, s, reverse, sign,
+		&r.Area, &r.Perimeter)
+	return r
+}
+
+// current returns the last point added, or NaN if there is none.
+func (p *corePolygon) current() (lat, lon float64) {
+	return p.p.Lat, p.p.Lon
+}
+
+func (p *corePolygon) num() int {
+	return p.p.Num
+}
+
+func (p *corePolygon) polyline() bool {
+	return p.p.Polyline
+}
+
+func (p *corePolygon) save() {
+	p.prev = p.p
+}
+
+func (p *corePolygon) restore() {
+	p.p = p.prev
+}
+
+func (p *corePolygon) clear() {
+	p.p.Clear()
+}
+
+func (p *corePolygon) free() {
+	p.c = nil
+}
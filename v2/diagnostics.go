@@ -0,0 +1,116 @@
+package geodesic
+
+import (
+	"fmt"
+
+	"github.com/tidwall/geodesic_cgo/v2/internal/geod"
+)
+
+// InverseMethod is the way that the inverse problem was solved, see
+// Ellipsoid.InverseDiagnostics.
+type InverseMethod int
+
+const (
+	// InverseMeridian is a geodesic along a meridian, or over a pole,
+	// which is solved directly.
+	InverseMeridian InverseMethod = geod.MethodMeridian
+	// InverseEquator is a geodesic along the equator, which is solved
+	// directly.
+	InverseEquator InverseMethod = geod.MethodEquator
+	// InverseShort is a short geodesic, solved directly from the starting
+	// guess of the iteration, which is accurate enough for it.
+	InverseShort InverseMethod = geod.MethodShort
+	// InverseIterative is a geodesic solved by Newton's method on the
+	// azimuth at its start.
+	InverseIterative InverseMethod = geod.MethodIterative
+)
+
+func (m InverseMethod) String() string {
+	switch m {
+	case InverseMeridian:
+		return "meridian"
+	case InverseEquator:
+		return "equator"
+	case InverseShort:
+		return "short"
+	case InverseIterative:
+		return "iterative"
+	}
+	return "unknown"
+}
+
+// InverseDiagnostics is how the solver arrived at the solution of an
+// inverse problem.
+type InverseDiagnostics struct {
+	// Method is the way that the problem was solved. Only InverseIterative
+	// uses the other fields.
+	Method InverseMethod
+	// Iterations is the number of steps of the iteration.
+	Iterations int
+	// Bisections is the number of steps that fell back to bisecting the
+	// range of azimuths known to hold the solution, because Newton's
+	// method had left it.
+	Bisections int
+	// Converged is whether the iteration met its tolerance, or bisected
+	// the range down to nothing, before its limit of steps.
+	Converged bool
+}
+
+// Err returns ErrNotConverged, wrapped with the number of steps, if the
+// iteration did not converge, or nil.
+func (d InverseDiagnostics) Err() error {
+	if d.Converged {
+		return nil
+	}
+	return fmt.Errorf("%w after %d steps", ErrNotConverged, d.Iterations)
+}
+
+// InverseDiagnostics solves the inverse geodesic problem, as
+// Ellipsoid.Inverse, and also returns how it was solved.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+// Param caps selects the quantities to compute, Distance and Azimuth; the
+// arc length is not computed and is NaN.
+//
+// The problem is solved by the pure Go port of the C routines, which
+// follows them step for step, so the diagnostics are those of the C
+// routines, and the results agree with Ellipsoid.Inverse to the last bit
+// or so. For the Earth, the iteration takes 3 or 4 steps on average and at
+// most about 20, and never bisects. Bisections happen on eccentric
+// ellipsoids, with flattenings of a few tenths or prolate ones, for nearly
+// antipodal points, where the solution is ill-conditioned.
+//
+// A solution that did not converge is the best found in the limit of
+// steps and may be off by a lot more than the usual nanometers, see
+// InverseDiagnostics.Err; it is worth logging, and checking with
+// Options.Exact if it matters. The diagnostics of an exact ellipsoid are
+// those of the series routines, not of the exact routines that its
+// Inverse uses.
+func (e *Ellipsoid) InverseDiagnostics(p1, p2 LatLng, caps Caps,
+) (InverseResult, InverseDiagnostics, error) {
+	if e.err != nil {
+		return nanInverse, InverseDiagnostics{}, e.err
+	}
+	p1, err := e.point(p1)
+	if err != nil {
+		return nanInverse, InverseDiagnostics{}, err
+	}
+	p2, err = e.point(p2)
+	if err != nil {
+		return nanInverse, InverseDiagnostics{}, err
+	}
+	var g geod.Geodesic
+	g.Init(e.Radius(), e.Flattening())
+	r := nanInverse
+	st := g.InverseWithStats(p1.Lat, p1.Lon, p2.Lat, p2.Lon,
+		out(caps, Distance, &r.Distance), out(caps, Azimuth, &r.Azi1),
+		out(caps, Azimuth, &r.Azi2))
+	if caps&Azimuth != 0 {
+		e.opts.Azimuths.apply(&r.Azi1)
+		e.opts.Azimuths.apply(&r.Azi2)
+	}
+	return r, InverseDiagnostics{Method: InverseMethod(st.Method),
+		Iterations: st.Iterations, Bisections: st.Bisections,
+		Converged: st.Converged}, nil
+}
@@ -0,0 +1,39 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestInverseDiagnostics(t *testing.T) {
+	// The results are those of Inverse.
+	p1, p2 := LatLng{Lat: 40.64, Lon: -73.78}, LatLng{Lat: 1.36, Lon: 103.99}
+	want, _ := WGS84.Inverse(p1, p2, Distance|Azimuth)
+	r, d, err := WGS84.InverseDiagnostics(p1, p2, Distance|Azimuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(math.Abs(r.Distance-want.Distance) < 1e-6) ||
+		!(math.Abs(r.Azi1-want.Azi1) < 1e-9) || !math.IsNaN(r.Arc) {
+		t.Fatalf("expected %v, got %v", want, r)
+	}
+	if d.Method != InverseIterative || !d.Converged || d.Iterations == 0 ||
+		d.Err() != nil {
+		t.Fatalf("expected a converged iteration, got %v", d)
+	}
+	if _, d, _ := WGS84.InverseDiagnostics(LatLng{Lat: 10, Lon: 5},
+		LatLng{Lat: 80, Lon: 5}, 0); d.Method != InverseMeridian {
+		t.Fatalf("expected %v, got %v", InverseMeridian, d.Method)
+	}
+	if _, _, err := WGS84.InverseDiagnostics(LatLng{Lat: 91}, p2, All); err != ErrInvalidLatitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLatitude, err)
+	}
+	if s := InverseShort.String(); s != "short" {
+		t.Fatalf("expected 'short', got '%s'", s)
+	}
+	d = InverseDiagnostics{Method: InverseIterative, Iterations: 83}
+	if err := d.Err(); !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("expected ErrNotConverged, got %v", err)
+	}
+}
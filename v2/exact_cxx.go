@@ -0,0 +1,180 @@
+//go:build cgo && geographiclib_exact
+
+package geodesic
+
+/*
+#cgo LDFLAGS: -lGeographicLib -lstdc++
+#include "geodesic.h"
+#include "geodesic_exact.h"
+*/
+import "C"
+
+import (
+	"math"
+	"runtime"
+)
+
+// exactGeodesic owns a GeographicLib::GeodesicExact object.
+type exactGeodesic struct {
+	g *C.struct_geod_exact
+}
+
+// newExactGeodesic returns the exact routines for an ellipsoid, or
+// ErrInvalidEllipsoid for a radius or flattening that is rejected by
+// GeographicLib.
+func newExactGeodesic(a, f float64) (*exactGeodesic, error) {
+	x := &exactGeodesic{g: C.geod_exact_new(C.double(a), C.double(f))}
+	if x.g == nil {
+		return nil, ErrInvalidEllipsoid
+	}
+	runtime.SetFinalizer(x, func(x *exactGeodesic) {
+		C.geod_exact_free(x.g)
+	})
+	return x, nil
+}
+
+// cout returns x if caps has c, or nil to leave the quantity uncomputed.
+func cout(caps, c Caps, x *C.double) *C.double {
+	if caps&c == 0 {
+		return nil
+	}
+	return x
+}
+
+// inverse solves the inverse problem. The caps select what is computed;
+// the arc length is always set.
+func (x *exactGeodesic) inverse(lat1, lon1, lat2, lon2 float64, caps Caps,
+) InverseResult {
+	s12, azi1, azi2 := C.double(math.NaN()), C.double(math.NaN()),
+		C.double(math.NaN())
+	a12 := C.geod_exact_inverse(x.g,
+		C.double(lat1), C.double(lon1), C.double(lat2), C.double(lon2),
+		cout(caps, Distance, &s12), cout(caps, Azimuth, &azi1),
+		cout(caps, Azimuth, &azi2))
+	runtime.KeepAlive(x)
+	return InverseResult{float64(s12), float64(azi1), float64(azi2),
+		float64(a12)}
+}
+
+func (x *exactGeodesic) direct(lat1, lon1, azi1, s12 float64, caps Caps,
+) DirectResult {
+	lat2, lon2, azi2 := C.double(math.NaN()), C.double(math.NaN()),
+		C.double(math.NaN())
+	C.geod_exact_direct(x.g,
+		C.double(lat1), C.double(lon1), C.double(azi1), C.double(s12),
+		cout(caps, Latitude, &lat2), cout(caps, Longitude, &lon2),
+		cout(caps, Azimuth, &azi2))
+	runtime.KeepAlive(x)
+	return DirectResult{LatLng{float64(lat2), float64(lon2)}, float64(azi2)}
+}
+
+// scales returns the reduced length m12 (meters) and the geodesic scales
+// M12 and M21 of the direct problem.
+func (x *exactGeodesic) scales(lat1, lon1, azi1, s12 float64,
+) (m12, M12, M21 float64) {
+	var cm12, cM12, cM21 C.double
+	C.geod_exact_scales(x.g,
+		C.double(lat1), C.double(lon1), C.double(azi1), C.double(s12),
+		&cm12, &cM12, &cM21)
+	runtime.KeepAlive(x)
+	return float64(cm12), float64(cM12), float64(cM21)
+}
+
+// arcDirect solves the direct problem in terms of the arc length a12
+// (degrees).
+func (x *exactGeodesic) arcDirect(lat1, lon1, azi1, a12 float64, caps Caps,
+) DirectResult {
+	lat2, lon2, azi2 := C.double(math.NaN()), C.double(math.NaN()),
+		C.double(math.NaN())
+	C.geod_exact_arcdirect(x.g,
+		C.double(lat1), C.double(lon1), C.double(azi1), C.double(a12),
+		cout(caps, Latitude, &lat2), cout(caps, Longitude, &lon2),
+		cout(caps, Azimuth, &azi2))
+	runtime.KeepAlive(x)
+	return DirectResult{LatLng{float64(lat2), float64(lon2)}, float64(azi2)}
+}
+
+// exactPolygon owns a GeographicLib::PolygonAreaExact object. It holds on
+// to its exactGeodesic, which must outlive it.
+type exactPolygon struct {
+	x *exactGeodesic
+	p *C.struct_geod_exact_polygon
+}
+
+func (x *exactGeodesic) polygon(polyline bool) *exactPolygon {
+	p := &exactPolygon{x: x,
+		p: C.geod_exact_polygon_new(x.g, cbool(polyline))}
+	if p.p == nil {
+		panic("geodesic: out of memory")
+	}
+	runtime.SetFinalizer(p, (*exactPolygon).free)
+	return p
+}
+
+// free releases the GeographicLib::PolygonAreaExact object. It is called
+// by Polygon.Close, or by the finalizer if the polygon is never closed.
+func (p *exactPolygon) free() {
+	if p.p != nil {
+		C.geod_exact_polygon_free(p.p)
+		p.p = nil
+	}
+	runtime.SetFinalizer(p, nil)
+}
+
+func (p *exactPolygon) addPoint(lat, lon float64) {
+	C.geod_exact_polygon_addpoint(p.p, C.double(lat), C.double(lon))
+	runtime.KeepAlive(p)
+}
+
+func (p *exactPolygon) addEdge(azi, s float64) {
+	C.geod_exact_polygon_addedge(p.p, C.double(azi), C.double(s))
+	runtime.KeepAlive(p)
+}
+
+func (p *exactPolygon) compute(reverse, sign bool) PolygonResult {
+	var area, perimeter C.double
+	n := C.geod_exact_polygon_compute(p.p, cbool(reverse), cbool(sign),
+		&area, &perimeter)
+	runtime.KeepAlive(p)
+	return PolygonResult{float64(area), float64(perimeter), int(n)}
+}
+
+func (p *exactPolygon) testPoint(lat, lon float64, reverse, sign bool,
+) PolygonResult {
+	var area, perimeter C.double
+	n := C.geod_exact_polygon_testpoint(p.p, C.double(lat), C.double(lon),
+		cbool(reverse), cbool(sign), &area, &perimeter)
+	runtime.KeepAlive(p)
+	return PolygonResult{float64(area), float64(perimeter), int(n)}
+}
+
+func (p *exactPolygon) testEdge(azi, s float64, reverse, sign bool,
+) PolygonResult {
+	var area, perimeter C.double
+	n := C.geod_exact_polygon_testedge(p.p, C.double(azi), C.double(s),
+		cbool(reverse), cbool(sign), &area, &perimeter)
+	runtime.KeepAlive(p)
+	return PolygonResult{float64(area), float64(perimeter), int(n)}
+}
+
+func (p *exactPolygon) clear() {
+	C.geod_exact_polygon_clear(p.p)
+	runtime.KeepAlive(p)
+}
+
+func (p *exactPolygon) current() (lat, lon float64) {
+	var clat, clon C.double
+	C.geod_exact_polygon_current(p.p, &clat, &clon)
+	runtime.KeepAlive(p)
+	return float64(clat), float64(clon)
+}
+
+func (p *exactPolygon) save() {
+	C.geod_exact_polygon_save(p.p)
+	runtime.KeepAlive(p)
+}
+
+func (p *exactPolygon) restore() {
+	C.geod_exact_polygon_restore(p.p)
+	runtime.KeepAlive(p)
+}
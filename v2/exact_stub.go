@@ -0,0 +1,67 @@
+//go:build !(cgo && geographiclib_exact)
+
+package geodesic
+
+// newExactGeodesic returns ErrExactUnavailable, as this build does not
+// have the geographiclib_exact tag, or cgo.
+func newExactGeodesic(a, f float64) (*exactGeodesic, error) {
+	return nil, ErrExactUnavailable
+}
+
+// exactGeodesic and exactPolygon are never created in this build. They
+// keep the cgo backend free of build tags.
+type exactGeodesic struct{}
+
+type exactPolygon struct{}
+
+func (*exactGeodesic) inverse(lat1, lon1, lat2, lon2 float64, caps Caps,
+) InverseResult {
+	panic("unreachable")
+}
+
+func (*exactGeodesic) direct(lat1, lon1, azi1, s12 float64, caps Caps,
+) DirectResult {
+	panic("unreachable")
+}
+
+func (*exactGeodesic) scales(lat1, lon1, azi1, s12 float64,
+) (m12, M12, M21 float64) {
+	panic("unreachable")
+}
+
+func (*exactGeodesic) arcDirect(lat1, lon1, azi1, a12 float64, caps Caps,
+) DirectResult {
+	panic("unreachable")
+}
+
+func (*exactGeodesic) polygon(polyline bool) *exactPolygon {
+	panic("unreachable")
+}
+
+func (*exactPolygon) addPoint(lat, lon float64) { panic("unreachable") }
+
+func (*exactPolygon) addEdge(azi, s float64) { panic("unreachable") }
+
+func (*exactPolygon) compute(reverse, sign bool) PolygonResult {
+	panic("unreachable")
+}
+
+func (*exactPolygon) testPoint(lat, lon float64, reverse, sign bool,
+) PolygonResult {
+	panic("unreachable")
+}
+
+func (*exactPolygon) testEdge(azi, s float64, reverse, sign bool,
+) PolygonResult {
+	panic("unreachable")
+}
+
+func (*exactPolygon) clear() { panic("unreachable") }
+
+func (*exactPolygon) save() { panic("unreachable") }
+
+func (*exactPolygon) current() (lat, lon float64) { panic("unreachable") }
+
+func (*exactPolygon) restore() { panic("unreachable") }
+
+func (*exactPolygon) free() { panic("unreachable") }
@@ -0,0 +1,260 @@
+//go:build cgo
+
+package geodesic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo/v2/internal/geod"
+)
+
+// The fuzz targets in this file check the cgo backend against the pure Go
+// port in internal/geod, which is what builds without cgo use. The seed
+// corpus runs with every "go test"; use "go test -fuzz FuzzInverse" and
+// friends to search further.
+
+// hardCases are inverse problems that are known to stress the solver.
+var hardCases = [][4]float64{
+	// near antipodal, from the GeographicLib change log
+	{48.522876735459, 0, -48.52287673545898293, 179.599720456223079643},
+	{56.320923501171, 0, -56.320923501171, 179.664747671772880215},
+	{52.784459512564, 0, -52.784459512563990912, 179.634407464943777557},
+	{0, 0, 0.5, 179.5},
+	{0, 0, 0, 180},
+	{0.1, 0, -0.1, 179.9},
+	{-30, 0, 30, 179.99},
+	// polar and meridional
+	{90, 0, -90, 0},
+	{90, 0, 90, 180},
+	{89.999999, 0, 89.999999, 180},
+	{-89.9999999, 10, 89.9999999, -170},
+	{20.001, 0, 20.001, 0},
+	// equatorial
+	{0, 0, 0, 90},
+	{0, -179.5, 0, 179.5},
+	// tiny separations
+	{45, 45, 45, 45 + 1e-12},
+	{-33.9, 151.2, -33.9 + 1e-10, 151.2},
+}
+
+func fuzzGeodesic() *geod.Geodesic {
+	g := new(geod.Geodesic)
+	g.Init(WGS84.Radius(), WGS84.Flattening())
+	return g
+}
+
+func finite(x ...float64) bool {
+	for _, x := range x {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzLat folds any finite value into [-90, 90].
+func fuzzLat(lat float64) float64 {
+	return math.Remainder(lat, 180)
+}
+
+func sameFloat(x, y, tol float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) == math.IsNaN(y)
+	}
+	return math.Abs(x-y) <= tol
+}
+
+func sameAngle(x, y, tol float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) == math.IsNaN(y)
+	}
+	return math.Abs(math.Remainder(x-y, 360)) <= tol
+}
+
+// totalArea returns the area of the ellipsoid (meters-squared).
+func totalArea(e *Ellipsoid) float64 {
+	a, f := e.Radius(), e.Flattening()
+	b := a * (1 - f)
+	ecc := math.Sqrt(f * (2 - f))
+	if ecc == 0 {
+		return 4 * math.Pi * a * a
+	}
+	return 2 * math.Pi * (a*a + b*b*math.Atanh(ecc)/ecc)
+}
+
+// distTol is the allowed disagreement in meters for a distance s.
+func distTol(s float64) float64 {
+	return 1e-6 + 1e-12*math.Abs(s)
+}
+
+func FuzzInverse(f *testing.F) {
+	for _, c := range hardCases {
+		f.Add(c[0], c[1], c[2], c[3])
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 64; i++ {
+		f.Add(rng.Float64()*180-90, rng.Float64()*360-180,
+			rng.Float64()*180-90, rng.Float64()*360-180)
+	}
+	g := fuzzGeodesic()
+	f.Fuzz(func(t *testing.T, lat1, lon1, lat2, lon2 float64) {
+		if !finite(lat1, lon1, lat2, lon2) {
+			t.Skip()
+		}
+		lat1, lat2 = fuzzLat(lat1), fuzzLat(lat2)
+		r, err := WGS84.Inverse(LatLng{lat1, lon1}, LatLng{lat2, lon2}, All)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cs12, cazi1, cazi2 := r.Distance, r.Azi1, r.Azi2
+		var gs12, gazi1, gazi2 float64
+		g.Inverse(lat1, lon1, lat2, lon2, &gs12, &gazi1, &gazi2)
+		if !sameFloat(cs12, gs12, distTol(cs12)) {
+			t.Fatalf("s12: expected %v, got %v", cs12, gs12)
+		}
+		if sameAngle(cazi1, gazi1, 1e-9) && sameAngle(cazi2, gazi2, 1e-9) {
+			return
+		}
+		// Antipodal points have many shortest paths, so a differing azimuth
+		// is fine as long as it still leads to point 2.
+		d, _ := WGS84.Direct(LatLng{lat1, lon1}, gazi1, gs12, All)
+		r, _ = WGS84.Inverse(d.Point, LatLng{lat2, lon2}, Distance)
+		if miss := r.Distance; !(miss <= 1e-6) {
+			t.Fatalf("azi1, azi2: expected %v, %v, got %v, %v (misses by %vm)",
+				cazi1, cazi2, gazi1, gazi2, miss)
+		}
+	})
+}
+
+func FuzzDirect(f *testing.F) {
+	for _, c := range hardCases {
+		r, _ := WGS84.Inverse(LatLng{c[0], c[1]}, LatLng{c[2], c[3]}, All)
+		f.Add(c[0], c[1], r.Azi1, r.Distance)
+	}
+	f.Add(90.0, 0.0, 180.0, 20003931.4586)
+	f.Add(-90.0, 0.0, 0.0, 1e7)
+	f.Add(0.0, 0.0, 90.0, 4e7)
+	f.Add(10.0, 20.0, 30.0, -5e6)
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 64; i++ {
+		f.Add(rng.Float64()*180-90, rng.Float64()*360-180,
+			rng.Float64()*360-180, rng.Float64()*4e7-2e7)
+	}
+	g := fuzzGeodesic()
+	f.Fuzz(func(t *testing.T, lat1, lon1, azi1, s12 float64) {
+		if !finite(lat1, lon1, azi1, s12) || math.Abs(s12) > 1e9 {
+			t.Skip()
+		}
+		lat1 = fuzzLat(lat1)
+		r, err := WGS84.Direct(LatLng{lat1, lon1}, azi1, s12, All)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clat2, clon2, cazi2 := r.Point.Lat, r.Point.Lon, r.Azi2
+		var glat2, glon2, gazi2 float64
+		g.Direct(lat1, lon1, azi1, s12, &glat2, &glon2, &gazi2)
+		// 1e-9 degrees is about 0.1 mm on the ground.
+		tol := 1e-9 + 1e-15*math.Abs(s12)
+		if !sameFloat(clat2, glat2, tol) ||
+			!sameAngle(clon2, glon2, tol) ||
+			!sameAngle(cazi2, gazi2, tol) {
+			t.Fatalf("expected '%v, %v, %v', got '%v, %v, %v'",
+				clat2, clon2, cazi2, glat2, glon2, gazi2)
+		}
+	})
+}
+
+func FuzzPolygon(f *testing.F) {
+	f.Add(0.0, 0.0, 0.0, 90.0, 90.0, 0.0)
+	f.Add(89.0, 0.0, 89.0, 120.0, 89.0, -120.0)
+	f.Add(-10.0, 179.0, 10.0, -179.0, 0.0, 170.0)
+	f.Add(48.522876735459, 0.0, -48.52287673545898293, 179.599720456223079643,
+		0.0, 90.0)
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 32; i++ {
+		f.Add(rng.Float64()*180-90, rng.Float64()*360-180,
+			rng.Float64()*180-90, rng.Float64()*360-180,
+			rng.Float64()*180-90, rng.Float64()*360-180)
+	}
+	g := fuzzGeodesic()
+	f.Fuzz(func(t *testing.T, lat1, lon1, lat2, lon2, lat3, lon3 float64) {
+		if !finite(lat1, lon1, lat2, lon2, lat3, lon3) {
+			t.Skip()
+		}
+		lats := []float64{fuzzLat(lat1), fuzzLat(lat2), fuzzLat(lat3)}
+		lons := []float64{lon1, lon2, lon3}
+		for i := range lats {
+			j := (i + 1) % len(lats)
+			r, _ := WGS84.Inverse(LatLng{lats[i], lons[i]},
+				LatLng{lats[j], lons[j]}, Azimuth)
+			var gazi1 float64
+			g.Inverse(lats[i], lons[i], lats[j], lons[j], nil, &gazi1, nil)
+			if !sameAngle(r.Azi1, gazi1, 1e-11) {
+				// A nearly antipodal edge, on which the azimuth is ill
+				// conditioned or the backends picked different shortest
+				// paths. FuzzInverse vets those, and the areas then
+				// legitimately differ.
+				t.Skip()
+			}
+		}
+		cp := WGS84.NewPolygon(false)
+		var gp geod.Polygon
+		gp.Init(false)
+		for i := range lats {
+			cp.AddPoint(LatLng{lats[i], lons[i]})
+			gp.AddPoint(g, lats[i], lons[i])
+		}
+		cr := cp.Result(false, true)
+		carea, cperim := cr.Area, cr.Perimeter
+		var garea, gperim float64
+		gp.Compute(g, false, true, &garea, &gperim)
+		if !sameFloat(cperim, gperim, distTol(cperim)*3) {
+			t.Fatalf("perimeter: expected %v, got %v", cperim, gperim)
+		}
+		// The edge contributions are up to the size of the earth and are
+		// summed differently by the backends. The area of a degenerate
+		// triangle is also only defined modulo the area of the earth, and
+		// rounding may land it on either side.
+		area0 := totalArea(WGS84)
+		darea := math.Remainder(carea-garea, area0)
+		if !(math.Abs(darea) <= 1e-12*area0) {
+			t.Fatalf("area: expected %v, got %v", carea, garea)
+		}
+	})
+}
+
+func TestPolygonAccumulator(t *testing.T) {
+	// A polygon with many small edges, for which the double-double
+	// accumulation of the C library matters, must come out the same from
+	// the port, but for the round off in the distance of each edge.
+	g := fuzzGeodesic()
+	cp := WGS84.NewPolygon(false)
+	var gp geod.Polygon
+	gp.Init(false)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		lat := 60 * math.Sin(2*math.Pi*float64(i)/n)
+		lon := 100 * math.Cos(2*math.Pi*float64(i)/n)
+		cp.AddPoint(LatLng{lat, lon})
+		gp.AddPoint(g, lat, lon)
+	}
+	check := func(cr PolygonResult, garea, gperim float64) {
+		t.Helper()
+		if !sameFloat(cr.Area, garea, 1e-15*cr.Area) ||
+			!sameFloat(cr.Perimeter, gperim, distTol(cr.Perimeter)) {
+			t.Fatalf("expected '%v, %v', got '%v, %v'", cr.Area, cr.Perimeter,
+				garea, gperim)
+		}
+	}
+	var garea, gperim float64
+	gp.Compute(g, false, true, &garea, &gperim)
+	check(cp.Result(false, true), garea, gperim)
+	gp.TestPoint(g, 0, 0, false, true, &garea, &gperim)
+	cr, _ := cp.TestPoint(LatLng{0, 0}, false, true)
+	check(cr, garea, gperim)
+	gp.TestEdge(g, 45, 1e5, false, true, &garea, &gperim)
+	cr, _ = cp.TestEdge(45, 1e5, false, true)
+	check(cr, garea, gperim)
+}
@@ -1,3 +1,5 @@
+//go:build !system_geographiclib
+
 /**
  * \file geodesic.c
  * \brief Implementation of the geodesic routines in C
@@ -34,7 +36,15 @@
 #define nullptr 0
 #endif
 
+#if !defined(GEOGRAPHICLIB_GEODESIC_ORDER)
 #define GEOGRAPHICLIB_GEODESIC_ORDER 6
+#endif
+/* The coefficient tables are for the highest order, nT, and a lower order
+ * uses the leading terms of each series from them. */
+#define nT    6
+#if GEOGRAPHICLIB_GEODESIC_ORDER < 3 || GEOGRAPHICLIB_GEODESIC_ORDER > nT
+#error "GEOGRAPHICLIB_GEODESIC_ORDER must be in [3,6]"
+#endif
 #define nA1   GEOGRAPHICLIB_GEODESIC_ORDER
 #define nC1   GEOGRAPHICLIB_GEODESIC_ORDER
 #define nC1p  GEOGRAPHICLIB_GEODESIC_ORDER
@@ -1650,8 +1660,8 @@ real A1m1f(real eps)  {
     /* (1-eps)*A1-1, polynomial in eps2 of order 3 */
     1, 4, 64, 0, 256,
   };
-  int m = nA1/2;
-  real t = polyval(m, coeff, sq(eps)) / coeff[m + 1];
+  int m = nA1/2, mt = nT/2;
+  real t = polyval(m, coeff + mt - m, sq(eps)) / coeff[mt + 1];
   return (t + eps) / (1 - eps);
 }
 
@@ -1677,8 +1687,9 @@ void C1f(real eps, real c[])  {
   int o = 0, l;
   for (l = 1; l <= nC1; ++l) {  /* l is index of C1p[l] */
     int m = (nC1 - l) / 2;      /* order of polynomial in eps^2 */
-    c[l] = d * polyval(m, coeff + o, eps2) / coeff[o + m + 1];
-    o += m + 2;
+    int mt = (nT - l) / 2;      /* and in the table */
+    c[l] = d * polyval(m, coeff + o + mt - m, eps2) / coeff[o + mt + 1];
+    o += mt + 2;
     d *= eps;
   }
 }
@@ -1705,8 +1716,9 @@ void C1pf(real eps, real c[])  {
   int o = 0, l;
   for (l = 1; l <= nC1p; ++l) { /* l is index of C1p[l] */
     int m = (nC1p - l) / 2;     /* order of polynomial in eps^2 */
-    c[l] = d * polyval(m, coeff + o, eps2) / coeff[o + m + 1];
-    o += m + 2;
+    int mt = (nT - l) / 2;      /* and in the table */
+    c[l] = d * polyval(m, coeff + o + mt - m, eps2) / coeff[o + mt + 1];
+    o += mt + 2;
     d *= eps;
   }
 }
@@ -1717,8 +1729,8 @@ real A2m1f(real eps)  {
     /* (eps+1)*A2-1, polynomial in eps2 of order 3 */
     -11, -28, -192, 0, 256,
   };
-  int m = nA2/2;
-  real t = polyval(m, coeff, sq(eps)) / coeff[m + 1];
+  int m = nA2/2, mt = nT/2;
+  real t = polyval(m, coeff + mt - m, sq(eps)) / coeff[mt + 1];
   return (t - eps) / (1 + eps);
 }
 
@@ -1744,8 +1756,9 @@ void C2f(real eps, real c[])  {
   int o = 0, l;
   for (l = 1; l <= nC2; ++l) { /* l is index of C2[l] */
     int m = (nC2 - l) / 2;     /* order of polynomial in eps^2 */
-    c[l] = d * polyval(m, coeff + o, eps2) / coeff[o + m + 1];
-    o += m + 2;
+    int mt = (nT - l) / 2;     /* and in the table */
+    c[l] = d * polyval(m, coeff + o + mt - m, eps2) / coeff[o + mt + 1];
+    o += mt + 2;
     d *= eps;
   }
 }
@@ -1767,10 +1780,12 @@ void A3coeff(struct geod_geodesic* g) {
     1, 1,
   };
   int o = 0, k = 0, j;
-  for (j = nA3 - 1; j >= 0; --j) {             /* coeff of eps^j */
+  for (j = nT - 1; j >= 0; --j) {              /* coeff of eps^j */
     int m = nA3 - j - 1 < j ? nA3 - j - 1 : j; /* order of polynomial in n */
-    g->A3x[k++] = polyval(m, coeff + o, g->n) / coeff[o + m + 1];
-    o += m + 2;
+    int mt = nT - j - 1 < j ? nT - j - 1 : j;  /* and in the table */
+    if (j < nA3)
+      g->A3x[k++] = polyval(m, coeff + o + mt - m, g->n) / coeff[o + mt + 1];
+    o += mt + 2;
   }
 }
 
@@ -1809,11 +1824,13 @@ void C3coeff(struct geod_geodesic* g) {
     21, 2560,
   };
   int o = 0, k = 0, l, j;
-  for (l = 1; l < nC3; ++l) {                    /* l is index of C3[l] */
-    for (j = nC3 - 1; j >= l; --j) {             /* coeff of eps^j */
+  for (l = 1; l < nT; ++l) {                     /* l is index of C3[l] */
+    for (j = nT - 1; j >= l; --j) {              /* coeff of eps^j */
       int m = nC3 - j - 1 < j ? nC3 - j - 1 : j; /* order of polynomial in n */
-      g->C3x[k++] = polyval(m, coeff + o, g->n) / coeff[o + m + 1];
-      o += m + 2;
+      int mt = nT - j - 1 < j ? nT - j - 1 : j;  /* and in the table */
+      if (j < nC3)
+        g->C3x[k++] = polyval(m, coeff + o + mt - m, g->n) / coeff[o + mt + 1];
+      o += mt + 2;
     }
   }
 }
@@ -1865,11 +1882,13 @@ void C4coeff(struct geod_geodesic* g) {
     128, 99099,
   };
   int o = 0, k = 0, l, j;
-  for (l = 0; l < nC4; ++l) {        /* l is index of C4[l] */
-    for (j = nC4 - 1; j >= l; --j) { /* coeff of eps^j */
+  for (l = 0; l < nT; ++l) {         /* l is index of C4[l] */
+    for (j = nT - 1; j >= l; --j) {  /* coeff of eps^j */
       int m = nC4 - j - 1;           /* order of polynomial in n */
-      g->C4x[k++] = polyval(m, coeff + o, g->n) / coeff[o + m + 1];
-      o += m + 2;
+      int mt = nT - j - 1;           /* and in the table */
+      if (j < nC4)
+        g->C4x[k++] = polyval(m, coeff + o + mt - m, g->n) / coeff[o + mt + 1];
+      o += mt + 2;
     }
   }
 }
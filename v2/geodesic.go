@@ -0,0 +1,338 @@
+// package geodesic
+//
+// Version 2 of the API for the geodesic routines in Go. It returns errors
+// for invalid input, returns results in structs rather than through
+// out-pointer parameters, takes an explicit selection of the quantities to
+// compute, and fixes an ellipsoid's options when it is created.
+//
+// This package holds the implementation: the bundled GeographicLib C
+// library, the pure Go port of it that builds without cgo use, and the
+// optional exact routines of GeographicLib C++. The original package,
+// github.com/tidwall/geodesic_cgo, is a thin wrapper around this one that
+// keeps its API unchanged for compatibility. Both are in the module
+// github.com/tidwall/geodesic_cgo, and are released together.
+//
+// This an implementation in Go of the geodesic algorithms described in
+//   - C. F. F. Karney, Algorithms for geodesics,
+//     J. Geodesy 87, 43--55 (2013);
+//     DOI: 10.1007/s00190-012-0578-z;
+//     addenda: https://geographiclib.sourceforge.io/geod-addenda.html;
+//     link: https://doi.org/10.1007/s00190-012-0578-z;
+//
+// Copyright (c) Charles Karney (2012-2021) <charles@karney.com> and licensed
+// under the MIT/X11 License.  For more information, see
+// https://geographiclib.sourceforge.io/
+//
+// Ported to Go by Joshua Baker <joshbaker77@gmail.com> and licensed
+// under the MIT License.
+package geodesic
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrInvalidRadius is returned for a radius that is not a positive
+	// finite number.
+	ErrInvalidRadius = errors.New("geodesic: invalid radius")
+	// ErrInvalidFlattening is returned for a flattening that is not a finite
+	// number less than one.
+	ErrInvalidFlattening = errors.New("geodesic: invalid flattening")
+	// ErrInvalidLatitude is returned for a latitude outside of [-90,+90],
+	// or farther outside of it than LatitudeClampTolerance for
+	// Options.Latitudes of LatitudeClamp.
+	ErrInvalidLatitude = errors.New("geodesic: invalid latitude")
+	// ErrInvalidLongitude is returned for a longitude that is not a finite
+	// number.
+	ErrInvalidLongitude = errors.New("geodesic: invalid longitude")
+	// ErrInvalidArgument is returned for other arguments that are not finite
+	// numbers, such as an azimuth or distance.
+	ErrInvalidArgument = errors.New("geodesic: invalid argument")
+	// ErrExactUnavailable is returned by New for Options.Exact when the
+	// package was built without cgo and the geographiclib_exact tag.
+	ErrExactUnavailable = errors.New(
+		"geodesic: exact routines not available, " +
+			"build with cgo and the geographiclib_exact tag")
+	// ErrInvalidEllipsoid is returned by New for Options.Exact for a radius
+	// or flattening that GeographicLib rejects, and by the methods of an
+	// ellipsoid that failed to initialize.
+	ErrInvalidEllipsoid = errors.New("geodesic: invalid ellipsoid")
+	// ErrNotConverged is returned by InverseDiagnostics.Err for a solution
+	// whose iteration did not converge.
+	ErrNotConverged = errors.New("geodesic: solution did not converge")
+)
+
+// LatLng is a point on the ellipsoid.
+type LatLng struct {
+	Lat float64 // latitude (degrees)
+	Lon float64 // longitude (degrees)
+}
+
+// AzimuthConvention selects the range of the azimuths in results.
+type AzimuthConvention int
+
+// Azimuth conventions.
+const (
+	AzimuthSigned   AzimuthConvention = iota // [-180,+180]
+	AzimuthUnsigned                          // [0,360)
+)
+
+// apply converts an azimuth to the convention.
+func (c AzimuthConvention) apply(azi *float64) {
+	if c == AzimuthUnsigned {
+		*azi = math.Mod(*azi, 360)
+		if *azi < 0 {
+			*azi += 360
+			if *azi == 360 {
+				// a tiny negative value rounded up
+				*azi = 0
+			}
+		}
+	}
+}
+
+// LatitudePolicy selects what is done with latitudes outside of [-90,+90].
+type LatitudePolicy int
+
+// Latitude policies.
+const (
+	LatitudeNaN   LatitudePolicy = iota // rejected with ErrInvalidLatitude
+	LatitudeClamp                       // clamped if slightly out of range
+)
+
+// LatitudeClampTolerance is how far outside of [-90,+90] a latitude may be
+// and still be clamped by the LatitudeClamp policy (degrees). It is about a
+// meter, which covers the rounding of converted or averaged positions and
+// the noise of a position fix near a pole.
+const LatitudeClampTolerance = 1e-5
+
+// fix returns the latitude to use for lat under the policy.
+func (p LatitudePolicy) fix(lat float64) float64 {
+	if p == LatitudeClamp && math.Abs(lat) > 90 &&
+		math.Abs(lat) <= 90+LatitudeClampTolerance {
+		return math.Copysign(90, lat)
+	}
+	return lat
+}
+
+// Caps selects the quantities to compute.
+type Caps uint
+
+const (
+	Latitude  Caps = 1 << iota // latitude of point 2
+	Longitude                  // longitude of point 2
+	Azimuth                    // azimuths
+	Distance                   // distance between the points
+	Arc                        // arc length between the points
+	All       = Latitude | Longitude | Azimuth | Distance | Arc
+)
+
+// Options are the options for an ellipsoid. They cannot be changed after
+// the ellipsoid is created.
+type Options struct {
+	// Azimuths is the range of the azimuths in results.
+	Azimuths AzimuthConvention
+	// Latitudes selects whether latitudes slightly outside of [-90,+90],
+	// such as those of noisy position fixes near a pole, are clamped to
+	// the range or rejected with ErrInvalidLatitude.
+	Latitudes LatitudePolicy
+	// Exact selects the exact routines of GeographicLib C++ instead of the
+	// series expansions of the C library, for very eccentric ellipsoids.
+	// The series are accurate to round off for |f| < 0.01, which covers the
+	// earth and the planets; the exact routines are accurate for any
+	// flattening, at a few times the cost.
+	//
+	// The exact routines need the GeographicLib C++ library, which is not
+	// bundled, and New returns ErrExactUnavailable unless the package is
+	// built with cgo and the geographiclib_exact tag:
+	//
+	//	go build -tags geographiclib_exact
+	//
+	// The library and its headers must be where the C++ compiler finds
+	// them, or be pointed to with CGO_CXXFLAGS and CGO_LDFLAGS.
+	Exact bool
+}
+
+// Ellipsoid is an immutable object for performing geodesic operations.
+type Ellipsoid struct {
+	c    core
+	opts Options
+	err  error // why Init failed, if it did
+}
+
+// WGS84 conforming ellispoid
+// https://en.wikipedia.org/wiki/World_Geodetic_System
+var WGS84, _ = New(6378137.0, float64(1.)/float64(298.257223563), nil)
+
+// New creates a geodesic ellipsoid object.
+// Param radius is the equatorial radius (meters).
+// Param flattening is the flattening.
+// Param opts are the options, or nil for the defaults.
+func New(radius, flattening float64, opts *Options) (*Ellipsoid, error) {
+	e := new(Ellipsoid)
+	if err := e.Init(radius, flattening, opts); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Init initializes an ellipsoid in place, as New does, such as one embedded
+// by value in another struct, without the allocation of New.
+//
+// Init is for making the ellipsoid, which must not be in use by another
+// goroutine while it is initialized. If Init returns an error, the methods
+// of the ellipsoid return it too, along with NaN results, and its Lines
+// and Polygons do the same.
+func (e *Ellipsoid) Init(radius, flattening float64, opts *Options) error {
+	e.opts = Options{}
+	if opts != nil {
+		e.opts = *opts
+	}
+	e.err = nil
+	switch {
+	case !(radius > 0) || math.IsInf(radius, 0):
+		e.err = ErrInvalidRadius
+	case !(flattening < 1) || math.IsInf(flattening, 0):
+		e.err = ErrInvalidFlattening
+	}
+	// The backend is initialized either way, so that Radius and Flattening
+	// report the values given.
+	err := e.c.init(radius, flattening, e.opts.Exact && e.err == nil)
+	if e.err == nil {
+		e.err = err
+	}
+	return e.err
+}
+
+// Radius returns the equatorial radius of the ellipsoid (meters).
+func (e *Ellipsoid) Radius() float64 { return e.c.radius() }
+
+// Flattening returns the flattening of the ellipsoid.
+func (e *Ellipsoid) Flattening() float64 { return e.c.flattening() }
+
+// Options returns the options of the ellipsoid.
+func (e *Ellipsoid) Options() Options { return e.opts }
+
+// point returns the point to use for p under the latitude policy, or an
+// error if it is out of range.
+func (e *Ellipsoid) point(p LatLng) (LatLng, error) {
+	return checkPoint(p, e.opts.Latitudes)
+}
+
+func checkPoint(p LatLng, lats LatitudePolicy) (LatLng, error) {
+	p.Lat = lats.fix(p.Lat)
+	if !(math.Abs(p.Lat) <= 90) {
+		return p, ErrInvalidLatitude
+	}
+	if math.IsNaN(p.Lon) || math.IsInf(p.Lon, 0) {
+		return p, ErrInvalidLongitude
+	}
+	return p, nil
+}
+
+// out returns x if caps has c, or nil to leave the quantity uncomputed.
+func out(caps, c Caps, x *float64) *float64 {
+	if caps&c == 0 {
+		return nil
+	}
+	return x
+}
+
+func checkFinite(x float64) error {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return ErrInvalidArgument
+	}
+	return nil
+}
+
+// InverseResult is the solution of the inverse geodesic problem.
+// Quantities that were not selected are NaN.
+type InverseResult struct {
+	Distance float64 // distance from point 1 to point 2 (meters)
+	Azi1     float64 // azimuth at point 1 (degrees)
+	Azi2     float64 // (forward) azimuth at point 2 (degrees)
+	Arc      float64 // arc length from point 1 to point 2 (degrees)
+}
+
+var nanInverse = InverseResult{math.NaN(), math.NaN(), math.NaN(), math.NaN()}
+
+// Inverse solves the inverse geodesic problem.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+// Param caps selects the quantities to compute, Distance, Azimuth, and Arc.
+//
+// The values of Azi1 and Azi2 are in the range [-180,+180], or [0,360) for
+// Options.Azimuths of AzimuthUnsigned. Leaving out Distance skips the
+// distance integral; the azimuths and arc length come out of the solution
+// itself.
+//
+// The solution to the inverse problem is found using Newton's method. If
+// this fails to converge (this is very unlikely in geodetic applications
+// but does occur for very eccentric ellipsoids), then the bisection method
+// is used to refine the solution, see Ellipsoid.InverseDiagnostics.
+func (e *Ellipsoid) Inverse(p1, p2 LatLng, caps Caps) (InverseResult, error) {
+	if e.err != nil {
+		return nanInverse, e.err
+	}
+	p1, err := e.point(p1)
+	if err != nil {
+		return nanInverse, err
+	}
+	p2, err = e.point(p2)
+	if err != nil {
+		return nanInverse, err
+	}
+	r := e.c.inverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, caps)
+	if caps&Arc == 0 {
+		r.Arc = math.NaN()
+	}
+	if caps&Azimuth != 0 {
+		e.opts.Azimuths.apply(&r.Azi1)
+		e.opts.Azimuths.apply(&r.Azi2)
+	}
+	return r, nil
+}
+
+// DirectResult is the solution of the direct geodesic problem.
+// Quantities that were not selected are NaN.
+type DirectResult struct {
+	Point LatLng  // point 2
+	Azi2  float64 // (forward) azimuth at point 2 (degrees)
+}
+
+var nanDirect = DirectResult{LatLng{math.NaN(), math.NaN()}, math.NaN()}
+
+// Direct solves the direct geodesic problem.
+//
+// Param p1 is point 1.
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+// Param caps selects the quantities to compute, Latitude, Longitude, and
+// Azimuth.
+//
+// The longitude of point 2 is in the range [-180,+180]. Asking only for
+// the latitude, or only for the azimuth, skips the longitude series and is
+// about a fifth faster.
+func (e *Ellipsoid) Direct(p1 LatLng, azi1, s12 float64, caps Caps,
+) (DirectResult, error) {
+	if e.err != nil {
+		return nanDirect, e.err
+	}
+	p1, err := e.point(p1)
+	if err != nil {
+		return nanDirect, err
+	}
+	if err := checkFinite(azi1); err != nil {
+		return nanDirect, err
+	}
+	if err := checkFinite(s12); err != nil {
+		return nanDirect, err
+	}
+	r := e.c.direct(p1.Lat, p1.Lon, azi1, s12, caps)
+	if caps&Azimuth != 0 {
+		e.opts.Azimuths.apply(&r.Azi2)
+	}
+	return r, nil
+}
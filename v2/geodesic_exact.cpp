@@ -0,0 +1,173 @@
+//go:build geographiclib_exact
+
+/**
+ * \file geodesic_exact.cpp
+ * \brief Implementation of the C interface to GeographicLib::GeodesicExact
+ *
+ * C++ exceptions must not cross into Go, so every entry point catches them.
+ * The only ones the wrapped routines throw are for invalid ellipsoid
+ * parameters and for running out of memory.
+ **********************************************************************/
+
+#include <new>
+#include <GeographicLib/GeodesicExact.hpp>
+#include <GeographicLib/PolygonArea.hpp>
+#include "geodesic_exact.h"
+
+using GeographicLib::GeodesicExact;
+using GeographicLib::PolygonAreaExact;
+
+struct geod_exact {
+  GeodesicExact g;
+  geod_exact(double a, double f) : g(a, f) {}
+};
+
+struct geod_exact_polygon {
+  PolygonAreaExact p, saved;
+  geod_exact_polygon(const GeodesicExact& g, bool polyline)
+    : p(g, polyline), saved(p) {}
+};
+
+struct geod_exact* geod_exact_new(double a, double f) {
+  try {
+    return new geod_exact(a, f);
+  } catch (...) {
+    return NULL;
+  }
+}
+
+void geod_exact_free(struct geod_exact* g) {
+  delete g;
+}
+
+/* The masks of the outputs wanted, so that GeodesicExact, like the C
+ * library, skips the quantities whose pointers are NULL. */
+static unsigned inverse_mask(double* ps12, double* pazi1, double* pazi2) {
+  return (ps12 ? GeodesicExact::DISTANCE : GeodesicExact::NONE) |
+    (pazi1 || pazi2 ? GeodesicExact::AZIMUTH : GeodesicExact::NONE);
+}
+
+static unsigned direct_mask(double* plat2, double* plon2, double* pazi2) {
+  return (plat2 ? GeodesicExact::LATITUDE : GeodesicExact::NONE) |
+    (plon2 ? GeodesicExact::LONGITUDE : GeodesicExact::NONE) |
+    (pazi2 ? GeodesicExact::AZIMUTH : GeodesicExact::NONE);
+}
+
+double geod_exact_inverse(const struct geod_exact* g,
+                          double lat1, double lon1, double lat2, double lon2,
+                          double* ps12, double* pazi1, double* pazi2) {
+  double s12, azi1, azi2, m12, M12, M21, S12;
+  double a12 = g->g.GenInverse(lat1, lon1, lat2, lon2,
+                               inverse_mask(ps12, pazi1, pazi2),
+                               s12, azi1, azi2, m12, M12, M21, S12);
+  if (ps12) *ps12 = s12;
+  if (pazi1) *pazi1 = azi1;
+  if (pazi2) *pazi2 = azi2;
+  return a12;
+}
+
+void geod_exact_direct(const struct geod_exact* g,
+                       double lat1, double lon1, double azi1, double s12,
+                       double* plat2, double* plon2, double* pazi2) {
+  double lat2, lon2, azi2, s12out, m12, M12, M21, S12;
+  g->g.GenDirect(lat1, lon1, azi1, false, s12,
+                 direct_mask(plat2, plon2, pazi2),
+                 lat2, lon2, azi2, s12out, m12, M12, M21, S12);
+  if (plat2) *plat2 = lat2;
+  if (plon2) *plon2 = lon2;
+  if (pazi2) *pazi2 = azi2;
+}
+
+void geod_exact_scales(const struct geod_exact* g,
+                       double lat1, double lon1, double azi1, double s12,
+                       double* pm12, double* pM12, double* pM21) {
+  double lat2, lon2, azi2;
+  g->g.Direct(lat1, lon1, azi1, s12, lat2, lon2, azi2, *pm12, *pM12, *pM21);
+}
+
+void geod_exact_arcdirect(const struct geod_exact* g,
+                          double lat1, double lon1, double azi1, double a12,
+                          double* plat2, double* plon2, double* pazi2) {
+  double lat2, lon2, azi2, s12, m12, M12, M21, S12;
+  g->g.GenDirect(lat1, lon1, azi1, true, a12,
+                 direct_mask(plat2, plon2, pazi2),
+                 lat2, lon2, azi2, s12, m12, M12, M21, S12);
+  if (plat2) *plat2 = lat2;
+  if (plon2) *plon2 = lon2;
+  if (pazi2) *pazi2 = azi2;
+}
+
+struct geod_exact_polygon*
+geod_exact_polygon_new(const struct geod_exact* g, int polylinep) {
+  try {
+    return new geod_exact_polygon(g->g, polylinep != 0);
+  } catch (...) {
+    return NULL;
+  }
+}
+
+void geod_exact_polygon_free(struct geod_exact_polygon* p) {
+  delete p;
+}
+
+void geod_exact_polygon_addpoint(struct geod_exact_polygon* p,
+                                 double lat, double lon) {
+  p->p.AddPoint(lat, lon);
+}
+
+void geod_exact_polygon_addedge(struct geod_exact_polygon* p,
+                                double azi, double s) {
+  p->p.AddEdge(azi, s);
+}
+
+unsigned geod_exact_polygon_compute(const struct geod_exact_polygon* p,
+                                    int reverse, int sign,
+                                    double* pA, double* pP) {
+  double A, P;
+  unsigned n = p->p.Compute(reverse != 0, sign != 0, P, A);
+  if (pA) *pA = A;
+  if (pP) *pP = P;
+  return n;
+}
+
+unsigned geod_exact_polygon_testpoint(const struct geod_exact_polygon* p,
+                                      double lat, double lon,
+                                      int reverse, int sign,
+                                      double* pA, double* pP) {
+  double A, P;
+  unsigned n = p->p.TestPoint(lat, lon, reverse != 0, sign != 0, P, A);
+  if (pA) *pA = A;
+  if (pP) *pP = P;
+  return n;
+}
+
+unsigned geod_exact_polygon_testedge(const struct geod_exact_polygon* p,
+                                     double azi, double s,
+                                     int reverse, int sign,
+                                     double* pA, double* pP) {
+  double A, P;
+  unsigned n = p->p.TestEdge(azi, s, reverse != 0, sign != 0, P, A);
+  if (pA) *pA = A;
+  if (pP) *pP = P;
+  return n;
+}
+
+void geod_exact_polygon_clear(struct geod_exact_polygon* p) {
+  p->p.Clear();
+}
+
+void geod_exact_polygon_current(const struct geod_exact_polygon* p,
+                                double* plat, double* plon) {
+  double lat, lon;
+  p->p.CurrentPoint(lat, lon);
+  *plat = lat;
+  *plon = lon;
+}
+
+void geod_exact_polygon_save(struct geod_exact_polygon* p) {
+  p->saved = p->p;
+}
+
+void geod_exact_polygon_restore(struct geod_exact_polygon* p) {
+  p->p = p->saved;
+}
@@ -0,0 +1,167 @@
+/**
+ * \file geodesic_exact.h
+ * \brief C interface to GeographicLib::GeodesicExact
+ *
+ * These functions wrap the exact geodesic routines of the GeographicLib C++
+ * library, which use elliptic integrals instead of series expansions in the
+ * flattening and so are accurate for any flattening. They are only compiled
+ * with the geographiclib_exact build tag; see Options.Exact.
+ **********************************************************************/
+
+#if !defined(GEODESIC_EXACT_H)
+#define GEODESIC_EXACT_H 1
+
+#if defined(__cplusplus)
+extern "C" {
+#endif
+
+  /**
+   * An opaque handle to a GeographicLib::GeodesicExact object.
+   **********************************************************************/
+  struct geod_exact;
+
+  /**
+   * An opaque handle to a GeographicLib::PolygonAreaExact object.
+   **********************************************************************/
+  struct geod_exact_polygon;
+
+  /**
+   * Create an exact geodesic object.
+   *
+   * @param[in] a the equatorial radius (meters).
+   * @param[in] f the flattening.
+   * @return the object, or NULL if \e a or \e f is invalid.  It must be
+   *   released with geod_exact_free().
+   **********************************************************************/
+  struct geod_exact* geod_exact_new(double a, double f);
+
+  /**
+   * Release an exact geodesic object.
+   **********************************************************************/
+  void geod_exact_free(struct geod_exact* g);
+
+  /**
+   * Solve the inverse geodesic problem, as geod_inverse().  Any of the
+   * output pointers may be NULL, and the quantities that are not wanted are
+   * not computed.
+   *
+   * @return \e a12 arc length from point 1 to point 2 (degrees).
+   **********************************************************************/
+  double geod_exact_inverse(const struct geod_exact* g,
+                          double lat1, double lon1, double lat2, double lon2,
+                          double* ps12, double* pazi1, double* pazi2);
+
+  /**
+   * Solve the direct geodesic problem, as geod_direct().  Any of the output
+   * pointers may be NULL, and the quantities that are not wanted are not
+   * computed.
+   **********************************************************************/
+  void geod_exact_direct(const struct geod_exact* g,
+                         double lat1, double lon1, double azi1, double s12,
+                         double* plat2, double* plon2, double* pazi2);
+
+  /**
+   * Compute the reduced length and geodesic scales of the direct geodesic
+   * problem, as geod_gendirect() with pm12, pM12, and pM21.
+   **********************************************************************/
+  void geod_exact_scales(const struct geod_exact* g,
+                         double lat1, double lon1, double azi1, double s12,
+                         double* pm12, double* pM12, double* pM21);
+
+  /**
+   * Solve the direct geodesic problem in terms of the arc length \e a12
+   * (degrees), as geod_gendirect() with GEOD_ARCMODE.  Any of the output
+   * pointers may be NULL, and the quantities that are not wanted are not
+   * computed.
+   **********************************************************************/
+  void geod_exact_arcdirect(const struct geod_exact* g,
+                            double lat1, double lon1, double azi1, double a12,
+                            double* plat2, double* plon2, double* pazi2);
+
+  /**
+   * Create a polygon on an exact geodesic object, as geod_polygon_init().
+   * The geodesic object must outlive the polygon.
+   *
+   * @return the polygon, or NULL if out of memory.  It must be released
+   *   with geod_exact_polygon_free().
+   **********************************************************************/
+  struct geod_exact_polygon*
+  geod_exact_polygon_new(const struct geod_exact* g, int polylinep);
+
+  /**
+   * Release a polygon.
+   **********************************************************************/
+  void geod_exact_polygon_free(struct geod_exact_polygon* p);
+
+  /**
+   * Add a point to a polygon, as geod_polygon_addpoint().
+   **********************************************************************/
+  void geod_exact_polygon_addpoint(struct geod_exact_polygon* p,
+                                   double lat, double lon);
+
+  /**
+   * Add an edge to a polygon, as geod_polygon_addedge().
+   **********************************************************************/
+  void geod_exact_polygon_addedge(struct geod_exact_polygon* p,
+                                  double azi, double s);
+
+  /**
+   * Compute the results for a polygon, as geod_polygon_compute().  Either
+   * output pointer may be NULL.
+   *
+   * @return the number of points.
+   **********************************************************************/
+  unsigned geod_exact_polygon_compute(const struct geod_exact_polygon* p,
+                                      int reverse, int sign,
+                                      double* pA, double* pP);
+
+  /**
+   * Compute the results for a polygon with a tentative final point, as
+   * geod_polygon_testpoint().  Either output pointer may be NULL.
+   *
+   * @return the number of points.
+   **********************************************************************/
+  unsigned geod_exact_polygon_testpoint(const struct geod_exact_polygon* p,
+                                        double lat, double lon,
+                                        int reverse, int sign,
+                                        double* pA, double* pP);
+
+  /**
+   * Compute the results for a polygon with a tentative final edge, as
+   * geod_polygon_testedge().  Either output pointer may be NULL.
+   *
+   * @return the number of points, or 0 if the polygon has no points.
+   **********************************************************************/
+  unsigned geod_exact_polygon_testedge(const struct geod_exact_polygon* p,
+                                       double azi, double s,
+                                       int reverse, int sign,
+                                       double* pA, double* pP);
+
+  /**
+   * Clear a polygon, as geod_polygon_clear().
+   **********************************************************************/
+  void geod_exact_polygon_clear(struct geod_exact_polygon* p);
+
+  /**
+   * Return the current point of a polygon, the last point added, as
+   * PolygonAreaExact::CurrentPoint().  After an edge the longitude is
+   * unrolled, so it gives the longitude change of the edge.
+   **********************************************************************/
+  void geod_exact_polygon_current(const struct geod_exact_polygon* p,
+                                  double* plat, double* plon);
+
+  /**
+   * Save the state of a polygon, replacing any previously saved state.
+   **********************************************************************/
+  void geod_exact_polygon_save(struct geod_exact_polygon* p);
+
+  /**
+   * Restore the state saved by geod_exact_polygon_save().
+   **********************************************************************/
+  void geod_exact_polygon_restore(struct geod_exact_polygon* p);
+
+#if defined(__cplusplus)
+}
+#endif
+
+#endif
@@ -0,0 +1,16 @@
+//go:build cgo && system_geographiclib
+
+package geodesic
+
+// Building with the system_geographiclib tag leaves out the bundled
+// geodesic.c and links the routines from an installed geographiclib-c
+// instead. The library is expected to be named libgeodesic, use CGO_LDFLAGS
+// to point the linker elsewhere.
+//
+// The declarations still come from the bundled geodesic.h, so the system
+// library must be compatible with it.
+
+/*
+#cgo LDFLAGS: -lgeodesic
+*/
+import "C"
@@ -0,0 +1,167 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo/v2/internal/geod"
+)
+
+func TestNew(t *testing.T) {
+	for _, v := range []struct {
+		a, f float64
+		err  error
+	}{
+		{6378137, 0, nil}, {0, 0, ErrInvalidRadius},
+		{math.NaN(), 0, ErrInvalidRadius}, {math.Inf(1), 0, ErrInvalidRadius},
+		{6378137, 1, ErrInvalidFlattening},
+		{6378137, math.NaN(), ErrInvalidFlattening},
+		{6378137, -0.5, nil},
+	} {
+		if _, err := New(v.a, v.f, nil); err != v.err {
+			t.Fatalf("New(%v, %v): expected %v, got %v", v.a, v.f, v.err, err)
+		}
+	}
+}
+
+func TestInit(t *testing.T) {
+	// An ellipsoid embedded by value, which is initialized without
+	// allocating and then used without allocating.
+	var v struct {
+		name string
+		e    Ellipsoid
+	}
+	p1, p2 := LatLng{40.64, -73.78}, LatLng{1.36, 103.99}
+	var r InverseResult
+	if n := testing.AllocsPerRun(100, func() {
+		v.e.Init(6378137.0, 1/298.257223563, nil)
+		r, _ = v.e.Inverse(p1, p2, Distance)
+	}); n != 0 {
+		t.Fatalf("expected no allocations, got %v", n)
+	}
+	if want, _ := WGS84.Inverse(p1, p2, Distance); r.Distance != want.Distance {
+		t.Fatalf("expected %v, got %v", want, r)
+	}
+	// A failed Init is reported by every method.
+	if err := v.e.Init(0, 0, nil); err != ErrInvalidRadius {
+		t.Fatalf("expected %v, got %v", ErrInvalidRadius, err)
+	}
+	if r, err := v.e.Inverse(p1, p2, All); err != ErrInvalidRadius ||
+		!math.IsNaN(r.Distance) {
+		t.Fatalf("expected %v and NaN, got %v, %v", ErrInvalidRadius, err, r)
+	}
+	if _, err := v.e.Direct(p1, 0, 1, All); err != ErrInvalidRadius {
+		t.Fatalf("expected %v, got %v", ErrInvalidRadius, err)
+	}
+}
+
+func TestInverseDirect(t *testing.T) {
+	p1, p2 := LatLng{Lat: 40.64, Lon: -73.78}, LatLng{Lat: 1.36, Lon: 103.99}
+	r, err := WGS84.Inverse(p1, p2, All)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pure Go port agrees with the C routines to round off.
+	var g geod.Geodesic
+	g.Init(WGS84.Radius(), WGS84.Flattening())
+	var s12, azi1, azi2 float64
+	a12 := g.GenInverse(p1.Lat, p1.Lon, p2.Lat, p2.Lon, &s12, &azi1, &azi2,
+		nil, nil, nil, nil)
+	if !(math.Abs(r.Distance-s12) < 1e-6 && math.Abs(r.Azi1-azi1) < 1e-12 &&
+		math.Abs(r.Azi2-azi2) < 1e-12 && math.Abs(r.Arc-a12) < 1e-12) {
+		t.Fatalf("expected %v, got %v", InverseResult{s12, azi1, azi2, a12}, r)
+	}
+	d, _ := WGS84.Inverse(p1, p2, Distance)
+	if d.Distance != r.Distance || !math.IsNaN(d.Azi1) ||
+		!math.IsNaN(d.Azi2) || !math.IsNaN(d.Arc) {
+		t.Fatalf("expected only the distance, got %v", d)
+	}
+	dr, err := WGS84.Direct(p1, r.Azi1, r.Distance, All)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(dr.Point.Lat-p2.Lat) > 1e-9 ||
+		math.Abs(dr.Point.Lon-p2.Lon) > 1e-9 ||
+		math.Abs(dr.Azi2-r.Azi2) > 1e-9 {
+		t.Fatalf("expected %v, got %v", p2, dr)
+	}
+	if dr, _ := WGS84.Direct(p1, r.Azi1, r.Distance, Latitude); math.IsNaN(
+		dr.Point.Lat) || !math.IsNaN(dr.Point.Lon) || !math.IsNaN(dr.Azi2) {
+		t.Fatalf("expected only the latitude, got %v", dr)
+	}
+	for _, v := range []struct {
+		p   LatLng
+		err error
+	}{
+		{LatLng{91, 0}, ErrInvalidLatitude},
+		{LatLng{math.NaN(), 0}, ErrInvalidLatitude},
+		{LatLng{0, math.Inf(1)}, ErrInvalidLongitude},
+	} {
+		if r, err := WGS84.Inverse(v.p, p2, All); err != v.err ||
+			!math.IsNaN(r.Distance) {
+			t.Fatalf("expected %v, got %v, %v", v.err, r, err)
+		}
+		if _, err := WGS84.Direct(v.p, 0, 1, All); err != v.err {
+			t.Fatalf("expected %v, got %v", v.err, err)
+		}
+	}
+	if _, err := WGS84.Direct(p1, math.NaN(), 1, All); err != ErrInvalidArgument {
+		t.Fatalf("expected %v, got %v", ErrInvalidArgument, err)
+	}
+	if _, err := WGS84.Direct(p1, 0, math.Inf(-1), All); err != ErrInvalidArgument {
+		t.Fatalf("expected %v, got %v", ErrInvalidArgument, err)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	p2 := LatLng{Lat: 1.36, Lon: 103.99}
+	noisy := LatLng{Lat: 90 + LatitudeClampTolerance/2, Lon: 0}
+	if _, err := WGS84.Inverse(noisy, p2, All); err != ErrInvalidLatitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLatitude, err)
+	}
+	c, _ := New(6378137.0, 1/298.257223563, &Options{Latitudes: LatitudeClamp})
+	r, err := c.Inverse(noisy, p2, Distance)
+	want, _ := WGS84.Inverse(LatLng{Lat: 90, Lon: 0}, p2, Distance)
+	if err != nil || r.Distance != want.Distance {
+		t.Fatalf("expected %v, got %v %v", want.Distance, r.Distance, err)
+	}
+	if _, err := c.Inverse(LatLng{Lat: 91, Lon: 0}, p2, All); err != ErrInvalidLatitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLatitude, err)
+	}
+	if c.Options().Latitudes != LatitudeClamp {
+		t.Fatalf("expected LatitudeClamp, got %v", c.Options())
+	}
+	e, _ := New(6378137.0, 1/298.257223563, &Options{Azimuths: AzimuthUnsigned})
+	if r, _ := e.Inverse(LatLng{Lat: 10, Lon: 10}, LatLng{Lat: 0, Lon: 0}, Azimuth); r.Azi1 < 180 {
+		t.Fatalf("expected an unsigned azimuth, got %v", r.Azi1)
+	}
+	if d, _ := e.Direct(LatLng{Lat: 10, Lon: 10}, -90, 1000, Azimuth); d.Azi2 < 180 {
+		t.Fatalf("expected an unsigned azimuth, got %v", d.Azi2)
+	}
+}
+
+func TestExact(t *testing.T) {
+	e, err := New(6378137, 1/298.257223563, &Options{Exact: true})
+	if errors.Is(err, ErrExactUnavailable) {
+		t.Skip("built without the geographiclib_exact tag")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !e.Options().Exact {
+		t.Fatalf("expected Exact option")
+	}
+	p1, p2 := LatLng{Lat: 40.64, Lon: -73.78}, LatLng{Lat: 1.36, Lon: 103.99}
+	r0, _ := WGS84.Inverse(p1, p2, Distance)
+	r1, err := e.Inverse(p1, p2, Distance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(math.Abs(r0.Distance-r1.Distance) <= 1e-6) {
+		t.Fatalf("expected %v, got %v", r0.Distance, r1.Distance)
+	}
+	if _, err := New(6378137, 1.5, &Options{Exact: true}); err != ErrInvalidFlattening {
+		t.Fatalf("expected %v, got %v", ErrInvalidFlattening, err)
+	}
+}
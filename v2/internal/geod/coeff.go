@@ -0,0 +1,268 @@
+package geod
+
+func (g *Geodesic) a3f(eps float64) float64 {
+	return polyval(nA3-1, g.a3x[:], eps)
+}
+
+func (g *Geodesic) c3f(eps float64, c []float64) {
+	// Elements c[1] through c[nC3 - 1] are set
+	mult := 1.0
+	o := 0
+	for l := 1; l < nC3; l++ {
+		m := nC3 - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, g.c3x[o:], eps)
+		o += m + 1
+	}
+}
+
+func (g *Geodesic) c4f(eps float64, c []float64) {
+	// Elements c[0] through c[nC4 - 1] are set
+	mult := 1.0
+	o := 0
+	for l := 0; l < nC4; l++ {
+		m := nC4 - l - 1
+		c[l] = mult * polyval(m, g.c4x[o:], eps)
+		o += m + 1
+		mult *= eps
+	}
+}
+
+// a1m1f is the scale factor A1-1 = mean value of (d/dsigma)I1 - 1
+func a1m1f(eps float64) float64 {
+	coeff := [...]float64{
+		// (1-eps)*A1-1, polynomial in eps2 of order 3
+		1, 4, 64, 0, 256,
+	}
+	m, mt := nA1/2, nT/2
+	t := polyval(m, coeff[mt-m:], sq(eps)) / coeff[mt+1]
+	return (t + eps) / (1 - eps)
+}
+
+// c1f sets the coefficients C1[l] in the Fourier expansion of B1
+func c1f(eps float64, c []float64) {
+	coeff := [...]float64{
+		// C1[1]/eps^1, polynomial in eps2 of order 2
+		-1, 6, -16, 32,
+		// C1[2]/eps^2, polynomial in eps2 of order 2
+		-9, 64, -128, 2048,
+		// C1[3]/eps^3, polynomial in eps2 of order 1
+		9, -16, 768,
+		// C1[4]/eps^4, polynomial in eps2 of order 1
+		3, -5, 512,
+		// C1[5]/eps^5, polynomial in eps2 of order 0
+		-7, 1280,
+		// C1[6]/eps^6, polynomial in eps2 of order 0
+		-7, 2048,
+	}
+	eps2 := sq(eps)
+	d := eps
+	o := 0
+	for l := 1; l <= nC1; l++ {
+		m := (nC1 - l) / 2
+		mt := (nT - l) / 2 // in the table
+		c[l] = d * polyval(m, coeff[o+mt-m:], eps2) / coeff[o+mt+1]
+		o += mt + 2
+		d *= eps
+	}
+}
+
+// c1pf sets the coefficients C1p[l] in the Fourier expansion of B1p
+func c1pf(eps float64, c []float64) {
+	coeff := [...]float64{
+		// C1p[1]/eps^1, polynomial in eps2 of order 2
+		205, -432, 768, 1536,
+		// C1p[2]/eps^2, polynomial in eps2 of order 2
+		4005, -4736, 3840, 12288,
+		// C1p[3]/eps^3, polynomial in eps2 of order 1
+		-225, 116, 384,
+		// C1p[4]/eps^4, polynomial in eps2 of order 1
+		-7173, 2695, 7680,
+		// C1p[5]/eps^5, polynomial in eps2 of order 0
+		3467, 7680,
+		// C1p[6]/eps^6, polynomial in eps2 of order 0
+		38081, 61440,
+	}
+	eps2 := sq(eps)
+	d := eps
+	o := 0
+	for l := 1; l <= nC1p; l++ {
+		m := (nC1p - l) / 2
+		mt := (nT - l) / 2 // in the table
+		c[l] = d * polyval(m, coeff[o+mt-m:], eps2) / coeff[o+mt+1]
+		o += mt + 2
+		d *= eps
+	}
+}
+
+// a2m1f is the scale factor A2-1 = mean value of (d/dsigma)I2 - 1
+func a2m1f(eps float64) float64 {
+	coeff := [...]float64{
+		// (eps+1)*A2-1, polynomial in eps2 of order 3
+		-11, -28, -192, 0, 256,
+	}
+	m, mt := nA2/2, nT/2
+	t := polyval(m, coeff[mt-m:], sq(eps)) / coeff[mt+1]
+	return (t - eps) / (1 + eps)
+}
+
+// c2f sets the coefficients C2[l] in the Fourier expansion of B2
+func c2f(eps float64, c []float64) {
+	coeff := [...]float64{
+		// C2[1]/eps^1, polynomial in eps2 of order 2
+		1, 2, 16, 32,
+		// C2[2]/eps^2, polynomial in eps2 of order 2
+		35, 64, 384, 2048,
+		// C2[3]/eps^3, polynomial in eps2 of order 1
+		15, 80, 768,
+		// C2[4]/eps^4, polynomial in eps2 of order 1
+		7, 35, 512,
+		// C2[5]/eps^5, polynomial in eps2 of order 0
+		63, 1280,
+		// C2[6]/eps^6, polynomial in eps2 of order 0
+		77, 2048,
+	}
+	eps2 := sq(eps)
+	d := eps
+	o := 0
+	for l := 1; l <= nC2; l++ {
+		m := (nC2 - l) / 2
+		mt := (nT - l) / 2 // in the table
+		c[l] = d * polyval(m, coeff[o+mt-m:], eps2) / coeff[o+mt+1]
+		o += mt + 2
+		d *= eps
+	}
+}
+
+// a3coeff sets the scale factor A3 = mean value of (d/dsigma)I3
+func (g *Geodesic) a3coeff() {
+	coeff := [...]float64{
+		// A3, coeff of eps^5, polynomial in n of order 0
+		-3, 128,
+		// A3, coeff of eps^4, polynomial in n of order 1
+		-2, -3, 64,
+		// A3, coeff of eps^3, polynomial in n of order 2
+		-1, -3, -1, 16,
+		// A3, coeff of eps^2, polynomial in n of order 2
+		3, -1, -2, 8,
+		// A3, coeff of eps^1, polynomial in n of order 1
+		1, -1, 2,
+		// A3, coeff of eps^0, polynomial in n of order 0
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := nT - 1; j >= 0; j-- {
+		m, mt := min(j, nA3-j-1), min(j, nT-j-1)
+		if j < nA3 {
+			g.a3x[k] = polyval(m, coeff[o+mt-m:], g.n) / coeff[o+mt+1]
+			k++
+		}
+		o += mt + 2
+	}
+}
+
+// c3coeff sets the coefficients C3[l] in the Fourier expansion of B3
+func (g *Geodesic) c3coeff() {
+	coeff := [...]float64{
+		// C3[1], coeff of eps^5, polynomial in n of order 0
+		3, 128,
+		// C3[1], coeff of eps^4, polynomial in n of order 1
+		2, 5, 128,
+		// C3[1], coeff of eps^3, polynomial in n of order 2
+		-1, 3, 3, 64,
+		// C3[1], coeff of eps^2, polynomial in n of order 2
+		-1, 0, 1, 8,
+		// C3[1], coeff of eps^1, polynomial in n of order 1
+		-1, 1, 4,
+		// C3[2], coeff of eps^5, polynomial in n of order 0
+		5, 256,
+		// C3[2], coeff of eps^4, polynomial in n of order 1
+		1, 3, 128,
+		// C3[2], coeff of eps^3, polynomial in n of order 2
+		-3, -2, 3, 64,
+		// C3[2], coeff of eps^2, polynomial in n of order 2
+		1, -3, 2, 32,
+		// C3[3], coeff of eps^5, polynomial in n of order 0
+		7, 512,
+		// C3[3], coeff of eps^4, polynomial in n of order 1
+		-10, 9, 384,
+		// C3[3], coeff of eps^3, polynomial in n of order 2
+		5, -9, 5, 192,
+		// C3[4], coeff of eps^5, polynomial in n of order 0
+		7, 512,
+		// C3[4], coeff of eps^4, polynomial in n of order 1
+		-14, 7, 512,
+		// C3[5], coeff of eps^5, polynomial in n of order 0
+		21, 2560,
+	}
+	o, k := 0, 0
+	for l := 1; l < nT; l++ {
+		for j := nT - 1; j >= l; j-- {
+			m, mt := min(j, nC3-j-1), min(j, nT-j-1)
+			if j < nC3 {
+				g.c3x[k] = polyval(m, coeff[o+mt-m:], g.n) / coeff[o+mt+1]
+				k++
+			}
+			o += mt + 2
+		}
+	}
+}
+
+// c4coeff sets the coefficients C4[l] in the Fourier expansion of I4
+func (g *Geodesic) c4coeff() {
+	coeff := [...]float64{
+		// C4[0], coeff of eps^5, polynomial in n of order 0
+		97, 15015,
+		// C4[0], coeff of eps^4, polynomial in n of order 1
+		1088, 156, 45045,
+		// C4[0], coeff of eps^3, polynomial in n of order 2
+		-224, -4784, 1573, 45045,
+		// C4[0], coeff of eps^2, polynomial in n of order 3
+		-10656, 14144, -4576, -858, 45045,
+		// C4[0], coeff of eps^1, polynomial in n of order 4
+		64, 624, -4576, 6864, -3003, 15015,
+		// C4[0], coeff of eps^0, polynomial in n of order 5
+		100, 208, 572, 3432, -12012, 30030, 45045,
+		// C4[1], coeff of eps^5, polynomial in n of order 0
+		1, 9009,
+		// C4[1], coeff of eps^4, polynomial in n of order 1
+		-2944, 468, 135135,
+		// C4[1], coeff of eps^3, polynomial in n of order 2
+		5792, 1040, -1287, 135135,
+		// C4[1], coeff of eps^2, polynomial in n of order 3
+		5952, -11648, 9152, -2574, 135135,
+		// C4[1], coeff of eps^1, polynomial in n of order 4
+		-64, -624, 4576, -6864, 3003, 135135,
+		// C4[2], coeff of eps^5, polynomial in n of order 0
+		8, 10725,
+		// C4[2], coeff of eps^4, polynomial in n of order 1
+		1856, -936, 225225,
+		// C4[2], coeff of eps^3, polynomial in n of order 2
+		-8448, 4992, -1144, 225225,
+		// C4[2], coeff of eps^2, polynomial in n of order 3
+		-1440, 4160, -4576, 1716, 225225,
+		// C4[3], coeff of eps^5, polynomial in n of order 0
+		-136, 63063,
+		// C4[3], coeff of eps^4, polynomial in n of order 1
+		1024, -208, 105105,
+		// C4[3], coeff of eps^3, polynomial in n of order 2
+		3584, -3328, 1144, 315315,
+		// C4[4], coeff of eps^5, polynomial in n of order 0
+		-128, 135135,
+		// C4[4], coeff of eps^4, polynomial in n of order 1
+		-2560, 832, 405405,
+		// C4[5], coeff of eps^5, polynomial in n of order 0
+		128, 99099,
+	}
+	o, k := 0, 0
+	for l := 0; l < nT; l++ {
+		for j := nT - 1; j >= l; j-- {
+			m, mt := nC4-j-1, nT-j-1
+			if j < nC4 {
+				g.c4x[k] = polyval(m, coeff[o+mt-m:], g.n) / coeff[o+mt+1]
+				k++
+			}
+			o += mt + 2
+		}
+	}
+}
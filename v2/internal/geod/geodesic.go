@@ -0,0 +1,875 @@
+// Package geod is a pure Go port of the geodesic routines in geodesic.c.
+//
+// It mirrors the C API closely, struct for struct and function for
+// function, so that it can stand in for the C library when cgo is not
+// available and so that the two can be checked against each other.
+package geod
+
+import "math"
+
+// The version of geodesic.c that this is a port of, the
+// GEODESIC_VERSION_MAJOR, GEODESIC_VERSION_MINOR, and
+// GEODESIC_VERSION_PATCH of geodesic.h. They move with the port.
+const (
+	VersionMajor = 1
+	VersionMinor = 52
+	VersionPatch = 0
+)
+
+const (
+	capNone = 0
+	capC1   = 1 << 0
+	capC1p  = 1 << 1
+	capC2   = 1 << 2
+	capC3   = 1 << 3
+	capC4   = 1 << 4
+	capAll  = 0x1F
+	outAll  = 0x7F80
+)
+
+// Output masks, the same values as the GEOD_* masks in geodesic.h.
+const (
+	None          = 0
+	Latitude      = 1<<7 | capNone
+	Longitude     = 1<<8 | capC3
+	Azimuth       = 1<<9 | capNone
+	Distance      = 1<<10 | capC1
+	DistanceIn    = 1<<11 | capC1 | capC1p
+	ReducedLength = 1<<12 | capC1 | capC2
+	GeodesicScale = 1<<13 | capC1 | capC2
+	Area          = 1<<14 | capC4
+	All           = outAll | capAll
+)
+
+// Flags, the same values as the GEOD_* flags in geodesic.h.
+const (
+	NoFlags    = 0
+	ArcMode    = 1 << 0
+	LongUnroll = 1 << 15
+)
+
+// Geodesic is the port of struct geod_geodesic.
+type Geodesic struct {
+	a, f                         float64
+	f1, e2, ep2, n, b, c2, etol2 float64
+	a3x                          [6]float64
+	c3x                          [15]float64
+	c4x                          [21]float64
+}
+
+// Init initializes g for the ellipsoid with equatorial radius a and
+// flattening f.
+func (g *Geodesic) Init(a, f float64) {
+	g.a = a
+	g.f = f
+	g.f1 = 1 - g.f
+	g.e2 = g.f * (2 - g.f)
+	g.ep2 = g.e2 / sq(g.f1)
+	g.n = g.f / (2 - g.f)
+	g.b = g.a * g.f1
+	var t float64
+	switch {
+	case g.e2 == 0:
+		t = 1
+	case g.e2 > 0:
+		t = math.Atanh(math.Sqrt(g.e2)) / math.Sqrt(math.Abs(g.e2))
+	default:
+		t = math.Atan(math.Sqrt(-g.e2)) / math.Sqrt(math.Abs(g.e2))
+	}
+	g.c2 = (sq(g.a) + sq(g.b)*t) / 2 // authalic radius squared
+	g.etol2 = 0.1 * tol2 /
+		math.Sqrt(maxx(0.001, math.Abs(g.f))*minx(1, 1-g.f/2)/2)
+	g.a3coeff()
+	g.c3coeff()
+	g.c4coeff()
+}
+
+// A returns the equatorial radius (meters).
+func (g *Geodesic) A() float64 { return g.a }
+
+// F returns the flattening.
+func (g *Geodesic) F() float64 { return g.f }
+
+// lengths is the port of Lengths.
+func (g *Geodesic) lengths(eps, sig12,
+	ssig1, csig1, dn1, ssig2, csig2, dn2, cbet1, cbet2 float64,
+	ps12b, pm12b, pm0, pM12, pM21 *float64, ca []float64,
+) {
+	var m0, J12, A1, A2 float64
+	var cb [nC]float64
+
+	redlp := pm12b != nil || pm0 != nil || pM12 != nil || pM21 != nil
+	if ps12b != nil || redlp {
+		A1 = a1m1f(eps)
+		c1f(eps, ca)
+		if redlp {
+			A2 = a2m1f(eps)
+			c2f(eps, cb[:])
+			m0 = A1 - A2
+			A2 = 1 + A2
+		}
+		A1 = 1 + A1
+	}
+	if ps12b != nil {
+		B1 := sinCosSeries(true, ssig2, csig2, ca, nC1) -
+			sinCosSeries(true, ssig1, csig1, ca, nC1)
+		*ps12b = A1 * (sig12 + B1)
+		if redlp {
+			B2 := sinCosSeries(true, ssig2, csig2, cb[:], nC2) -
+				sinCosSeries(true, ssig1, csig1, cb[:], nC2)
+			J12 = m0*sig12 + (A1*B1 - A2*B2)
+		}
+	} else if redlp {
+		for l := 1; l <= nC2; l++ {
+			cb[l] = A1*ca[l] - A2*cb[l]
+		}
+		J12 = m0*sig12 + (sinCosSeries(true, ssig2, csig2, cb[:], nC2) -
+			sinCosSeries(true, ssig1, csig1, cb[:], nC2))
+	}
+	if pm0 != nil {
+		*pm0 = m0
+	}
+	if pm12b != nil {
+		*pm12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*J12
+	}
+	if pM12 != nil || pM21 != nil {
+		csig12 := csig1*csig2 + ssig1*ssig2
+		t := g.ep2 * (cbet1 - cbet2) * (cbet1 + cbet2) / (dn1 + dn2)
+		if pM12 != nil {
+			*pM12 = csig12 + (t*ssig2-csig2*J12)*ssig1/dn1
+		}
+		if pM21 != nil {
+			*pM21 = csig12 - (t*ssig1-csig1*J12)*ssig2/dn2
+		}
+	}
+}
+
+// astroid solves k^4+2*k^3-(x^2+y^2-1)*k^2-2*y^2*k-y^2 = 0 for the positive
+// root k.
+func astroid(x, y float64) float64 {
+	p := sq(x)
+	q := sq(y)
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	S := p * q / 4
+	r2 := sq(r)
+	r3 := r * r2
+	disc := S * (S + 2*r3)
+	u := r
+	if disc >= 0 {
+		T3 := S + r3
+		if T3 < 0 {
+			T3 -= math.Sqrt(disc)
+		} else {
+			T3 += math.Sqrt(disc)
+		}
+		T := math.Cbrt(T3)
+		u += T
+		if T != 0 {
+			u += r2 / T
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(S + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(sq(u) + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+sq(w)) + w)
+}
+
+// inverseStart is the port of InverseStart.
+func (g *Geodesic) inverseStart(
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64,
+	psalp1, pcalp1, psalp2, pcalp2, pdnm *float64, ca []float64,
+) float64 {
+	var salp1, calp1, salp2, calp2, dnm float64
+	sig12 := -1.0
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	var somg12, comg12 float64
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	if shortline {
+		sbetm2 := sq(sbet1 + sbet2)
+		sbetm2 /= sbetm2 + sq(cbet1+cbet2)
+		dnm = math.Sqrt(1 + g.ep2*sbetm2)
+		omg12 := lam12 / (g.f1 * dnm)
+		somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*sq(somg12)/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && ssig12 < g.etol2 {
+		// really short lines
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*(sq(somg12)/(1+comg12))
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		norm2(&salp2, &calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	} else if math.Abs(g.n) > 0.1 || csig12 >= 0 ||
+		ssig12 >= 6*math.Abs(g.n)*math.Pi*sq(cbet1) {
+		// Nothing to do, zeroth order spherical approximation is OK
+	} else {
+		// Scale lam12 and bet2 to x, y coordinate system where antipodal
+		// point is at origin and singular point is at y = 0, x = -1.
+		var x, y, lamscale, betscale float64
+		lam12x := math.Atan2(-slam12, -clam12) // lam12 - pi
+		if g.f >= 0 {
+			// x = dlong, y = dlat
+			k2 := sq(sbet1) * g.ep2
+			eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+			lamscale = g.f * cbet1 * g.a3f(eps) * math.Pi
+			betscale = lamscale * cbet1
+			x = lam12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			// x = dlat, y = dlong
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12a, cbet12a)
+			var m12b, m0 float64
+			g.lengths(g.n, math.Pi+bet12a,
+				sbet1, -cbet1, dn1, sbet2, cbet2, dn2,
+				cbet1, cbet2, nil, &m12b, &m0, nil, nil, ca)
+			x = -1 + m12b/(cbet1*cbet2*m0*math.Pi)
+			if x < -0.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -g.f * sq(cbet1) * math.Pi
+			}
+			lamscale = betscale / cbet1
+			y = lam12x / lamscale
+		}
+
+		if y > -tol1 && x > -1-xthresh {
+			// strip near cut
+			if g.f >= 0 {
+				salp1 = minx(1, -x)
+				calp1 = -math.Sqrt(1 - sq(salp1))
+			} else {
+				lo := -1.0
+				if x > -tol1 {
+					lo = 0
+				}
+				calp1 = maxx(lo, x)
+				salp1 = math.Sqrt(1 - sq(calp1))
+			}
+		} else {
+			// Estimate alp1, by solving the astroid problem.
+			k := astroid(x, y)
+			var omg12a float64
+			if g.f >= 0 {
+				omg12a = lamscale * (-x * k / (1 + k))
+			} else {
+				omg12a = lamscale * (-y * (1 + k) / k)
+			}
+			somg12, comg12 = math.Sin(omg12a), -math.Cos(omg12a)
+			// Update spherical estimate of alp1 using omg12 instead of lam12
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+		}
+	}
+	// Sanity check on starting guess.  Backwards check allows NaN through.
+	if !(salp1 <= 0) {
+		norm2(&salp1, &calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+
+	*psalp1 = salp1
+	*pcalp1 = calp1
+	if shortline {
+		*pdnm = dnm
+	}
+	if sig12 >= 0 {
+		*psalp2 = salp2
+		*pcalp2 = calp2
+	}
+	return sig12
+}
+
+// lambda12 is the port of Lambda12.
+func (g *Geodesic) lambda12(
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1,
+	slam120, clam120 float64,
+	psalp2, pcalp2, psig12, pssig1, pcsig1, pssig2, pcsig2,
+	peps, pdomg12 *float64, diffp bool, pdlam12 *float64, ca []float64,
+) float64 {
+	if sbet1 == 0 && calp1 == 0 {
+		// Break degeneracy of equatorial line.
+		calp1 = -tiny
+	}
+
+	// sin(alp1) * cos(bet1) = sin(alp0)
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1) // calp0 > 0
+
+	ssig1 := sbet1
+	somg1 := salp0 * sbet1
+	csig1 := calp1 * cbet1
+	comg1 := csig1
+	norm2(&ssig1, &csig1)
+
+	// Enforce symmetries in the case abs(bet2) = -bet1.
+	var salp2, calp2 float64
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var t float64
+		if cbet1 < -sbet1 {
+			t = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			t = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(sq(calp1*cbet1)+t) / cbet2
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+
+	ssig2 := sbet2
+	somg2 := salp0 * sbet2
+	csig2 := calp2 * cbet2
+	comg2 := csig2
+	norm2(&ssig2, &csig2)
+
+	// sig12 = sig2 - sig1, limit to [0, pi]
+	sig12 := math.Atan2(maxx(0, csig1*ssig2-ssig1*csig2),
+		csig1*csig2+ssig1*ssig2)
+
+	// omg12 = omg2 - omg1, limit to [0, pi]
+	somg12 := maxx(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	// eta = omg12 - lam120
+	eta := math.Atan2(somg12*clam120-comg12*slam120,
+		comg12*clam120+somg12*slam120)
+	k2 := sq(calp0) * g.ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+
+	g.c3f(eps, ca)
+	B312 := sinCosSeries(true, ssig2, csig2, ca, nC3-1) -
+		sinCosSeries(true, ssig1, csig1, ca, nC3-1)
+	domg12 := -g.f * g.a3f(eps) * salp0 * (sig12 + B312)
+	lam12 := eta + domg12
+
+	var dlam12 float64
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * g.f1 * dn1 / sbet1
+		} else {
+			g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2,
+				cbet1, cbet2, nil, &dlam12, nil, nil, nil, ca)
+			dlam12 *= g.f1 / (calp2 * cbet2)
+		}
+	}
+
+	*psalp2 = salp2
+	*pcalp2 = calp2
+	*psig12 = sig12
+	*pssig1 = ssig1
+	*pcsig1 = csig1
+	*pssig2 = ssig2
+	*pcsig2 = csig2
+	*peps = eps
+	*pdomg12 = domg12
+	if diffp {
+		*pdlam12 = dlam12
+	}
+	return lam12
+}
+
+// Solution methods of the inverse problem, see InverseStats.
+const (
+	MethodMeridian  = iota // along a meridian
+	MethodEquator          // along the equator
+	MethodShort            // short lines, from inverseStart
+	MethodIterative        // Newton's method on lambda12
+)
+
+// InverseStats is how an inverse problem was solved. It has no counterpart
+// in the C library.
+type InverseStats struct {
+	Method     int  // one of the Method constants
+	Iterations int  // evaluations of lambda12
+	Bisections int  // steps that fell back to the middle of the bracket
+	Converged  bool // whether the iteration stopped before maxit2
+}
+
+// genInverseInt is the port of geod_geninverse_int. If pst is not nil, it
+// is set to how the problem was solved.
+func (g *Geodesic) genInverseInt(lat1, lon1, lat2, lon2 float64,
+	ps12, psalp1, pcalp1, psalp2, pcalp2, pm12, pM12, pM21, pS12 *float64,
+	pst *InverseStats,
+) float64 {
+	st := InverseStats{Method: MethodMeridian, Converged: true}
+	var s12, m12, M12, M21, S12 float64
+	var lon12s float64
+	var sbet1, cbet1, sbet2, cbet2, s12x, m12x float64
+	var slam12, clam12 float64
+	var a12, sig12, calp1, salp1, calp2, salp2 float64
+	var ca [nC]float64
+	// somg12 > 1 marks that it needs to be calculated
+	omg12, somg12, comg12 := 0.0, 2.0, 0.0
+
+	outmask := 0
+	if ps12 != nil {
+		outmask |= Distance
+	}
+	if pm12 != nil {
+		outmask |= ReducedLength
+	}
+	if pM12 != nil || pM21 != nil {
+		outmask |= GeodesicScale
+	}
+	if pS12 != nil {
+		outmask |= Area
+	}
+	outmask &= outAll
+
+	// Compute longitude difference (angDiff does this carefully).
+	lon12 := angDiff(lon1, lon2, &lon12s)
+	// Make longitude difference positive.
+	lonsign := 1.0
+	if lon12 < 0 {
+		lonsign = -1
+	}
+	// If very close to being on the same half-meridian, then make it so.
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	lam12 := lon12 * degree
+	if lon12 > 90 {
+		sincosdx(lon12s, &slam12, &clam12)
+		clam12 = -clam12
+	} else {
+		sincosdx(lon12, &slam12, &clam12)
+	}
+
+	// If really close to the equator, treat as on equator.
+	lat1 = angRound(latFix(lat1))
+	lat2 = angRound(latFix(lat2))
+	// Swap points so that point with higher (abs) latitude is point 1.
+	// If one latitude is a nan, then it becomes lat1.
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+	}
+	if swapp < 0 {
+		lonsign *= -1
+		lat1, lat2 = lat2, lat1
+	}
+	// Make lat1 <= 0
+	latsign := -1.0
+	if lat1 < 0 {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sincosdx(lat1, &sbet1, &cbet1)
+	sbet1 *= g.f1
+	// Ensure cbet1 = +epsilon at poles
+	norm2(&sbet1, &cbet1)
+	cbet1 = maxx(tiny, cbet1)
+
+	sincosdx(lat2, &sbet2, &cbet2)
+	sbet2 *= g.f1
+	// Ensure cbet2 = +epsilon at poles
+	norm2(&sbet2, &cbet2)
+	cbet2 = maxx(tiny, cbet2)
+
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			if sbet2 < 0 {
+				sbet2 = sbet1
+			} else {
+				sbet2 = -sbet1
+			}
+		}
+	} else {
+		if math.Abs(sbet2) == -sbet1 {
+			cbet2 = cbet1
+		}
+	}
+
+	dn1 := math.Sqrt(1 + g.ep2*sq(sbet1))
+	dn2 := math.Sqrt(1 + g.ep2*sq(sbet2))
+
+	meridian := lat1 == -90 || slam12 == 0
+
+	var pM12x, pM21x *float64
+	if outmask&GeodesicScale != 0 {
+		pM12x, pM21x = &M12, &M21
+	}
+
+	if meridian {
+		// Endpoints are on a single full meridian, so the geodesic might
+		// lie on a meridian.
+		calp1, salp1 = clam12, slam12 // Head to the target longitude
+		calp2, salp2 = 1, 0           // At the target we're heading north
+
+		// tan(bet) = tan(sig) * cos(alp)
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+
+		// sig12 = sig2 - sig1
+		sig12 = math.Atan2(maxx(0, csig1*ssig2-ssig1*csig2),
+			csig1*csig2+ssig1*ssig2)
+		g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2,
+			cbet1, cbet2, &s12x, &m12x, nil, pM12x, pM21x, ca[:])
+
+		if sig12 < 1 || m12x >= 0 {
+			// Need at least 2, to handle 90 0 90 180
+			if sig12 < 3*tiny ||
+				// Prevent negative s12 or m12 for short lines
+				(sig12 < tol0 && (s12x < 0 || m12x < 0)) {
+				sig12, m12x, s12x = 0, 0, 0
+			}
+			m12x *= g.b
+			s12x *= g.b
+			a12 = sig12 / degree
+		} else {
+			// m12 < 0, i.e., prolate and too close to anti-podal
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0 &&
+		// Mimic the way Lambda12 works with calp1 = 0
+		(g.f <= 0 || lon12s >= g.f*180) {
+		// Geodesic runs along equator
+		st.Method = MethodEquator
+		calp1, calp2 = 0, 0
+		salp1, salp2 = 1, 1
+		s12x = g.a * lam12
+		sig12 = lam12 / g.f1
+		omg12 = sig12
+		m12x = g.b * math.Sin(sig12)
+		if outmask&GeodesicScale != 0 {
+			M12 = math.Cos(sig12)
+			M21 = M12
+		}
+		a12 = lon12 / g.f1
+	} else if !meridian {
+		// Now point1 and point2 belong within a hemisphere bounded by a
+		// meridian and geodesic is neither meridional or equatorial.
+
+		// Figure a starting point for Newton's method
+		var dnm float64
+		sig12 = g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2,
+			lam12, slam12, clam12,
+			&salp1, &calp1, &salp2, &calp2, &dnm, ca[:])
+
+		if sig12 >= 0 {
+			// Short lines (inverseStart sets salp2, calp2, dnm)
+			st.Method = MethodShort
+			s12x = sig12 * g.b * dnm
+			m12x = sq(dnm) * g.b * math.Sin(sig12/dnm)
+			if outmask&GeodesicScale != 0 {
+				M12 = math.Cos(sig12 / dnm)
+				M21 = M12
+			}
+			a12 = sig12 / degree
+			omg12 = lam12 / (g.f1 * dnm)
+		} else {
+			// Newton's method.  This is a straightforward solution of
+			// f(alp1) = lambda12(alp1) - lam12 = 0 with one wrinkle.  A
+			// range (alp1a, alp1b) is maintained which brackets the root
+			// and Newton's method is restarted from the middle of the range
+			// whenever it strays outside of it.
+			var ssig1, csig1, ssig2, csig2, eps, domg12 float64
+			// Bracketing range
+			salp1a, calp1a, salp1b, calp1b := tiny, 1.0, tiny, -1.0
+			tripn, tripb := false, false
+			st.Method, st.Converged = MethodIterative, false
+			for numit := 0; numit < maxit2; numit++ {
+				st.Iterations++
+				var dv float64
+				v := g.lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2,
+					salp1, calp1, slam12, clam12,
+					&salp2, &calp2, &sig12, &ssig1, &csig1, &ssig2, &csig2,
+					&eps, &domg12, numit < maxit1, &dv, ca[:])
+				// Reversed test to allow escape with NaNs
+				tol := 1.0
+				if tripn {
+					tol = 8
+				}
+				if tripb || !(math.Abs(v) >= tol*tol0) {
+					st.Converged = true
+					break
+				}
+				// Update bracketing values
+				if v > 0 && (numit > maxit1 || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0 &&
+					(numit > maxit1 || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+				if numit < maxit1 && dv > 0 {
+					dalp1 := -v / dv
+					sdalp1, cdalp1 := math.Sin(dalp1), math.Cos(dalp1)
+					nsalp1 := salp1*cdalp1 + calp1*sdalp1
+					if nsalp1 > 0 && math.Abs(dalp1) < math.Pi {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1 = nsalp1
+						norm2(&salp1, &calp1)
+						// In some regimes we don't get quadratic
+						// convergence because slope -> 0.  So use
+						// convergence conditions based on epsilon instead
+						// of sqrt(epsilon).
+						tripn = math.Abs(v) <= 16*tol0
+						continue
+					}
+				}
+				// Either dv was not positive or updated value was outside
+				// legal range.  Use the midpoint of the bracket as the next
+				// estimate.
+				st.Bisections++
+				salp1 = (salp1a + salp1b) / 2
+				calp1 = (calp1a + calp1b) / 2
+				norm2(&salp1, &calp1)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < tolb ||
+					math.Abs(salp1-salp1b)+(calp1-calp1b) < tolb
+			}
+			g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2,
+				cbet1, cbet2, &s12x, &m12x, nil, pM12x, pM21x, ca[:])
+			m12x *= g.b
+			s12x *= g.b
+			a12 = sig12 / degree
+			if outmask&Area != 0 {
+				// omg12 = lam12 - domg12
+				sdomg12, cdomg12 := math.Sin(domg12), math.Cos(domg12)
+				somg12 = slam12*cdomg12 - clam12*sdomg12
+				comg12 = clam12*cdomg12 + slam12*sdomg12
+			}
+		}
+	}
+
+	if outmask&Distance != 0 {
+		s12 = 0 + s12x // Convert -0 to 0
+	}
+	if outmask&ReducedLength != 0 {
+		m12 = 0 + m12x // Convert -0 to 0
+	}
+
+	if outmask&Area != 0 {
+		// From lambda12: sin(alp1) * cos(bet1) = sin(alp0)
+		salp0 := salp1 * cbet1
+		calp0 := math.Hypot(calp1, salp1*sbet1) // calp0 > 0
+		if calp0 != 0 && salp0 != 0 {
+			// From lambda12: tan(bet) = tan(sig) * cos(alp)
+			ssig1, csig1 := sbet1, calp1*cbet1
+			ssig2, csig2 := sbet2, calp2*cbet2
+			k2 := sq(calp0) * g.ep2
+			eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+			// Multiplier = a^2 * e^2 * cos(alpha0) * sin(alpha0).
+			A4 := sq(g.a) * calp0 * salp0 * g.e2
+			norm2(&ssig1, &csig1)
+			norm2(&ssig2, &csig2)
+			g.c4f(eps, ca[:])
+			B41 := sinCosSeries(false, ssig1, csig1, ca[:], nC4)
+			B42 := sinCosSeries(false, ssig2, csig2, ca[:], nC4)
+			S12 = A4 * (B42 - B41)
+		} else {
+			// Avoid problems with indeterminate sig1, sig2 on equator
+			S12 = 0
+		}
+
+		if !meridian && somg12 > 1 {
+			somg12, comg12 = math.Sin(omg12), math.Cos(omg12)
+		}
+
+		var alp12 float64
+		if !meridian &&
+			comg12 > -0.7071 && // Long difference not too big
+			sbet2-sbet1 < 1.75 { // Lat difference not too big
+			// Use tan(Gamma/2) = tan(omg12/2)
+			// * (tan(bet1/2)+tan(bet2/2))/(1+tan(bet1/2)*tan(bet2/2))
+			// with tan(x/2) = sin(x)/(1+cos(x))
+			domg12 := 1 + comg12
+			dbet1 := 1 + cbet1
+			dbet2 := 1 + cbet2
+			alp12 = 2 * math.Atan2(somg12*(sbet1*dbet2+sbet2*dbet1),
+				domg12*(sbet1*sbet2+dbet1*dbet2))
+		} else {
+			// alp12 = alp2 - alp1, used in atan2 so no need to normalize
+			salp12 := salp2*calp1 - calp2*salp1
+			calp12 := calp2*calp1 + salp2*salp1
+			// The right thing appears to happen if alp1 = +/-180 and
+			// alp2 = 0, viz salp12 = -0 and alp12 = -180.  However this
+			// depends on the sign being attached to 0 correctly.
+			if salp12 == 0 && calp12 < 0 {
+				salp12 = tiny * calp1
+				calp12 = -1
+			}
+			alp12 = math.Atan2(salp12, calp12)
+		}
+		S12 += g.c2 * alp12
+		S12 *= swapp * lonsign * latsign
+		// Convert -0 to 0
+		S12 += 0
+	}
+
+	// Convert calp, salp to azimuth accounting for lonsign, swapp, latsign.
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+		if outmask&GeodesicScale != 0 {
+			M12, M21 = M21, M12
+		}
+	}
+
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	if psalp1 != nil {
+		*psalp1 = salp1
+	}
+	if pcalp1 != nil {
+		*pcalp1 = calp1
+	}
+	if psalp2 != nil {
+		*psalp2 = salp2
+	}
+	if pcalp2 != nil {
+		*pcalp2 = calp2
+	}
+	if outmask&Distance != 0 {
+		*ps12 = s12
+	}
+	if outmask&ReducedLength != 0 {
+		*pm12 = m12
+	}
+	if outmask&GeodesicScale != 0 {
+		if pM12 != nil {
+			*pM12 = M12
+		}
+		if pM21 != nil {
+			*pM21 = M21
+		}
+	}
+	if outmask&Area != 0 {
+		*pS12 = S12
+	}
+	if pst != nil {
+		*pst = st
+	}
+	// Returned value in [0, 180]
+	return a12
+}
+
+// GenInverse is the port of geod_geninverse. It returns the arc length
+// a12 (degrees) and any of the out params may be nil.
+func (g *Geodesic) GenInverse(lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2, m12, M12, M21, S12 *float64,
+) float64 {
+	var salp1, calp1, salp2, calp2 float64
+	a12 := g.genInverseInt(lat1, lon1, lat2, lon2, s12,
+		&salp1, &calp1, &salp2, &calp2, m12, M12, M21, S12, nil)
+	if azi1 != nil {
+		*azi1 = atan2dx(salp1, calp1)
+	}
+	if azi2 != nil {
+		*azi2 = atan2dx(salp2, calp2)
+	}
+	return a12
+}
+
+// InverseWithStats is Inverse, and also returns how the problem was solved.
+func (g *Geodesic) InverseWithStats(lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2 *float64,
+) InverseStats {
+	var salp1, calp1, salp2, calp2 float64
+	var st InverseStats
+	g.genInverseInt(lat1, lon1, lat2, lon2, s12,
+		&salp1, &calp1, &salp2, &calp2, nil, nil, nil, nil, &st)
+	if azi1 != nil {
+		*azi1 = atan2dx(salp1, calp1)
+	}
+	if azi2 != nil {
+		*azi2 = atan2dx(salp2, calp2)
+	}
+	return st
+}
+
+// Inverse is the port of geod_inverse.
+func (g *Geodesic) Inverse(lat1, lon1, lat2, lon2 float64,
+	s12, azi1, azi2 *float64,
+) {
+	g.GenInverse(lat1, lon1, lat2, lon2, s12, azi1, azi2, nil, nil, nil, nil)
+}
+
+// GenDirect is the port of geod_gendirect. It returns the arc length a12
+// (degrees) and any of the out params may be nil.
+func (g *Geodesic) GenDirect(lat1, lon1, azi1 float64, flags int,
+	s12a12 float64,
+	lat2, lon2, azi2, s12, m12, M12, M21, S12 *float64,
+) float64 {
+	outmask := outMask(lat2, lon2, azi2, s12, m12, M12, M21, S12)
+	// Automatically supply DistanceIn if necessary
+	if flags&ArcMode == 0 {
+		outmask |= DistanceIn
+	}
+	var l Line
+	g.LineInit(&l, lat1, lon1, azi1, outmask)
+	return l.GenPosition(flags, s12a12,
+		lat2, lon2, azi2, s12, m12, M12, M21, S12)
+}
+
+// Direct is the port of geod_direct.
+func (g *Geodesic) Direct(lat1, lon1, azi1, s12 float64,
+	lat2, lon2, azi2 *float64,
+) {
+	g.GenDirect(lat1, lon1, azi1, NoFlags, s12,
+		lat2, lon2, azi2, nil, nil, nil, nil, nil)
+}
+
+func outMask(lat2, lon2, azi2, s12, m12, M12, M21, S12 *float64) int {
+	outmask := None
+	if lat2 != nil {
+		outmask |= Latitude
+	}
+	if lon2 != nil {
+		outmask |= Longitude
+	}
+	if azi2 != nil {
+		outmask |= Azimuth
+	}
+	if s12 != nil {
+		outmask |= Distance
+	}
+	if m12 != nil {
+		outmask |= ReducedLength
+	}
+	if M12 != nil || M21 != nil {
+		outmask |= GeodesicScale
+	}
+	if S12 != nil {
+		outmask |= Area
+	}
+	return outmask
+}
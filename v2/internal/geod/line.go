@@ -0,0 +1,352 @@
+package geod
+
+import "math"
+
+// Line is the port of struct geod_geodesicline.
+type Line struct {
+	Lat1, Lon1, Azi1 float64
+	A13, S13         float64
+	Caps             int
+
+	a, f, salp1, calp1                            float64
+	b, c2, f1, salp0, calp0, k2                   float64
+	ssig1, csig1, dn1, stau1, ctau1, somg1, comg1 float64
+	A1m1, A2m1, A3c, B11, B21, B31, A4, B41       float64
+	C1a, C1pa, C2a                                [nC]float64
+	C3a, C4a                                      [nC - 1]float64
+}
+
+func (g *Geodesic) lineInitInt(l *Line, lat1, lon1, azi1, salp1, calp1 float64,
+	caps int,
+) {
+	l.a = g.a
+	l.f = g.f
+	l.b = g.b
+	l.c2 = g.c2
+	l.f1 = g.f1
+	// If caps is 0 assume the standard direct calculation
+	if caps == 0 {
+		caps = DistanceIn | Longitude
+	}
+	// always allow latitude and azimuth and unrolling of longitude
+	l.Caps = caps | Latitude | Azimuth | LongUnroll
+
+	l.Lat1 = latFix(lat1)
+	l.Lon1 = lon1
+	l.Azi1 = azi1
+	l.salp1 = salp1
+	l.calp1 = calp1
+
+	var sbet1, cbet1 float64
+	sincosdx(angRound(l.Lat1), &sbet1, &cbet1)
+	sbet1 *= l.f1
+	// Ensure cbet1 = +epsilon at poles
+	norm2(&sbet1, &cbet1)
+	cbet1 = maxx(tiny, cbet1)
+	l.dn1 = math.Sqrt(1 + g.ep2*sq(sbet1))
+
+	// Evaluate alp0 from sin(alp1) * cos(bet1) = sin(alp0),
+	l.salp0 = l.salp1 * cbet1 // alp0 in [0, pi/2 - |bet1|]
+	l.calp0 = math.Hypot(l.calp1, l.salp1*sbet1)
+	// Evaluate sig with tan(bet1) = tan(sig1) * cos(alp1).
+	// Evaluate omg1 with tan(omg1) = sin(alp0) * tan(sig1).
+	l.ssig1 = sbet1
+	l.somg1 = l.salp0 * sbet1
+	if sbet1 != 0 || l.calp1 != 0 {
+		l.csig1 = cbet1 * l.calp1
+	} else {
+		l.csig1 = 1
+	}
+	l.comg1 = l.csig1
+	norm2(&l.ssig1, &l.csig1) // sig1 in (-pi, pi]
+
+	l.k2 = sq(l.calp0) * g.ep2
+	eps := l.k2 / (2*(1+math.Sqrt(1+l.k2)) + l.k2)
+
+	if l.Caps&capC1 != 0 {
+		l.A1m1 = a1m1f(eps)
+		c1f(eps, l.C1a[:])
+		l.B11 = sinCosSeries(true, l.ssig1, l.csig1, l.C1a[:], nC1)
+		s, c := math.Sin(l.B11), math.Cos(l.B11)
+		// tau1 = sig1 + B11
+		l.stau1 = l.ssig1*c + l.csig1*s
+		l.ctau1 = l.csig1*c - l.ssig1*s
+	}
+
+	if l.Caps&capC1p != 0 {
+		c1pf(eps, l.C1pa[:])
+	}
+
+	if l.Caps&capC2 != 0 {
+		l.A2m1 = a2m1f(eps)
+		c2f(eps, l.C2a[:])
+		l.B21 = sinCosSeries(true, l.ssig1, l.csig1, l.C2a[:], nC2)
+	}
+
+	if l.Caps&capC3 != 0 {
+		g.c3f(eps, l.C3a[:])
+		l.A3c = -l.f * l.salp0 * g.a3f(eps)
+		l.B31 = sinCosSeries(true, l.ssig1, l.csig1, l.C3a[:], nC3-1)
+	}
+
+	if l.Caps&capC4 != 0 {
+		g.c4f(eps, l.C4a[:])
+		// Multiplier = a^2 * e^2 * cos(alpha0) * sin(alpha0)
+		l.A4 = sq(l.a) * l.calp0 * l.salp0 * g.e2
+		l.B41 = sinCosSeries(false, l.ssig1, l.csig1, l.C4a[:], nC4)
+	}
+
+	l.A13 = nan
+	l.S13 = nan
+}
+
+// LineInit is the port of geod_lineinit.
+func (g *Geodesic) LineInit(l *Line, lat1, lon1, azi1 float64, caps int) {
+	var salp1, calp1 float64
+	azi1 = angNormalize(azi1)
+	// Guard against underflow in salp0
+	sincosdx(angRound(azi1), &salp1, &calp1)
+	g.lineInitInt(l, lat1, lon1, azi1, salp1, calp1, caps)
+}
+
+// GenDirectLine is the port of geod_gendirectline.
+func (g *Geodesic) GenDirectLine(l *Line, lat1, lon1, azi1 float64,
+	flags int, s12a12 float64, caps int,
+) {
+	g.LineInit(l, lat1, lon1, azi1, caps)
+	l.GenSetDistance(flags, s12a12)
+}
+
+// DirectLine is the port of geod_directline.
+func (g *Geodesic) DirectLine(l *Line, lat1, lon1, azi1, s12 float64,
+	caps int,
+) {
+	g.GenDirectLine(l, lat1, lon1, azi1, NoFlags, s12, caps)
+}
+
+// InverseLine is the port of geod_inverseline.
+func (g *Geodesic) InverseLine(l *Line, lat1, lon1, lat2, lon2 float64,
+	caps int,
+) {
+	var salp1, calp1 float64
+	a12 := g.genInverseInt(lat1, lon1, lat2, lon2, nil,
+		&salp1, &calp1, nil, nil, nil, nil, nil, nil, nil)
+	azi1 := atan2dx(salp1, calp1)
+	if caps == 0 {
+		caps = DistanceIn | Longitude
+	}
+	// Ensure that a12 can be converted to a distance
+	if caps&(outAll&DistanceIn) != 0 {
+		caps |= Distance
+	}
+	g.lineInitInt(l, lat1, lon1, azi1, salp1, calp1, caps)
+	l.setArc(a12)
+}
+
+// GenPosition is the port of geod_genposition. It returns the arc length
+// from point 1 to point 2 (degrees) and any of the out params may be nil.
+func (l *Line) GenPosition(flags int, s12a12 float64,
+	plat2, plon2, pazi2, ps12, pm12, pM12, pM21, pS12 *float64,
+) float64 {
+	var lat2, lon2, azi2, s12, m12, M12, M21, S12 float64
+	var sig12, ssig12, csig12, B12, AB1 float64
+	var ssig2, csig2 float64
+	outmask := outMask(plat2, plon2, pazi2, ps12, pm12, pM12, pM21, pS12)
+	outmask &= l.Caps & outAll
+	if !(flags&ArcMode != 0 || l.Caps&(DistanceIn&outAll) != 0) {
+		// Impossible distance calculation requested
+		return nan
+	}
+
+	if flags&ArcMode != 0 {
+		// Interpret s12a12 as spherical arc length
+		sig12 = s12a12 * degree
+		sincosdx(s12a12, &ssig12, &csig12)
+	} else {
+		// Interpret s12a12 as distance
+		tau12 := s12a12 / (l.b * (1 + l.A1m1))
+		s, c := math.Sin(tau12), math.Cos(tau12)
+		// tau2 = tau1 + tau12
+		B12 = -sinCosSeries(true,
+			l.stau1*c+l.ctau1*s,
+			l.ctau1*c-l.stau1*s,
+			l.C1pa[:], nC1p)
+		sig12 = tau12 - (B12 - l.B11)
+		ssig12, csig12 = math.Sin(sig12), math.Cos(sig12)
+		if math.Abs(l.f) > 0.01 {
+			// Reverted distance series is inaccurate for |f| > 1/100, so
+			// correct sig12 with 1 Newton iteration.
+			ssig2 = l.ssig1*csig12 + l.csig1*ssig12
+			csig2 = l.csig1*csig12 - l.ssig1*ssig12
+			B12 = sinCosSeries(true, ssig2, csig2, l.C1a[:], nC1)
+			serr := (1+l.A1m1)*(sig12+(B12-l.B11)) - s12a12/l.b
+			sig12 = sig12 - serr/math.Sqrt(1+l.k2*sq(ssig2))
+			ssig12, csig12 = math.Sin(sig12), math.Cos(sig12)
+			// Update B12 below
+		}
+	}
+
+	// sig2 = sig1 + sig12
+	ssig2 = l.ssig1*csig12 + l.csig1*ssig12
+	csig2 = l.csig1*csig12 - l.ssig1*ssig12
+	dn2 := math.Sqrt(1 + l.k2*sq(ssig2))
+	if outmask&(Distance|ReducedLength|GeodesicScale) != 0 {
+		if flags&ArcMode != 0 || math.Abs(l.f) > 0.01 {
+			B12 = sinCosSeries(true, ssig2, csig2, l.C1a[:], nC1)
+		}
+		AB1 = (1 + l.A1m1) * (B12 - l.B11)
+	}
+	// sin(bet2) = cos(alp0) * sin(sig2)
+	sbet2 := l.calp0 * ssig2
+	// Alt: cbet2 = hypot(csig2, salp0 * ssig2);
+	cbet2 := math.Hypot(l.salp0, l.calp0*csig2)
+	if cbet2 == 0 {
+		// I.e., salp0 = 0, csig2 = 0.  Break the degeneracy in this case
+		cbet2 = tiny
+		csig2 = tiny
+	}
+	// tan(alp0) = cos(sig2)*tan(alp2)
+	salp2 := l.salp0
+	calp2 := l.calp0 * csig2 // No need to normalize
+
+	if outmask&Distance != 0 {
+		if flags&ArcMode != 0 {
+			s12 = l.b * ((1+l.A1m1)*sig12 + AB1)
+		} else {
+			s12 = s12a12
+		}
+	}
+
+	if outmask&Longitude != 0 {
+		E := math.Copysign(1, l.salp0) // east or west going?
+		// tan(omg2) = sin(alp0) * tan(sig2)
+		somg2 := l.salp0 * ssig2
+		comg2 := csig2 // No need to normalize
+		// omg12 = omg2 - omg1
+		var omg12 float64
+		if flags&LongUnroll != 0 {
+			omg12 = E * (sig12 -
+				(math.Atan2(ssig2, csig2) - math.Atan2(l.ssig1, l.csig1)) +
+				(math.Atan2(E*somg2, comg2) - math.Atan2(E*l.somg1, l.comg1)))
+		} else {
+			omg12 = math.Atan2(somg2*l.comg1-comg2*l.somg1,
+				comg2*l.comg1+somg2*l.somg1)
+		}
+		lam12 := omg12 + l.A3c*(sig12+
+			(sinCosSeries(true, ssig2, csig2, l.C3a[:], nC3-1)-l.B31))
+		lon12 := lam12 / degree
+		if flags&LongUnroll != 0 {
+			lon2 = l.Lon1 + lon12
+		} else {
+			lon2 = angNormalize(angNormalize(l.Lon1) + angNormalize(lon12))
+		}
+	}
+
+	if outmask&Latitude != 0 {
+		lat2 = atan2dx(sbet2, l.f1*cbet2)
+	}
+
+	if outmask&Azimuth != 0 {
+		azi2 = atan2dx(salp2, calp2)
+	}
+
+	if outmask&(ReducedLength|GeodesicScale) != 0 {
+		B22 := sinCosSeries(true, ssig2, csig2, l.C2a[:], nC2)
+		AB2 := (1 + l.A2m1) * (B22 - l.B21)
+		J12 := (l.A1m1-l.A2m1)*sig12 + (AB1 - AB2)
+		if outmask&ReducedLength != 0 {
+			// Add parens around (csig1 * ssig2) and (ssig1 * csig2) to
+			// ensure accurate cancellation in the case of coincident
+			// points.
+			m12 = l.b * ((dn2*(l.csig1*ssig2) - l.dn1*(l.ssig1*csig2)) -
+				l.csig1*csig2*J12)
+		}
+		if outmask&GeodesicScale != 0 {
+			t := l.k2 * (ssig2 - l.ssig1) * (ssig2 + l.ssig1) / (l.dn1 + dn2)
+			M12 = csig12 + (t*ssig2-csig2*J12)*l.ssig1/l.dn1
+			M21 = csig12 - (t*l.ssig1-l.csig1*J12)*ssig2/dn2
+		}
+	}
+
+	if outmask&Area != 0 {
+		B42 := sinCosSeries(false, ssig2, csig2, l.C4a[:], nC4)
+		var salp12, calp12 float64
+		if l.calp0 == 0 || l.salp0 == 0 {
+			// alp12 = alp2 - alp1, used in atan2 so no need to normalize
+			salp12 = salp2*l.calp1 - calp2*l.salp1
+			calp12 = calp2*l.calp1 + salp2*l.salp1
+		} else {
+			// tan(alp) = tan(alp0) * sec(sig)
+			// tan(alp2-alp1) = (tan(alp2) -tan(alp1)) / (tan(alp2)*tan(alp1)+1)
+			// = calp0 * salp0 * (csig1-csig2) / (salp0^2 + calp0^2 * csig1*csig2)
+			if csig12 <= 0 {
+				salp12 = l.calp0 * l.salp0 *
+					(l.csig1*(1-csig12) + ssig12*l.ssig1)
+			} else {
+				salp12 = l.calp0 * l.salp0 *
+					(ssig12 * (l.csig1*ssig12/(1+csig12) + l.ssig1))
+			}
+			calp12 = sq(l.salp0) + sq(l.calp0)*l.csig1*csig2
+		}
+		S12 = l.c2*math.Atan2(salp12, calp12) + l.A4*(B42-l.B41)
+	}
+
+	if outmask&Latitude != 0 && plat2 != nil {
+		*plat2 = lat2
+	}
+	if outmask&Longitude != 0 && plon2 != nil {
+		*plon2 = lon2
+	}
+	if outmask&Azimuth != 0 && pazi2 != nil {
+		*pazi2 = azi2
+	}
+	if outmask&Distance != 0 && ps12 != nil {
+		*ps12 = s12
+	}
+	if outmask&ReducedLength != 0 && pm12 != nil {
+		*pm12 = m12
+	}
+	if outmask&GeodesicScale != 0 {
+		if pM12 != nil {
+			*pM12 = M12
+		}
+		if pM21 != nil {
+			*pM21 = M21
+		}
+	}
+	if outmask&Area != 0 && pS12 != nil {
+		*pS12 = S12
+	}
+
+	if flags&ArcMode != 0 {
+		return s12a12
+	}
+	return sig12 / degree
+}
+
+// SetDistance is the port of geod_setdistance.
+func (l *Line) SetDistance(s13 float64) {
+	l.S13 = s13
+	l.A13 = l.GenPosition(NoFlags, l.S13,
+		nil, nil, nil, nil, nil, nil, nil, nil)
+}
+
+func (l *Line) setArc(a13 float64) {
+	l.A13 = a13
+	l.S13 = nan
+	l.GenPosition(ArcMode, l.A13, nil, nil, nil, &l.S13, nil, nil, nil, nil)
+}
+
+// GenSetDistance is the port of geod_gensetdistance.
+func (l *Line) GenSetDistance(flags int, s13a13 float64) {
+	if flags&ArcMode != 0 {
+		l.setArc(s13a13)
+	} else {
+		l.SetDistance(s13a13)
+	}
+}
+
+// Position is the port of geod_position.
+func (l *Line) Position(s12 float64, lat2, lon2, azi2 *float64) {
+	l.GenPosition(NoFlags, s12, lat2, lon2, azi2, nil, nil, nil, nil, nil)
+}
@@ -0,0 +1,215 @@
+package geod
+
+import "math"
+
+// The coefficient tables are for the highest order, nT, and a lower order,
+// selected with a build tag, see Order, uses the leading terms of each
+// series from them.
+const (
+	nT    = 6
+	order = Order
+	nA1   = order
+	nC1   = order
+	nC1p  = order
+	nA2   = order
+	nC2   = order
+	nA3   = order
+	nA3x  = nA3
+	nC3   = order
+	nC3x  = (nC3 * (nC3 - 1)) / 2
+	nC4   = order
+	nC4x  = (nC4 * (nC4 + 1)) / 2
+	nC    = order + 1
+)
+
+const (
+	digits  = 53
+	epsilon = 0x1p-52
+	realmin = 0x1p-1022
+	maxit1  = 20
+	maxit2  = maxit1 + digits + 10
+	degree  = math.Pi / 180
+)
+
+var (
+	nan     = math.NaN()
+	tiny    = math.Sqrt(realmin)
+	tol0    = epsilon
+	tol1    = 200 * tol0
+	tol2    = math.Sqrt(tol0)
+	tolb    = tol0 * tol2
+	xthresh = 1000 * tol2
+)
+
+func sq(x float64) float64 { return x * x }
+
+// sumx returns the error-free sum s = round(u + v) and sets t to the
+// round-off so that u + v = s + t.
+func sumx(u, v float64, t *float64) float64 {
+	s := u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	if t != nil {
+		*t = -(up + vpp)
+	}
+	return s
+}
+
+func polyval(n int, p []float64, x float64) float64 {
+	var y float64
+	if n >= 0 {
+		y = p[0]
+		for i := 1; i <= n; i++ {
+			y = y*x + p[i]
+		}
+	}
+	return y
+}
+
+func minx(a, b float64) float64 {
+	if b < a {
+		return b
+	}
+	return a
+}
+
+func maxx(a, b float64) float64 {
+	if a < b {
+		return b
+	}
+	return a
+}
+
+func norm2(sinx, cosx *float64) {
+	r := math.Hypot(*sinx, *cosx)
+	*sinx /= r
+	*cosx /= r
+}
+
+func angNormalize(x float64) float64 {
+	x = math.Remainder(x, 360)
+	if x != -180 {
+		return x
+	}
+	return 180
+}
+
+func latFix(x float64) float64 {
+	if math.Abs(x) > 90 {
+		return nan
+	}
+	return x
+}
+
+func angDiff(x, y float64, e *float64) float64 {
+	var t float64
+	d := angNormalize(sumx(angNormalize(-x), angNormalize(y), &t))
+	if d == 180 && t > 0 {
+		d = -180
+	}
+	return sumx(d, t, e)
+}
+
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	if x == 0 {
+		return 0
+	}
+	y := math.Abs(x)
+	// The compiler mustn't "simplify" z - (z - y) to y
+	if y < z {
+		y = z - (z - y)
+	}
+	if x < 0 {
+		return -y
+	}
+	return y
+}
+
+// remquo90 mirrors C's remquo(x, 90, &q), returning the remainder and the
+// low bits of the quotient.
+func remquo90(x float64) (float64, int) {
+	r := math.Remainder(x, 90)
+	if math.IsNaN(r) {
+		return r, 0
+	}
+	return r, int(math.Round((x - r) / 90))
+}
+
+func sincosdx(x float64, sinx, cosx *float64) {
+	r, q := remquo90(x)
+	r *= degree
+	s, c := math.Sin(r), math.Cos(r)
+	switch uint(q) & 3 {
+	case 0:
+		*sinx, *cosx = s, c
+	case 1:
+		*sinx, *cosx = c, -s
+	case 2:
+		*sinx, *cosx = -s, -c
+	default:
+		*sinx, *cosx = -c, s
+	}
+	if x != 0 {
+		*sinx += 0
+		*cosx += 0
+	}
+}
+
+func atan2dx(y, x float64) float64 {
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		x, y = y, x
+		q = 2
+	}
+	if x < 0 {
+		x = -x
+		q++
+	}
+	ang := math.Atan2(y, x) / degree
+	switch q {
+	case 1:
+		if y >= 0 {
+			ang = 180 - ang
+		} else {
+			ang = -180 - ang
+		}
+	case 2:
+		ang = 90 - ang
+	case 3:
+		ang = -90 + ang
+	}
+	return ang
+}
+
+// sinCosSeries evaluates
+//
+//	sinp ? sum(c[i] * sin( 2*i    * x), i, 1, n) :
+//	       sum(c[i] * cos((2*i+1) * x), i, 0, n-1)
+//
+// using Clenshaw summation.
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64, n int) float64 {
+	k := n
+	if sinp {
+		k++
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	n /= 2
+	for ; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	if sinp {
+		return 2 * sinx * cosx * y0
+	}
+	return cosx * (y0 - y1)
+}
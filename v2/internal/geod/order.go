@@ -0,0 +1,8 @@
+//go:build !geodesic_order3 && !geodesic_order4 && !geodesic_order5
+
+package geod
+
+// Order is the order of the series expansions, the
+// GEOGRAPHICLIB_GEODESIC_ORDER of geodesic.c. It is 6 unless one of the
+// geodesic_order3, geodesic_order4, or geodesic_order5 build tags is set.
+const Order = 6
@@ -0,0 +1,6 @@
+//go:build geodesic_order4
+
+package geod
+
+// Order is the order of the series expansions, see order.go.
+const Order = 4
@@ -0,0 +1,329 @@
+package geod
+
+import "math"
+
+// Polygon is a port of struct geod_polygon.
+//
+// The perimeter and area are accumulated at twice the float64 precision,
+// with the double-double accumulators of the C library.
+type Polygon struct {
+	Lat, Lon   float64
+	lat0, lon0 float64
+	a, p       [2]float64
+	Polyline   bool
+	crossings  int
+	Num        int
+}
+
+// Init is the port of geod_polygon_init.
+func (p *Polygon) Init(polyline bool) {
+	p.Polyline = polyline
+	p.Clear()
+}
+
+// Clear is the port of geod_polygon_clear.
+func (p *Polygon) Clear() {
+	p.lat0, p.lon0, p.Lat, p.Lon = nan, nan, nan, nan
+	p.a, p.p = [2]float64{}, [2]float64{}
+	p.Num, p.crossings = 0, 0
+}
+
+// AddPoint is the port of geod_polygon_addpoint.
+func (p *Polygon) AddPoint(g *Geodesic, lat, lon float64) {
+	lon = angNormalize(lon)
+	if p.Num == 0 {
+		p.lat0, p.Lat = lat, lat
+		p.lon0, p.Lon = lon, lon
+	} else {
+		var s12, S12 float64
+		var pS12 *float64
+		if !p.Polyline {
+			pS12 = &S12
+		}
+		g.GenInverse(p.Lat, p.Lon, lat, lon,
+			&s12, nil, nil, nil, nil, nil, pS12)
+		accadd(&p.p, s12)
+		if !p.Polyline {
+			accadd(&p.a, S12)
+			p.crossings += transit(p.Lon, lon)
+		}
+		p.Lat, p.Lon = lat, lon
+	}
+	p.Num++
+}
+
+// AddEdge is the port of geod_polygon_addedge.
+func (p *Polygon) AddEdge(g *Geodesic, azi, s float64) {
+	if p.Num == 0 {
+		// Do nothing if num is zero
+		return
+	}
+	var lat, lon, S12 float64
+	var pS12 *float64
+	if !p.Polyline {
+		pS12 = &S12
+	}
+	g.GenDirect(p.Lat, p.Lon, azi, LongUnroll, s,
+		&lat, &lon, nil, nil, nil, nil, nil, pS12)
+	accadd(&p.p, s)
+	if !p.Polyline {
+		accadd(&p.a, S12)
+		p.crossings += transitDirect(p.Lon, lon)
+	}
+	p.Lat, p.Lon = lat, lon
+	p.Num++
+}
+
+// Compute is the port of geod_polygon_compute.
+func (p *Polygon) Compute(g *Geodesic, reverse, sign bool,
+	pA, pP *float64,
+) int {
+	if p.Num < 2 {
+		if pP != nil {
+			*pP = 0
+		}
+		if !p.Polyline && pA != nil {
+			*pA = 0
+		}
+		return p.Num
+	}
+	if p.Polyline {
+		if pP != nil {
+			*pP = p.p[0]
+		}
+		return p.Num
+	}
+	var s12, S12 float64
+	g.GenInverse(p.Lat, p.Lon, p.lat0, p.lon0,
+		&s12, nil, nil, nil, nil, nil, &S12)
+	if pP != nil {
+		*pP = accsum(p.p, s12)
+	}
+	if pA != nil {
+		t := p.a
+		accadd(&t, S12)
+		*pA = areaReduceA(&t, 4*math.Pi*g.c2,
+			p.crossings+transit(p.Lon, p.lon0), reverse, sign)
+	}
+	return p.Num
+}
+
+// TestPoint is the port of geod_polygon_testpoint.
+func (p *Polygon) TestPoint(g *Geodesic, lat, lon float64, reverse, sign bool,
+	pA, pP *float64,
+) int {
+	num := p.Num + 1
+	if num == 1 {
+		if pP != nil {
+			*pP = 0
+		}
+		if !p.Polyline && pA != nil {
+			*pA = 0
+		}
+		return num
+	}
+	perimeter := p.p[0]
+	var tempsum float64
+	if !p.Polyline {
+		tempsum = p.a[0]
+	}
+	crossings := p.crossings
+	n := 2
+	if p.Polyline {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		lat1, lon1, lat2, lon2 := p.Lat, p.Lon, lat, lon
+		if i != 0 {
+			lat1, lon1, lat2, lon2 = lat, lon, p.lat0, p.lon0
+		}
+		var s12, S12 float64
+		var pS12 *float64
+		if !p.Polyline {
+			pS12 = &S12
+		}
+		g.GenInverse(lat1, lon1, lat2, lon2,
+			&s12, nil, nil, nil, nil, nil, pS12)
+		perimeter += s12
+		if !p.Polyline {
+			tempsum += S12
+			crossings += transit(lon1, lon2)
+		}
+	}
+	if pP != nil {
+		*pP = perimeter
+	}
+	if p.Polyline {
+		return num
+	}
+	if pA != nil {
+		*pA = areaReduce(tempsum, 4*math.Pi*g.c2, crossings, reverse, sign)
+	}
+	return num
+}
+
+// TestEdge is the port of geod_polygon_testedge.
+func (p *Polygon) TestEdge(g *Geodesic, azi, s float64, reverse, sign bool,
+	pA, pP *float64,
+) int {
+	num := p.Num + 1
+	if num == 1 {
+		// we don't have a starting point!
+		if pP != nil {
+			*pP = nan
+		}
+		if !p.Polyline && pA != nil {
+			*pA = nan
+		}
+		return 0
+	}
+	perimeter := p.p[0] + s
+	if p.Polyline {
+		if pP != nil {
+			*pP = perimeter
+		}
+		return num
+	}
+	tempsum := p.a[0]
+	crossings := p.crossings
+	var lat, lon, s12, S12 float64
+	g.GenDirect(p.Lat, p.Lon, azi, LongUnroll, s,
+		&lat, &lon, nil, nil, nil, nil, nil, &S12)
+	tempsum += S12
+	crossings += transitDirect(p.Lon, lon)
+	g.GenInverse(lat, lon, p.lat0, p.lon0,
+		&s12, nil, nil, nil, nil, nil, &S12)
+	perimeter += s12
+	tempsum += S12
+	crossings += transit(lon, p.lon0)
+	if pP != nil {
+		*pP = perimeter
+	}
+	if pA != nil {
+		*pA = areaReduce(tempsum, 4*math.Pi*g.c2, crossings, reverse, sign)
+	}
+	return num
+}
+
+// accadd adds y to an accumulator, the port of accadd.
+func accadd(s *[2]float64, y float64) {
+	var u float64
+	z := sumx(y, s[1], &u)
+	s[0] = sumx(z, s[0], &s[1])
+	if s[0] == 0 {
+		s[0] = u
+	} else {
+		s[1] = s[1] + u
+	}
+}
+
+// accsum returns the accumulator plus y, without adding to it, the port of
+// accsum.
+func accsum(s [2]float64, y float64) float64 {
+	accadd(&s, y)
+	return s[0]
+}
+
+// accrem reduces an accumulator to [-y/2, y/2], the port of accrem.
+func accrem(s *[2]float64, y float64) {
+	s[0] = math.Remainder(s[0], y)
+	accadd(s, 0)
+}
+
+// transit returns 1 or -1 if crossing the prime meridian in the east or
+// west direction, otherwise zero.
+func transit(lon1, lon2 float64) int {
+	// Compute lon12 the same way as Geodesic.Inverse.
+	lon1 = angNormalize(lon1)
+	lon2 = angNormalize(lon2)
+	lon12 := angDiff(lon1, lon2, nil)
+	if lon1 <= 0 && lon2 > 0 && lon12 > 0 {
+		return 1
+	}
+	if lon2 <= 0 && lon1 > 0 && lon12 < 0 {
+		return -1
+	}
+	return 0
+}
+
+// transitDirect computes exactly the parity of
+// int(ceil(lon2 / 360)) - int(ceil(lon1 / 360))
+func transitDirect(lon1, lon2 float64) int {
+	lon1 = math.Remainder(lon1, 720)
+	lon2 = math.Remainder(lon2, 720)
+	var a, b int
+	if lon2 <= 0 && lon2 > -360 {
+		a = 1
+	}
+	if lon1 <= 0 && lon1 > -360 {
+		b = 1
+	}
+	return a - b
+}
+
+// areaReduceA is the port of areareduceA, reducing an accumulator of area.
+func areaReduceA(area *[2]float64, area0 float64, crossings int,
+	reverse, sign bool,
+) float64 {
+	accrem(area, area0)
+	if crossings&1 != 0 {
+		if area[0] < 0 {
+			accadd(area, area0/2)
+		} else {
+			accadd(area, -area0/2)
+		}
+	}
+	// area is with the clockwise sense.  If !reverse convert to
+	// counter-clockwise convention.
+	if !reverse {
+		area[0], area[1] = -area[0], -area[1]
+	}
+	// If sign put area in (-area0/2, area0/2], else put area in [0, area0)
+	if sign {
+		if area[0] > area0/2 {
+			accadd(area, -area0)
+		} else if area[0] <= -area0/2 {
+			accadd(area, area0)
+		}
+	} else {
+		if area[0] >= area0 {
+			accadd(area, -area0)
+		} else if area[0] < 0 {
+			accadd(area, area0)
+		}
+	}
+	return 0 + area[0]
+}
+
+// areaReduce is the port of areareduceB, reducing a plain area.
+func areaReduce(area, area0 float64, crossings int, reverse, sign bool,
+) float64 {
+	area = math.Remainder(area, area0)
+	if crossings&1 != 0 {
+		if area < 0 {
+			area += area0 / 2
+		} else {
+			area -= area0 / 2
+		}
+	}
+	// area is with the clockwise sense.  If !reverse convert to
+	// counter-clockwise convention.
+	if !reverse {
+		area *= -1
+	}
+	// If sign put area in (-area0/2, area0/2], else put area in [0, area0)
+	if sign {
+		if area > area0/2 {
+			area -= area0
+		} else if area <= -area0/2 {
+			area += area0
+		}
+	} else {
+		if area >= area0 {
+			area -= area0
+		} else if area < 0 {
+			area += area0
+		}
+	}
+	return 0 + area
+}
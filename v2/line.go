@@ -0,0 +1,218 @@
+package geodesic
+
+import "math"
+
+// Line computes many points along a single geodesic. It is made by
+// Ellipsoid.NewLine, Ellipsoid.NewDirectLine, or Ellipsoid.NewInverseLine,
+// or initialized in place by their Init counterparts.
+type Line struct {
+	l        coreLine
+	azimuths AzimuthConvention
+	err      error // why the line could not be made, if it could not
+}
+
+// NewLine creates a geodesic line starting at a point with an azimuth.
+//
+// Param p1 is point 1.
+// Param azi1 is the azimuth at point 1 (degrees).
+//
+// Point 3 of the line is undefined and Line.Distance returns NaN.
+func (e *Ellipsoid) NewLine(p1 LatLng, azi1 float64) (*Line, error) {
+	l := new(Line)
+	if err := e.InitLine(l, p1, azi1); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// InitLine initializes *l in place, as NewLine does, without the
+// allocation of NewLine. If it returns an error, the methods of the line
+// return it too, along with NaN results.
+func (e *Ellipsoid) InitLine(l *Line, p1 LatLng, azi1 float64) error {
+	p1, err := e.linePoint(l, p1)
+	if err == nil {
+		err = checkFinite(azi1)
+	}
+	if l.err = err; err == nil {
+		e.c.lineInit(&l.l, p1.Lat, p1.Lon, azi1)
+	}
+	return err
+}
+
+// NewDirectLine creates a geodesic line in terms of the direct geodesic
+// problem.
+//
+// Param p1 is point 1.
+// Param azi1 is the azimuth at point 1 (degrees).
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+//
+// Point 3 of the line is set to point 2 of the direct geodesic problem.
+func (e *Ellipsoid) NewDirectLine(p1 LatLng, azi1, s12 float64,
+) (*Line, error) {
+	l := new(Line)
+	if err := e.InitDirectLine(l, p1, azi1, s12); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// InitDirectLine initializes *l in place, as NewDirectLine does.
+func (e *Ellipsoid) InitDirectLine(l *Line, p1 LatLng, azi1, s12 float64,
+) error {
+	p1, err := e.linePoint(l, p1)
+	if err == nil {
+		err = checkFinite(azi1)
+	}
+	if err == nil {
+		err = checkFinite(s12)
+	}
+	if l.err = err; err == nil {
+		e.c.directLine(&l.l, p1.Lat, p1.Lon, azi1, s12)
+	}
+	return err
+}
+
+// NewInverseLine creates a geodesic line in terms of the inverse geodesic
+// problem.
+//
+// Param p1 is point 1.
+// Param p2 is point 2.
+//
+// Point 3 of the line is set to point 2 of the inverse geodesic problem.
+func (e *Ellipsoid) NewInverseLine(p1, p2 LatLng) (*Line, error) {
+	l := new(Line)
+	if err := e.InitInverseLine(l, p1, p2); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// InitInverseLine initializes *l in place, as NewInverseLine does.
+func (e *Ellipsoid) InitInverseLine(l *Line, p1, p2 LatLng) error {
+	p1, err := e.linePoint(l, p1)
+	if err == nil {
+		p2, err = e.point(p2)
+	}
+	if l.err = err; err == nil {
+		e.c.inverseLine(&l.l, p1.Lat, p1.Lon, p2.Lat, p2.Lon)
+	}
+	return err
+}
+
+// linePoint starts the initialization of a line and checks its point 1.
+func (e *Ellipsoid) linePoint(l *Line, p1 LatLng) (LatLng, error) {
+	l.azimuths = e.opts.Azimuths
+	if e.err != nil {
+		return p1, e.err
+	}
+	return e.point(p1)
+}
+
+// Position computes the position along the line.
+//
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+// Param caps selects the quantities to compute, Latitude, Longitude, and
+// Azimuth.
+//
+// The longitude of point 2 is in the range [-180,+180].
+func (l *Line) Position(s12 float64, caps Caps) (DirectResult, error) {
+	if err := l.check(s12); err != nil {
+		return nanDirect, err
+	}
+	r := l.l.position(s12, caps)
+	if caps&Azimuth != 0 {
+		l.azimuths.apply(&r.Azi2)
+	}
+	return r, nil
+}
+
+// ArcPosition computes the position along the line in terms of the
+// spherical arc length.
+//
+// Param a12 is the arc length from point 1 to point 2 (degrees). negative
+// is ok.
+// Param caps selects the quantities to compute, as for Line.Position.
+//
+// The arc length is measured on the auxiliary sphere, so equal arc steps
+// are nearly, but not exactly, equal distances on the ground.
+func (l *Line) ArcPosition(a12 float64, caps Caps) (DirectResult, error) {
+	if err := l.check(a12); err != nil {
+		return nanDirect, err
+	}
+	r := l.l.arcPosition(a12, caps)
+	if caps&Azimuth != 0 {
+		l.azimuths.apply(&r.Azi2)
+	}
+	return r, nil
+}
+
+// ScalesResult is the reduced length and geodesic scales at a position
+// along a line.
+type ScalesResult struct {
+	ReducedLength float64 // reduced length m12 of the geodesic (meters)
+	M12           float64 // geodesic scale of point 2 relative to point 1
+	M21           float64 // geodesic scale of point 1 relative to point 2
+}
+
+// Scales computes the reduced length and geodesic scales at a position
+// along the line.
+//
+// Param s12 is the distance from point 1 to point 2 (meters). negative is ok.
+//
+// These are the Jacobian of the geodesic. Turning the line at point 1 by a
+// small angle dazi1 (radians) moves point 2 sideways by m12 dazi1, and
+// moving point 1 sideways by dt, keeping the line parallel, moves point 2
+// sideways by M12 dt. M21 is the same with the roles of the points
+// swapped.
+func (l *Line) Scales(s12 float64) (ScalesResult, error) {
+	if err := l.check(s12); err != nil {
+		return ScalesResult{math.NaN(), math.NaN(), math.NaN()}, err
+	}
+	m12, M12, M21 := l.l.scales(s12)
+	return ScalesResult{m12, M12, M21}, nil
+}
+
+// check returns the error of the line, or of an argument of a method.
+func (l *Line) check(x float64) error {
+	if l.err != nil {
+		return l.err
+	}
+	return checkFinite(x)
+}
+
+// Point1 returns point 1 of the line, or NaN for a line that could not be
+// made.
+func (l *Line) Point1() LatLng {
+	if l.err != nil {
+		return LatLng{math.NaN(), math.NaN()}
+	}
+	return LatLng{l.l.lat1(), l.l.lon1()}
+}
+
+// Azi1 returns the azimuth at point 1 (degrees).
+func (l *Line) Azi1() float64 {
+	if l.err != nil {
+		return math.NaN()
+	}
+	azi1 := l.l.azi1()
+	l.azimuths.apply(&azi1)
+	return azi1
+}
+
+// Distance returns the distance from point 1 to point 3 (meters). It is
+// NaN for a line from Ellipsoid.NewLine.
+func (l *Line) Distance() float64 {
+	if l.err != nil {
+		return math.NaN()
+	}
+	return l.l.distance()
+}
+
+// Arc returns the arc length from point 1 to point 3 (degrees). Like
+// Line.Distance, it is NaN for a line from Ellipsoid.NewLine.
+func (l *Line) Arc() float64 {
+	if l.err != nil {
+		return math.NaN()
+	}
+	return l.l.arc()
+}
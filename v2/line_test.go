@@ -0,0 +1,92 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	p1, p2 := LatLng{Lat: 40.64, Lon: -73.78}, LatLng{Lat: 1.36, Lon: 103.99}
+	inv, _ := WGS84.Inverse(p1, p2, All)
+	l, err := WGS84.NewInverseLine(p1, p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Point1() != p1 || math.Abs(l.Azi1()-inv.Azi1) > 1e-12 ||
+		math.Abs(l.Distance()-inv.Distance) > 1e-6 ||
+		math.Abs(l.Arc()-inv.Arc) > 1e-12 {
+		t.Fatalf("unexpected line %v %v %v %v", l.Point1(), l.Azi1(),
+			l.Distance(), l.Arc())
+	}
+	// The positions are those of Direct.
+	for _, s := range []float64{0, 1e3, 5e6, l.Distance(), -2e6} {
+		r, err := l.Position(s, All)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, _ := WGS84.Direct(p1, inv.Azi1, s, All)
+		if math.Abs(r.Point.Lat-d.Point.Lat) > 1e-9 ||
+			math.Abs(r.Point.Lon-d.Point.Lon) > 1e-9 ||
+			math.Abs(r.Azi2-d.Azi2) > 1e-9 {
+			t.Fatalf("%v: expected %v, got %v", s, d, r)
+		}
+	}
+	r, _ := l.ArcPosition(l.Arc(), Latitude|Longitude)
+	if math.Abs(r.Point.Lat-p2.Lat) > 1e-9 ||
+		math.Abs(r.Point.Lon-p2.Lon) > 1e-9 || !math.IsNaN(r.Azi2) {
+		t.Fatalf("expected %v, got %v", p2, r)
+	}
+	sc, err := l.Scales(1e3)
+	if err != nil || !(math.Abs(sc.ReducedLength-1e3) < 1e-3) ||
+		!(math.Abs(sc.M12-1) < 1e-6) || !(math.Abs(sc.M21-1) < 1e-6) {
+		t.Fatalf("unexpected scales %v, %v", sc, err)
+	}
+	if _, err := l.Position(math.NaN(), All); err != ErrInvalidArgument {
+		t.Fatalf("expected %v, got %v", ErrInvalidArgument, err)
+	}
+
+	l, _ = WGS84.NewDirectLine(p1, 30, 1e6)
+	if l.Distance() != 1e6 || math.IsNaN(l.Arc()) {
+		t.Fatalf("expected a distance of 1e6, got %v", l.Distance())
+	}
+	l, _ = WGS84.NewLine(p1, 30)
+	if !math.IsNaN(l.Distance()) || !math.IsNaN(l.Arc()) {
+		t.Fatalf("expected no point 3, got %v %v", l.Distance(), l.Arc())
+	}
+}
+
+func TestInitLine(t *testing.T) {
+	var l Line
+	if err := WGS84.InitLine(&l, LatLng{Lat: 91, Lon: 0}, 0); err != ErrInvalidLatitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLatitude, err)
+	}
+	if r, err := l.Position(1e3, All); err != ErrInvalidLatitude ||
+		!math.IsNaN(r.Point.Lat) {
+		t.Fatalf("expected %v and NaN, got %v, %v", ErrInvalidLatitude, err, r)
+	}
+	if !math.IsNaN(l.Point1().Lat) || !math.IsNaN(l.Azi1()) {
+		t.Fatalf("expected NaN, got %v %v", l.Point1(), l.Azi1())
+	}
+	if err := WGS84.InitDirectLine(&l, LatLng{}, 0, math.Inf(1)); err != ErrInvalidArgument {
+		t.Fatalf("expected %v, got %v", ErrInvalidArgument, err)
+	}
+	if _, err := WGS84.NewInverseLine(LatLng{}, LatLng{Lon: math.NaN()}); err != ErrInvalidLongitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLongitude, err)
+	}
+	// A line is reinitialized and used in place without allocating.
+	var r DirectResult
+	if n := testing.AllocsPerRun(100, func() {
+		WGS84.InitInverseLine(&l, LatLng{Lat: 10, Lon: 20},
+			LatLng{Lat: 30, Lon: 40})
+		r, _ = l.Position(l.Distance()/2, Latitude)
+	}); n != 0 {
+		t.Fatalf("expected no allocations, got %v", n)
+	}
+	if !(r.Point.Lat > 10 && r.Point.Lat < 30) {
+		t.Fatalf("expected a midpoint, got %v", r)
+	}
+	e, _ := New(6378137.0, 1/298.257223563, &Options{Azimuths: AzimuthUnsigned})
+	if l, _ := e.NewLine(LatLng{}, -90); l.Azi1() != 270 {
+		t.Fatalf("expected 270, got %v", l.Azi1())
+	}
+}
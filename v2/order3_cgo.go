@@ -0,0 +1,8 @@
+//go:build geodesic_order3 && !system_geographiclib
+
+package geodesic
+
+/*
+#cgo CFLAGS: -DGEOGRAPHICLIB_GEODESIC_ORDER=3
+*/
+import "C"
@@ -0,0 +1,8 @@
+//go:build geodesic_order4 && !system_geographiclib
+
+package geodesic
+
+/*
+#cgo CFLAGS: -DGEOGRAPHICLIB_GEODESIC_ORDER=4
+*/
+import "C"
@@ -0,0 +1,8 @@
+//go:build geodesic_order5 && !system_geographiclib
+
+package geodesic
+
+/*
+#cgo CFLAGS: -DGEOGRAPHICLIB_GEODESIC_ORDER=5
+*/
+import "C"
@@ -0,0 +1,241 @@
+package geodesic
+
+import "math"
+
+// PolygonResult is the perimeter and area of a polygon or polyline.
+type PolygonResult struct {
+	Area      float64 // area of the polygon (meters-squared), zero for polylines
+	Perimeter float64 // perimeter of the polygon or length of the polyline (meters)
+	NumPoints int     // number of points
+}
+
+// Polygon accumulates information about a geodesic polygon or polyline. It
+// is made by Ellipsoid.NewPolygon, or initialized in place by
+// Ellipsoid.InitPolygon.
+//
+// The area and perimeter are accumulated at two times the standard floating
+// point precision to guard against the loss of accuracy with many-sided
+// polygons. At any point you can ask for the perimeter and area so far.
+type Polygon struct {
+	p         corePolygon
+	latitudes LatitudePolicy
+	last      undoKind
+	err       error // from the ellipsoid
+}
+
+// undoKind is the kind of the last change to a Polygon, which is the one
+// that can be undone.
+type undoKind uint8
+
+const (
+	undoNone undoKind = iota
+	undoPoint
+	undoEdge
+)
+
+// NewPolygon creates a polygon, or a polyline if polyline is set.
+//
+// For a polygon, the points and edges added by Polygon.AddPoint and
+// Polygon.AddEdge define a polygon and Polygon.Result returns its
+// perimeter and area. For a polyline they define a polyline and only the
+// perimeter is returned.
+func (e *Ellipsoid) NewPolygon(polyline bool) *Polygon {
+	p := new(Polygon)
+	e.InitPolygon(p, polyline)
+	return p
+}
+
+// InitPolygon initializes *p in place, as NewPolygon does, without the
+// allocation of NewPolygon. Any previous state of p is discarded, but a
+// polygon of an exact ellipsoid should be closed first, see Polygon.Close.
+func (e *Ellipsoid) InitPolygon(p *Polygon, polyline bool) {
+	e.c.polygonInit(&p.p, polyline)
+	p.latitudes = e.opts.Latitudes
+	p.last = undoNone
+	p.err = e.err
+}
+
+// AddPoint adds a point to the polygon or polyline. A point that is not
+// valid is not added.
+func (p *Polygon) AddPoint(pt LatLng) error {
+	if p.err != nil {
+		return p.err
+	}
+	pt, err := checkPoint(pt, p.latitudes)
+	if err != nil {
+		return err
+	}
+	p.save(undoPoint)
+	p.p.addPoint(pt.Lat, pt.Lon)
+	return nil
+}
+
+// AddEdge adds an edge to the polygon or polyline. An edge that is not
+// valid is not added, and an edge does nothing until there is a point.
+//
+// Param azi is the azimuth at current point (degrees).
+// Param s is the distance from current point to next point (meters).
+func (p *Polygon) AddEdge(azi, s float64) error {
+	if err := p.checkEdge(azi, s); err != nil {
+		return err
+	}
+	p.save(undoEdge)
+	p.p.addEdge(azi, s)
+	return nil
+}
+
+func (p *Polygon) checkEdge(azi, s float64) error {
+	if p.err != nil {
+		return p.err
+	}
+	if err := checkFinite(azi); err != nil {
+		return err
+	}
+	return checkFinite(s)
+}
+
+// Result returns the area and perimeter of the polygon so far.
+//
+// Param reverse, if set then clockwise (instead of counter-clockwise)
+// traversal counts as a positive area.
+// Param sign, if set then return a signed result for the area if the
+// polygon is traversed in the "wrong" direction instead of returning the
+// area for the rest of the earth.
+//
+// Arbitrarily complex polygons are allowed. In the case of
+// self-intersecting polygons the area is accumulated "algebraically", e.g.,
+// the areas of the 2 loops in a figure-8 polygon will partially cancel.
+// There's no need to "close" the polygon by repeating the first vertex.
+// More points can be added to the polygon after this call.
+func (p *Polygon) Result(reverse, sign bool) PolygonResult {
+	if p.err != nil {
+		return PolygonResult{math.NaN(), math.NaN(), 0}
+	}
+	return p.p.compute(reverse, sign)
+}
+
+// TestPoint returns the results assuming a tentative final test point is
+// added; however, the data for the test point is not saved.
+//
+// Param pt is the test point.
+// Param reverse and sign are as for Polygon.Result.
+//
+// This lets you report a running result for the perimeter and area as the
+// user moves the mouse cursor. Ordinary floating point arithmetic is used
+// to accumulate the data for the test point; thus the area and perimeter
+// returned are less accurate than if Polygon.AddPoint and Polygon.Result
+// are used.
+func (p *Polygon) TestPoint(pt LatLng, reverse, sign bool,
+) (PolygonResult, error) {
+	if p.err != nil {
+		return PolygonResult{math.NaN(), math.NaN(), 0}, p.err
+	}
+	pt, err := checkPoint(pt, p.latitudes)
+	if err != nil {
+		return PolygonResult{math.NaN(), math.NaN(), p.p.num()}, err
+	}
+	return p.p.testPoint(pt.Lat, pt.Lon, reverse, sign), nil
+}
+
+// TestEdge returns the results assuming a tentative final test point is
+// added via an azimuth and distance; however, the data for the test point
+// is not saved.
+//
+// Param azi is the azimuth at current point (degrees).
+// Param s is the distance from current point to final test point (meters).
+// Param reverse and sign are as for Polygon.Result.
+//
+// The results are NaN, with no points, if the polygon has no points yet.
+// As for Polygon.TestPoint, they are less accurate than if Polygon.AddEdge
+// and Polygon.Result are used.
+func (p *Polygon) TestEdge(azi, s float64, reverse, sign bool,
+) (PolygonResult, error) {
+	if err := p.checkEdge(azi, s); err != nil {
+		n := 0
+		if p.err == nil {
+			n = p.p.num()
+		}
+		return PolygonResult{math.NaN(), math.NaN(), n}, err
+	}
+	return p.p.testEdge(azi, s, reverse, sign), nil
+}
+
+// NumPoints returns the number of points added so far.
+func (p *Polygon) NumPoints() int {
+	if p.err != nil {
+		return 0
+	}
+	return p.p.num()
+}
+
+// Polyline reports whether p is a polyline rather than a polygon.
+func (p *Polygon) Polyline() bool {
+	return p.err == nil && p.p.polyline()
+}
+
+// Current returns the last point added, or NaN if there is none. After an
+// edge, its longitude is unrolled, so that it counts the turns around the
+// earth since the first point.
+func (p *Polygon) Current() LatLng {
+	if p.err != nil {
+		return LatLng{math.NaN(), math.NaN()}
+	}
+	lat, lon := p.p.current()
+	return LatLng{lat, lon}
+}
+
+// save records the state before a change of the kind.
+func (p *Polygon) save(kind undoKind) {
+	p.p.save()
+	p.last = kind
+}
+
+// restore reverts the last change if it was of the kind.
+func (p *Polygon) restore(kind undoKind) bool {
+	if p.last != kind {
+		return false
+	}
+	p.p.restore()
+	p.last = undoNone
+	return true
+}
+
+// RemoveLastPoint removes the point added by the last call to
+// Polygon.AddPoint, restoring the polygon to its state before that call.
+//
+// Only the state before the last change is kept, so one change can be
+// undone. Returns false, leaving the polygon unchanged, if the last change
+// was not Polygon.AddPoint, such as when it was Polygon.AddEdge or
+// Polygon.Clear, or when it was already undone.
+func (p *Polygon) RemoveLastPoint() bool {
+	return p.restore(undoPoint)
+}
+
+// RemoveLastEdge removes the edge added by the last call to
+// Polygon.AddEdge, restoring the polygon to its state before that call.
+//
+// Returns false, leaving the polygon unchanged, if the last change was not
+// Polygon.AddEdge. See Polygon.RemoveLastPoint.
+func (p *Polygon) RemoveLastEdge() bool {
+	return p.restore(undoEdge)
+}
+
+// Clear the polygon, allowing a new polygon to be started.
+func (p *Polygon) Clear() {
+	p.last = undoNone
+	if p.err == nil {
+		p.p.clear()
+	}
+}
+
+// Close releases the memory held by the polygon. The polygon must not be
+// used afterwards.
+//
+// Only a polygon of an ellipsoid with Options.Exact holds memory outside
+// of Go, a C++ object that is otherwise freed when the garbage collector
+// gets to it. Closing such polygons once done with them keeps the C++ heap
+// from growing when many are made in a loop. For the other polygons Close
+// does nothing but may still be called.
+func (p *Polygon) Close() {
+	p.p.free()
+}
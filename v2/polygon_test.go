@@ -0,0 +1,83 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	p := WGS84.NewPolygon(false)
+	for _, pt := range []LatLng{
+		{Lat: 0, Lon: 0}, {Lat: 0, Lon: 1}, {Lat: 1, Lon: 1}, {Lat: 1, Lon: 0},
+	} {
+		if err := p.AddPoint(pt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Invalid points and edges are not added.
+	if err := p.AddPoint(LatLng{Lat: math.NaN(), Lon: 0}); err != ErrInvalidLatitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLatitude, err)
+	}
+	if err := p.AddEdge(0, math.NaN()); err != ErrInvalidArgument {
+		t.Fatalf("expected %v, got %v", ErrInvalidArgument, err)
+	}
+	r := p.Result(false, true)
+	if r.NumPoints != 4 || r.Area < 1.2e10 || r.Area > 1.3e10 ||
+		p.NumPoints() != 4 {
+		t.Fatalf("unexpected result %v", r)
+	}
+	if c := p.Current(); c != (LatLng{Lat: 1, Lon: 0}) {
+		t.Fatalf("expected the last point, got %v", c)
+	}
+	// The tentative results agree with adding the point.
+	tr, err := p.TestPoint(LatLng{Lat: 0.5, Lon: -0.5}, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.AddPoint(LatLng{Lat: 0.5, Lon: -0.5})
+	if r := p.Result(false, true); tr.NumPoints != 5 ||
+		!(math.Abs(tr.Area-r.Area) < 1e-3) ||
+		!(math.Abs(tr.Perimeter-r.Perimeter) < 1e-6) {
+		t.Fatalf("expected %v, got %v", r, tr)
+	}
+	if _, err := p.TestPoint(LatLng{Lat: 0, Lon: math.Inf(1)}, false, true); err != ErrInvalidLongitude {
+		t.Fatalf("expected %v, got %v", ErrInvalidLongitude, err)
+	}
+	if !p.RemoveLastPoint() || p.RemoveLastPoint() || p.NumPoints() != 4 {
+		t.Fatalf("expected the last point to be removed once")
+	}
+	if r2 := p.Result(false, true); r2 != r {
+		t.Fatalf("expected %v, got %v", r, r2)
+	}
+	if p.Polyline() {
+		t.Fatalf("expected a polygon")
+	}
+	p.Clear()
+	if p.NumPoints() != 0 || !math.IsNaN(p.Current().Lat) {
+		t.Fatalf("expected an empty polygon")
+	}
+	if r, _ := p.TestEdge(90, 1000, false, true); r.NumPoints != 0 ||
+		!math.IsNaN(r.Perimeter) {
+		t.Fatalf("expected no points and NaN, got %v", r)
+	}
+	p.Close()
+}
+
+func TestPolyline(t *testing.T) {
+	var l Polygon
+	WGS84.InitPolygon(&l, true)
+	l.AddPoint(LatLng{Lat: 0, Lon: 0})
+	l.AddEdge(90, 1000)
+	if r := l.Result(false, true); r.Area != 0 ||
+		math.Abs(r.Perimeter-1000) > 1e-9 || r.NumPoints != 2 {
+		t.Fatalf("unexpected result %v", r)
+	}
+	if r, _ := l.TestEdge(90, 500, false, true); r.NumPoints != 3 ||
+		math.Abs(r.Perimeter-1500) > 1e-9 {
+		t.Fatalf("unexpected result %v", r)
+	}
+	if !l.Polyline() || l.RemoveLastPoint() || !l.RemoveLastEdge() ||
+		l.NumPoints() != 1 {
+		t.Fatalf("expected the edge to be removed")
+	}
+}
@@ -0,0 +1,28 @@
+package geodesic
+
+import "github.com/tidwall/geodesic_cgo/v2/internal/geod"
+
+// SeriesOrder returns the order of the series expansions used by the
+// geodesic routines, the GEOGRAPHICLIB_GEODESIC_ORDER of the C library.
+//
+// The order is 6 by default, which gives the full double precision
+// accuracy of about 15 nanometers for the ellipsoids of the Earth. A build
+// with the geodesic_order5, geodesic_order4, or geodesic_order3 tag uses
+// the first terms of each series instead, in both the C routines and the
+// Go port, trading accuracy for speed on bulk workloads. With WGS84 the
+// errors of order 5 are about those of order 6 for most problems, but
+// larger for nearly antipodal points, whose azimuths may be off by a
+// tenth of a microdegree. Order 4 is good to a few micrometers and order
+// 3 to about a tenth of a millimeter, and these errors do not shrink with
+// the distance, so a step of a meter is off by about as much as one
+// across an ocean. The solutions are roughly 10 to 20 percent faster,
+// though that depends on the problems and the machine and is worth
+// measuring. The errors grow with the flattening, and the exact routines
+// of Options.Exact are not affected.
+//
+// The tags have no effect on the C routines of a build with the
+// system_geographiclib tag, which are those of the installed library, so
+// they must not be combined with it.
+func SeriesOrder() int {
+	return geod.Order
+}
@@ -0,0 +1,17 @@
+package geodesic
+
+import "fmt"
+
+// Version returns the version of the GeographicLib C library that the
+// geodesic routines come from, such as "1.52.0".
+//
+// It is the version of the bundled geodesic.c, or, without cgo, of the
+// geodesic.c that the Go port follows, which is always the same. A build
+// with the system_geographiclib tag reports the version of the bundled
+// geodesic.h that it is compiled against, which the installed library must
+// be compatible with. See CHANGELOG.md for how the bundled copy tracks the
+// upstream releases.
+func Version() string {
+	major, minor, patch := libraryVersion()
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch)
+}
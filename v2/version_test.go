@@ -0,0 +1,18 @@
+package geodesic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tidwall/geodesic_cgo/v2/internal/geod"
+)
+
+func TestVersion(t *testing.T) {
+	// With cgo the version comes from geodesic.h, which must move in step
+	// with the Go port.
+	want := fmt.Sprintf("%d.%d.%d", geod.VersionMajor, geod.VersionMinor,
+		geod.VersionPatch)
+	if v := Version(); v != want || v != "1.52.0" {
+		t.Fatalf("expected %v, got %v", want, v)
+	}
+}
@@ -0,0 +1,9 @@
+package geodesic
+
+import v2 "github.com/tidwall/geodesic_cgo/v2"
+
+// Version returns the version of the GeographicLib C library that the
+// geodesic routines come from, such as "1.52.0". See the Version of v2.
+func Version() string {
+	return v2.Version()
+}
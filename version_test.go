@@ -0,0 +1,13 @@
+package geodesic
+
+import (
+	"testing"
+
+	v2 "github.com/tidwall/geodesic_cgo/v2"
+)
+
+func TestVersion(t *testing.T) {
+	if v := Version(); v != v2.Version() || v == "" {
+		t.Fatalf("expected %v, got %v", v2.Version(), v)
+	}
+}
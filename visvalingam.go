@@ -0,0 +1,102 @@
+package geodesic
+
+import (
+	"container/heap"
+	"math"
+)
+
+// triangleArea returns the unsigned area of the geodesic triangle a, b, c
+// (meters-squared).
+func (e *Ellipsoid) triangleArea(a, b, c LatLng) float64 {
+	p := e.PolygonInit(false)
+	p.AddPoint(a.Lat, a.Lon)
+	p.AddPoint(b.Lat, b.Lon)
+	p.AddPoint(c.Lat, c.Lon)
+	var area float64
+	p.Compute(false, true, &area, nil)
+	return math.Abs(area)
+}
+
+type vwItem struct {
+	idx  int     // index of the point in the line
+	area float64 // effective area of the point
+	gen  int     // generation, for skipping stale items
+}
+
+type vwHeap []vwItem
+
+func (h vwHeap) Len() int            { return len(h) }
+func (h vwHeap) Less(i, j int) bool  { return h[i].area < h[j].area }
+func (h vwHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *vwHeap) Push(x interface{}) { *h = append(*h, x.(vwItem)) }
+func (h *vwHeap) Pop() interface{} {
+	x := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return x
+}
+
+// SimplifyVisvalingam reduces the number of points in a polyline using the
+// Visvalingam-Whyatt algorithm.
+//
+// Param line is the polyline to simplify.
+// Param minArea is the smallest effective area to keep (meters-squared).
+// Returns the simplified polyline.
+//
+// The effective area of a point is the area of the geodesic triangle that
+// it forms with its two neighbors, computed on the ellipsoid. Points are
+// removed smallest area first until every remaining interior point has an
+// effective area of at least minArea. The first and last points are always
+// kept.
+func (e *Ellipsoid) SimplifyVisvalingam(line []LatLng, minArea float64) []LatLng {
+	n := len(line)
+	if n < 3 {
+		return append([]LatLng(nil), line...)
+	}
+	prev := make([]int, n)
+	next := make([]int, n)
+	gens := make([]int, n)
+	areas := make([]float64, n)
+	h := make(vwHeap, 0, n)
+	for i := 0; i < n; i++ {
+		prev[i], next[i] = i-1, i+1
+		if i > 0 && i < n-1 {
+			areas[i] = e.triangleArea(line[i-1], line[i], line[i+1])
+			h = append(h, vwItem{idx: i, area: areas[i]})
+		}
+	}
+	heap.Init(&h)
+	removed := make([]bool, n)
+	update := func(i int, floor float64) {
+		if prev[i] < 0 || next[i] >= n {
+			return
+		}
+		// Never let a point's area drop below that of a point already
+		// removed, so that removal order stays monotonic.
+		areas[i] = math.Max(e.triangleArea(line[prev[i]], line[i],
+			line[next[i]]), floor)
+		gens[i]++
+		heap.Push(&h, vwItem{idx: i, area: areas[i], gen: gens[i]})
+	}
+	for h.Len() > 0 {
+		it := heap.Pop(&h).(vwItem)
+		if it.gen != gens[it.idx] || removed[it.idx] {
+			continue
+		}
+		if it.area >= minArea {
+			break
+		}
+		i := it.idx
+		removed[i] = true
+		next[prev[i]] = next[i]
+		prev[next[i]] = prev[i]
+		update(prev[i], it.area)
+		update(next[i], it.area)
+	}
+	var out []LatLng
+	for i := 0; i < n; i++ {
+		if !removed[i] {
+			out = append(out, line[i])
+		}
+	}
+	return out
+}
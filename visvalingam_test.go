@@ -0,0 +1,27 @@
+package geodesic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimplifyVisvalingam(t *testing.T) {
+	line := []LatLng{{0, 0}, {0.001, 0.5}, {0, 1}, {0.5, 1.5}, {0, 2}}
+	small := WGS84.triangleArea(line[0], line[1], line[2])
+	big := WGS84.triangleArea(line[2], line[3], line[4])
+	if !(small > 0 && small < big) {
+		t.Fatalf("unexpected areas %f, %f", small, big)
+	}
+	out := WGS84.SimplifyVisvalingam(line, small*2)
+	if len(out) != 4 || out[1] != line[2] {
+		t.Fatalf("expected the small bump to be removed, got %v", out)
+	}
+	out = WGS84.SimplifyVisvalingam(line, math.Inf(1))
+	if len(out) != 2 || out[0] != line[0] || out[1] != line[4] {
+		t.Fatalf("expected the endpoints only, got %v", out)
+	}
+	out = WGS84.SimplifyVisvalingam(line, 0)
+	if len(out) != len(line) {
+		t.Fatalf("expected all points, got %v", out)
+	}
+}